@@ -19,17 +19,277 @@ type General struct {
 	Tracing        Tracing `mapstructure:"TRACING"`
 	TlsEnabled     bool    `mapstructure:"TLS_ENABLED"`
 	LoggingEnabled bool    `mapstructure:"LOGGING_ENABLED"`
+	LoggingLevel   string  `mapstructure:"LOGGING_LEVEL"`
+	LoggingFormat  string  `mapstructure:"LOGGING_FORMAT"`
 }
 
 type Config struct {
-	General General `mapstructure:"GENERAL,squash"`
-	HTTP    HTTP    `mapstructure:"HTTP"`
-	DB      DB      `mapstructure:"DB"`
+	General    General    `mapstructure:"GENERAL,squash"`
+	HTTP       HTTP       `mapstructure:"HTTP"`
+	GRPC       GRPC       `mapstructure:"GRPC"`
+	TLS        TLS        `mapstructure:"TLS"`
+	CORS       CORS       `mapstructure:"CORS"`
+	Auth       Auth       `mapstructure:"AUTH"`
+	Admin      Admin      `mapstructure:"ADMIN"`
+	DB         DB         `mapstructure:"DB"`
+	Metrics    Metrics    `mapstructure:"METRICS"`
+	RateLimit  RateLimit  `mapstructure:"RATE_LIMIT"`
+	GraphQL    GraphQL    `mapstructure:"GRAPHQL"`
+	Batch      Batch      `mapstructure:"BATCH"`
+	ITunes     ITunes     `mapstructure:"ITUNES"`
+	Providers  Providers  `mapstructure:"PROVIDERS"`
+	Jobs       Jobs       `mapstructure:"JOBS"`
+	Watchlists Watchlists `mapstructure:"WATCHLISTS"`
+	Webhooks   Webhooks   `mapstructure:"WEBHOOKS"`
+	Outbox     Outbox     `mapstructure:"OUTBOX"`
+	Podcasts   Podcasts   `mapstructure:"PODCASTS"`
+	// Availability holds the config of the endpoint comparing a media across iTunes storefronts.
+	Availability Availability `mapstructure:"AVAILABILITY"`
 }
 
 type HTTP struct {
 	Port             int           `mapstructure:"PORT"`
 	GracefulShutdown time.Duration `mapstructure:"GRACEFUL_SHUTDOWN"`
+	// TrustedProxies lists the CIDRs whose X-Forwarded-For header is honored when resolving the client ip.
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+	// CompressionEnabled compresses responses with brotli, zstd or gzip as negotiated from Accept-Encoding.
+	CompressionEnabled bool `mapstructure:"COMPRESSION_ENABLED"`
+	// CacheMaxAge is how long search responses stay fresh in browsers and shared caches, they must be revalidated when zero.
+	CacheMaxAge time.Duration `mapstructure:"CACHE_MAX_AGE"`
+	// CacheStaleWhileRevalidate is how long a stale search response may be served while it is revalidated.
+	CacheStaleWhileRevalidate time.Duration `mapstructure:"CACHE_STALE_WHILE_REVALIDATE"`
+}
+
+// GRPC holds the config of the grpc server, it is served alongside the http server when a port is set
+// and shares its tls, auth and rate limit config.
+type GRPC struct {
+	Port             int           `mapstructure:"PORT"`
+	GracefulShutdown time.Duration `mapstructure:"GRACEFUL_SHUTDOWN"`
+}
+
+// TLS holds the config used to serve https when General.TlsEnabled is set.
+type TLS struct {
+	CertFile string `mapstructure:"CERT_FILE"`
+	KeyFile  string `mapstructure:"KEY_FILE"`
+	// MinVersion is the minimum accepted tls version, either 1.2 or 1.3. Defaults to 1.2.
+	MinVersion string `mapstructure:"MIN_VERSION"`
+	// CipherSuites lists the accepted tls 1.2 cipher suites by their IANA name, all secure suites are accepted when empty.
+	CipherSuites []string `mapstructure:"CIPHER_SUITES"`
+	// ClientCAFile is the CA bundle client certificates are verified against, mutual tls is disabled when empty.
+	ClientCAFile string `mapstructure:"CLIENT_CA_FILE"`
+	// ClientCertOptional accepts clients without a certificate, presented certificates are still verified.
+	ClientCertOptional bool `mapstructure:"CLIENT_CERT_OPTIONAL"`
+}
+
+// CORS holds the cross-origin policy of the public http server, allowed methods are derived per route from the router.
+type CORS struct {
+	// AllowedOrigins lists exact origins or patterns where * matches a single host label sequence,
+	// e.g. https://*.example.com. A lone * allows every origin, it can't be combined with AllowCredentials.
+	AllowedOrigins   []string      `mapstructure:"ALLOWED_ORIGINS"`
+	AllowedHeaders   []string      `mapstructure:"ALLOWED_HEADERS"`
+	ExposedHeaders   []string      `mapstructure:"EXPOSED_HEADERS"`
+	AllowCredentials bool          `mapstructure:"ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `mapstructure:"MAX_AGE"`
+}
+
+// Auth holds the config of the authentication of the public api.
+type Auth struct {
+	// APIKeysEnabled requires an api key on every /api/v1 request.
+	APIKeysEnabled bool `mapstructure:"API_KEYS_ENABLED"`
+	// APIKeyHeader is the header clients send their api key in. Defaults to X-API-Key.
+	APIKeyHeader string `mapstructure:"API_KEY_HEADER"`
+	// JWTEnabled accepts bearer tokens on every /api/v1 request, requests without one fall back to the api key when enabled.
+	JWTEnabled bool `mapstructure:"JWT_ENABLED"`
+	// JWKSFile and JWKSURL locate the key set tokens are verified against, the file takes precedence when both are set.
+	JWKSFile string `mapstructure:"JWKS_FILE"`
+	JWKSURL  string `mapstructure:"JWKS_URL"`
+	// JWKSRefreshInterval is how long the key set is cached. Defaults to 15m.
+	JWKSRefreshInterval time.Duration `mapstructure:"JWKS_REFRESH_INTERVAL"`
+	JWTIssuer           string        `mapstructure:"JWT_ISSUER"`
+	JWTAudience         string        `mapstructure:"JWT_AUDIENCE"`
+	// JWTLeeway is the clock skew tolerated when checking the expiry and not before claims.
+	JWTLeeway time.Duration `mapstructure:"JWT_LEEWAY"`
+	// JWTRequiredScopes lists the scopes a token needs to call /api/v1.
+	JWTRequiredScopes []string `mapstructure:"JWT_REQUIRED_SCOPES"`
+}
+
+// Admin holds the config of the admin http server, it is kept on a separate port
+// so operational endpoints are never exposed through the public listener.
+type Admin struct {
+	Port int `mapstructure:"PORT"`
+	// Token is the bearer token the /admin endpoints require, they are not served without one.
+	Token string `mapstructure:"TOKEN"`
+}
+
+// RateLimit holds the per-client rate limits of the /api/v1 endpoints.
+type RateLimit struct {
+	Enabled bool `mapstructure:"ENABLED"`
+	// Store is where buckets are kept, either memory for per-replica limits or postgres for limits shared by every replica.
+	Store string `mapstructure:"STORE"`
+	// Rate is the number of requests per second a client is refilled with and Burst the most it can send at once.
+	Rate  float64 `mapstructure:"RATE"`
+	Burst int     `mapstructure:"BURST"`
+	// Routes overrides the rule of single routes, written as operation=rate:burst, e.g. search.media=5:10.
+	Routes []string `mapstructure:"ROUTES"`
+	// IPRule limits every ip across routes ahead of authentication, written as rate:burst. Defaults to 20:100.
+	IPRule string `mapstructure:"IP_RULE"`
+}
+
+// GraphQL holds the limits of the queries accepted by the /graphql endpoint.
+type GraphQL struct {
+	// MaxDepth is the deepest nesting of fields a query may select. Defaults to 10.
+	MaxDepth int `mapstructure:"MAX_DEPTH"`
+	// MaxComplexity is the most fields a query may resolve, counting fields below a list once per item. Defaults to 10000.
+	MaxComplexity int `mapstructure:"MAX_COMPLEXITY"`
+}
+
+// Batch holds the config of the batch search endpoint.
+type Batch struct {
+	// MaxSize is the most searches a single batch may hold. Defaults to 100.
+	MaxSize int `mapstructure:"MAX_SIZE"`
+	// Concurrency is the most searches of a batch run at once. Defaults to 4.
+	Concurrency int `mapstructure:"CONCURRENCY"`
+}
+
+// ITunes holds the config of the iTunes API client.
+type ITunes struct {
+	// Rate is the number of calls per second sent to the iTunes API and Burst the most sent at once,
+	// calls beyond it wait for their turn. Calls are not limited when Rate is zero.
+	Rate  float64 `mapstructure:"RATE"`
+	Burst int     `mapstructure:"BURST"`
+}
+
+// Providers holds the config of the providers media can be searched in besides iTunes, the default one.
+// Timeout bounds the search sent to every provider of a federated search so a slow one doesn't hold up the others.
+type Providers struct {
+	Timeout     time.Duration `mapstructure:"TIMEOUT"`
+	MusicBrainz MusicBrainz   `mapstructure:"MUSICBRAINZ"`
+	TMDB        TMDB          `mapstructure:"TMDB"`
+}
+
+// MusicBrainz holds the config of the MusicBrainz provider, UserAgent identifies the service to MusicBrainz which
+// rejects anonymous clients and Rate is the number of calls per second sent to it.
+type MusicBrainz struct {
+	Enabled   bool    `mapstructure:"ENABLED"`
+	BaseURL   string  `mapstructure:"BASE_URL"`
+	UserAgent string  `mapstructure:"USER_AGENT"`
+	Rate      float64 `mapstructure:"RATE"`
+}
+
+// TMDB holds the config of the TMDB provider, it is enabled when Token, a TMDB API read access token, is set.
+type TMDB struct {
+	Token   string `mapstructure:"TOKEN"`
+	BaseURL string `mapstructure:"BASE_URL"`
+}
+
+// Jobs holds the config of the background search jobs and of the workers running them.
+type Jobs struct {
+	// Workers is the number of jobs this replica runs at once, queued jobs are left to other replicas when zero.
+	Workers int `mapstructure:"WORKERS"`
+	// MaxSize is the most searches a single job may hold. Defaults to 1000.
+	MaxSize int `mapstructure:"MAX_SIZE"`
+	// PollInterval is how often idle workers look for due jobs. Defaults to 1s.
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	// MaxAttempts is the number of times a job is run before it fails. Defaults to 3.
+	MaxAttempts int `mapstructure:"MAX_ATTEMPTS"`
+	// Backoff is the delay before retrying a job, it doubles on every further retry up to MaxBackoff. Defaults to 30s and 10m.
+	Backoff    time.Duration `mapstructure:"BACKOFF"`
+	MaxBackoff time.Duration `mapstructure:"MAX_BACKOFF"`
+	// Lease is how long a job stays claimed by a worker that stopped renewing it, e.g. because it crashed. Defaults to 1m.
+	Lease time.Duration `mapstructure:"LEASE"`
+	// EventsPollInterval is how often event streams read new events of their job. Defaults to 1s.
+	EventsPollInterval time.Duration `mapstructure:"EVENTS_POLL_INTERVAL"`
+	// EventsHeartbeat is how long an event stream may stay silent before a heartbeat is sent. Defaults to 15s.
+	EventsHeartbeat time.Duration `mapstructure:"EVENTS_HEARTBEAT"`
+}
+
+// Watchlists holds the config of the checker detecting new releases of the artists of watchlists.
+type Watchlists struct {
+	// CheckerEnabled runs the checker on this replica, due artists are claimed so replicas don't check the same ones.
+	CheckerEnabled bool `mapstructure:"CHECKER_ENABLED"`
+	// CheckInterval is how often every artist is checked for new releases. Defaults to 6h.
+	CheckInterval time.Duration `mapstructure:"CHECK_INTERVAL"`
+	// PollInterval is how often the checker looks for artists due for a check. Defaults to 1m.
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	// BatchSize is the most artists claimed at once by the checker. Defaults to 50.
+	BatchSize int `mapstructure:"BATCH_SIZE"`
+	// Entities are the iTunes entities looked up for new releases. Defaults to album and song.
+	Entities []string `mapstructure:"ENTITIES"`
+}
+
+// Webhooks holds the config of the workers delivering events to the subscribed webhooks.
+type Webhooks struct {
+	// Workers is the number of deliveries this replica sends at once, pending deliveries are left to other replicas when zero.
+	Workers int `mapstructure:"WORKERS"`
+	// PollInterval is how often idle workers look for due deliveries. Defaults to 1s.
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	// Timeout is how long a webhook may take to answer a delivery, a delivery stays claimed for twice as long. Defaults to 10s.
+	Timeout time.Duration `mapstructure:"TIMEOUT"`
+	// MaxAttempts is the number of times a delivery is sent before it is dead. Defaults to 8.
+	MaxAttempts int `mapstructure:"MAX_ATTEMPTS"`
+	// Backoff is the delay before resending a delivery, it doubles on every further retry up to MaxBackoff. Defaults to 30s and 1h.
+	Backoff    time.Duration `mapstructure:"BACKOFF"`
+	MaxBackoff time.Duration `mapstructure:"MAX_BACKOFF"`
+}
+
+// Outbox holds the config of the relay publishing the domain events written to the outbox to a broker.
+type Outbox struct {
+	// RelayEnabled runs the relay on this replica, due events are claimed so replicas don't publish the same ones.
+	RelayEnabled bool `mapstructure:"RELAY_ENABLED"`
+	// Broker is where events are published, nats or memory. The memory broker only reaches subscribers of this
+	// process and is meant for development. Events are only written to the outbox when it is set.
+	Broker string `mapstructure:"BROKER"`
+	// NATSURL is the url of the NATS server when Broker is nats. Defaults to nats://127.0.0.1:4222.
+	NATSURL string `mapstructure:"NATS_URL"`
+	// SubjectPrefix prefixes the subject of every event, followed by its type. Defaults to mediascout.
+	SubjectPrefix string `mapstructure:"SUBJECT_PREFIX"`
+	// Timeout is how long the broker may take to acknowledge an event. Defaults to 5s.
+	Timeout time.Duration `mapstructure:"TIMEOUT"`
+	// PollInterval is how often the relay looks for due events once the outbox is empty. Defaults to 1s.
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	// BatchSize is the most events claimed at once by the relay. Defaults to 100.
+	BatchSize int `mapstructure:"BATCH_SIZE"`
+	// Backoff is the delay before publishing an event again, it doubles on every further retry up to MaxBackoff. Defaults to 1s and 1m.
+	Backoff    time.Duration `mapstructure:"BACKOFF"`
+	MaxBackoff time.Duration `mapstructure:"MAX_BACKOFF"`
+	// Lease is how long events stay claimed by a relay that stopped, e.g. because it crashed. Defaults to 1m.
+	Lease time.Duration `mapstructure:"LEASE"`
+}
+
+// Podcasts holds the config of the refresher fetching the feeds of the podcasts whose episodes were listed.
+type Podcasts struct {
+	// RefresherEnabled runs the refresher on this replica, due podcasts are claimed so replicas don't refresh the same ones.
+	RefresherEnabled bool `mapstructure:"REFRESHER_ENABLED"`
+	// RefreshInterval is how often the feed of every podcast is refreshed. Defaults to 1h.
+	RefreshInterval time.Duration `mapstructure:"REFRESH_INTERVAL"`
+	// PollInterval is how often the refresher looks for podcasts due for a refresh. Defaults to 1m.
+	PollInterval time.Duration `mapstructure:"POLL_INTERVAL"`
+	// BatchSize is the most podcasts claimed at once by the refresher. Defaults to 20.
+	BatchSize int `mapstructure:"BATCH_SIZE"`
+	// MaxEpisodes is the most episodes of a feed stored on a refresh, the most recent ones. Defaults to 500.
+	MaxEpisodes int `mapstructure:"MAX_EPISODES"`
+	// UserAgent identifies the service to the hosts of the feeds. Defaults to the one identifying it to MusicBrainz.
+	UserAgent string `mapstructure:"USER_AGENT"`
+	// FetchTimeout is how long the fetch of a feed may take, including its download. Defaults to 30s.
+	FetchTimeout time.Duration `mapstructure:"FETCH_TIMEOUT"`
+}
+
+// Availability holds the config of the availability endpoint looking a media up in several iTunes storefronts, its
+// calls wait for the iTunes rate limit like every other one.
+type Availability struct {
+	// MaxCountries is the most storefronts a single request may check. Defaults to 20.
+	MaxCountries int `mapstructure:"MAX_COUNTRIES"`
+	// Concurrency is the most storefronts looked up at once. Defaults to 4.
+	Concurrency int `mapstructure:"CONCURRENCY"`
+	// BaseCurrency is the currency prices are normalized to, they aren't normalized when empty.
+	BaseCurrency string `mapstructure:"BASE_CURRENCY"`
+	// ExchangeRates are the values of a unit of other currencies in BaseCurrency, written as currency=rate, e.g. GBP=1.27.
+	ExchangeRates []string `mapstructure:"EXCHANGE_RATES"`
+}
+
+type Metrics struct {
+	Enabled bool   `mapstructure:"ENABLED"`
+	Path    string `mapstructure:"PATH"`
 }
 
 type DB struct {
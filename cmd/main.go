@@ -2,20 +2,26 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/NawafSwe/media-scout-service/cmd/config"
 	"github.com/NawafSwe/media-scout-service/cmd/mediascout"
 	"github.com/NawafSwe/media-scout-service/pkg/db"
+	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel"
 	otelgrpc "go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	otelgrpctrace "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/propagation"
 	otellog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/semconv/v1.10.0"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"log"
+	"os"
 )
 
 func main() {
@@ -23,20 +29,57 @@ func main() {
 	if err != nil {
 		log.Fatalf("err loading config, err: %v", err)
 	}
-	tp, err := initTracer(cfg)
+	res, err := initResource(cfg)
+	if err != nil {
+		log.Fatalf("err initializing resource, err: %v", err)
+	}
+	tp, err := initTracer(cfg, res)
 	if err != nil {
 		log.Fatalf("err initializing trace, err: %v", err)
 	}
-	dbConn, err := db.NewDBConn(cfg.DB, tp)
+	mp, err := initMeter(cfg, res)
+	if err != nil {
+		log.Fatalf("err initializing meter, err: %v", err)
+	}
+	dbConn, err := db.NewDBConn(cfg.DB, tp, mp)
 	if err != nil {
 		log.Fatalf("err creating db conn, err: %v", err)
 	}
-	if err := mediascout.RunHTTPServer(context.Background(), tp, dbConn, cfg); err != nil {
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), dbConn, os.Args[1:]); err != nil {
+			log.Fatalf("failed to run %s: %v", os.Args[1], err)
+		}
+		return
+	}
+	if err := mediascout.RunHTTPServer(context.Background(), tp, mp, dbConn, cfg); err != nil {
 		log.Fatalf("failed to run http server: %v", err)
 	}
 }
 
-func initTracer(cfg config.Config) (*trace.TracerProvider, error) {
+// runCommand runs the management command named by the first arg instead of the http server.
+func runCommand(ctx context.Context, dbConn *sqlx.DB, args []string) error {
+	switch args[0] {
+	case "apikeys":
+		return mediascout.RunAPIKeysCommand(ctx, dbConn, args[1:], os.Stdout)
+	case "export":
+		return mediascout.RunExportCommand(ctx, dbConn, args[1:], os.Stdout)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func initResource(cfg config.Config) (*resource.Resource, error) {
+	return resource.New(context.Background(),
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(cfg.General.ServiceName),
+			semconv.ServiceNamespaceKey.String(cfg.General.ServiceName),
+			semconv.ServiceVersionKey.String(cfg.General.AppVersion),
+			semconv.DeploymentEnvironmentKey.String(cfg.General.AppEnvironment),
+		),
+	)
+}
+
+func initTracer(cfg config.Config, res *resource.Resource) (*trace.TracerProvider, error) {
 	ctx := context.Background()
 	// Create the gRPC connection
 	grpcConn, err := grpc.NewClient(cfg.General.Tracing.ReceiverEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -55,19 +98,6 @@ func initTracer(cfg config.Config) (*trace.TracerProvider, error) {
 		return nil, err
 	}
 
-	// Create the resource
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(cfg.General.ServiceName),
-			semconv.ServiceNamespaceKey.String(cfg.General.ServiceName),
-			semconv.ServiceVersionKey.String(cfg.General.AppVersion),
-			semconv.DeploymentEnvironmentKey.String(cfg.General.AppEnvironment),
-		),
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	// Create the tracer provider
 	tp := trace.NewTracerProvider(
 		trace.WithBatcher(tpExporter),
@@ -81,6 +111,27 @@ func initTracer(cfg config.Config) (*trace.TracerProvider, error) {
 
 	// Set the global tracer provider
 	otel.SetTracerProvider(tp)
+	// Propagate the W3C trace context so incoming traceparent headers are honored and returned.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	return tp, nil
 }
+
+func initMeter(cfg config.Config, res *resource.Resource) (*metric.MeterProvider, error) {
+	opts := []metric.Option{metric.WithResource(res)}
+	if cfg.Metrics.Enabled {
+		// The exporter registers itself with the default prometheus registry,
+		// which is what the admin server scrapes from.
+		exporter, err := otelprometheus.New()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, metric.WithReader(exporter))
+	}
+	mp := metric.NewMeterProvider(opts...)
+
+	// Set the global meter provider
+	otel.SetMeterProvider(mp)
+
+	return mp, nil
+}
@@ -3,21 +3,142 @@ package mediascout
 import (
 	"context"
 	"fmt"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
+	"io"
 
 	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/cli"
 	"github.com/NawafSwe/media-scout-service/pkg/worker"
 	"github.com/jmoiron/sqlx"
 )
 
-// RunHTTPServer run http server.
-func RunHTTPServer(ctx context.Context, tracer *trace.TracerProvider, db *sqlx.DB, cfg config.Config) error {
-	w, err := worker.NewHTTPWorker(cfg, tracer, db, "media_scout.http_srv")
+// RunHTTPServer run http server, along with the grpc server when a grpc port is configured.
+// The grpc server is stopped once the http server shut down, both share the api guard.
+func RunHTTPServer(ctx context.Context, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, cfg config.Config) error {
+	guard, err := worker.NewAPIGuard(cfg, tracer, meter, db, "media_scout.api_guard")
+	if err != nil {
+		return fmt.Errorf("failed to create api guard: %w", err)
+	}
+	w, err := worker.NewHTTPWorker(cfg, tracer, meter, db, guard, "media_scout.http_srv")
 	if err != nil {
 		return fmt.Errorf("failed to create http server: %w", err)
 	}
+	if cfg.GRPC.Port != 0 {
+		g, err := worker.NewGRPCWorker(cfg, tracer, meter, db, guard, "media_scout.grpc_srv")
+		if err != nil {
+			return fmt.Errorf("failed to create grpc server: %w", err)
+		}
+		grpcCtx, stopGRPC := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		defer func() {
+			stopGRPC()
+			<-stopped
+		}()
+		go func() {
+			defer close(stopped)
+			// failures are logged by the worker, the http server keeps running without grpc.
+			_ = g.Run(grpcCtx)
+		}()
+	}
+	if cfg.Jobs.Workers > 0 {
+		j, err := worker.NewJobWorker(cfg, tracer, meter, db, "media_scout.job_worker")
+		if err != nil {
+			return fmt.Errorf("failed to create job worker: %w", err)
+		}
+		jobsCtx, stopJobs := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		defer func() {
+			stopJobs()
+			<-stopped
+		}()
+		go func() {
+			defer close(stopped)
+			_ = j.Run(jobsCtx)
+		}()
+	}
+	if cfg.Watchlists.CheckerEnabled {
+		c, err := worker.NewWatchlistWorker(cfg, tracer, meter, db, "media_scout.watchlist_checker")
+		if err != nil {
+			return fmt.Errorf("failed to create watchlist worker: %w", err)
+		}
+		checkerCtx, stopChecker := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		defer func() {
+			stopChecker()
+			<-stopped
+		}()
+		go func() {
+			defer close(stopped)
+			_ = c.Run(checkerCtx)
+		}()
+	}
+	if cfg.Webhooks.Workers > 0 {
+		d, err := worker.NewWebhookWorker(cfg, tracer, db, "media_scout.webhook_worker")
+		if err != nil {
+			return fmt.Errorf("failed to create webhook worker: %w", err)
+		}
+		webhooksCtx, stopWebhooks := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		defer func() {
+			stopWebhooks()
+			<-stopped
+		}()
+		go func() {
+			defer close(stopped)
+			_ = d.Run(webhooksCtx)
+		}()
+	}
+	if cfg.Outbox.RelayEnabled {
+		o, err := worker.NewOutboxWorker(cfg, db, "media_scout.outbox_relay")
+		if err != nil {
+			return fmt.Errorf("failed to create outbox worker: %w", err)
+		}
+		relayCtx, stopRelay := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		defer func() {
+			stopRelay()
+			<-stopped
+		}()
+		go func() {
+			defer close(stopped)
+			_ = o.Run(relayCtx)
+		}()
+	}
+	if cfg.Podcasts.RefresherEnabled {
+		p, err := worker.NewPodcastWorker(cfg, tracer, meter, db, "media_scout.podcast_refresher")
+		if err != nil {
+			return fmt.Errorf("failed to create podcast worker: %w", err)
+		}
+		refresherCtx, stopRefresher := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		defer func() {
+			stopRefresher()
+			<-stopped
+		}()
+		go func() {
+			defer close(stopped)
+			_ = p.Run(refresherCtx)
+		}()
+	}
 	if err := w.Run(ctx); err != nil {
 		return fmt.Errorf("failed to run http worker: %w", err)
 	}
 	return nil
 }
+
+// RunAPIKeysCommand run the api keys management command.
+func RunAPIKeysCommand(ctx context.Context, db *sqlx.DB, args []string, out io.Writer) error {
+	if err := cli.RunAPIKeys(ctx, db, args, out); err != nil {
+		return fmt.Errorf("failed to run apikeys command: %w", err)
+	}
+	return nil
+}
+
+// RunExportCommand run the export command.
+func RunExportCommand(ctx context.Context, db *sqlx.DB, args []string, out io.Writer) error {
+	if err := cli.RunExport(ctx, db, args, out); err != nil {
+		return fmt.Errorf("failed to run export command: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS api_key_usage;
+DROP TABLE IF EXISTS api_key;
+COMMIT;
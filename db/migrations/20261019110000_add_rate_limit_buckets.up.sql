@@ -0,0 +1,10 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS rate_limit_bucket (
+    bucket_key VARCHAR PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_bucket_updated_at_idx ON rate_limit_bucket (updated_at);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS search_job_result;
+DROP TABLE IF EXISTS search_job;
+COMMIT;
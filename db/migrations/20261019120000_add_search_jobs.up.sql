@@ -0,0 +1,31 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS search_job (
+    id BIGSERIAL PRIMARY KEY,
+    -- owner is the identity of the client which queued the job, an empty one when auth is disabled.
+    owner VARCHAR NOT NULL DEFAULT '',
+    status VARCHAR NOT NULL DEFAULT 'queued',
+    searches JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    last_error VARCHAR,
+    run_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- workers claim due queued jobs and running jobs whose lease expired.
+CREATE INDEX IF NOT EXISTS search_job_queued_idx ON search_job (run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS search_job_running_idx ON search_job (locked_until) WHERE status = 'running';
+
+CREATE TABLE IF NOT EXISTS search_job_result (
+    job_id BIGINT NOT NULL REFERENCES search_job (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    media_result_id BIGINT REFERENCES media_result (id) ON DELETE SET NULL,
+    error VARCHAR,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (job_id, position)
+);
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS search_job_event (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES search_job (id) ON DELETE CASCADE,
+    kind VARCHAR NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- event streams read the events of a job following the last one they sent.
+CREATE INDEX IF NOT EXISTS search_job_event_job_idx ON search_job_event (job_id, id);
+COMMIT;
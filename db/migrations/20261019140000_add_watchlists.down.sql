@@ -0,0 +1,6 @@
+BEGIN;
+DROP TABLE IF EXISTS artist_release;
+DROP TABLE IF EXISTS watchlist_artist;
+DROP TABLE IF EXISTS watched_artist;
+DROP TABLE IF EXISTS watchlist;
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS watchlist (
+    id BIGSERIAL PRIMARY KEY,
+    -- owner is the identity of the client which created the watchlist, an empty one when auth is disabled.
+    owner VARCHAR NOT NULL DEFAULT '',
+    name VARCHAR NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- artists are checked once whatever the number of watchlists subscribed to them.
+CREATE TABLE IF NOT EXISTS watched_artist (
+    artist_id BIGINT PRIMARY KEY,
+    artist_name VARCHAR,
+    last_release_date TIMESTAMP,
+    checked_at TIMESTAMP,
+    next_check_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS watched_artist_next_check_idx ON watched_artist (next_check_at);
+
+CREATE TABLE IF NOT EXISTS watchlist_artist (
+    watchlist_id BIGINT NOT NULL REFERENCES watchlist (id) ON DELETE CASCADE,
+    artist_id BIGINT NOT NULL REFERENCES watched_artist (artist_id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (watchlist_id, artist_id)
+);
+
+CREATE INDEX IF NOT EXISTS watchlist_artist_artist_idx ON watchlist_artist (artist_id);
+
+CREATE TABLE IF NOT EXISTS artist_release (
+    id BIGSERIAL PRIMARY KEY,
+    artist_id BIGINT NOT NULL REFERENCES watched_artist (artist_id),
+    artist_name VARCHAR,
+    collection_id BIGINT NOT NULL,
+    collection_name VARCHAR,
+    release_date TIMESTAMP NOT NULL,
+    collection_view_url VARCHAR,
+    artwork_url_100 VARCHAR,
+    detected_at TIMESTAMP NOT NULL,
+    UNIQUE (artist_id, collection_id)
+);
+COMMIT;
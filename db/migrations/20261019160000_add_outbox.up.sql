@@ -0,0 +1,15 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS outbox_event (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    last_error VARCHAR,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- the relay claims the oldest due events, events are deleted once published.
+CREATE INDEX IF NOT EXISTS outbox_event_next_attempt_idx ON outbox_event (next_attempt_at, id);
+COMMIT;
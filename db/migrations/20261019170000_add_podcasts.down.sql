@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS podcast_episode;
+DROP TABLE IF EXISTS podcast;
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS podcast (
+    collection_id BIGINT PRIMARY KEY,
+    title VARCHAR,
+    feed_url VARCHAR NOT NULL,
+    -- validators of the last fetched feed, sent along the next fetch to skip unchanged feeds.
+    etag VARCHAR,
+    last_modified VARCHAR,
+    refreshed_at TIMESTAMP,
+    next_refresh_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS podcast_next_refresh_idx ON podcast (next_refresh_at);
+
+CREATE TABLE IF NOT EXISTS podcast_episode (
+    id BIGSERIAL PRIMARY KEY,
+    collection_id BIGINT NOT NULL REFERENCES podcast (collection_id) ON DELETE CASCADE,
+    guid VARCHAR NOT NULL,
+    title VARCHAR,
+    description TEXT,
+    link VARCHAR,
+    audio_url VARCHAR,
+    audio_type VARCHAR,
+    published_at TIMESTAMP,
+    duration_seconds INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (collection_id, guid)
+);
+
+CREATE INDEX IF NOT EXISTS podcast_episode_published_idx ON podcast_episode (collection_id, published_at DESC NULLS LAST, id DESC);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX IF EXISTS media_result_search_key_idx;
+ALTER TABLE media_result DROP COLUMN IF EXISTS search_key;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+-- identifies the search a result was fetched for, conditional searches are answered from the latest one.
+ALTER TABLE media_result ADD COLUMN IF NOT EXISTS search_key VARCHAR NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS media_result_search_key_idx ON media_result (search_key, created_at DESC);
+COMMIT;
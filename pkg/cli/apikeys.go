@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/apikeydb"
+	"github.com/jmoiron/sqlx"
+)
+
+const apiKeysUsage = `usage: media-scout apikeys <command> [flags]
+
+commands:
+  create -name <name> [-minute-quota n] [-daily-quota n]
+  list
+  rotate -id <id>
+  revoke -id <id>`
+
+// RunAPIKeys runs the api keys management command with the given args, writing its output to out.
+func RunAPIKeys(ctx context.Context, db *sqlx.DB, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(apiKeysUsage)
+	}
+	handler := business.NewAPIKeyHandler(apikeydb.NewAPIKeyRepository(db))
+	fs := flag.NewFlagSet("apikeys "+args[0], flag.ContinueOnError)
+	fs.SetOutput(out)
+
+	switch args[0] {
+	case "create":
+		name := fs.String("name", "", "name of the api key owner")
+		minuteQuota := fs.Int("minute-quota", 0, "requests allowed per minute, 0 for unlimited")
+		dailyQuota := fs.Int("daily-quota", 0, "requests allowed per day, 0 for unlimited")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+		key, raw, err := handler.CreateAPIKey(ctx, *name, *minuteQuota, *dailyQuota)
+		if err != nil {
+			return err
+		}
+		printAPIKeys(out, key)
+		_, _ = fmt.Fprintf(out, "\nkey: %s\nstore it now, it can't be shown again.\n", raw)
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		keys, err := handler.ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		printAPIKeys(out, keys...)
+	case "rotate":
+		id := fs.Int64("id", 0, "id of the api key to rotate")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		key, raw, err := handler.RotateAPIKey(ctx, *id)
+		if err != nil {
+			return err
+		}
+		printAPIKeys(out, key)
+		_, _ = fmt.Fprintf(out, "\nkey: %s\nstore it now, it can't be shown again.\n", raw)
+	case "revoke":
+		id := fs.Int64("id", 0, "id of the api key to revoke")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := handler.RevokeAPIKey(ctx, *id); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "revoked api key %d\n", *id)
+	default:
+		return fmt.Errorf("unknown apikeys command %q\n%s", args[0], apiKeysUsage)
+	}
+	return nil
+}
+
+func printAPIKeys(out io.Writer, keys ...business.APIKey) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tPREFIX\tMINUTE QUOTA\tDAILY QUOTA\tCREATED AT\tREVOKED AT")
+	for _, k := range keys {
+		revokedAt := "-"
+		if k.RevokedAt != nil {
+			revokedAt = k.RevokedAt.Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\t%s\n",
+			k.ID, k.Name, k.Prefix, k.MinuteQuota, k.DailyQuota, k.CreatedAt.Format(time.RFC3339), revokedAt)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/export"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediadb"
+	"github.com/jmoiron/sqlx"
+)
+
+// RunExport runs the export command with the given args, writing the stored searches to the -output file
+// and a summary to out.
+func RunExport(ctx context.Context, db *sqlx.DB, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(out)
+	formatName := fs.String("format", string(export.FormatCSV), "export format, one of csv, ndjson or parquet")
+	output := fs.String("output", "", "file to write to, searches.<format> by default and - for stdout")
+	term := fs.String("term", "", "only export searches of this term, matched case-insensitively")
+	since := fs.String("since", "", "only export searches stored from this RFC 3339 timestamp")
+	until := fs.String("until", "", "only export searches stored before this RFC 3339 timestamp")
+	limit := fs.Int("limit", 0, "number of searches to export, 0 for every search")
+	offset := fs.Int("offset", 0, "number of searches to skip")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	filter := business.HistoryFilter{Term: *term, Limit: *limit, Offset: *offset}
+	if filter.Since, err = parseFlagTime("since", *since); err != nil {
+		return err
+	}
+	if filter.Until, err = parseFlagTime("until", *until); err != nil {
+		return err
+	}
+	if *limit < 0 || *offset < 0 {
+		return errors.New("-limit and -offset should be non-negative numbers")
+	}
+
+	path := *output
+	if path == "" {
+		path = "searches." + string(format)
+	}
+	var dst io.Writer = out
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer f.Close()
+		dst = f
+	}
+	rows, err := exportSearches(ctx, business.NewSearchExportHandler(mediadb.NewMediaRepository(db)), filter, format, dst)
+	if err != nil {
+		if path != "-" {
+			// a partial export would pass for a complete one.
+			_ = os.Remove(path)
+		}
+		return err
+	}
+	if path != "-" {
+		_, _ = fmt.Fprintf(out, "exported %d rows to %s\n", rows, path)
+	}
+	return nil
+}
+
+// exportSearches writes the searches matching filter to dst in the given format, returning the number of rows.
+func exportSearches(ctx context.Context, handler business.SearchExportHandler, filter business.HistoryFilter, format export.Format, dst io.Writer) (int, error) {
+	w, err := export.NewWriter(format, dst)
+	if err != nil {
+		return 0, err
+	}
+	var rows int
+	err = handler.ExportSearches(ctx, filter, func(row business.ExportRow) error {
+		rows++
+		return w.Write(row)
+	})
+	if err != nil {
+		return rows, fmt.Errorf("failed to export searches: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return rows, err
+	}
+	return rows, nil
+}
+
+// parseFlagTime parses the optional RFC 3339 value of the flag name.
+func parseFlagTime(name, v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("-%s should be an RFC 3339 timestamp", name)
+	}
+	return t, nil
+}
@@ -0,0 +1,112 @@
+package broker
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Message represents a message published to a broker.
+type Message struct {
+	// ID identifies the message, a message published more than once keeps its id.
+	ID      string
+	Subject string
+	Data    []byte
+}
+
+// MemoryBroker is a broker delivering messages to the subscribers of the same process, it is meant for
+// development and tests since messages are lost with the process.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]*subscription
+}
+
+// subscription represents the subscriber of the subjects matching pattern.
+type subscription struct {
+	pattern  string
+	messages chan Message
+	done     chan struct{}
+}
+
+// NewMemoryBroker creates a new instance of MemoryBroker.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subs: map[int]*subscription{}}
+}
+
+// Subscribe returns the messages published to the subjects matching pattern from now on, along with the function
+// ending the subscription. Patterns follow the NATS syntax, * matches a single token and a trailing > the rest of
+// the subject. Publishing waits for subscribers whose buffer of size messages is full.
+func (b *MemoryBroker) Subscribe(pattern string, size int) (<-chan Message, func()) {
+	sub := &subscription{pattern: pattern, messages: make(chan Message, size), done: make(chan struct{})}
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	return sub.messages, func() { b.unsubscribe(id) }
+}
+
+// unsubscribe ends the subscription with the given id, ended subscriptions are skipped.
+func (b *MemoryBroker) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if sub, ok := b.subs[id]; ok {
+		delete(b.subs, id)
+		close(sub.done)
+	}
+}
+
+// Close ends every subscription.
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	ids := make([]int, 0, len(b.subs))
+	for id := range b.subs {
+		ids = append(ids, id)
+	}
+	b.mu.Unlock()
+	for _, id := range ids {
+		b.unsubscribe(id)
+	}
+	return nil
+}
+
+// Publish delivers data to the subscribers of subject, it returns once every one of them received it or ctx is done.
+// Messages published without subscribers are dropped.
+func (b *MemoryBroker) Publish(ctx context.Context, subject, id string, data []byte) error {
+	b.mu.Lock()
+	var subs []*subscription
+	for _, sub := range b.subs {
+		if matchSubject(sub.pattern, subject) {
+			subs = append(subs, sub)
+		}
+	}
+	b.mu.Unlock()
+
+	msg := Message{ID: id, Subject: subject, Data: data}
+	for _, sub := range subs {
+		select {
+		case sub.messages <- msg:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// matchSubject reports whether subject matches pattern.
+func matchSubject(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+	for i, token := range patternTokens {
+		if token == ">" && i == len(patternTokens)-1 {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+	return len(patternTokens) == len(subjectTokens)
+}
@@ -0,0 +1,53 @@
+package broker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/broker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	b := broker.NewMemoryBroker()
+	performed, stopPerformed := b.Subscribe("mediascout.search.performed", 1)
+	defer stopPerformed()
+	all, stopAll := b.Subscribe("mediascout.>", 2)
+	defer stopAll()
+	discovered, stopDiscovered := b.Subscribe("mediascout.*.discovered", 1)
+	defer stopDiscovered()
+
+	assert.NoError(t, b.Publish(context.Background(), "mediascout.search.performed", "1", []byte(`{"media_result_id":1}`)))
+	assert.NoError(t, b.Publish(context.Background(), "mediascout.media.discovered", "2", []byte(`{"media_result_id":1}`)))
+
+	assert.Equal(t, broker.Message{ID: "1", Subject: "mediascout.search.performed", Data: []byte(`{"media_result_id":1}`)}, <-performed)
+	assert.Equal(t, "1", (<-all).ID)
+	assert.Equal(t, "2", (<-all).ID)
+	assert.Equal(t, "2", (<-discovered).ID)
+	assert.Empty(t, performed)
+}
+
+func TestMemoryBroker_Publish(t *testing.T) {
+	t.Run("full subscriber", func(t *testing.T) {
+		b := broker.NewMemoryBroker()
+		_, stop := b.Subscribe("mediascout.>", 0)
+		defer stop()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := b.Publish(ctx, "mediascout.search.performed", "1", nil)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("ended subscription", func(t *testing.T) {
+		b := broker.NewMemoryBroker()
+		messages, stop := b.Subscribe("mediascout.>", 0)
+		stop()
+		stop()
+
+		assert.NoError(t, b.Publish(context.Background(), "mediascout.search.performed", "1", nil))
+		assert.Empty(t, messages)
+	})
+}
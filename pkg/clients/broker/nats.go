@@ -0,0 +1,51 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSBroker is a broker publishing messages to a NATS server. The id of a message is sent as its Nats-Msg-Id
+// header so JetStream streams capturing its subject drop the messages published twice.
+type NATSBroker struct {
+	conn    *nats.Conn
+	timeout time.Duration
+}
+
+// NewNATSBroker connects to the NATS server at url, publishing waits up to timeout for the server to acknowledge a
+// message. The connection is reestablished whenever it is lost until Close is called.
+func NewNATSBroker(url, name string, timeout time.Duration) (*NATSBroker, error) {
+	conn, err := nats.Connect(url, nats.Name(name), nats.MaxReconnects(-1), nats.Timeout(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	return &NATSBroker{conn: conn, timeout: timeout}, nil
+}
+
+// Publish sends data to subject and waits for the server to acknowledge it, so a message isn't reported published
+// while it is only buffered by the connection.
+func (b *NATSBroker) Publish(ctx context.Context, subject, id string, data []byte) error {
+	msg := nats.NewMsg(subject)
+	msg.Header.Set(nats.MsgIdHdr, id)
+	msg.Data = data
+	if err := b.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+	if err := b.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush message: %w", err)
+	}
+	return nil
+}
+
+// Close publishes the buffered messages and closes the connection.
+func (b *NATSBroker) Close() error {
+	if err := b.conn.Drain(); err != nil {
+		return fmt.Errorf("failed to drain nats connection: %w", err)
+	}
+	return nil
+}
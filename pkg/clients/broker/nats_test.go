@@ -0,0 +1,57 @@
+package broker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/broker"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runNATSServer starts a local nats-server on a random port, it is shut down once the test ends.
+func runNATSServer(t *testing.T) *server.Server {
+	t.Helper()
+	ns, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, NoLog: true, NoSigs: true})
+	require.NoError(t, err)
+	go ns.Start()
+	require.True(t, ns.ReadyForConnections(5*time.Second), "nats-server isn't ready")
+	t.Cleanup(ns.Shutdown)
+	return ns
+}
+
+func TestNATSBroker_Publish(t *testing.T) {
+	ns := runNATSServer(t)
+	conn, err := nats.Connect(ns.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	sub, err := conn.SubscribeSync("mediascout.>")
+	require.NoError(t, err)
+	require.NoError(t, conn.Flush())
+
+	b, err := broker.NewNATSBroker(ns.ClientURL(), "media_scout.test", time.Second)
+	require.NoError(t, err)
+	defer b.Close()
+
+	err = b.Publish(context.Background(), "mediascout.search.performed", "1", []byte(`{"media_result_id":1}`))
+
+	assert.NoError(t, err)
+	msg, err := sub.NextMsg(time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "mediascout.search.performed", msg.Subject)
+	assert.Equal(t, "1", msg.Header.Get(nats.MsgIdHdr))
+	assert.Equal(t, []byte(`{"media_result_id":1}`), msg.Data)
+}
+
+func TestNewNATSBroker(t *testing.T) {
+	ns := runNATSServer(t)
+	url := ns.ClientURL()
+	ns.Shutdown()
+
+	_, err := broker.NewNATSBroker(url, "media_scout.test", 100*time.Millisecond)
+
+	assert.ErrorContains(t, err, "failed to connect to nats")
+}
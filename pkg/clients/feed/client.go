@@ -0,0 +1,87 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/publicnet"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// maxFeedSize is the largest feed read, feeds of long running podcasts reach a few megabytes.
+const maxFeedSize = 32 << 20
+
+// Client represents the client fetching RSS and Atom feeds.
+type Client struct {
+	httpClient   http.Client
+	userAgent    string
+	allowPrivate bool
+}
+
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithPrivateAddresses lets the client fetch feeds from addresses which aren't public, for feeds on a trusted network.
+func WithPrivateAddresses() Option {
+	return func(c *Client) {
+		c.allowPrivate = true
+	}
+}
+
+// NewClient creates a new feed client identifying itself with userAgent, every fetch is abandoned once timeout
+// elapses, including the read of the feed. Feed urls come from upstream, so only public addresses are dialed,
+// redirects included.
+func NewClient(tracer *trace.TracerProvider, userAgent string, timeout time.Duration, opts ...Option) *Client {
+	c := &Client{userAgent: userAgent}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.httpClient = http.Client{
+		Transport: otelhttp.NewTransport(publicnet.NewTransport(c.allowPrivate), otelhttp.WithTracerProvider(tracer)),
+		Timeout:   timeout,
+	}
+	return c
+}
+
+// Fetch fetches and parses the feed at url. The request is conditional when etag or lastModified, the validators of
+// an earlier fetch, are set: the returned feed is then only NotModified when the feed didn't change since.
+func (c *Client) Fetch(ctx context.Context, url, etag, lastModified string) (Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Feed{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8")
+	req.Header.Set("User-Agent", c.userAgent)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Feed{}, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return Feed{NotModified: true, ETag: etag, LastModified: lastModified}, nil
+	default:
+		return Feed{}, fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+	}
+
+	feed, err := Parse(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return Feed{}, err
+	}
+	feed.ETag = resp.Header.Get("ETag")
+	feed.LastModified = resp.Header.Get("Last-Modified")
+	return feed, nil
+}
@@ -0,0 +1,179 @@
+package feed_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/feed"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/publicnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title> The Daily </title>
+    <item>
+      <guid isPermaLink="false">ep-2</guid>
+      <title>Episode 2</title>
+      <description><![CDATA[<p>Second</p>]]></description>
+      <link>https://example.com/2</link>
+      <pubDate>Mon, 19 Oct 2026 10:00:00 +0000</pubDate>
+      <itunes:duration>01:02:03</itunes:duration>
+      <enclosure url="https://cdn.example.com/2.mp3" type="audio/mpeg" length="1"/>
+    </item>
+    <item>
+      <title>Episode 1</title>
+      <itunes:summary>First</itunes:summary>
+      <pubDate>Sun, 4 Oct 2026 10:00:00 GMT</pubDate>
+      <itunes:duration>1800</itunes:duration>
+      <enclosure url="https://cdn.example.com/1.mp3" type="audio/mpeg" length="1"/>
+    </item>
+    <item>
+      <title>Without identifier</title>
+    </item>
+  </channel>
+</rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom Cast</title>
+  <entry>
+    <id>urn:uuid:1</id>
+    <title>Entry 1</title>
+    <summary>Summary</summary>
+    <updated>2026-10-19T10:00:00Z</updated>
+    <link href="https://example.com/1"/>
+    <link rel="enclosure" type="audio/mpeg" href="https://cdn.example.com/1.mp3"/>
+  </entry>
+</feed>`
+
+func TestParse(t *testing.T) {
+	t.Run("rss", func(t *testing.T) {
+		f, err := feed.Parse(strings.NewReader(rssFeed))
+
+		require.NoError(t, err)
+		assert.Equal(t, feed.Feed{
+			Title: "The Daily",
+			Items: []feed.Item{
+				{
+					GUID:          "ep-2",
+					Title:         "Episode 2",
+					Description:   "<p>Second</p>",
+					Link:          "https://example.com/2",
+					EnclosureURL:  "https://cdn.example.com/2.mp3",
+					EnclosureType: "audio/mpeg",
+					PublishedAt:   time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+					Duration:      time.Hour + 2*time.Minute + 3*time.Second,
+				},
+				{
+					GUID:          "https://cdn.example.com/1.mp3",
+					Title:         "Episode 1",
+					Description:   "First",
+					EnclosureURL:  "https://cdn.example.com/1.mp3",
+					EnclosureType: "audio/mpeg",
+					PublishedAt:   time.Date(2026, 10, 4, 10, 0, 0, 0, time.UTC),
+					Duration:      30 * time.Minute,
+				},
+			},
+		}, f)
+	})
+
+	t.Run("atom", func(t *testing.T) {
+		f, err := feed.Parse(strings.NewReader(atomFeed))
+
+		require.NoError(t, err)
+		assert.Equal(t, feed.Feed{
+			Title: "Atom Cast",
+			Items: []feed.Item{{
+				GUID:          "urn:uuid:1",
+				Title:         "Entry 1",
+				Description:   "Summary",
+				Link:          "https://example.com/1",
+				EnclosureURL:  "https://cdn.example.com/1.mp3",
+				EnclosureType: "audio/mpeg",
+				PublishedAt:   time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+			}},
+		}, f)
+	})
+
+	t.Run("latin-1", func(t *testing.T) {
+		doc := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rss><channel><title>Caf\xe9</title></channel></rss>"
+
+		f, err := feed.Parse(strings.NewReader(doc))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Café", f.Title)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := feed.Parse(strings.NewReader(`<html><body/></html>`))
+
+		assert.ErrorIs(t, err, feed.ErrUnknownFormat)
+	})
+}
+
+func TestClient_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/slow":
+			<-r.Context().Done()
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 19 Oct 2026 10:00:00 GMT")
+		_, _ = w.Write([]byte(rssFeed))
+	}))
+	defer srv.Close()
+	client := feed.NewClient(trace.NewTracerProvider(), "media-scout-test/1.0", time.Second, feed.WithPrivateAddresses())
+
+	t.Run("fetched", func(t *testing.T) {
+		f, err := client.Fetch(context.Background(), srv.URL, "", "")
+
+		require.NoError(t, err)
+		assert.False(t, f.NotModified)
+		assert.Len(t, f.Items, 2)
+		assert.Equal(t, `"v1"`, f.ETag)
+		assert.Equal(t, "Mon, 19 Oct 2026 10:00:00 GMT", f.LastModified)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		f, err := client.Fetch(context.Background(), srv.URL, `"v1"`, "Mon, 19 Oct 2026 10:00:00 GMT")
+
+		require.NoError(t, err)
+		assert.Equal(t, feed.Feed{NotModified: true, ETag: `"v1"`, LastModified: "Mon, 19 Oct 2026 10:00:00 GMT"}, f)
+	})
+
+	t.Run("non-200 response", func(t *testing.T) {
+		_, err := client.Fetch(context.Background(), srv.URL+"/missing", "", "")
+
+		assert.EqualError(t, err, "received non-200 response code: 404")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client := feed.NewClient(trace.NewTracerProvider(), "media-scout-test/1.0", 50*time.Millisecond, feed.WithPrivateAddresses())
+		_, err := client.Fetch(context.Background(), srv.URL+"/slow", "", "")
+
+		assert.ErrorContains(t, err, "Client.Timeout exceeded")
+	})
+
+	t.Run("forbidden address", func(t *testing.T) {
+		client := feed.NewClient(trace.NewTracerProvider(), "media-scout-test/1.0", time.Second)
+		_, err := client.Fetch(context.Background(), srv.URL, "", "")
+
+		assert.ErrorIs(t, err, publicnet.ErrForbiddenAddress)
+	})
+}
@@ -0,0 +1,216 @@
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// ErrUnknownFormat is returned when a document is neither an RSS nor an Atom feed.
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+// Feed represents a parsed RSS or Atom feed. ETag and LastModified are the validators of the fetched feed, used to
+// fetch it again only once it changed.
+type Feed struct {
+	Title        string
+	Items        []Item
+	ETag         string
+	LastModified string
+	// NotModified is set when the feed didn't change since the fetch its validators come from, it is empty then.
+	NotModified bool
+}
+
+// Item represents an entry of a feed, e.g. the episode of a podcast. GUID identifies the item within its feed,
+// it falls back to its enclosure url or link when the feed doesn't set it.
+type Item struct {
+	GUID          string
+	Title         string
+	Description   string
+	Link          string
+	EnclosureURL  string
+	EnclosureType string
+	// PublishedAt is zero when the feed doesn't date the item.
+	PublishedAt time.Time
+	Duration    time.Duration
+}
+
+type (
+	rssDocument struct {
+		Channel struct {
+			Title string    `xml:"title"`
+			Items []rssItem `xml:"item"`
+		} `xml:"channel"`
+	}
+
+	rssItem struct {
+		GUID        string `xml:"guid"`
+		Title       string `xml:"title"`
+		Description string `xml:"description"`
+		Summary     string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+		Link        string `xml:"link"`
+		PubDate     string `xml:"pubDate"`
+		Duration    string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+		Enclosure   struct {
+			URL  string `xml:"url,attr"`
+			Type string `xml:"type,attr"`
+		} `xml:"enclosure"`
+	}
+
+	atomDocument struct {
+		Title   string      `xml:"title"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomEntry struct {
+		ID        string     `xml:"id"`
+		Title     string     `xml:"title"`
+		Summary   string     `xml:"summary"`
+		Content   string     `xml:"content"`
+		Published string     `xml:"published"`
+		Updated   string     `xml:"updated"`
+		Duration  string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+		Links     []atomLink `xml:"link"`
+	}
+
+	atomLink struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+		Type string `xml:"type,attr"`
+	}
+)
+
+// pubDateLayouts are the layouts of the dates of RSS items, RFC 822 dates are written in many variants.
+var pubDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 06 15:04:05 -0700",
+	time.RFC3339,
+}
+
+// Parse parses the RSS or Atom feed read from r, items without any identifier are left out.
+func Parse(r io.Reader) (Feed, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return Feed{}, fmt.Errorf("failed to parse feed: %w", err)
+		}
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch root.Name.Local {
+		case "rss":
+			var doc rssDocument
+			if err := decoder.DecodeElement(&doc, &root); err != nil {
+				return Feed{}, fmt.Errorf("failed to parse rss feed: %w", err)
+			}
+			return parseRSS(doc), nil
+		case "feed":
+			var doc atomDocument
+			if err := decoder.DecodeElement(&doc, &root); err != nil {
+				return Feed{}, fmt.Errorf("failed to parse atom feed: %w", err)
+			}
+			return parseAtom(doc), nil
+		default:
+			return Feed{}, fmt.Errorf("%w: root element %s", ErrUnknownFormat, root.Name.Local)
+		}
+	}
+}
+
+// parseRSS maps an RSS document to a Feed.
+func parseRSS(doc rssDocument) Feed {
+	feed := Feed{Title: strings.TrimSpace(doc.Channel.Title)}
+	for _, i := range doc.Channel.Items {
+		item := Item{
+			GUID:          firstNonEmpty(i.GUID, i.Enclosure.URL, i.Link),
+			Title:         strings.TrimSpace(i.Title),
+			Description:   strings.TrimSpace(firstNonEmpty(i.Description, i.Summary)),
+			Link:          strings.TrimSpace(i.Link),
+			EnclosureURL:  strings.TrimSpace(i.Enclosure.URL),
+			EnclosureType: i.Enclosure.Type,
+			PublishedAt:   parseDate(i.PubDate, pubDateLayouts),
+			Duration:      parseDuration(i.Duration),
+		}
+		if item.GUID != "" {
+			feed.Items = append(feed.Items, item)
+		}
+	}
+	return feed
+}
+
+// parseAtom maps an Atom document to a Feed, the enclosure of an entry is its link of rel enclosure.
+func parseAtom(doc atomDocument) Feed {
+	feed := Feed{Title: strings.TrimSpace(doc.Title)}
+	for _, e := range doc.Entries {
+		item := Item{
+			Title:       strings.TrimSpace(e.Title),
+			Description: strings.TrimSpace(firstNonEmpty(e.Summary, e.Content)),
+			PublishedAt: parseDate(firstNonEmpty(e.Published, e.Updated), []string{time.RFC3339}),
+			Duration:    parseDuration(e.Duration),
+		}
+		for _, link := range e.Links {
+			switch link.Rel {
+			case "enclosure":
+				item.EnclosureURL, item.EnclosureType = strings.TrimSpace(link.Href), link.Type
+			case "", "alternate":
+				item.Link = strings.TrimSpace(link.Href)
+			}
+		}
+		item.GUID = firstNonEmpty(e.ID, item.EnclosureURL, item.Link)
+		if item.GUID != "" {
+			feed.Items = append(feed.Items, item)
+		}
+	}
+	return feed
+}
+
+// parseDate parses s with the first matching layout, it returns the zero time when none matches.
+func parseDate(s string, layouts []string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// parseDuration parses an itunes:duration, written either in seconds or as [HH:]MM:SS, it returns zero when s is
+// malformed.
+func parseDuration(s string) time.Duration {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) > 3 {
+		return 0
+	}
+	var seconds int
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// firstNonEmpty returns the first of values which isn't blank, trimmed.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -4,9 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	meterName = "github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	// baseURL is the url the iTunes API endpoints are relative to.
+	baseURL = "https://itunes.apple.com/"
+	// rateLimitKey is the bucket of the calls made to the iTunes API.
+	rateLimitKey = "itunes"
+	// maxLookupLimit is the most related media a lookup returns, the upper bound of the iTunes API.
+	maxLookupLimit = 200
 )
 
 // Media represents a single media item with various attributes.
@@ -38,6 +57,29 @@ type Media struct {
 	ArtworkURL600          string   `json:"artworkUrl600"`
 	GenreIDs               []string `json:"genreIds"`
 	Genres                 []string `json:"genres"`
+	// the fields below are only set on software, FileSizeBytes is a number sent as a string.
+	BundleID          string   `json:"bundleId"`
+	AverageUserRating float64  `json:"averageUserRating"`
+	UserRatingCount   int      `json:"userRatingCount"`
+	ScreenshotURLs    []string `json:"screenshotUrls"`
+	SupportedDevices  []string `json:"supportedDevices"`
+	FileSizeBytes     string   `json:"fileSizeBytes"`
+	MinimumOSVersion  string   `json:"minimumOsVersion"`
+	SellerName        string   `json:"sellerName"`
+	Price             float64  `json:"price"`
+	FormattedPrice    string   `json:"formattedPrice"`
+	// the fields below are set on movies and tv shows, a tv season is a collection of tv episodes numbered by
+	// TrackNumber. Songs and albums are priced by TrackPrice and CollectionPrice as well.
+	CollectionType     string  `json:"collectionType"`
+	LongDescription    string  `json:"longDescription"`
+	ShortDescription   string  `json:"shortDescription"`
+	TrackNumber        int     `json:"trackNumber"`
+	TrackPrice         float64 `json:"trackPrice"`
+	TrackRentalPrice   float64 `json:"trackRentalPrice"`
+	TrackHDPrice       float64 `json:"trackHdPrice"`
+	TrackHDRentalPrice float64 `json:"trackHdRentalPrice"`
+	CollectionPrice    float64 `json:"collectionPrice"`
+	CollectionHDPrice  float64 `json:"collectionHdPrice"`
 }
 
 // SearchResponse represents the response from the iTunes search API.
@@ -49,40 +91,166 @@ type SearchResponse struct {
 // Client represents the iTunes API client.
 type Client struct {
 	httpClient http.Client
+	metrics    clientMetrics
+	limiter    *ratelimit.Limiter
+	rule       ratelimit.Rule
+}
+
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithRateLimit makes the client wait for a token of rule before every call, clients sharing limiter share the budget.
+func WithRateLimit(limiter *ratelimit.Limiter, rule ratelimit.Rule) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+		c.rule = rule
+	}
+}
+
+// clientMetrics holds the instruments recorded for every call made to the iTunes API.
+type clientMetrics struct {
+	requests otelmetric.Int64Counter
+	errors   otelmetric.Int64Counter
+	duration otelmetric.Float64Histogram
 }
 
 // NewClient creates a new iTunes API client.
-func NewClient(tracer *trace.TracerProvider) *Client {
+func NewClient(tracer *trace.TracerProvider, meter *metric.MeterProvider, opts ...Option) *Client {
 	otelTransport := otelhttp.NewTransport(nil, otelhttp.WithTracerProvider(tracer))
-	return &Client{
+	c := &Client{
 		httpClient: http.Client{
 			Transport: otelTransport,
 		},
+		metrics: newClientMetrics(meter),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func newClientMetrics(meter *metric.MeterProvider) clientMetrics {
+	var m otelmetric.Meter = noop.NewMeterProvider().Meter(meterName)
+	if meter != nil {
+		m = meter.Meter(meterName)
+	}
+	// instrument creation only fails on invalid names, a noop instrument is returned alongside the error.
+	requests, _ := m.Int64Counter("itunes.client.requests",
+		otelmetric.WithDescription("Number of requests sent to the iTunes API."))
+	errors, _ := m.Int64Counter("itunes.client.errors",
+		otelmetric.WithDescription("Number of failed requests sent to the iTunes API."))
+	duration, _ := m.Float64Histogram("itunes.client.duration",
+		otelmetric.WithDescription("Duration of requests sent to the iTunes API."),
+		otelmetric.WithUnit("s"))
+	return clientMetrics{requests: requests, errors: errors, duration: duration}
+}
+
+// record records a finished call to the iTunes API, reason is empty for successful calls.
+func (m clientMetrics) record(ctx context.Context, endpoint string, status int, reason string, start time.Time) {
+	attrs := []attribute.KeyValue{
+		attribute.String("itunes.endpoint", endpoint),
+		attribute.Int("http.response.status_code", status),
+	}
+	m.requests.Add(ctx, 1, otelmetric.WithAttributes(attrs...))
+	m.duration.Record(ctx, time.Since(start).Seconds(), otelmetric.WithAttributes(attrs...))
+	if reason != "" {
+		m.errors.Add(ctx, 1, otelmetric.WithAttributes(append(attrs, attribute.String("error.type", reason))...))
 	}
 }
 
 // Search fetches media items from the iTunes API based on the search term.
 func (c *Client) Search(ctx context.Context, term string, limit int) (SearchResponse, error) {
-	url := fmt.Sprintf("https://itunes.apple.com/search?term=%s&limit=%d", term, limit)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	return c.get(ctx, "search", url.Values{
+		"term":  {term},
+		"limit": {strconv.Itoa(limit)},
+	})
+}
+
+// SearchMedia fetches media items of the given media type, e.g. music or software, from the iTunes API based on the
+// search term.
+func (c *Client) SearchMedia(ctx context.Context, term, media string, limit int) (SearchResponse, error) {
+	return c.get(ctx, "search", url.Values{
+		"term":  {term},
+		"media": {media},
+		"limit": {strconv.Itoa(limit)},
+	})
+}
+
+// Lookup fetches the media items with the given iTunes ids in a single call, unknown ids are left out of the response.
+func (c *Client) Lookup(ctx context.Context, ids ...int) (SearchResponse, error) {
+	return c.get(ctx, "lookup", url.Values{"id": {joinIDs(ids)}})
+}
+
+// LookupInCountry fetches the media items with the given iTunes ids as sold in the storefront of the given country,
+// an ISO 3166-1 alpha-2 code such as gb. Ids not sold in the storefront are left out of the response.
+func (c *Client) LookupInCountry(ctx context.Context, country string, ids ...int) (SearchResponse, error) {
+	return c.get(ctx, "lookup", url.Values{
+		"id":      {joinIDs(ids)},
+		"country": {country},
+	})
+}
+
+// LookupEntity fetches the media items of the given entity, e.g. album or song, related to the given iTunes ids,
+// most recent first. The items of the ids themselves, such as the artist wrapper, are part of the response.
+func (c *Client) LookupEntity(ctx context.Context, entity string, limit int, ids ...int) (SearchResponse, error) {
+	return c.get(ctx, "lookup", url.Values{
+		"id":     {joinIDs(ids)},
+		"entity": {entity},
+		"limit":  {strconv.Itoa(limit)},
+		"sort":   {"recent"},
+	})
+}
+
+// LookupTVSeason fetches the tv season with the given iTunes collection id along with its episodes.
+func (c *Client) LookupTVSeason(ctx context.Context, collectionID int) (SearchResponse, error) {
+	return c.get(ctx, "lookup", url.Values{
+		"id":     {strconv.Itoa(collectionID)},
+		"entity": {"tvEpisode"},
+		"limit":  {strconv.Itoa(maxLookupLimit)},
+	})
+}
+
+// joinIDs joins ids with commas, as expected by the lookup endpoint.
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// get calls the given iTunes API endpoint with query and decodes its response, query values are escaped.
+// Calls wait for the rate limit of the client first, if any.
+func (c *Client) get(ctx context.Context, endpoint string, query url.Values) (SearchResponse, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, rateLimitKey, c.rule); err != nil {
+			return SearchResponse{}, fmt.Errorf("failed to wait for the iTunes API rate limit: %w", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+endpoint+"?"+query.Encode(), nil)
 	if err != nil {
 		return SearchResponse{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		c.metrics.record(ctx, endpoint, 0, "transport", start)
 		return SearchResponse{}, fmt.Errorf("failed to fetch data from iTunes API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		c.metrics.record(ctx, endpoint, resp.StatusCode, "status", start)
 		return SearchResponse{}, fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
 	}
 
 	var searchResponse SearchResponse
 	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		c.metrics.record(ctx, endpoint, resp.StatusCode, "decode", start)
 		return SearchResponse{}, fmt.Errorf("failed to decode response: %w", err)
 	}
+	c.metrics.record(ctx, endpoint, resp.StatusCode, "", start)
 
 	return searchResponse, nil
 }
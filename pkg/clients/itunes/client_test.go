@@ -0,0 +1,72 @@
+package itunes
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// roundTripFunc answers requests without reaching the iTunes API.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClient_QueryEscaping(t *testing.T) {
+	var requested *http.Request
+	c := NewClient(trace.NewTracerProvider(), nil)
+	c.httpClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"resultCount":0,"results":[]}`))}, nil
+	})
+
+	tests := []struct {
+		name          string
+		call          func() (SearchResponse, error)
+		expectedPath  string
+		expectedQuery map[string]string
+	}{
+		{
+			name:          "search",
+			call:          func() (SearchResponse, error) { return c.Search(context.Background(), "simon & garfunkel", 5) },
+			expectedPath:  "/search",
+			expectedQuery: map[string]string{"term": "simon & garfunkel", "limit": "5"},
+		},
+		{
+			name: "search media",
+			call: func() (SearchResponse, error) {
+				return c.SearchMedia(context.Background(), "notes#1", "software&entity=album", 5)
+			},
+			expectedPath:  "/search",
+			expectedQuery: map[string]string{"term": "notes#1", "media": "software&entity=album", "limit": "5"},
+		},
+		{
+			name:          "lookup in country",
+			call:          func() (SearchResponse, error) { return c.LookupInCountry(context.Background(), "gb&id=1", 1, 2) },
+			expectedPath:  "/lookup",
+			expectedQuery: map[string]string{"id": "1,2", "country": "gb&id=1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.call()
+
+			require.NoError(t, err)
+			assert.Equal(t, "itunes.apple.com", requested.URL.Host)
+			assert.Equal(t, tt.expectedPath, requested.URL.Path)
+			query := requested.URL.Query()
+			assert.Len(t, query, len(tt.expectedQuery))
+			for key, value := range tt.expectedQuery {
+				assert.Equal(t, []string{value}, query[key], key)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package musicbrainz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// rateLimitKey is the bucket of the calls made to the MusicBrainz API.
+const rateLimitKey = "musicbrainz"
+
+type (
+	// Artist represents a MusicBrainz artist.
+	Artist struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// ArtistCredit represents an artist credited on a recording, Name is how the artist is credited and JoinPhrase
+	// what separates it from the next credit, e.g. " feat. ".
+	ArtistCredit struct {
+		Name       string `json:"name"`
+		JoinPhrase string `json:"joinphrase"`
+		Artist     Artist `json:"artist"`
+	}
+
+	// Release represents a release a recording appears on.
+	Release struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		Date    string `json:"date"`
+		Country string `json:"country"`
+	}
+
+	// Tag represents a folksonomy tag of a recording.
+	Tag struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	// Recording represents a MusicBrainz recording, Length is in milliseconds.
+	Recording struct {
+		ID               string         `json:"id"`
+		Score            int            `json:"score"`
+		Title            string         `json:"title"`
+		Length           int            `json:"length"`
+		Disambiguation   string         `json:"disambiguation"`
+		FirstReleaseDate string         `json:"first-release-date"`
+		ArtistCredit     []ArtistCredit `json:"artist-credit"`
+		Releases         []Release      `json:"releases"`
+		Tags             []Tag          `json:"tags"`
+		ISRCs            []string       `json:"isrcs"`
+	}
+
+	// SearchResponse represents the response from the MusicBrainz recording search API.
+	SearchResponse struct {
+		Count      int         `json:"count"`
+		Offset     int         `json:"offset"`
+		Recordings []Recording `json:"recordings"`
+	}
+)
+
+// Client represents the MusicBrainz API client.
+type Client struct {
+	httpClient http.Client
+	baseURL    string
+	userAgent  string
+	limiter    *ratelimit.Limiter
+	rule       ratelimit.Rule
+}
+
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithRateLimit makes the client wait for a token of rule before every call, MusicBrainz blocks clients sending
+// more than a call per second.
+func WithRateLimit(limiter *ratelimit.Limiter, rule ratelimit.Rule) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+		c.rule = rule
+	}
+}
+
+// NewClient creates a new MusicBrainz API client calling the API at baseURL, e.g. https://musicbrainz.org/ws/2.
+// userAgent identifies the application since MusicBrainz rejects anonymous clients.
+func NewClient(tracer *trace.TracerProvider, baseURL, userAgent string, opts ...Option) *Client {
+	c := &Client{
+		httpClient: http.Client{
+			Transport: otelhttp.NewTransport(nil, otelhttp.WithTracerProvider(tracer)),
+		},
+		baseURL:   baseURL,
+		userAgent: userAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SearchRecordings fetches the recordings matching term, best match first.
+func (c *Client) SearchRecordings(ctx context.Context, term string, limit int) (SearchResponse, error) {
+	query := url.Values{"query": {term}, "limit": {strconv.Itoa(limit)}, "fmt": {"json"}}
+	return c.get(ctx, c.baseURL+"/recording?"+query.Encode())
+}
+
+// get calls the given MusicBrainz API url and decodes its response.
+// Calls wait for the rate limit of the client first, if any.
+func (c *Client) get(ctx context.Context, url string) (SearchResponse, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, rateLimitKey, c.rule); err != nil {
+			return SearchResponse{}, fmt.Errorf("failed to wait for the MusicBrainz API rate limit: %w", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return SearchResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return SearchResponse{}, fmt.Errorf("failed to fetch data from MusicBrainz API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return SearchResponse{}, fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+	}
+
+	var searchResponse SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		return SearchResponse{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return searchResponse, nil
+}
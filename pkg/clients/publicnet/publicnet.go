@@ -0,0 +1,53 @@
+// Package publicnet dials public addresses only, for clients reaching urls given by customers or found upstream.
+package publicnet
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a host resolves to an address which isn't public.
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// IsPublicAddress reports whether addr may be reached: loopback, link-local, private, multicast and unspecified
+// addresses are internal to the network the service runs in.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsPrivate() &&
+		!addr.IsUnspecified()
+}
+
+// NewTransport returns a clone of the default transport only dialing public addresses, any address is dialed when
+// allowPrivate is set, for hosts on a trusted network. Addresses are checked once resolved so a host can't be rebound
+// to an internal address.
+func NewTransport(allowPrivate bool) *http.Transport {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = checkAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// a proxy would be dialed instead of the host, letting it reach any address.
+	transport.Proxy = nil
+	return transport
+}
+
+// checkAddress is the dialer control rejecting the resolved addresses which aren't public.
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	return nil
+}
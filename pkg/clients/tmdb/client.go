@@ -0,0 +1,91 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// MediaTypeMovie, MediaTypeTV and MediaTypePerson are the media types of the results of a multi search.
+	MediaTypeMovie  = "movie"
+	MediaTypeTV     = "tv"
+	MediaTypePerson = "person"
+)
+
+// Result represents a single result of a multi search, movies have a Title and a ReleaseDate while tv shows
+// and people have a Name and tv shows a FirstAirDate.
+type Result struct {
+	ID               int      `json:"id"`
+	MediaType        string   `json:"media_type"`
+	Title            string   `json:"title"`
+	Name             string   `json:"name"`
+	Overview         string   `json:"overview"`
+	ReleaseDate      string   `json:"release_date"`
+	FirstAirDate     string   `json:"first_air_date"`
+	PosterPath       string   `json:"poster_path"`
+	GenreIDs         []int    `json:"genre_ids"`
+	Adult            bool     `json:"adult"`
+	OriginalLanguage string   `json:"original_language"`
+	OriginCountry    []string `json:"origin_country"`
+}
+
+// SearchResponse represents a page of results from the TMDB search API.
+type SearchResponse struct {
+	Page         int      `json:"page"`
+	Results      []Result `json:"results"`
+	TotalPages   int      `json:"total_pages"`
+	TotalResults int      `json:"total_results"`
+}
+
+// Client represents the TMDB API client.
+type Client struct {
+	httpClient http.Client
+	baseURL    string
+	token      string
+}
+
+// NewClient creates a new TMDB API client calling the API at baseURL, e.g. https://api.themoviedb.org/3,
+// authenticated with the given API read access token.
+func NewClient(tracer *trace.TracerProvider, baseURL, token string) *Client {
+	return &Client{
+		httpClient: http.Client{
+			Transport: otelhttp.NewTransport(nil, otelhttp.WithTracerProvider(tracer)),
+		},
+		baseURL: baseURL,
+		token:   token,
+	}
+}
+
+// SearchMulti fetches the given page of the movies, tv shows and people matching term, pages start at 1.
+func (c *Client) SearchMulti(ctx context.Context, term string, page int) (SearchResponse, error) {
+	query := url.Values{"query": {term}, "page": {strconv.Itoa(page)}, "include_adult": {"false"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/search/multi?"+query.Encode(), nil)
+	if err != nil {
+		return SearchResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return SearchResponse{}, fmt.Errorf("failed to fetch data from TMDB API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return SearchResponse{}, fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+	}
+
+	var searchResponse SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		return SearchResponse{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return searchResponse, nil
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/publicnet"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// HeaderID carries the id of the delivery, it stays the same across the retries of a delivery.
+	HeaderID = "X-Webhook-ID"
+	// HeaderEvent carries the type of the delivered event.
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderTimestamp carries the unix time the payload was signed at.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature carries the signature of the payload, formatted as t=<unix time>,v1=<hex HMAC-SHA256>.
+	HeaderSignature = "X-Webhook-Signature"
+
+	// maxResponseBodySize is the most of a response body read before the connection is reused.
+	maxResponseBodySize = 1 << 12
+)
+
+var (
+	// ErrInvalidSignature is returned by Verify when the signature doesn't match the payload.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrExpiredSignature is returned by Verify when the signature is older than the tolerance.
+	ErrExpiredSignature = errors.New("expired webhook signature")
+)
+
+// Sign returns the signature header of payload signed with secret at the given time. The HMAC-SHA256 covers
+// "<unix time>.<payload>" so a captured payload can't be replayed with a later timestamp.
+func Sign(secret string, payload []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + ts + ",v1=" + signature(secret, ts, payload)
+}
+
+// Verify checks header is a signature of payload made with secret less than tolerance before now, receivers
+// may use it to authenticate deliveries.
+func Verify(secret, header string, payload []byte, tolerance time.Duration, now time.Time) error {
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)).Abs() > tolerance {
+		return ErrExpiredSignature
+	}
+	expected := []byte(signature(secret, ts, payload))
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// signature returns the hex HMAC-SHA256 of "<ts>.<payload>" keyed with secret.
+func signature(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Client represents the client sending signed payloads to webhooks.
+type Client struct {
+	httpClient   http.Client
+	now          func() time.Time
+	allowPrivate bool
+}
+
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithPrivateAddresses lets the client send to addresses which aren't public, for receivers on a trusted network.
+func WithPrivateAddresses() Option {
+	return func(c *Client) {
+		c.allowPrivate = true
+	}
+}
+
+// NewClient creates a new webhook client, every request is abandoned once timeout elapses. Only public addresses
+// are dialed, they are checked once resolved so a webhook host can't be rebound to an internal address.
+func NewClient(tracer *trace.TracerProvider, timeout time.Duration, opts ...Option) *Client {
+	c := &Client{now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.httpClient = http.Client{
+		Transport: otelhttp.NewTransport(publicnet.NewTransport(c.allowPrivate), otelhttp.WithTracerProvider(tracer)),
+		Timeout:   timeout,
+		// a redirect would resend the payload somewhere the customer didn't subscribe.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return c
+}
+
+// Send posts payload to url signed with secret and returns the status code it was answered with, zero when url
+// couldn't be reached. Responses other than 2xx are errors.
+func (c *Client) Send(ctx context.Context, url, secret string, deliveryID int64, event string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	now := c.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "media-scout-webhooks/1")
+	req.Header.Set(HeaderID, strconv.FormatInt(deliveryID, 10))
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(secret, payload, now))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("received non-2xx response code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
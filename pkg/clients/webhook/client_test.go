@@ -0,0 +1,111 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/publicnet"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/webhook"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSend(t *testing.T) {
+	payload := []byte(`{"type":"search.stored","data":{"id":1}}`)
+	tests := []struct {
+		name          string
+		status        int
+		expectedError string
+	}{
+		{name: "delivered", status: http.StatusNoContent},
+		{name: "rejected", status: http.StatusInternalServerError, expectedError: "received non-2xx response code: 500"},
+		{name: "redirect isn't followed", status: http.StatusFound, expectedError: "received non-2xx response code: 302"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, body)
+				assert.Equal(t, "7", r.Header.Get(webhook.HeaderID))
+				assert.Equal(t, "search.stored", r.Header.Get(webhook.HeaderEvent))
+				assert.NotEmpty(t, r.Header.Get(webhook.HeaderTimestamp))
+				assert.NoError(t, webhook.Verify("whsec_test", r.Header.Get(webhook.HeaderSignature), body, 5*time.Minute, time.Now()))
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+
+			client := webhook.NewClient(trace.NewTracerProvider(), time.Second, webhook.WithPrivateAddresses())
+			status, err := client.Send(context.Background(), receiver.URL, "whsec_test", 7, "search.stored", payload)
+
+			assert.Equal(t, tt.status, status)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSendUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	client := webhook.NewClient(trace.NewTracerProvider(), time.Second, webhook.WithPrivateAddresses())
+	status, err := client.Send(context.Background(), receiver.URL, "whsec_test", 7, "search.stored", []byte(`{}`))
+
+	assert.Zero(t, status)
+	assert.ErrorContains(t, err, "failed to send webhook")
+}
+
+func TestSendForbiddenAddress(t *testing.T) {
+	var received bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		received = true
+	}))
+	defer receiver.Close()
+
+	client := webhook.NewClient(trace.NewTracerProvider(), time.Second)
+	status, err := client.Send(context.Background(), receiver.URL, "whsec_test", 7, "search.stored", []byte(`{}`))
+
+	assert.Zero(t, status)
+	assert.ErrorIs(t, err, publicnet.ErrForbiddenAddress)
+	assert.False(t, received)
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"type":"job.finished"}`)
+	signedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	header := webhook.Sign("whsec_test", payload, signedAt)
+	tests := []struct {
+		name          string
+		secret        string
+		header        string
+		payload       []byte
+		now           time.Time
+		expectedError error
+	}{
+		{name: "valid signature", secret: "whsec_test", header: header, payload: payload, now: signedAt.Add(time.Minute)},
+		{name: "wrong secret", secret: "whsec_other", header: header, payload: payload, now: signedAt, expectedError: webhook.ErrInvalidSignature},
+		{name: "tampered payload", secret: "whsec_test", header: header, payload: []byte(`{"type":"search.stored"}`), now: signedAt, expectedError: webhook.ErrInvalidSignature},
+		{name: "expired signature", secret: "whsec_test", header: header, payload: payload, now: signedAt.Add(time.Hour), expectedError: webhook.ErrExpiredSignature},
+		{name: "malformed header", secret: "whsec_test", header: "v1=abc", payload: payload, now: signedAt, expectedError: webhook.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.header, tt.payload, 5*time.Minute, tt.now)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
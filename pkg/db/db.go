@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"reflect"
 	"unsafe"
@@ -14,7 +15,8 @@ import (
 )
 
 // NewDBConn creates a new db connection and returns *sqlx.DB.
-func NewDBConn(cfg config.DB, tracer *trace.TracerProvider) (*sqlx.DB, error) {
+// When a meter is given, the connection pool stats are exported as gauges.
+func NewDBConn(cfg config.DB, tracer *trace.TracerProvider, meter *metric.MeterProvider) (*sqlx.DB, error) {
 	var opts []otelsql.Option
 	if tracer != nil {
 		opts = append(opts, otelsql.WithTracerProvider(tracer), otelsql.WithSpanOptions(otelsql.SpanOptions{
@@ -22,6 +24,9 @@ func NewDBConn(cfg config.DB, tracer *trace.TracerProvider) (*sqlx.DB, error) {
 		}),
 			otelsql.WithAttributes(extractAttrsFromDSN(cfg.DSN)...))
 	}
+	if meter != nil {
+		opts = append(opts, otelsql.WithMeterProvider(meter))
+	}
 	db, err := otelsql.Open("postgres", cfg.DSN, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a db conn: %w", err)
@@ -55,6 +60,12 @@ func NewDBConn(cfg config.DB, tracer *trace.TracerProvider) (*sqlx.DB, error) {
 	// Replace connector value with new instrumented connector
 	connFldMod.Set(connAddr)
 
+	if meter != nil {
+		if err := otelsql.RegisterDBStatsMetrics(db, otelsql.WithMeterProvider(meter), otelsql.WithAttributes(extractAttrsFromDSN(cfg.DSN)...)); err != nil {
+			return nil, fmt.Errorf("failed to register db stats metrics: %w", err)
+		}
+	}
+
 	return sqlx.NewDb(db, "postgres"), nil
 }
 
@@ -0,0 +1,207 @@
+package business
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	apiKeyPrefix      = "msk_"
+	apiKeyIDBytes     = 4
+	apiKeySecretBytes = 24
+)
+
+var (
+	// ErrUnauthorized is returned when a request carries no api key or an unknown or revoked one.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when an authenticated request lacks the permissions it needs.
+	ErrForbidden = errors.New("forbidden")
+	// ErrQuotaExceeded is returned when an api key used up one of its quotas.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrNotFound is returned when the requested resource doesn't exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when the requested change doesn't apply to the current state of the resource.
+	ErrConflict = errors.New("conflict")
+)
+
+// APIKey represents a key clients authenticate with, only its hash is ever stored.
+type APIKey struct {
+	ID          int64
+	Name        string
+	Prefix      string
+	MinuteQuota int
+	DailyQuota  int
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// Quota represents the state of the tightest quota window of an api key after a request.
+type Quota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// QuotaWindow identifies a quota window.
+type QuotaWindow string
+
+const (
+	QuotaWindowMinute QuotaWindow = "minute"
+	QuotaWindowDay    QuotaWindow = "day"
+)
+
+//go:generate mockgen -source=api_key.go -destination=mock/api_key.go -package=mock
+type (
+	// apiKeyRepository defines the interface for api key repository operations.
+	apiKeyRepository interface {
+		InsertAPIKey(ctx context.Context, key APIKey, hash []byte) (APIKey, error)
+		GetAPIKeyByHash(ctx context.Context, hash []byte) (APIKey, error)
+		ListAPIKeys(ctx context.Context) ([]APIKey, error)
+		UpdateAPIKeyHash(ctx context.Context, id int64, prefix string, hash []byte) (APIKey, error)
+		RevokeAPIKey(ctx context.Context, id int64, at time.Time) error
+		IncrementAPIKeyUsage(ctx context.Context, id int64, windows map[QuotaWindow]time.Time) (map[QuotaWindow]int, error)
+	}
+)
+
+type APIKeyHandler struct {
+	repo apiKeyRepository
+	now  func() time.Time
+}
+
+// NewAPIKeyHandler creates a new instance of APIKeyHandler.
+func NewAPIKeyHandler(repo apiKeyRepository) APIKeyHandler {
+	return APIKeyHandler{repo: repo, now: time.Now}
+}
+
+// CreateAPIKey creates a new api key and returns it along with its raw value, which can't be recovered later.
+func (h APIKeyHandler) CreateAPIKey(ctx context.Context, name string, minuteQuota, dailyQuota int) (APIKey, string, error) {
+	prefix, raw, err := generateAPIKey()
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	key, err := h.repo.InsertAPIKey(ctx, APIKey{
+		Name:        name,
+		Prefix:      prefix,
+		MinuteQuota: minuteQuota,
+		DailyQuota:  dailyQuota,
+	}, hashAPIKey(raw))
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("failed to insert api key: %w", err)
+	}
+	return key, raw, nil
+}
+
+// ListAPIKeys lists all api keys, including revoked ones.
+func (h APIKeyHandler) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	keys, err := h.repo.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RotateAPIKey replaces the secret of an api key, the previous raw value stops working immediately.
+func (h APIKeyHandler) RotateAPIKey(ctx context.Context, id int64) (APIKey, string, error) {
+	prefix, raw, err := generateAPIKey()
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	key, err := h.repo.UpdateAPIKeyHash(ctx, id, prefix, hashAPIKey(raw))
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("failed to rotate api key: %w", err)
+	}
+	return key, raw, nil
+}
+
+// RevokeAPIKey revokes an api key.
+func (h APIKeyHandler) RevokeAPIKey(ctx context.Context, id int64) error {
+	if err := h.repo.RevokeAPIKey(ctx, id, h.now().UTC()); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves the api key of raw and consumes one request of its quotas.
+// The returned quota is valid even when ErrQuotaExceeded is returned.
+func (h APIKeyHandler) Authenticate(ctx context.Context, raw string) (APIKey, Quota, error) {
+	if raw == "" {
+		return APIKey{}, Quota{}, ErrUnauthorized
+	}
+	key, err := h.repo.GetAPIKeyByHash(ctx, hashAPIKey(raw))
+	if errors.Is(err, ErrNotFound) {
+		return APIKey{}, Quota{}, ErrUnauthorized
+	}
+	if err != nil {
+		return APIKey{}, Quota{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+	if key.RevokedAt != nil {
+		return APIKey{}, Quota{}, ErrUnauthorized
+	}
+	if key.MinuteQuota <= 0 && key.DailyQuota <= 0 {
+		return key, Quota{}, nil
+	}
+
+	now := h.now().UTC()
+	windows := map[QuotaWindow]time.Time{
+		QuotaWindowMinute: now.Truncate(time.Minute),
+		QuotaWindowDay:    now.Truncate(24 * time.Hour),
+	}
+	counts, err := h.repo.IncrementAPIKeyUsage(ctx, key.ID, windows)
+	if err != nil {
+		return APIKey{}, Quota{}, fmt.Errorf("failed to increment api key usage: %w", err)
+	}
+
+	var quota Quota
+	exceeded := false
+	for _, w := range []struct {
+		window QuotaWindow
+		limit  int
+		length time.Duration
+	}{
+		{QuotaWindowMinute, key.MinuteQuota, time.Minute},
+		{QuotaWindowDay, key.DailyQuota, 24 * time.Hour},
+	} {
+		if w.limit <= 0 {
+			continue
+		}
+		q := Quota{
+			Limit:     w.limit,
+			Remaining: max(w.limit-counts[w.window], 0),
+			Reset:     windows[w.window].Add(w.length),
+		}
+		// report the window with the least requests left, the one resetting last on ties.
+		if quota.Limit == 0 || q.Remaining < quota.Remaining || q.Remaining == quota.Remaining && q.Reset.After(quota.Reset) {
+			quota = q
+		}
+		exceeded = exceeded || counts[w.window] > w.limit
+	}
+	if exceeded {
+		return key, quota, ErrQuotaExceeded
+	}
+	return key, quota, nil
+}
+
+// generateAPIKey returns a new raw api key and its public prefix, the prefix identifies the key in listings.
+func generateAPIKey() (string, string, error) {
+	id := make([]byte, apiKeyIDBytes)
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	prefix := apiKeyPrefix + hex.EncodeToString(id)
+	return prefix, prefix + "_" + hex.EncodeToString(secret), nil
+}
+
+// hashAPIKey hashes a raw api key, keys are random and long enough for a plain sha256 to be safe.
+func hashAPIKey(raw string) []byte {
+	sum := sha256.Sum256([]byte(raw))
+	return sum[:]
+}
@@ -0,0 +1,145 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockapiKeyRepository(ctrl)
+	handler := business.NewAPIKeyHandler(mockRepo)
+
+	tests := []struct {
+		name          string
+		raw           string
+		mockSetup     func()
+		expectedError error
+		expectedKeyID int64
+		expectedLimit int
+		expectedLeft  int
+	}{
+		{
+			name:          "missing key",
+			raw:           "",
+			mockSetup:     func() {},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name: "unknown key",
+			raw:  "msk_unknown",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(business.APIKey{}, business.ErrNotFound)
+			},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name: "revoked key",
+			raw:  "msk_revoked",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(business.APIKey{ID: 1, RevokedAt: lo.ToPtr(time.Now())}, nil)
+			},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name: "key without quotas skips usage",
+			raw:  "msk_unlimited",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(business.APIKey{ID: 2}, nil)
+			},
+			expectedKeyID: 2,
+		},
+		{
+			name: "tightest window is reported",
+			raw:  "msk_valid",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(business.APIKey{ID: 3, MinuteQuota: 10, DailyQuota: 100}, nil)
+				mockRepo.EXPECT().IncrementAPIKeyUsage(gomock.Any(), int64(3), gomock.Any()).Return(map[business.QuotaWindow]int{
+					business.QuotaWindowMinute: 2,
+					business.QuotaWindowDay:    95,
+				}, nil)
+			},
+			expectedKeyID: 3,
+			expectedLimit: 100,
+			expectedLeft:  5,
+		},
+		{
+			name: "exceeded minute quota",
+			raw:  "msk_valid",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(business.APIKey{ID: 4, MinuteQuota: 10, DailyQuota: 100}, nil)
+				mockRepo.EXPECT().IncrementAPIKeyUsage(gomock.Any(), int64(4), gomock.Any()).Return(map[business.QuotaWindow]int{
+					business.QuotaWindowMinute: 11,
+					business.QuotaWindowDay:    20,
+				}, nil)
+			},
+			expectedError: business.ErrQuotaExceeded,
+			expectedKeyID: 4,
+			expectedLimit: 10,
+			expectedLeft:  0,
+		},
+		{
+			name: "usage error",
+			raw:  "msk_valid",
+			mockSetup: func() {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(business.APIKey{ID: 5, DailyQuota: 100}, nil)
+				mockRepo.EXPECT().IncrementAPIKeyUsage(gomock.Any(), int64(5), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			expectedError: errors.New("failed to increment api key usage: db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			key, quota, err := handler.Authenticate(context.Background(), tt.raw)
+
+			switch {
+			case tt.expectedError == nil:
+				assert.NoError(t, err)
+			case errors.Is(tt.expectedError, business.ErrUnauthorized), errors.Is(tt.expectedError, business.ErrQuotaExceeded):
+				assert.ErrorIs(t, err, tt.expectedError)
+			default:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			}
+			assert.Equal(t, tt.expectedKeyID, key.ID)
+			assert.Equal(t, tt.expectedLimit, quota.Limit)
+			assert.Equal(t, tt.expectedLeft, quota.Remaining)
+		})
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockapiKeyRepository(ctrl)
+	handler := business.NewAPIKeyHandler(mockRepo)
+
+	var storedHash []byte
+	mockRepo.EXPECT().InsertAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key business.APIKey, hash []byte) (business.APIKey, error) {
+			storedHash = hash
+			key.ID = 1
+			return key, nil
+		})
+
+	key, raw, err := handler.CreateAPIKey(context.Background(), "ingestion", 60, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), key.ID)
+	assert.True(t, strings.HasPrefix(raw, key.Prefix+"_"))
+	assert.Len(t, storedHash, 32)
+	assert.NotContains(t, string(storedHash), raw)
+}
@@ -0,0 +1,134 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Offer represents a media as sold in a single iTunes storefront, Price is in Currency and zero for free media.
+type Offer struct {
+	Media    Media
+	Price    float64
+	Currency string
+}
+
+// StorefrontAvailability reports whether a media is sold in the storefront of Country and at which price.
+// NormalizedPrice is Price in the base currency of the policy, nil when there is no exchange rate for Currency.
+// Err is set when the storefront couldn't be looked up.
+type StorefrontAvailability struct {
+	Country         string
+	Available       bool
+	Price           float64
+	Currency        string
+	NormalizedPrice *float64
+	Err             error
+}
+
+// Availability represents a media across several iTunes storefronts. Media is the media as sold in the first
+// storefront it is available in, nil when it isn't available in any of them.
+type Availability struct {
+	ID           int
+	Media        *Media
+	BaseCurrency string
+	Storefronts  []StorefrontAvailability
+}
+
+//go:generate mockgen -source=availability.go -destination=mock/availability.go -package=mock
+type (
+	// storefrontLookup defines the interface for looking a media up in a single storefront.
+	storefrontLookup interface {
+		// LookupOffer returns the media with the given iTunes id as sold in the storefront of country, ErrNotFound is
+		// returned when it isn't sold there.
+		LookupOffer(ctx context.Context, id int, country string) (Offer, error)
+	}
+)
+
+// AvailabilityPolicy defines how availability is checked. Concurrency is the most storefronts looked up at once and
+// ExchangeRates the value of a unit of every currency in BaseCurrency, prices aren't normalized when BaseCurrency is
+// empty.
+type AvailabilityPolicy struct {
+	Concurrency   int
+	BaseCurrency  string
+	ExchangeRates map[string]float64
+}
+
+type AvailabilityHandler struct {
+	lookup storefrontLookup
+	policy AvailabilityPolicy
+	lgr    logger
+}
+
+// NewAvailabilityHandler creates a new instance of AvailabilityHandler.
+func NewAvailabilityHandler(lookup storefrontLookup, policy AvailabilityPolicy, lgr logger) AvailabilityHandler {
+	policy.Concurrency = max(policy.Concurrency, 1)
+	return AvailabilityHandler{lookup: lookup, policy: policy, lgr: lgr}
+}
+
+// CheckAvailability looks the media with the given iTunes id up in the storefront of every given country and reports
+// its availability and price in each, in the order of countries. Storefronts failing to be looked up are reported
+// rather than failing the check, which fails only when every one of them did.
+func (h AvailabilityHandler) CheckAvailability(ctx context.Context, id int, countries []string) (Availability, error) {
+	offers := make([]Offer, len(countries))
+	errs := make([]error, len(countries))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, h.policy.Concurrency)
+	for i, country := range countries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			offers[i], errs[i] = h.lookup.LookupOffer(ctx, id, country)
+		}()
+	}
+	wg.Wait()
+
+	availability := Availability{ID: id, BaseCurrency: h.policy.BaseCurrency}
+	failures := 0
+	for i, country := range countries {
+		storefront := StorefrontAvailability{Country: country}
+		switch err := errs[i]; {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			h.lgr.ErrorContext(ctx, "failed to lookup storefront", "country", country, "error", err)
+			storefront.Err = err
+			failures++
+		default:
+			storefront.Available = true
+			storefront.Price = offers[i].Price
+			storefront.Currency = offers[i].Currency
+			storefront.NormalizedPrice = h.normalize(offers[i].Price, offers[i].Currency)
+			if availability.Media == nil {
+				availability.Media = &offers[i].Media
+			}
+		}
+		availability.Storefronts = append(availability.Storefronts, storefront)
+	}
+	if failures > 0 && failures == len(countries) {
+		return Availability{}, fmt.Errorf("failed to lookup every storefront: %w", errors.Join(errs...))
+	}
+	return availability, nil
+}
+
+// normalize returns price in the base currency of the policy rounded to the cent, nil when it has none or there is no
+// exchange rate for currency.
+func (h AvailabilityHandler) normalize(price float64, currency string) *float64 {
+	if h.policy.BaseCurrency == "" {
+		return nil
+	}
+	rate, ok := h.policy.ExchangeRates[strings.ToUpper(currency)]
+	if strings.EqualFold(currency, h.policy.BaseCurrency) {
+		rate, ok = 1, true
+	}
+	if !ok {
+		return nil
+	}
+	normalized := math.Round(price*rate*100) / 100
+	return &normalized
+}
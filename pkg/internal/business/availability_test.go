@@ -0,0 +1,81 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLookup := mock.NewMockstorefrontLookup(ctrl)
+	mockLogger := mock.NewMocklogger(ctrl)
+	policy := business.AvailabilityPolicy{Concurrency: 2, BaseCurrency: "USD", ExchangeRates: map[string]float64{"GBP": 1.25}}
+	handler := business.NewAvailabilityHandler(mockLookup, policy, mockLogger)
+
+	usSong := business.Media{WrapperType: "track", Kind: "song", TrackID: 456, Country: "USA", Currency: "USD"}
+	gbSong := business.Media{WrapperType: "track", Kind: "song", TrackID: 456, Country: "GBR", Currency: "GBP"}
+
+	t.Run("reports every storefront", func(t *testing.T) {
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "us").Return(business.Offer{Media: usSong, Price: 1.29, Currency: "USD"}, nil)
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "gb").Return(business.Offer{Media: gbSong, Price: 0.99, Currency: "GBP"}, nil)
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "sa").Return(business.Offer{}, business.ErrNotFound)
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "jp").Return(business.Offer{Media: usSong, Price: 250, Currency: "JPY"}, nil)
+
+		availability, err := handler.CheckAvailability(context.Background(), 456, []string{"sa", "us", "gb", "jp"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, business.Availability{
+			ID:           456,
+			Media:        &usSong,
+			BaseCurrency: "USD",
+			Storefronts: []business.StorefrontAvailability{
+				{Country: "sa"},
+				{Country: "us", Available: true, Price: 1.29, Currency: "USD", NormalizedPrice: lo.ToPtr(1.29)},
+				{Country: "gb", Available: true, Price: 0.99, Currency: "GBP", NormalizedPrice: lo.ToPtr(1.24)},
+				{Country: "jp", Available: true, Price: 250, Currency: "JPY"},
+			},
+		}, availability)
+	})
+
+	t.Run("failed storefronts are reported", func(t *testing.T) {
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "us").Return(business.Offer{Media: usSong, Price: 1.29, Currency: "USD"}, nil)
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "gb").Return(business.Offer{}, errors.New("upstream down"))
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to lookup storefront", "country", "gb", "error", gomock.Any())
+
+		availability, err := handler.CheckAvailability(context.Background(), 456, []string{"us", "gb"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []business.StorefrontAvailability{
+			{Country: "us", Available: true, Price: 1.29, Currency: "USD", NormalizedPrice: lo.ToPtr(1.29)},
+			{Country: "gb", Err: errors.New("upstream down")},
+		}, availability.Storefronts)
+	})
+
+	t.Run("every storefront failed", func(t *testing.T) {
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "us").Return(business.Offer{}, errors.New("upstream down"))
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to lookup storefront", "country", "us", "error", gomock.Any())
+
+		_, err := handler.CheckAvailability(context.Background(), 456, []string{"us"})
+
+		assert.EqualError(t, err, "failed to lookup every storefront: upstream down")
+	})
+
+	t.Run("prices aren't normalized without a base currency", func(t *testing.T) {
+		handler := business.NewAvailabilityHandler(mockLookup, business.AvailabilityPolicy{}, mockLogger)
+		mockLookup.EXPECT().LookupOffer(gomock.Any(), 456, "us").Return(business.Offer{Media: usSong, Price: 1.29, Currency: "USD"}, nil)
+
+		availability, err := handler.CheckAvailability(context.Background(), 456, []string{"us"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []business.StorefrontAvailability{{Country: "us", Available: true, Price: 1.29, Currency: "USD"}}, availability.Storefronts)
+	})
+}
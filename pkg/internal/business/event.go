@@ -0,0 +1,87 @@
+package business
+
+import (
+	"context"
+	"time"
+)
+
+// EventType identifies what an event reports.
+type EventType string
+
+const (
+	// EventSearchStored reports a search result got stored.
+	EventSearchStored EventType = "search.stored"
+	// EventReleaseDetected reports a new release of a watched artist.
+	EventReleaseDetected EventType = "release.detected"
+	// EventJobFinished reports a search job succeeded, failed or got canceled.
+	EventJobFinished EventType = "job.finished"
+)
+
+// EventTypes lists every event type.
+var EventTypes = []EventType{EventSearchStored, EventReleaseDetected, EventJobFinished}
+
+// Event represents a change pushed to the subscribers of its type, Data is one of the event data types below.
+type Event struct {
+	Type EventType
+	// Owner is the client the change was made for, only its webhooks are delivered the event.
+	Owner      string
+	OccurredAt time.Time
+	Data       any
+}
+
+// ownerKey is the context key of the client a call is made for.
+type ownerKey struct{}
+
+// WithOwner returns a copy of ctx making calls for owner, the identity of an authenticated client.
+func WithOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+// OwnerFromContext returns the client calls made with ctx are made for, empty when unknown.
+func OwnerFromContext(ctx context.Context) string {
+	owner, _ := ctx.Value(ownerKey{}).(string)
+	return owner
+}
+
+type (
+	// SearchStoredData represents the data of a search.stored event.
+	SearchStoredData struct {
+		ID          int64     `json:"id"`
+		SearchTerm  string    `json:"search_term"`
+		ResultCount int       `json:"result_count"`
+		FetchedAt   time.Time `json:"fetched_at"`
+	}
+
+	// ReleaseDetectedData represents the data of a release.detected event.
+	ReleaseDetectedData struct {
+		ArtistID          int       `json:"artist_id"`
+		ArtistName        string    `json:"artist_name,omitempty"`
+		CollectionID      int       `json:"collection_id"`
+		CollectionName    string    `json:"collection_name,omitempty"`
+		ReleaseDate       time.Time `json:"release_date"`
+		CollectionViewURL string    `json:"collection_view_url,omitempty"`
+	}
+
+	// JobFinishedData represents the data of a job.finished event.
+	JobFinishedData struct {
+		ID        int64           `json:"id"`
+		Status    SearchJobStatus `json:"status"`
+		Attempts  int             `json:"attempts"`
+		LastError string          `json:"last_error,omitempty"`
+	}
+)
+
+//go:generate mockgen -source=event.go -destination=mock/event.go -package=mock
+type (
+	// eventPublisher defines the interface for publishing events.
+	eventPublisher interface {
+		Publish(ctx context.Context, event Event) error
+	}
+)
+
+// publishEvent publishes event, failures are only logged since the change it reports is stored already.
+func publishEvent(ctx context.Context, publisher eventPublisher, lgr logger, event Event) {
+	if err := publisher.Publish(ctx, event); err != nil {
+		lgr.ErrorContext(ctx, "failed to publish event", "type", string(event.Type), "error", err.Error())
+	}
+}
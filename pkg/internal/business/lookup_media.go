@@ -0,0 +1,94 @@
+package business
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// maxLookupBatch is the most ids looked up in a single upstream call.
+const maxLookupBatch = 100
+
+//go:generate mockgen -source=lookup_media.go -destination=mock/lookup_media.go -package=mock
+type (
+	// mediaLookup defines the interface for looking media up by id.
+	mediaLookup interface {
+		LookupMediaByID(ctx context.Context, id int) (MediaResult, error)
+		LookupMediaByIDs(ctx context.Context, ids []int) (MediaResult, error)
+		LookupTVSeason(ctx context.Context, collectionID int) (MediaResult, error)
+	}
+)
+
+// TVSeason represents a tv season along with its episodes.
+type TVSeason struct {
+	Season Media
+	// Episodes are ordered by their number in the season.
+	Episodes []Media
+	// FetchedAt is when the season was fetched from upstream.
+	FetchedAt time.Time
+}
+
+type LookupMediaHandler struct {
+	lookup mediaLookup
+}
+
+// NewLookupMediaHandler creates a new instance of LookupMediaHandler.
+func NewLookupMediaHandler(lookup mediaLookup) LookupMediaHandler {
+	return LookupMediaHandler{lookup: lookup}
+}
+
+// LookupMedia returns the media with the given iTunes id, ErrNotFound is returned when the id is unknown.
+func (h LookupMediaHandler) LookupMedia(ctx context.Context, id int) (MediaResult, error) {
+	result, err := h.lookup.LookupMediaByID(ctx, id)
+	if err != nil {
+		return MediaResult{}, fmt.Errorf("failed to lookup media: %w", err)
+	}
+	if len(result.Media) == 0 {
+		return MediaResult{}, fmt.Errorf("%w: media %d", ErrNotFound, id)
+	}
+	return result, nil
+}
+
+// LookupMediaBatch returns the media with the given iTunes ids, unknown ids are left out of the result.
+// The ids are looked up in as few upstream calls as possible.
+func (h LookupMediaHandler) LookupMediaBatch(ctx context.Context, ids []int) ([]Media, error) {
+	var media []Media
+	for start := 0; start < len(ids); start += maxLookupBatch {
+		result, err := h.lookup.LookupMediaByIDs(ctx, ids[start:min(start+maxLookupBatch, len(ids))])
+		if err != nil {
+			return nil, fmt.Errorf("failed to lookup media: %w", err)
+		}
+		media = append(media, result.Media...)
+	}
+	return media, nil
+}
+
+// ListSeasonEpisodes returns the tv season with the given iTunes collection id along with its episodes, ErrNotFound is
+// returned when the id isn't the one of a tv season.
+func (h LookupMediaHandler) ListSeasonEpisodes(ctx context.Context, collectionID int) (TVSeason, error) {
+	result, err := h.lookup.LookupTVSeason(ctx, collectionID)
+	if err != nil {
+		return TVSeason{}, fmt.Errorf("failed to lookup tv season: %w", err)
+	}
+	season := TVSeason{FetchedAt: result.FetchedAt}
+	found := false
+	for _, m := range result.Media {
+		switch {
+		case m.WrapperType == "collection" && m.CollectionID == collectionID && m.Video != nil:
+			season.Season, found = m, true
+		case m.Kind == "tv-episode":
+			season.Episodes = append(season.Episodes, m)
+		}
+	}
+	if !found {
+		return TVSeason{}, fmt.Errorf("%w: tv season %d", ErrNotFound, collectionID)
+	}
+	slices.SortStableFunc(season.Episodes, func(a, b Media) int {
+		return cmp.Compare(lo.FromPtr(a.Video).EpisodeNumber, lo.FromPtr(b.Video).EpisodeNumber)
+	})
+	return season, nil
+}
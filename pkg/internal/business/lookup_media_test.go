@@ -0,0 +1,204 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLookup := mock.NewMockmediaLookup(ctrl)
+	handler := business.NewLookupMediaHandler(mockLookup)
+
+	found := business.MediaResult{
+		ResultCount: 1,
+		Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456, TrackName: "Test Track"}},
+	}
+
+	tests := []struct {
+		name           string
+		id             int
+		mockSetup      func()
+		expectedError  error
+		expectedResult business.MediaResult
+	}{
+		{
+			name: "found",
+			id:   456,
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaByID(gomock.Any(), 456).Return(found, nil)
+			},
+			expectedResult: found,
+		},
+		{
+			name: "unknown id",
+			id:   1,
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaByID(gomock.Any(), 1).Return(business.MediaResult{}, nil)
+			},
+			expectedError: business.ErrNotFound,
+		},
+		{
+			name: "lookup error",
+			id:   456,
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaByID(gomock.Any(), 456).Return(business.MediaResult{}, errors.New("upstream down"))
+			},
+			expectedError: errors.New("failed to lookup media: upstream down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := handler.LookupMedia(context.Background(), tt.id)
+
+			switch {
+			case errors.Is(tt.expectedError, business.ErrNotFound):
+				assert.ErrorIs(t, err, business.ErrNotFound)
+			case tt.expectedError != nil:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestLookupMediaBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLookup := mock.NewMockmediaLookup(ctrl)
+	handler := business.NewLookupMediaHandler(mockLookup)
+
+	ids := make([]int, 150)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	tests := []struct {
+		name           string
+		ids            []int
+		mockSetup      func()
+		expectedError  string
+		expectedResult []business.Media
+	}{
+		{
+			name: "single call",
+			ids:  []int{1, 2},
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaByIDs(gomock.Any(), []int{1, 2}).
+					Return(business.MediaResult{Media: []business.Media{{TrackID: 1}}}, nil)
+			},
+			expectedResult: []business.Media{{TrackID: 1}},
+		},
+		{
+			name: "split into batches",
+			ids:  ids,
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaByIDs(gomock.Any(), ids[:100]).
+					Return(business.MediaResult{Media: []business.Media{{TrackID: 1}}}, nil)
+				mockLookup.EXPECT().LookupMediaByIDs(gomock.Any(), ids[100:]).
+					Return(business.MediaResult{Media: []business.Media{{TrackID: 150}}}, nil)
+			},
+			expectedResult: []business.Media{{TrackID: 1}, {TrackID: 150}},
+		},
+		{
+			name: "lookup error",
+			ids:  []int{1},
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaByIDs(gomock.Any(), []int{1}).
+					Return(business.MediaResult{}, errors.New("upstream down"))
+			},
+			expectedError: "failed to lookup media: upstream down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := handler.LookupMediaBatch(context.Background(), tt.ids)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestListSeasonEpisodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLookup := mock.NewMockmediaLookup(ctrl)
+	handler := business.NewLookupMediaHandler(mockLookup)
+
+	season := business.Media{WrapperType: "collection", CollectionID: 10, CollectionName: "Show, Season 2", Video: &business.Video{SeasonNumber: 2}}
+	episode := func(number int) business.Media {
+		return business.Media{WrapperType: "track", Kind: "tv-episode", CollectionID: 10, TrackID: 100 + number, Video: &business.Video{SeasonNumber: 2, EpisodeNumber: number}}
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedError  error
+		expectedSeason business.TVSeason
+	}{
+		{
+			name: "episodes ordered by number",
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupTVSeason(gomock.Any(), 10).
+					Return(business.MediaResult{Media: []business.Media{season, episode(2), episode(1)}}, nil)
+			},
+			expectedSeason: business.TVSeason{Season: season, Episodes: []business.Media{episode(1), episode(2)}},
+		},
+		{
+			name: "not a tv season",
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupTVSeason(gomock.Any(), 10).
+					Return(business.MediaResult{Media: []business.Media{{WrapperType: "collection", CollectionID: 10, CollectionName: "Album"}}}, nil)
+			},
+			expectedError: business.ErrNotFound,
+		},
+		{
+			name: "lookup error",
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupTVSeason(gomock.Any(), 10).Return(business.MediaResult{}, errors.New("upstream down"))
+			},
+			expectedError: errors.New("failed to lookup tv season: upstream down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := handler.ListSeasonEpisodes(context.Background(), 10)
+
+			switch {
+			case errors.Is(tt.expectedError, business.ErrNotFound):
+				assert.ErrorIs(t, err, business.ErrNotFound)
+			case tt.expectedError != nil:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSeason, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockapiKeyRepository is a mock of apiKeyRepository interface.
+type MockapiKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyRepositoryMockRecorder
+}
+
+// MockapiKeyRepositoryMockRecorder is the mock recorder for MockapiKeyRepository.
+type MockapiKeyRepositoryMockRecorder struct {
+	mock *MockapiKeyRepository
+}
+
+// NewMockapiKeyRepository creates a new mock instance.
+func NewMockapiKeyRepository(ctrl *gomock.Controller) *MockapiKeyRepository {
+	mock := &MockapiKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockapiKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyRepository) EXPECT() *MockapiKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockapiKeyRepository) GetAPIKeyByHash(ctx context.Context, hash []byte) (business.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, hash)
+	ret0, _ := ret[0].(business.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockapiKeyRepositoryMockRecorder) GetAPIKeyByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockapiKeyRepository)(nil).GetAPIKeyByHash), ctx, hash)
+}
+
+// IncrementAPIKeyUsage mocks base method.
+func (m *MockapiKeyRepository) IncrementAPIKeyUsage(ctx context.Context, id int64, windows map[business.QuotaWindow]time.Time) (map[business.QuotaWindow]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementAPIKeyUsage", ctx, id, windows)
+	ret0, _ := ret[0].(map[business.QuotaWindow]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementAPIKeyUsage indicates an expected call of IncrementAPIKeyUsage.
+func (mr *MockapiKeyRepositoryMockRecorder) IncrementAPIKeyUsage(ctx, id, windows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAPIKeyUsage", reflect.TypeOf((*MockapiKeyRepository)(nil).IncrementAPIKeyUsage), ctx, id, windows)
+}
+
+// InsertAPIKey mocks base method.
+func (m *MockapiKeyRepository) InsertAPIKey(ctx context.Context, key business.APIKey, hash []byte) (business.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIKey", ctx, key, hash)
+	ret0, _ := ret[0].(business.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAPIKey indicates an expected call of InsertAPIKey.
+func (mr *MockapiKeyRepositoryMockRecorder) InsertAPIKey(ctx, key, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIKey", reflect.TypeOf((*MockapiKeyRepository)(nil).InsertAPIKey), ctx, key, hash)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockapiKeyRepository) ListAPIKeys(ctx context.Context) ([]business.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]business.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockapiKeyRepositoryMockRecorder) ListAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockapiKeyRepository)(nil).ListAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockapiKeyRepository) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockapiKeyRepositoryMockRecorder) RevokeAPIKey(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockapiKeyRepository)(nil).RevokeAPIKey), ctx, id, at)
+}
+
+// UpdateAPIKeyHash mocks base method.
+func (m *MockapiKeyRepository) UpdateAPIKeyHash(ctx context.Context, id int64, prefix string, hash []byte) (business.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyHash", ctx, id, prefix, hash)
+	ret0, _ := ret[0].(business.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAPIKeyHash indicates an expected call of UpdateAPIKeyHash.
+func (mr *MockapiKeyRepositoryMockRecorder) UpdateAPIKeyHash(ctx, id, prefix, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyHash", reflect.TypeOf((*MockapiKeyRepository)(nil).UpdateAPIKeyHash), ctx, id, prefix, hash)
+}
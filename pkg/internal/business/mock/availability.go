@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: availability.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockstorefrontLookup is a mock of storefrontLookup interface.
+type MockstorefrontLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorefrontLookupMockRecorder
+}
+
+// MockstorefrontLookupMockRecorder is the mock recorder for MockstorefrontLookup.
+type MockstorefrontLookupMockRecorder struct {
+	mock *MockstorefrontLookup
+}
+
+// NewMockstorefrontLookup creates a new mock instance.
+func NewMockstorefrontLookup(ctrl *gomock.Controller) *MockstorefrontLookup {
+	mock := &MockstorefrontLookup{ctrl: ctrl}
+	mock.recorder = &MockstorefrontLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstorefrontLookup) EXPECT() *MockstorefrontLookupMockRecorder {
+	return m.recorder
+}
+
+// LookupOffer mocks base method.
+func (m *MockstorefrontLookup) LookupOffer(ctx context.Context, id int, country string) (business.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupOffer", ctx, id, country)
+	ret0, _ := ret[0].(business.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupOffer indicates an expected call of LookupOffer.
+func (mr *MockstorefrontLookupMockRecorder) LookupOffer(ctx, id, country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupOffer", reflect.TypeOf((*MockstorefrontLookup)(nil).LookupOffer), ctx, id, country)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lookup_media.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockmediaLookup is a mock of mediaLookup interface.
+type MockmediaLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockmediaLookupMockRecorder
+}
+
+// MockmediaLookupMockRecorder is the mock recorder for MockmediaLookup.
+type MockmediaLookupMockRecorder struct {
+	mock *MockmediaLookup
+}
+
+// NewMockmediaLookup creates a new mock instance.
+func NewMockmediaLookup(ctrl *gomock.Controller) *MockmediaLookup {
+	mock := &MockmediaLookup{ctrl: ctrl}
+	mock.recorder = &MockmediaLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmediaLookup) EXPECT() *MockmediaLookupMockRecorder {
+	return m.recorder
+}
+
+// LookupMediaByID mocks base method.
+func (m *MockmediaLookup) LookupMediaByID(ctx context.Context, id int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupMediaByID", ctx, id)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupMediaByID indicates an expected call of LookupMediaByID.
+func (mr *MockmediaLookupMockRecorder) LookupMediaByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupMediaByID", reflect.TypeOf((*MockmediaLookup)(nil).LookupMediaByID), ctx, id)
+}
+
+// LookupMediaByIDs mocks base method.
+func (m *MockmediaLookup) LookupMediaByIDs(ctx context.Context, ids []int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupMediaByIDs", ctx, ids)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupMediaByIDs indicates an expected call of LookupMediaByIDs.
+func (mr *MockmediaLookupMockRecorder) LookupMediaByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupMediaByIDs", reflect.TypeOf((*MockmediaLookup)(nil).LookupMediaByIDs), ctx, ids)
+}
+
+// LookupTVSeason mocks base method.
+func (m *MockmediaLookup) LookupTVSeason(ctx context.Context, collectionID int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupTVSeason", ctx, collectionID)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupTVSeason indicates an expected call of LookupTVSeason.
+func (mr *MockmediaLookupMockRecorder) LookupTVSeason(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupTVSeason", reflect.TypeOf((*MockmediaLookup)(nil).LookupTVSeason), ctx, collectionID)
+}
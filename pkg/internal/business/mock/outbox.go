@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockoutboxRepository is a mock of outboxRepository interface.
+type MockoutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepositoryMockRecorder
+}
+
+// MockoutboxRepositoryMockRecorder is the mock recorder for MockoutboxRepository.
+type MockoutboxRepositoryMockRecorder struct {
+	mock *MockoutboxRepository
+}
+
+// NewMockoutboxRepository creates a new mock instance.
+func NewMockoutboxRepository(ctrl *gomock.Controller) *MockoutboxRepository {
+	mock := &MockoutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepository) EXPECT() *MockoutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimOutboxEvents mocks base method.
+func (m *MockoutboxRepository) ClaimOutboxEvents(ctx context.Context, limit int, now, lockedUntil time.Time) ([]business.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutboxEvents", ctx, limit, now, lockedUntil)
+	ret0, _ := ret[0].([]business.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutboxEvents indicates an expected call of ClaimOutboxEvents.
+func (mr *MockoutboxRepositoryMockRecorder) ClaimOutboxEvents(ctx, limit, now, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutboxEvents", reflect.TypeOf((*MockoutboxRepository)(nil).ClaimOutboxEvents), ctx, limit, now, lockedUntil)
+}
+
+// DeleteOutboxEvents mocks base method.
+func (m *MockoutboxRepository) DeleteOutboxEvents(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutboxEvents", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOutboxEvents indicates an expected call of DeleteOutboxEvents.
+func (mr *MockoutboxRepositoryMockRecorder) DeleteOutboxEvents(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutboxEvents", reflect.TypeOf((*MockoutboxRepository)(nil).DeleteOutboxEvents), ctx, ids)
+}
+
+// ReleaseOutboxEvents mocks base method.
+func (m *MockoutboxRepository) ReleaseOutboxEvents(ctx context.Context, ids []int64, lastError string, nextAttemptAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseOutboxEvents", ctx, ids, lastError, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseOutboxEvents indicates an expected call of ReleaseOutboxEvents.
+func (mr *MockoutboxRepositoryMockRecorder) ReleaseOutboxEvents(ctx, ids, lastError, nextAttemptAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseOutboxEvents", reflect.TypeOf((*MockoutboxRepository)(nil).ReleaseOutboxEvents), ctx, ids, lastError, nextAttemptAt)
+}
+
+// MockmessageBroker is a mock of messageBroker interface.
+type MockmessageBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockmessageBrokerMockRecorder
+}
+
+// MockmessageBrokerMockRecorder is the mock recorder for MockmessageBroker.
+type MockmessageBrokerMockRecorder struct {
+	mock *MockmessageBroker
+}
+
+// NewMockmessageBroker creates a new mock instance.
+func NewMockmessageBroker(ctrl *gomock.Controller) *MockmessageBroker {
+	mock := &MockmessageBroker{ctrl: ctrl}
+	mock.recorder = &MockmessageBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmessageBroker) EXPECT() *MockmessageBrokerMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockmessageBroker) Publish(ctx context.Context, subject, id string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, subject, id, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockmessageBrokerMockRecorder) Publish(ctx, subject, id, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockmessageBroker)(nil).Publish), ctx, subject, id, data)
+}
@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: podcast.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpodcastRepository is a mock of podcastRepository interface.
+type MockpodcastRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockpodcastRepositoryMockRecorder
+}
+
+// MockpodcastRepositoryMockRecorder is the mock recorder for MockpodcastRepository.
+type MockpodcastRepositoryMockRecorder struct {
+	mock *MockpodcastRepository
+}
+
+// NewMockpodcastRepository creates a new mock instance.
+func NewMockpodcastRepository(ctrl *gomock.Controller) *MockpodcastRepository {
+	mock := &MockpodcastRepository{ctrl: ctrl}
+	mock.recorder = &MockpodcastRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpodcastRepository) EXPECT() *MockpodcastRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDuePodcasts mocks base method.
+func (m *MockpodcastRepository) ClaimDuePodcasts(ctx context.Context, now, nextRefreshAt time.Time, limit int) ([]business.Podcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDuePodcasts", ctx, now, nextRefreshAt, limit)
+	ret0, _ := ret[0].([]business.Podcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDuePodcasts indicates an expected call of ClaimDuePodcasts.
+func (mr *MockpodcastRepositoryMockRecorder) ClaimDuePodcasts(ctx, now, nextRefreshAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDuePodcasts", reflect.TypeOf((*MockpodcastRepository)(nil).ClaimDuePodcasts), ctx, now, nextRefreshAt, limit)
+}
+
+// GetPodcast mocks base method.
+func (m *MockpodcastRepository) GetPodcast(ctx context.Context, collectionID int) (business.Podcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodcast", ctx, collectionID)
+	ret0, _ := ret[0].(business.Podcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodcast indicates an expected call of GetPodcast.
+func (mr *MockpodcastRepositoryMockRecorder) GetPodcast(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodcast", reflect.TypeOf((*MockpodcastRepository)(nil).GetPodcast), ctx, collectionID)
+}
+
+// InsertPodcast mocks base method.
+func (m *MockpodcastRepository) InsertPodcast(ctx context.Context, podcast business.Podcast) (business.Podcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPodcast", ctx, podcast)
+	ret0, _ := ret[0].(business.Podcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPodcast indicates an expected call of InsertPodcast.
+func (mr *MockpodcastRepositoryMockRecorder) InsertPodcast(ctx, podcast interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPodcast", reflect.TypeOf((*MockpodcastRepository)(nil).InsertPodcast), ctx, podcast)
+}
+
+// ListPodcastEpisodes mocks base method.
+func (m *MockpodcastRepository) ListPodcastEpisodes(ctx context.Context, collectionID, limit, offset int) ([]business.Episode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPodcastEpisodes", ctx, collectionID, limit, offset)
+	ret0, _ := ret[0].([]business.Episode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPodcastEpisodes indicates an expected call of ListPodcastEpisodes.
+func (mr *MockpodcastRepositoryMockRecorder) ListPodcastEpisodes(ctx, collectionID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPodcastEpisodes", reflect.TypeOf((*MockpodcastRepository)(nil).ListPodcastEpisodes), ctx, collectionID, limit, offset)
+}
+
+// SavePodcastRefresh mocks base method.
+func (m *MockpodcastRepository) SavePodcastRefresh(ctx context.Context, refresh business.PodcastRefresh) (business.Podcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePodcastRefresh", ctx, refresh)
+	ret0, _ := ret[0].(business.Podcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePodcastRefresh indicates an expected call of SavePodcastRefresh.
+func (mr *MockpodcastRepositoryMockRecorder) SavePodcastRefresh(ctx, refresh interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePodcastRefresh", reflect.TypeOf((*MockpodcastRepository)(nil).SavePodcastRefresh), ctx, refresh)
+}
+
+// MockpodcastLookup is a mock of podcastLookup interface.
+type MockpodcastLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockpodcastLookupMockRecorder
+}
+
+// MockpodcastLookupMockRecorder is the mock recorder for MockpodcastLookup.
+type MockpodcastLookupMockRecorder struct {
+	mock *MockpodcastLookup
+}
+
+// NewMockpodcastLookup creates a new mock instance.
+func NewMockpodcastLookup(ctrl *gomock.Controller) *MockpodcastLookup {
+	mock := &MockpodcastLookup{ctrl: ctrl}
+	mock.recorder = &MockpodcastLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpodcastLookup) EXPECT() *MockpodcastLookupMockRecorder {
+	return m.recorder
+}
+
+// LookupMediaByID mocks base method.
+func (m *MockpodcastLookup) LookupMediaByID(ctx context.Context, id int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupMediaByID", ctx, id)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupMediaByID indicates an expected call of LookupMediaByID.
+func (mr *MockpodcastLookupMockRecorder) LookupMediaByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupMediaByID", reflect.TypeOf((*MockpodcastLookup)(nil).LookupMediaByID), ctx, id)
+}
+
+// MockfeedFetcher is a mock of feedFetcher interface.
+type MockfeedFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockfeedFetcherMockRecorder
+}
+
+// MockfeedFetcherMockRecorder is the mock recorder for MockfeedFetcher.
+type MockfeedFetcherMockRecorder struct {
+	mock *MockfeedFetcher
+}
+
+// NewMockfeedFetcher creates a new mock instance.
+func NewMockfeedFetcher(ctrl *gomock.Controller) *MockfeedFetcher {
+	mock := &MockfeedFetcher{ctrl: ctrl}
+	mock.recorder = &MockfeedFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockfeedFetcher) EXPECT() *MockfeedFetcherMockRecorder {
+	return m.recorder
+}
+
+// FetchFeed mocks base method.
+func (m *MockfeedFetcher) FetchFeed(ctx context.Context, url, etag, lastModified string) (business.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchFeed", ctx, url, etag, lastModified)
+	ret0, _ := ret[0].(business.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchFeed indicates an expected call of FetchFeed.
+func (mr *MockfeedFetcherMockRecorder) FetchFeed(ctx, url, etag, lastModified interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFeed", reflect.TypeOf((*MockfeedFetcher)(nil).FetchFeed), ctx, url, etag, lastModified)
+}
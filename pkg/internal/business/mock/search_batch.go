@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_batch.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockmediaSearcher is a mock of mediaSearcher interface.
+type MockmediaSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockmediaSearcherMockRecorder
+}
+
+// MockmediaSearcherMockRecorder is the mock recorder for MockmediaSearcher.
+type MockmediaSearcherMockRecorder struct {
+	mock *MockmediaSearcher
+}
+
+// NewMockmediaSearcher creates a new mock instance.
+func NewMockmediaSearcher(ctrl *gomock.Controller) *MockmediaSearcher {
+	mock := &MockmediaSearcher{ctrl: ctrl}
+	mock.recorder = &MockmediaSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmediaSearcher) EXPECT() *MockmediaSearcherMockRecorder {
+	return m.recorder
+}
+
+// FetchAndInsertMedia mocks base method.
+func (m *MockmediaSearcher) FetchAndInsertMedia(ctx context.Context, term string, limit int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndInsertMedia", ctx, term, limit)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAndInsertMedia indicates an expected call of FetchAndInsertMedia.
+func (mr *MockmediaSearcherMockRecorder) FetchAndInsertMedia(ctx, term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndInsertMedia", reflect.TypeOf((*MockmediaSearcher)(nil).FetchAndInsertMedia), ctx, term, limit)
+}
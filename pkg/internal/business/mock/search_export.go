@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_export.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockexportRepository is a mock of exportRepository interface.
+type MockexportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockexportRepositoryMockRecorder
+}
+
+// MockexportRepositoryMockRecorder is the mock recorder for MockexportRepository.
+type MockexportRepositoryMockRecorder struct {
+	mock *MockexportRepository
+}
+
+// NewMockexportRepository creates a new mock instance.
+func NewMockexportRepository(ctrl *gomock.Controller) *MockexportRepository {
+	mock := &MockexportRepository{ctrl: ctrl}
+	mock.recorder = &MockexportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexportRepository) EXPECT() *MockexportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// StreamMediaResults mocks base method.
+func (m *MockexportRepository) StreamMediaResults(ctx context.Context, filter business.HistoryFilter, fn func(business.MediaResult) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamMediaResults", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamMediaResults indicates an expected call of StreamMediaResults.
+func (mr *MockexportRepositoryMockRecorder) StreamMediaResults(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamMediaResults", reflect.TypeOf((*MockexportRepository)(nil).StreamMediaResults), ctx, filter, fn)
+}
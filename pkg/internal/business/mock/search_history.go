@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_history.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhistoryRepository is a mock of historyRepository interface.
+type MockhistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockhistoryRepositoryMockRecorder
+}
+
+// MockhistoryRepositoryMockRecorder is the mock recorder for MockhistoryRepository.
+type MockhistoryRepositoryMockRecorder struct {
+	mock *MockhistoryRepository
+}
+
+// NewMockhistoryRepository creates a new mock instance.
+func NewMockhistoryRepository(ctrl *gomock.Controller) *MockhistoryRepository {
+	mock := &MockhistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockhistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhistoryRepository) EXPECT() *MockhistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListMediaResults mocks base method.
+func (m *MockhistoryRepository) ListMediaResults(ctx context.Context, filter business.HistoryFilter) ([]business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMediaResults", ctx, filter)
+	ret0, _ := ret[0].([]business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMediaResults indicates an expected call of ListMediaResults.
+func (mr *MockhistoryRepositoryMockRecorder) ListMediaResults(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaResults", reflect.TypeOf((*MockhistoryRepository)(nil).ListMediaResults), ctx, filter)
+}
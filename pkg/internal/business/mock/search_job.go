@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_job.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocksearchJobRepository is a mock of searchJobRepository interface.
+type MocksearchJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearchJobRepositoryMockRecorder
+}
+
+// MocksearchJobRepositoryMockRecorder is the mock recorder for MocksearchJobRepository.
+type MocksearchJobRepositoryMockRecorder struct {
+	mock *MocksearchJobRepository
+}
+
+// NewMocksearchJobRepository creates a new mock instance.
+func NewMocksearchJobRepository(ctrl *gomock.Controller) *MocksearchJobRepository {
+	mock := &MocksearchJobRepository{ctrl: ctrl}
+	mock.recorder = &MocksearchJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksearchJobRepository) EXPECT() *MocksearchJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendSearchJobEvents mocks base method.
+func (m *MocksearchJobRepository) AppendSearchJobEvents(ctx context.Context, events []business.SearchJobEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendSearchJobEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendSearchJobEvents indicates an expected call of AppendSearchJobEvents.
+func (mr *MocksearchJobRepositoryMockRecorder) AppendSearchJobEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendSearchJobEvents", reflect.TypeOf((*MocksearchJobRepository)(nil).AppendSearchJobEvents), ctx, events)
+}
+
+// CancelSearchJob mocks base method.
+func (m *MocksearchJobRepository) CancelSearchJob(ctx context.Context, owner string, id int64, at time.Time) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSearchJob", ctx, owner, id, at)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSearchJob indicates an expected call of CancelSearchJob.
+func (mr *MocksearchJobRepositoryMockRecorder) CancelSearchJob(ctx, owner, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSearchJob", reflect.TypeOf((*MocksearchJobRepository)(nil).CancelSearchJob), ctx, owner, id, at)
+}
+
+// ClaimSearchJob mocks base method.
+func (m *MocksearchJobRepository) ClaimSearchJob(ctx context.Context, now, lockedUntil time.Time) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimSearchJob", ctx, now, lockedUntil)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimSearchJob indicates an expected call of ClaimSearchJob.
+func (mr *MocksearchJobRepositoryMockRecorder) ClaimSearchJob(ctx, now, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimSearchJob", reflect.TypeOf((*MocksearchJobRepository)(nil).ClaimSearchJob), ctx, now, lockedUntil)
+}
+
+// ExtendSearchJobLease mocks base method.
+func (m *MocksearchJobRepository) ExtendSearchJobLease(ctx context.Context, id int64, attempt int, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendSearchJobLease", ctx, id, attempt, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendSearchJobLease indicates an expected call of ExtendSearchJobLease.
+func (mr *MocksearchJobRepositoryMockRecorder) ExtendSearchJobLease(ctx, id, attempt, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendSearchJobLease", reflect.TypeOf((*MocksearchJobRepository)(nil).ExtendSearchJobLease), ctx, id, attempt, lockedUntil)
+}
+
+// FinishSearchJob mocks base method.
+func (m *MocksearchJobRepository) FinishSearchJob(ctx context.Context, id int64, attempt int, status business.SearchJobStatus, lastError string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSearchJob", ctx, id, attempt, status, lastError, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishSearchJob indicates an expected call of FinishSearchJob.
+func (mr *MocksearchJobRepositoryMockRecorder) FinishSearchJob(ctx, id, attempt, status, lastError, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSearchJob", reflect.TypeOf((*MocksearchJobRepository)(nil).FinishSearchJob), ctx, id, attempt, status, lastError, at)
+}
+
+// GetSearchJob mocks base method.
+func (m *MocksearchJobRepository) GetSearchJob(ctx context.Context, id int64) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchJob", ctx, id)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchJob indicates an expected call of GetSearchJob.
+func (mr *MocksearchJobRepositoryMockRecorder) GetSearchJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchJob", reflect.TypeOf((*MocksearchJobRepository)(nil).GetSearchJob), ctx, id)
+}
+
+// InsertSearchJob mocks base method.
+func (m *MocksearchJobRepository) InsertSearchJob(ctx context.Context, job business.SearchJob) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSearchJob", ctx, job)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSearchJob indicates an expected call of InsertSearchJob.
+func (mr *MocksearchJobRepositoryMockRecorder) InsertSearchJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSearchJob", reflect.TypeOf((*MocksearchJobRepository)(nil).InsertSearchJob), ctx, job)
+}
+
+// ListSearchJobEvents mocks base method.
+func (m *MocksearchJobRepository) ListSearchJobEvents(ctx context.Context, jobID, afterID int64, limit int) ([]business.SearchJobEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSearchJobEvents", ctx, jobID, afterID, limit)
+	ret0, _ := ret[0].([]business.SearchJobEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSearchJobEvents indicates an expected call of ListSearchJobEvents.
+func (mr *MocksearchJobRepositoryMockRecorder) ListSearchJobEvents(ctx, jobID, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearchJobEvents", reflect.TypeOf((*MocksearchJobRepository)(nil).ListSearchJobEvents), ctx, jobID, afterID, limit)
+}
+
+// RetrySearchJob mocks base method.
+func (m *MocksearchJobRepository) RetrySearchJob(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrySearchJob", ctx, id, attempt, runAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetrySearchJob indicates an expected call of RetrySearchJob.
+func (mr *MocksearchJobRepositoryMockRecorder) RetrySearchJob(ctx, id, attempt, runAt, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrySearchJob", reflect.TypeOf((*MocksearchJobRepository)(nil).RetrySearchJob), ctx, id, attempt, runAt, lastError)
+}
+
+// SaveSearchJobResults mocks base method.
+func (m *MocksearchJobRepository) SaveSearchJobResults(ctx context.Context, id int64, attempt int, results map[int]business.SearchJobResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSearchJobResults", ctx, id, attempt, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSearchJobResults indicates an expected call of SaveSearchJobResults.
+func (mr *MocksearchJobRepositoryMockRecorder) SaveSearchJobResults(ctx, id, attempt, results interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSearchJobResults", reflect.TypeOf((*MocksearchJobRepository)(nil).SaveSearchJobResults), ctx, id, attempt, results)
+}
+
+// MockmediaResultReader is a mock of mediaResultReader interface.
+type MockmediaResultReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockmediaResultReaderMockRecorder
+}
+
+// MockmediaResultReaderMockRecorder is the mock recorder for MockmediaResultReader.
+type MockmediaResultReaderMockRecorder struct {
+	mock *MockmediaResultReader
+}
+
+// NewMockmediaResultReader creates a new mock instance.
+func NewMockmediaResultReader(ctrl *gomock.Controller) *MockmediaResultReader {
+	mock := &MockmediaResultReader{ctrl: ctrl}
+	mock.recorder = &MockmediaResultReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmediaResultReader) EXPECT() *MockmediaResultReaderMockRecorder {
+	return m.recorder
+}
+
+// GetMediaResults mocks base method.
+func (m *MockmediaResultReader) GetMediaResults(ctx context.Context, ids []int64) ([]business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaResults", ctx, ids)
+	ret0, _ := ret[0].([]business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaResults indicates an expected call of GetMediaResults.
+func (mr *MockmediaResultReaderMockRecorder) GetMediaResults(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaResults", reflect.TypeOf((*MockmediaResultReader)(nil).GetMediaResults), ctx, ids)
+}
+
+// MockbatchSearcher is a mock of batchSearcher interface.
+type MockbatchSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockbatchSearcherMockRecorder
+}
+
+// MockbatchSearcherMockRecorder is the mock recorder for MockbatchSearcher.
+type MockbatchSearcherMockRecorder struct {
+	mock *MockbatchSearcher
+}
+
+// NewMockbatchSearcher creates a new mock instance.
+func NewMockbatchSearcher(ctrl *gomock.Controller) *MockbatchSearcher {
+	mock := &MockbatchSearcher{ctrl: ctrl}
+	mock.recorder = &MockbatchSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbatchSearcher) EXPECT() *MockbatchSearcherMockRecorder {
+	return m.recorder
+}
+
+// SearchEach mocks base method.
+func (m *MockbatchSearcher) SearchEach(ctx context.Context, queries []business.SearchQuery, fn func(int, business.SearchOutcome)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SearchEach", ctx, queries, fn)
+}
+
+// SearchEach indicates an expected call of SearchEach.
+func (mr *MockbatchSearcherMockRecorder) SearchEach(ctx, queries, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEach", reflect.TypeOf((*MockbatchSearcher)(nil).SearchEach), ctx, queries, fn)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockmediaRepository) EXPECT() *MockmediaRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetLatestMediaResult mocks base method.
+func (m *MockmediaRepository) GetLatestMediaResult(ctx context.Context, searchKey string, since time.Time) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestMediaResult", ctx, searchKey, since)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestMediaResult indicates an expected call of GetLatestMediaResult.
+func (mr *MockmediaRepositoryMockRecorder) GetLatestMediaResult(ctx, searchKey, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestMediaResult", reflect.TypeOf((*MockmediaRepository)(nil).GetLatestMediaResult), ctx, searchKey, since)
+}
+
 // InsertMedia mocks base method.
 func (m *MockmediaRepository) InsertMedia(ctx context.Context, media business.MediaResult) (int64, error) {
 	m.ctrl.T.Helper()
@@ -74,18 +90,47 @@ func (m *MockmediaFetcher) EXPECT() *MockmediaFetcherMockRecorder {
 }
 
 // FetchMediaByTerm mocks base method.
-func (m *MockmediaFetcher) FetchMediaByTerm(ctx context.Context, term string, limit int) (business.MediaResult, error) {
+func (m *MockmediaFetcher) FetchMediaByTerm(ctx context.Context, provider, term string, limit int) (business.MediaResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchMediaByTerm", ctx, term, limit)
+	ret := m.ctrl.Call(m, "FetchMediaByTerm", ctx, provider, term, limit)
 	ret0, _ := ret[0].(business.MediaResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchMediaByTerm indicates an expected call of FetchMediaByTerm.
-func (mr *MockmediaFetcherMockRecorder) FetchMediaByTerm(ctx, term, limit interface{}) *gomock.Call {
+func (mr *MockmediaFetcherMockRecorder) FetchMediaByTerm(ctx, provider, term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMediaByTerm", reflect.TypeOf((*MockmediaFetcher)(nil).FetchMediaByTerm), ctx, provider, term, limit)
+}
+
+// FetchMediaOfType mocks base method.
+func (m *MockmediaFetcher) FetchMediaOfType(ctx context.Context, provider, term, media string, limit int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMediaOfType", ctx, provider, term, media, limit)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMediaOfType indicates an expected call of FetchMediaOfType.
+func (mr *MockmediaFetcherMockRecorder) FetchMediaOfType(ctx, provider, term, media, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMediaOfType", reflect.TypeOf((*MockmediaFetcher)(nil).FetchMediaOfType), ctx, provider, term, media, limit)
+}
+
+// HasProvider mocks base method.
+func (m *MockmediaFetcher) HasProvider(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasProvider", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasProvider indicates an expected call of HasProvider.
+func (mr *MockmediaFetcherMockRecorder) HasProvider(name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMediaByTerm", reflect.TypeOf((*MockmediaFetcher)(nil).FetchMediaByTerm), ctx, term, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasProvider", reflect.TypeOf((*MockmediaFetcher)(nil).HasProvider), name)
 }
 
 // Mocklogger is a mock of logger interface.
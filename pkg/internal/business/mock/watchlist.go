@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watchlist.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwatchlistRepository is a mock of watchlistRepository interface.
+type MockwatchlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockwatchlistRepositoryMockRecorder
+}
+
+// MockwatchlistRepositoryMockRecorder is the mock recorder for MockwatchlistRepository.
+type MockwatchlistRepositoryMockRecorder struct {
+	mock *MockwatchlistRepository
+}
+
+// NewMockwatchlistRepository creates a new mock instance.
+func NewMockwatchlistRepository(ctrl *gomock.Controller) *MockwatchlistRepository {
+	mock := &MockwatchlistRepository{ctrl: ctrl}
+	mock.recorder = &MockwatchlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwatchlistRepository) EXPECT() *MockwatchlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddWatchlistArtist mocks base method.
+func (m *MockwatchlistRepository) AddWatchlistArtist(ctx context.Context, owner string, id int64, artistID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWatchlistArtist", ctx, owner, id, artistID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWatchlistArtist indicates an expected call of AddWatchlistArtist.
+func (mr *MockwatchlistRepositoryMockRecorder) AddWatchlistArtist(ctx, owner, id, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWatchlistArtist", reflect.TypeOf((*MockwatchlistRepository)(nil).AddWatchlistArtist), ctx, owner, id, artistID)
+}
+
+// ClaimDueArtists mocks base method.
+func (m *MockwatchlistRepository) ClaimDueArtists(ctx context.Context, now, nextCheckAt time.Time, limit int) ([]business.WatchedArtist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueArtists", ctx, now, nextCheckAt, limit)
+	ret0, _ := ret[0].([]business.WatchedArtist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueArtists indicates an expected call of ClaimDueArtists.
+func (mr *MockwatchlistRepositoryMockRecorder) ClaimDueArtists(ctx, now, nextCheckAt, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueArtists", reflect.TypeOf((*MockwatchlistRepository)(nil).ClaimDueArtists), ctx, now, nextCheckAt, limit)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockwatchlistRepository) GetWatchlist(ctx context.Context, owner string, id int64) (business.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, owner, id)
+	ret0, _ := ret[0].(business.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockwatchlistRepositoryMockRecorder) GetWatchlist(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockwatchlistRepository)(nil).GetWatchlist), ctx, owner, id)
+}
+
+// InsertWatchlist mocks base method.
+func (m *MockwatchlistRepository) InsertWatchlist(ctx context.Context, owner, name string, artistIDs []int) (business.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWatchlist", ctx, owner, name, artistIDs)
+	ret0, _ := ret[0].(business.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWatchlist indicates an expected call of InsertWatchlist.
+func (mr *MockwatchlistRepositoryMockRecorder) InsertWatchlist(ctx, owner, name, artistIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWatchlist", reflect.TypeOf((*MockwatchlistRepository)(nil).InsertWatchlist), ctx, owner, name, artistIDs)
+}
+
+// ListWatchlistReleases mocks base method.
+func (m *MockwatchlistRepository) ListWatchlistReleases(ctx context.Context, owner string, id int64, limit, offset int) ([]business.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWatchlistReleases", ctx, owner, id, limit, offset)
+	ret0, _ := ret[0].([]business.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWatchlistReleases indicates an expected call of ListWatchlistReleases.
+func (mr *MockwatchlistRepositoryMockRecorder) ListWatchlistReleases(ctx, owner, id, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWatchlistReleases", reflect.TypeOf((*MockwatchlistRepository)(nil).ListWatchlistReleases), ctx, owner, id, limit, offset)
+}
+
+// RemoveWatchlistArtist mocks base method.
+func (m *MockwatchlistRepository) RemoveWatchlistArtist(ctx context.Context, owner string, id int64, artistID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWatchlistArtist", ctx, owner, id, artistID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWatchlistArtist indicates an expected call of RemoveWatchlistArtist.
+func (mr *MockwatchlistRepositoryMockRecorder) RemoveWatchlistArtist(ctx, owner, id, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWatchlistArtist", reflect.TypeOf((*MockwatchlistRepository)(nil).RemoveWatchlistArtist), ctx, owner, id, artistID)
+}
+
+// SaveArtistCheck mocks base method.
+func (m *MockwatchlistRepository) SaveArtistCheck(ctx context.Context, check business.ArtistCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveArtistCheck", ctx, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveArtistCheck indicates an expected call of SaveArtistCheck.
+func (mr *MockwatchlistRepositoryMockRecorder) SaveArtistCheck(ctx, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveArtistCheck", reflect.TypeOf((*MockwatchlistRepository)(nil).SaveArtistCheck), ctx, check)
+}
+
+// MockreleaseLookup is a mock of releaseLookup interface.
+type MockreleaseLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockreleaseLookupMockRecorder
+}
+
+// MockreleaseLookupMockRecorder is the mock recorder for MockreleaseLookup.
+type MockreleaseLookupMockRecorder struct {
+	mock *MockreleaseLookup
+}
+
+// NewMockreleaseLookup creates a new mock instance.
+func NewMockreleaseLookup(ctrl *gomock.Controller) *MockreleaseLookup {
+	mock := &MockreleaseLookup{ctrl: ctrl}
+	mock.recorder = &MockreleaseLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreleaseLookup) EXPECT() *MockreleaseLookupMockRecorder {
+	return m.recorder
+}
+
+// LookupArtistReleases mocks base method.
+func (m *MockreleaseLookup) LookupArtistReleases(ctx context.Context, artistID int, entity string) ([]business.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupArtistReleases", ctx, artistID, entity)
+	ret0, _ := ret[0].([]business.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupArtistReleases indicates an expected call of LookupArtistReleases.
+func (mr *MockreleaseLookupMockRecorder) LookupArtistReleases(ctx, artistID, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupArtistReleases", reflect.TypeOf((*MockreleaseLookup)(nil).LookupArtistReleases), ctx, artistID, entity)
+}
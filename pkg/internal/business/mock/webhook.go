@@ -0,0 +1,231 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwebhookRepository is a mock of webhookRepository interface.
+type MockwebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookRepositoryMockRecorder
+}
+
+// MockwebhookRepositoryMockRecorder is the mock recorder for MockwebhookRepository.
+type MockwebhookRepositoryMockRecorder struct {
+	mock *MockwebhookRepository
+}
+
+// NewMockwebhookRepository creates a new mock instance.
+func NewMockwebhookRepository(ctrl *gomock.Controller) *MockwebhookRepository {
+	mock := &MockwebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockwebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookRepository) EXPECT() *MockwebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockwebhookRepository) DeleteWebhook(ctx context.Context, owner string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, owner, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockwebhookRepositoryMockRecorder) DeleteWebhook(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockwebhookRepository)(nil).DeleteWebhook), ctx, owner, id)
+}
+
+// EnqueueWebhookDeliveries mocks base method.
+func (m *MockwebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, owner string, event business.EventType, payload []byte, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookDeliveries", ctx, owner, event, payload, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhookDeliveries indicates an expected call of EnqueueWebhookDeliveries.
+func (mr *MockwebhookRepositoryMockRecorder) EnqueueWebhookDeliveries(ctx, owner, event, payload, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookDeliveries", reflect.TypeOf((*MockwebhookRepository)(nil).EnqueueWebhookDeliveries), ctx, owner, event, payload, at)
+}
+
+// GetWebhook mocks base method.
+func (m *MockwebhookRepository) GetWebhook(ctx context.Context, owner string, id int64) (business.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, owner, id)
+	ret0, _ := ret[0].(business.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockwebhookRepositoryMockRecorder) GetWebhook(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockwebhookRepository)(nil).GetWebhook), ctx, owner, id)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockwebhookRepository) GetWebhookDelivery(ctx context.Context, owner string, webhookID, id int64) (business.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, owner, webhookID, id)
+	ret0, _ := ret[0].(business.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockwebhookRepositoryMockRecorder) GetWebhookDelivery(ctx, owner, webhookID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockwebhookRepository)(nil).GetWebhookDelivery), ctx, owner, webhookID, id)
+}
+
+// InsertWebhook mocks base method.
+func (m *MockwebhookRepository) InsertWebhook(ctx context.Context, webhook business.Webhook) (business.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhook", ctx, webhook)
+	ret0, _ := ret[0].(business.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWebhook indicates an expected call of InsertWebhook.
+func (mr *MockwebhookRepositoryMockRecorder) InsertWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhook", reflect.TypeOf((*MockwebhookRepository)(nil).InsertWebhook), ctx, webhook)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockwebhookRepository) ListWebhookDeliveries(ctx context.Context, owner string, webhookID int64, status business.WebhookDeliveryStatus, limit, offset int) ([]business.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, owner, webhookID, status, limit, offset)
+	ret0, _ := ret[0].([]business.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockwebhookRepositoryMockRecorder) ListWebhookDeliveries(ctx, owner, webhookID, status, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockwebhookRepository)(nil).ListWebhookDeliveries), ctx, owner, webhookID, status, limit, offset)
+}
+
+// RedeliverWebhookDelivery mocks base method.
+func (m *MockwebhookRepository) RedeliverWebhookDelivery(ctx context.Context, owner string, webhookID, id int64, at time.Time) (business.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhookDelivery", ctx, owner, webhookID, id, at)
+	ret0, _ := ret[0].(business.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhookDelivery indicates an expected call of RedeliverWebhookDelivery.
+func (mr *MockwebhookRepositoryMockRecorder) RedeliverWebhookDelivery(ctx, owner, webhookID, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhookDelivery", reflect.TypeOf((*MockwebhookRepository)(nil).RedeliverWebhookDelivery), ctx, owner, webhookID, id, at)
+}
+
+// MockwebhookDeliveryRepository is a mock of webhookDeliveryRepository interface.
+type MockwebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookDeliveryRepositoryMockRecorder
+}
+
+// MockwebhookDeliveryRepositoryMockRecorder is the mock recorder for MockwebhookDeliveryRepository.
+type MockwebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockwebhookDeliveryRepository
+}
+
+// NewMockwebhookDeliveryRepository creates a new mock instance.
+func NewMockwebhookDeliveryRepository(ctrl *gomock.Controller) *MockwebhookDeliveryRepository {
+	mock := &MockwebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockwebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookDeliveryRepository) EXPECT() *MockwebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimWebhookDelivery mocks base method.
+func (m *MockwebhookDeliveryRepository) ClaimWebhookDelivery(ctx context.Context, now, lockedUntil time.Time) (business.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDelivery", ctx, now, lockedUntil)
+	ret0, _ := ret[0].(business.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDelivery indicates an expected call of ClaimWebhookDelivery.
+func (mr *MockwebhookDeliveryRepositoryMockRecorder) ClaimWebhookDelivery(ctx, now, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDelivery", reflect.TypeOf((*MockwebhookDeliveryRepository)(nil).ClaimWebhookDelivery), ctx, now, lockedUntil)
+}
+
+// RecordWebhookAttempt mocks base method.
+func (m *MockwebhookDeliveryRepository) RecordWebhookAttempt(ctx context.Context, delivery business.WebhookDelivery, attempt business.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookAttempt", ctx, delivery, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWebhookAttempt indicates an expected call of RecordWebhookAttempt.
+func (mr *MockwebhookDeliveryRepositoryMockRecorder) RecordWebhookAttempt(ctx, delivery, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookAttempt", reflect.TypeOf((*MockwebhookDeliveryRepository)(nil).RecordWebhookAttempt), ctx, delivery, attempt)
+}
+
+// MockwebhookSender is a mock of webhookSender interface.
+type MockwebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookSenderMockRecorder
+}
+
+// MockwebhookSenderMockRecorder is the mock recorder for MockwebhookSender.
+type MockwebhookSenderMockRecorder struct {
+	mock *MockwebhookSender
+}
+
+// NewMockwebhookSender creates a new mock instance.
+func NewMockwebhookSender(ctrl *gomock.Controller) *MockwebhookSender {
+	mock := &MockwebhookSender{ctrl: ctrl}
+	mock.recorder = &MockwebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookSender) EXPECT() *MockwebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockwebhookSender) Send(ctx context.Context, url, secret string, deliveryID int64, event string, payload []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, url, secret, deliveryID, event, payload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockwebhookSenderMockRecorder) Send(ctx, url, secret, deliveryID, event, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockwebhookSender)(nil).Send), ctx, url, secret, deliveryID, event, payload)
+}
@@ -0,0 +1,188 @@
+package business
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// DomainEventType identifies a domain event written to the outbox.
+type DomainEventType string
+
+const (
+	// DomainEventSearchPerformed reports a search result got stored.
+	DomainEventSearchPerformed DomainEventType = "search.performed"
+	// DomainEventMediaDiscovered reports a media returned by a stored search.
+	DomainEventMediaDiscovered DomainEventType = "media.discovered"
+)
+
+// OutboxEvent represents a domain event written to the outbox along with the change it reports,
+// it stays there until the relay published it.
+type OutboxEvent struct {
+	ID      int64
+	Type    DomainEventType
+	Payload json.RawMessage
+	// Attempts is the number of times the event was claimed by a relay.
+	Attempts  int
+	CreatedAt time.Time
+}
+
+type (
+	// SearchPerformed represents the payload of a search.performed event.
+	SearchPerformed struct {
+		MediaResultID int64     `json:"media_result_id"`
+		SearchTerm    string    `json:"search_term"`
+		ResultCount   int       `json:"result_count"`
+		PerformedAt   time.Time `json:"performed_at"`
+	}
+
+	// MediaDiscovered represents the payload of a media.discovered event.
+	MediaDiscovered struct {
+		MediaResultID  int64  `json:"media_result_id"`
+		WrapperType    string `json:"wrapper_type"`
+		Kind           string `json:"kind,omitempty"`
+		ArtistID       int    `json:"artist_id,omitempty"`
+		CollectionID   int    `json:"collection_id,omitempty"`
+		TrackID        int    `json:"track_id,omitempty"`
+		ArtistName     string `json:"artist_name,omitempty"`
+		CollectionName string `json:"collection_name,omitempty"`
+		TrackName      string `json:"track_name,omitempty"`
+		ReleaseDate    string `json:"release_date,omitempty"`
+	}
+)
+
+// NewSearchEvents returns the domain events of storing result, a search.performed event followed by a
+// media.discovered event per media of the result.
+func NewSearchEvents(result MediaResult, at time.Time) ([]OutboxEvent, error) {
+	events := make([]OutboxEvent, 0, len(result.Media)+1)
+	add := func(eventType DomainEventType, payload any) error {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+		}
+		events = append(events, OutboxEvent{Type: eventType, Payload: data, CreatedAt: at})
+		return nil
+	}
+	if err := add(DomainEventSearchPerformed, SearchPerformed{
+		MediaResultID: result.ID,
+		SearchTerm:    result.SearchTerm,
+		ResultCount:   result.ResultCount,
+		PerformedAt:   at,
+	}); err != nil {
+		return nil, err
+	}
+	for _, m := range result.Media {
+		if err := add(DomainEventMediaDiscovered, MediaDiscovered{
+			MediaResultID:  result.ID,
+			WrapperType:    m.WrapperType,
+			Kind:           m.Kind,
+			ArtistID:       m.ArtistID,
+			CollectionID:   m.CollectionID,
+			TrackID:        m.TrackID,
+			ArtistName:     m.ArtistName,
+			CollectionName: m.CollectionName,
+			TrackName:      m.TrackName,
+			ReleaseDate:    m.ReleaseDate,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// OutboxPolicy defines how the outbox is relayed.
+type OutboxPolicy struct {
+	// SubjectPrefix prefixes the subject events are published to, followed by their type.
+	SubjectPrefix string
+	// BatchSize is the most events claimed at once.
+	BatchSize int
+	// Backoff is the delay before publishing an event again, it doubles on every further attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Lease is how long claimed events are left to their relay before another one may claim them.
+	Lease time.Duration
+}
+
+//go:generate mockgen -source=outbox.go -destination=mock/outbox.go -package=mock
+type (
+	// outboxRepository defines the interface for outbox repository operations.
+	outboxRepository interface {
+		ClaimOutboxEvents(ctx context.Context, limit int, now, lockedUntil time.Time) ([]OutboxEvent, error)
+		DeleteOutboxEvents(ctx context.Context, ids []int64) error
+		ReleaseOutboxEvents(ctx context.Context, ids []int64, lastError string, nextAttemptAt time.Time) error
+	}
+
+	// messageBroker defines the interface of the broker outbox events are published to, id identifies the
+	// message so consumers may drop the ones published twice.
+	messageBroker interface {
+		Publish(ctx context.Context, subject, id string, data []byte) error
+	}
+)
+
+// OutboxRelayHandler publishes the events of the outbox, every event is published at least once.
+type OutboxRelayHandler struct {
+	repo   outboxRepository
+	broker messageBroker
+	policy OutboxPolicy
+	now    func() time.Time
+}
+
+// NewOutboxRelayHandler creates a new instance of OutboxRelayHandler.
+func NewOutboxRelayHandler(repo outboxRepository, broker messageBroker, policy OutboxPolicy) OutboxRelayHandler {
+	return OutboxRelayHandler{repo: repo, broker: broker, policy: policy, now: time.Now}
+}
+
+// RelayOutbox claims the next due events and publishes them oldest first, it returns the number of events claimed.
+// Published events are removed from the outbox. Once an event can't be published it is put back along with the
+// rest of the batch so they are published in order after the backoff.
+func (h OutboxRelayHandler) RelayOutbox(ctx context.Context) (int, error) {
+	now := h.now().UTC()
+	events, err := h.repo.ClaimOutboxEvents(ctx, h.policy.BatchSize, now, now.Add(h.policy.Lease))
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	slices.SortFunc(events, func(a, b OutboxEvent) int { return cmp.Compare(a.ID, b.ID) })
+
+	published := 0
+	var publishErr error
+	for _, event := range events {
+		subject := h.policy.SubjectPrefix + "." + string(event.Type)
+		if err := h.broker.Publish(ctx, subject, strconv.FormatInt(event.ID, 10), event.Payload); err != nil {
+			publishErr = fmt.Errorf("failed to publish outbox event %d: %w", event.ID, err)
+			break
+		}
+		published++
+	}
+	// the outcome is stored even when ctx is done so published events aren't published again.
+	ctx = context.WithoutCancel(ctx)
+	ids := lo.Map(events, func(e OutboxEvent, _ int) int64 { return e.ID })
+	if published > 0 {
+		if err := h.repo.DeleteOutboxEvents(ctx, ids[:published]); err != nil {
+			return len(events), errors.Join(publishErr, fmt.Errorf("failed to delete published outbox events: %w", err))
+		}
+	}
+	if publishErr != nil {
+		next := h.now().UTC().Add(h.backoff(events[published].Attempts))
+		if err := h.repo.ReleaseOutboxEvents(ctx, ids[published:], publishErr.Error(), next); err != nil {
+			return len(events), errors.Join(publishErr, fmt.Errorf("failed to release outbox events: %w", err))
+		}
+		return len(events), publishErr
+	}
+	return len(events), nil
+}
+
+// backoff returns the delay before publishing an event again after its given attempt.
+func (h OutboxRelayHandler) backoff(attempt int) time.Duration {
+	delay := h.policy.Backoff
+	for i := 1; i < attempt && delay < h.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, h.policy.MaxBackoff)
+}
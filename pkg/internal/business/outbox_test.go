@@ -0,0 +1,129 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearchEvents(t *testing.T) {
+	at := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+	events, err := business.NewSearchEvents(business.MediaResult{
+		ID:          7,
+		SearchTerm:  "jack johnson",
+		ResultCount: 1,
+		Media:       []business.Media{{WrapperType: "track", Kind: "song", ArtistID: 909253, TrackID: 879273552, TrackName: "Upside Down"}},
+	}, at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []business.OutboxEvent{
+		{
+			Type:      business.DomainEventSearchPerformed,
+			Payload:   []byte(`{"media_result_id":7,"search_term":"jack johnson","result_count":1,"performed_at":"2026-10-19T15:00:00Z"}`),
+			CreatedAt: at,
+		},
+		{
+			Type:      business.DomainEventMediaDiscovered,
+			Payload:   []byte(`{"media_result_id":7,"wrapper_type":"track","kind":"song","artist_id":909253,"track_id":879273552,"track_name":"Upside Down"}`),
+			CreatedAt: at,
+		},
+	}, events)
+}
+
+func TestRelayOutbox(t *testing.T) {
+	policy := business.OutboxPolicy{SubjectPrefix: "mediascout", BatchSize: 10, Backoff: time.Second, MaxBackoff: time.Minute, Lease: time.Minute}
+	// claimed events aren't returned in order.
+	claimed := []business.OutboxEvent{
+		{ID: 2, Type: business.DomainEventMediaDiscovered, Payload: []byte(`{"media_result_id":1}`), Attempts: 3},
+		{ID: 1, Type: business.DomainEventSearchPerformed, Payload: []byte(`{"media_result_id":1}`), Attempts: 3},
+		{ID: 3, Type: business.DomainEventMediaDiscovered, Payload: []byte(`{"media_result_id":1}`), Attempts: 1},
+	}
+	tests := []struct {
+		name            string
+		mockSetup       func(*mock.MockoutboxRepository, *mock.MockmessageBroker)
+		expectedClaimed int
+		expectedError   string
+	}{
+		{
+			name: "empty outbox",
+			mockSetup: func(repo *mock.MockoutboxRepository, _ *mock.MockmessageBroker) {
+				repo.EXPECT().ClaimOutboxEvents(gomock.Any(), 10, gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name: "claim error",
+			mockSetup: func(repo *mock.MockoutboxRepository, _ *mock.MockmessageBroker) {
+				repo.EXPECT().ClaimOutboxEvents(gomock.Any(), 10, gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to claim outbox events: db down",
+		},
+		{
+			name: "published in order",
+			mockSetup: func(repo *mock.MockoutboxRepository, broker *mock.MockmessageBroker) {
+				repo.EXPECT().ClaimOutboxEvents(gomock.Any(), 10, gomock.Any(), gomock.Any()).Return(claimed, nil)
+				gomock.InOrder(
+					broker.EXPECT().Publish(gomock.Any(), "mediascout.search.performed", "1", []byte(`{"media_result_id":1}`)).Return(nil),
+					broker.EXPECT().Publish(gomock.Any(), "mediascout.media.discovered", "2", []byte(`{"media_result_id":1}`)).Return(nil),
+					broker.EXPECT().Publish(gomock.Any(), "mediascout.media.discovered", "3", []byte(`{"media_result_id":1}`)).Return(nil),
+				)
+				repo.EXPECT().DeleteOutboxEvents(gomock.Any(), []int64{1, 2, 3}).Return(nil)
+			},
+			expectedClaimed: 3,
+		},
+		{
+			name: "failed event is put back along with the rest of the batch",
+			mockSetup: func(repo *mock.MockoutboxRepository, broker *mock.MockmessageBroker) {
+				repo.EXPECT().ClaimOutboxEvents(gomock.Any(), 10, gomock.Any(), gomock.Any()).Return(claimed, nil)
+				broker.EXPECT().Publish(gomock.Any(), gomock.Any(), "1", gomock.Any()).Return(nil)
+				broker.EXPECT().Publish(gomock.Any(), gomock.Any(), "2", gomock.Any()).Return(errors.New("nats: timeout"))
+				repo.EXPECT().DeleteOutboxEvents(gomock.Any(), []int64{1}).Return(nil)
+				repo.EXPECT().ReleaseOutboxEvents(gomock.Any(), []int64{2, 3}, "failed to publish outbox event 2: nats: timeout", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ []int64, _ string, next time.Time) error {
+						// the third attempt waits four times the base backoff.
+						assert.WithinDuration(t, time.Now().Add(4*time.Second), next, time.Second)
+						return nil
+					})
+			},
+			expectedClaimed: 3,
+			expectedError:   "failed to publish outbox event 2: nats: timeout",
+		},
+		{
+			name: "delete error",
+			mockSetup: func(repo *mock.MockoutboxRepository, broker *mock.MockmessageBroker) {
+				repo.EXPECT().ClaimOutboxEvents(gomock.Any(), 10, gomock.Any(), gomock.Any()).Return([]business.OutboxEvent{{ID: 4, Type: business.DomainEventSearchPerformed}}, nil)
+				broker.EXPECT().Publish(gomock.Any(), gomock.Any(), "4", gomock.Any()).Return(nil)
+				repo.EXPECT().DeleteOutboxEvents(gomock.Any(), []int64{4}).Return(errors.New("db down"))
+			},
+			expectedClaimed: 1,
+			expectedError:   "failed to delete published outbox events: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockoutboxRepository(ctrl)
+			broker := mock.NewMockmessageBroker(ctrl)
+			tt.mockSetup(repo, broker)
+			handler := business.NewOutboxRelayHandler(repo, broker, policy)
+
+			claimed, err := handler.RelayOutbox(context.Background())
+
+			assert.Equal(t, tt.expectedClaimed, claimed)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
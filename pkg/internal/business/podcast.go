@@ -0,0 +1,228 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	defaultEpisodesLimit = 20
+	maxEpisodesLimit     = 100
+)
+
+// Podcast represents a podcast of the iTunes store whose feed gets refreshed by the podcast refresher.
+type Podcast struct {
+	CollectionID int
+	Title        string
+	FeedURL      string
+	// ETag and LastModified are the validators of the last fetched feed, the feed is only fetched again once it changed.
+	ETag         string
+	LastModified string
+	// RefreshedAt is when the feed was last refreshed, nil until its first successful refresh.
+	RefreshedAt *time.Time
+}
+
+// Episode represents an episode of a podcast, GUID identifies it within the feed of its podcast.
+type Episode struct {
+	ID           int64
+	CollectionID int
+	GUID         string
+	Title        string
+	Description  string
+	Link         string
+	AudioURL     string
+	AudioType    string
+	// PublishedAt is nil when the feed doesn't date the episode.
+	PublishedAt     *time.Time
+	DurationSeconds int
+}
+
+// Feed represents a fetched podcast feed. NotModified is set when the feed didn't change since the fetch its
+// validators come from, its episodes are empty then.
+type Feed struct {
+	Title        string
+	Episodes     []Episode
+	ETag         string
+	LastModified string
+	NotModified  bool
+}
+
+// PodcastEpisodes represents a page of the episodes of a podcast, most recent first.
+type PodcastEpisodes struct {
+	Podcast  Podcast
+	Episodes []Episode
+}
+
+// PodcastRefresh represents the outcome of refreshing the feed of a podcast. Episodes are upserted by GUID, they're
+// empty when the feed didn't change.
+type PodcastRefresh struct {
+	CollectionID  int
+	Title         string
+	ETag          string
+	LastModified  string
+	Episodes      []Episode
+	RefreshedAt   time.Time
+	NextRefreshAt time.Time
+}
+
+// PodcastPolicy defines how podcast feeds are refreshed.
+type PodcastPolicy struct {
+	// RefreshInterval is how long a feed goes unrefreshed after a refresh, failed or not.
+	RefreshInterval time.Duration
+	// BatchSize is the most podcasts claimed by a single refresh.
+	BatchSize int
+	// MaxEpisodes is the most episodes of a feed stored on a refresh, the most recent ones.
+	MaxEpisodes int
+}
+
+//go:generate mockgen -source=podcast.go -destination=mock/podcast.go -package=mock
+type (
+	// podcastRepository defines the interface for podcast repository operations.
+	podcastRepository interface {
+		GetPodcast(ctx context.Context, collectionID int) (Podcast, error)
+		InsertPodcast(ctx context.Context, podcast Podcast) (Podcast, error)
+		ListPodcastEpisodes(ctx context.Context, collectionID int, limit, offset int) ([]Episode, error)
+		ClaimDuePodcasts(ctx context.Context, now, nextRefreshAt time.Time, limit int) ([]Podcast, error)
+		SavePodcastRefresh(ctx context.Context, refresh PodcastRefresh) (Podcast, error)
+	}
+	// podcastLookup defines the interface for looking a podcast up by its iTunes collection id.
+	podcastLookup interface {
+		LookupMediaByID(ctx context.Context, id int) (MediaResult, error)
+	}
+	// feedFetcher defines the interface for fetching podcast feeds.
+	feedFetcher interface {
+		FetchFeed(ctx context.Context, url, etag, lastModified string) (Feed, error)
+	}
+)
+
+type PodcastHandler struct {
+	repo    podcastRepository
+	lookup  podcastLookup
+	fetcher feedFetcher
+	policy  PodcastPolicy
+	lgr     logger
+	now     func() time.Time
+}
+
+// NewPodcastHandler creates a new instance of PodcastHandler.
+func NewPodcastHandler(repo podcastRepository, lookup podcastLookup, fetcher feedFetcher, policy PodcastPolicy, lgr logger) PodcastHandler {
+	policy.BatchSize = max(policy.BatchSize, 1)
+	return PodcastHandler{repo: repo, lookup: lookup, fetcher: fetcher, policy: policy, lgr: lgr, now: time.Now}
+}
+
+// ListPodcastEpisodes returns the podcast with the given iTunes collection id along with a page of its episodes, most
+// recent first. The limit defaults to 20 and is capped at 100. Podcasts are looked up on iTunes the first time they're
+// listed and their feed refreshed right away, ErrNotFound is returned when the collection isn't a podcast with a feed.
+func (h PodcastHandler) ListPodcastEpisodes(ctx context.Context, collectionID int, limit, offset int) (PodcastEpisodes, error) {
+	podcast, err := h.repo.GetPodcast(ctx, collectionID)
+	if errors.Is(err, ErrNotFound) {
+		podcast, err = h.addPodcast(ctx, collectionID)
+	}
+	if err != nil {
+		return PodcastEpisodes{}, fmt.Errorf("failed to get podcast: %w", err)
+	}
+	if podcast.RefreshedAt == nil {
+		if podcast, err = h.refreshPodcast(ctx, podcast); err != nil {
+			return PodcastEpisodes{}, err
+		}
+	}
+
+	if limit <= 0 {
+		limit = defaultEpisodesLimit
+	}
+	episodes, err := h.repo.ListPodcastEpisodes(ctx, collectionID, min(limit, maxEpisodesLimit), max(offset, 0))
+	if err != nil {
+		return PodcastEpisodes{}, fmt.Errorf("failed to list podcast episodes: %w", err)
+	}
+	return PodcastEpisodes{Podcast: podcast, Episodes: episodes}, nil
+}
+
+// RefreshDuePodcasts claims the podcasts due for a refresh and refreshes their feed, it returns the number of
+// podcasts claimed. Podcasts failing to be refreshed are logged and refreshed again after the refresh interval.
+func (h PodcastHandler) RefreshDuePodcasts(ctx context.Context) (int, error) {
+	now := h.now().UTC()
+	podcasts, err := h.repo.ClaimDuePodcasts(ctx, now, now.Add(h.policy.RefreshInterval), h.policy.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due podcasts: %w", err)
+	}
+	for _, podcast := range podcasts {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, err := h.refreshPodcast(ctx, podcast); err != nil {
+			h.lgr.ErrorContext(ctx, "failed to refresh podcast", "collection_id", podcast.CollectionID, "error", err.Error())
+		}
+	}
+	return len(podcasts), nil
+}
+
+// addPodcast looks up the podcast with the given collection id on iTunes and inserts it.
+func (h PodcastHandler) addPodcast(ctx context.Context, collectionID int) (Podcast, error) {
+	result, err := h.lookup.LookupMediaByID(ctx, collectionID)
+	if err != nil {
+		return Podcast{}, fmt.Errorf("failed to lookup podcast: %w", err)
+	}
+	media, ok := lo.Find(result.Media, func(m Media) bool {
+		return m.CollectionID == collectionID && m.FeedURL != ""
+	})
+	if !ok {
+		return Podcast{}, fmt.Errorf("%w: podcast %d", ErrNotFound, collectionID)
+	}
+	podcast, err := h.repo.InsertPodcast(ctx, Podcast{CollectionID: collectionID, Title: media.CollectionName, FeedURL: media.FeedURL})
+	if err != nil {
+		return Podcast{}, fmt.Errorf("failed to insert podcast: %w", err)
+	}
+	return podcast, nil
+}
+
+// refreshPodcast fetches the feed of podcast, conditionally on its validators, and stores its most recent episodes.
+func (h PodcastHandler) refreshPodcast(ctx context.Context, podcast Podcast) (Podcast, error) {
+	feed, err := h.fetcher.FetchFeed(ctx, podcast.FeedURL, podcast.ETag, podcast.LastModified)
+	if err != nil {
+		return Podcast{}, fmt.Errorf("failed to fetch podcast feed: %w", err)
+	}
+	now := h.now().UTC()
+	refresh := PodcastRefresh{
+		CollectionID:  podcast.CollectionID,
+		Title:         feed.Title,
+		ETag:          feed.ETag,
+		LastModified:  feed.LastModified,
+		RefreshedAt:   now,
+		NextRefreshAt: now.Add(h.policy.RefreshInterval),
+	}
+	if !feed.NotModified {
+		refresh.Episodes = recentEpisodes(feed.Episodes, h.policy.MaxEpisodes)
+	}
+	podcast, err = h.repo.SavePodcastRefresh(ctx, refresh)
+	if err != nil {
+		return Podcast{}, fmt.Errorf("failed to save podcast refresh: %w", err)
+	}
+	return podcast, nil
+}
+
+// recentEpisodes returns up to limit of episodes, most recent first, undated episodes last. Episodes repeating the
+// GUID of a more recent one are left out, a limit of zero keeps every episode.
+func recentEpisodes(episodes []Episode, limit int) []Episode {
+	episodes = slices.Clone(episodes)
+	slices.SortStableFunc(episodes, func(a, b Episode) int {
+		switch {
+		case a.PublishedAt == nil && b.PublishedAt == nil:
+			return 0
+		case a.PublishedAt == nil:
+			return 1
+		case b.PublishedAt == nil:
+			return -1
+		}
+		return b.PublishedAt.Compare(*a.PublishedAt)
+	})
+	episodes = lo.UniqBy(episodes, func(e Episode) string { return e.GUID })
+	if limit > 0 && len(episodes) > limit {
+		episodes = episodes[:limit]
+	}
+	return episodes
+}
@@ -0,0 +1,182 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPodcastEpisodes(t *testing.T) {
+	policy := business.PodcastPolicy{RefreshInterval: time.Hour, BatchSize: 10, MaxEpisodes: 2}
+	refreshedAt := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	refreshed := business.Podcast{CollectionID: 5, Title: "The Daily", FeedURL: "https://example.com/feed", ETag: `"v1"`, RefreshedAt: lo.ToPtr(refreshedAt)}
+	episodes := []business.Episode{{ID: 1, CollectionID: 5, GUID: "ep-1", Title: "Episode 1"}}
+	tests := []struct {
+		name             string
+		limit, offset    int
+		mockSetup        func(*mock.MockpodcastRepository, *mock.MockpodcastLookup, *mock.MockfeedFetcher)
+		expectedEpisodes business.PodcastEpisodes
+		expectedError    string
+		expectedErrorIs  error
+	}{
+		{
+			name:  "refreshed podcast is listed",
+			limit: 500, offset: -1,
+			mockSetup: func(repo *mock.MockpodcastRepository, _ *mock.MockpodcastLookup, _ *mock.MockfeedFetcher) {
+				repo.EXPECT().GetPodcast(gomock.Any(), 5).Return(refreshed, nil)
+				repo.EXPECT().ListPodcastEpisodes(gomock.Any(), 5, 100, 0).Return(episodes, nil)
+			},
+			expectedEpisodes: business.PodcastEpisodes{Podcast: refreshed, Episodes: episodes},
+		},
+		{
+			name: "unknown podcast is looked up and refreshed",
+			mockSetup: func(repo *mock.MockpodcastRepository, lookup *mock.MockpodcastLookup, fetcher *mock.MockfeedFetcher) {
+				podcast := business.Podcast{CollectionID: 5, Title: "The Daily", FeedURL: "https://example.com/feed"}
+				repo.EXPECT().GetPodcast(gomock.Any(), 5).Return(business.Podcast{}, business.ErrNotFound)
+				lookup.EXPECT().LookupMediaByID(gomock.Any(), 5).Return(business.MediaResult{Media: []business.Media{
+					{WrapperType: "track", Kind: "podcast", CollectionID: 5, CollectionName: "The Daily", FeedURL: "https://example.com/feed"},
+				}}, nil)
+				repo.EXPECT().InsertPodcast(gomock.Any(), podcast).Return(podcast, nil)
+				fetcher.EXPECT().FetchFeed(gomock.Any(), "https://example.com/feed", "", "").Return(business.Feed{
+					Title: "The Daily",
+					ETag:  `"v1"`,
+					Episodes: []business.Episode{
+						{GUID: "ep-1", PublishedAt: lo.ToPtr(refreshedAt.Add(-48 * time.Hour))},
+						{GUID: "undated"},
+						{GUID: "ep-2", PublishedAt: lo.ToPtr(refreshedAt.Add(-time.Hour))},
+					},
+				}, nil)
+				repo.EXPECT().SavePodcastRefresh(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, refresh business.PodcastRefresh) (business.Podcast, error) {
+					assert.Equal(t, "The Daily", refresh.Title)
+					assert.Equal(t, `"v1"`, refresh.ETag)
+					assert.Equal(t, time.Hour, refresh.NextRefreshAt.Sub(refresh.RefreshedAt))
+					assert.Equal(t, []string{"ep-2", "ep-1"}, lo.Map(refresh.Episodes, func(e business.Episode, _ int) string { return e.GUID }))
+					return refreshed, nil
+				})
+				repo.EXPECT().ListPodcastEpisodes(gomock.Any(), 5, 20, 0).Return(episodes, nil)
+			},
+			expectedEpisodes: business.PodcastEpisodes{Podcast: refreshed, Episodes: episodes},
+		},
+		{
+			name: "collection without feed is not found",
+			mockSetup: func(repo *mock.MockpodcastRepository, lookup *mock.MockpodcastLookup, _ *mock.MockfeedFetcher) {
+				repo.EXPECT().GetPodcast(gomock.Any(), 5).Return(business.Podcast{}, business.ErrNotFound)
+				lookup.EXPECT().LookupMediaByID(gomock.Any(), 5).Return(business.MediaResult{Media: []business.Media{
+					{WrapperType: "collection", CollectionID: 5, CollectionName: "An Album"},
+				}}, nil)
+			},
+			expectedErrorIs: business.ErrNotFound,
+		},
+		{
+			name: "feed error",
+			mockSetup: func(repo *mock.MockpodcastRepository, _ *mock.MockpodcastLookup, fetcher *mock.MockfeedFetcher) {
+				repo.EXPECT().GetPodcast(gomock.Any(), 5).Return(business.Podcast{CollectionID: 5, FeedURL: "https://example.com/feed"}, nil)
+				fetcher.EXPECT().FetchFeed(gomock.Any(), "https://example.com/feed", "", "").Return(business.Feed{}, errors.New("feed down"))
+			},
+			expectedError: "failed to fetch podcast feed: feed down",
+		},
+		{
+			name: "repository error",
+			mockSetup: func(repo *mock.MockpodcastRepository, _ *mock.MockpodcastLookup, _ *mock.MockfeedFetcher) {
+				repo.EXPECT().GetPodcast(gomock.Any(), 5).Return(business.Podcast{}, errors.New("db down"))
+			},
+			expectedError: "failed to get podcast: db down",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mock.NewMockpodcastRepository(ctrl)
+			mockLookup := mock.NewMockpodcastLookup(ctrl)
+			mockFetcher := mock.NewMockfeedFetcher(ctrl)
+			tt.mockSetup(mockRepo, mockLookup, mockFetcher)
+			handler := business.NewPodcastHandler(mockRepo, mockLookup, mockFetcher, policy, mock.NewMocklogger(ctrl))
+
+			res, err := handler.ListPodcastEpisodes(context.Background(), 5, tt.limit, tt.offset)
+
+			switch {
+			case tt.expectedErrorIs != nil:
+				assert.ErrorIs(t, err, tt.expectedErrorIs)
+			case tt.expectedError != "":
+				assert.EqualError(t, err, tt.expectedError)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedEpisodes, res)
+			}
+		})
+	}
+}
+
+func TestRefreshDuePodcasts(t *testing.T) {
+	policy := business.PodcastPolicy{RefreshInterval: time.Hour, BatchSize: 10}
+	podcast := business.Podcast{CollectionID: 5, FeedURL: "https://example.com/feed", ETag: `"v1"`, LastModified: "Mon, 19 Oct 2026 07:00:00 GMT"}
+	tests := []struct {
+		name            string
+		mockSetup       func(*mock.MockpodcastRepository, *mock.MockfeedFetcher, *mock.Mocklogger)
+		expectedClaimed int
+		expectedError   string
+	}{
+		{
+			name: "claim error",
+			mockSetup: func(repo *mock.MockpodcastRepository, _ *mock.MockfeedFetcher, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimDuePodcasts(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to claim due podcasts: db down",
+		},
+		{
+			name: "unchanged feed only records the refresh",
+			mockSetup: func(repo *mock.MockpodcastRepository, fetcher *mock.MockfeedFetcher, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimDuePodcasts(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]business.Podcast{podcast}, nil)
+				fetcher.EXPECT().FetchFeed(gomock.Any(), podcast.FeedURL, podcast.ETag, podcast.LastModified).
+					Return(business.Feed{NotModified: true, ETag: podcast.ETag, LastModified: podcast.LastModified}, nil)
+				repo.EXPECT().SavePodcastRefresh(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, refresh business.PodcastRefresh) (business.Podcast, error) {
+					assert.Equal(t, 5, refresh.CollectionID)
+					assert.Equal(t, podcast.ETag, refresh.ETag)
+					assert.Empty(t, refresh.Episodes)
+					return podcast, nil
+				})
+			},
+			expectedClaimed: 1,
+		},
+		{
+			name: "refresh error is logged",
+			mockSetup: func(repo *mock.MockpodcastRepository, fetcher *mock.MockfeedFetcher, lgr *mock.Mocklogger) {
+				repo.EXPECT().ClaimDuePodcasts(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]business.Podcast{podcast, {CollectionID: 6}}, nil)
+				fetcher.EXPECT().FetchFeed(gomock.Any(), podcast.FeedURL, gomock.Any(), gomock.Any()).Return(business.Feed{}, errors.New("feed down"))
+				lgr.EXPECT().ErrorContext(gomock.Any(), "failed to refresh podcast", "collection_id", 5, "error", "failed to fetch podcast feed: feed down")
+				fetcher.EXPECT().FetchFeed(gomock.Any(), "", "", "").Return(business.Feed{Title: "Other"}, nil)
+				repo.EXPECT().SavePodcastRefresh(gomock.Any(), gomock.Any()).Return(business.Podcast{CollectionID: 6}, nil)
+			},
+			expectedClaimed: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mock.NewMockpodcastRepository(ctrl)
+			mockFetcher := mock.NewMockfeedFetcher(ctrl)
+			mockLogger := mock.NewMocklogger(ctrl)
+			tt.mockSetup(mockRepo, mockFetcher, mockLogger)
+			handler := business.NewPodcastHandler(mockRepo, mock.NewMockpodcastLookup(ctrl), mockFetcher, policy, mockLogger)
+
+			claimed, err := handler.RefreshDuePodcasts(context.Background())
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedClaimed, claimed)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package business
+
+import (
+	"context"
+	"sync"
+)
+
+// SearchQuery represents a single search of a batch.
+type SearchQuery struct {
+	Term  string
+	Limit int
+}
+
+// SearchOutcome represents the outcome of a single search of a batch, either its result or the error it failed with.
+type SearchOutcome struct {
+	Result MediaResult
+	Err    error
+}
+
+//go:generate mockgen -source=search_batch.go -destination=mock/search_batch.go -package=mock
+type (
+	// mediaSearcher defines the interface for running a single search.
+	mediaSearcher interface {
+		FetchAndInsertMedia(ctx context.Context, term string, limit int) (MediaResult, error)
+	}
+)
+
+type SearchBatchHandler struct {
+	searcher    mediaSearcher
+	concurrency int
+}
+
+// NewSearchBatchHandler creates a new instance of SearchBatchHandler running at most concurrency searches at once.
+func NewSearchBatchHandler(searcher mediaSearcher, concurrency int) SearchBatchHandler {
+	return SearchBatchHandler{searcher: searcher, concurrency: max(concurrency, 1)}
+}
+
+// SearchBatch runs every query and returns their outcomes in the order of queries, a failed search doesn't fail the others.
+// Queries not started when ctx is done fail with its error.
+func (h SearchBatchHandler) SearchBatch(ctx context.Context, queries []SearchQuery) []SearchOutcome {
+	outcomes := make([]SearchOutcome, len(queries))
+	h.SearchEach(ctx, queries, func(i int, outcome SearchOutcome) {
+		outcomes[i] = outcome
+	})
+	return outcomes
+}
+
+// SearchEach runs every query like SearchBatch and passes the outcome of each to fn along with the index of its query,
+// as soon as it is known. fn is never called concurrently.
+func (h SearchBatchHandler) SearchEach(ctx context.Context, queries []SearchQuery, fn func(i int, outcome SearchOutcome)) {
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	report := func(i int, outcome SearchOutcome) {
+		mu.Lock()
+		defer mu.Unlock()
+		fn(i, outcome)
+	}
+	slots := make(chan struct{}, h.concurrency)
+	for i, query := range queries {
+		if err := ctx.Err(); err != nil {
+			report(i, SearchOutcome{Err: err})
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			report(i, SearchOutcome{Err: ctx.Err()})
+			continue
+		case slots <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			result, err := h.searcher.FetchAndInsertMedia(ctx, query.Term, query.Limit)
+			report(i, SearchOutcome{Result: result, Err: err})
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mock.NewMockmediaSearcher(ctrl)
+	handler := business.NewSearchBatchHandler(mockSearcher, 2)
+
+	var inFlight, maxInFlight atomic.Int32
+	search := func(_ context.Context, term string, limit int) (business.MediaResult, error) {
+		maxInFlight.Store(max(maxInFlight.Load(), inFlight.Add(1)))
+		defer inFlight.Add(-1)
+		time.Sleep(5 * time.Millisecond)
+		if term == "broken" {
+			return business.MediaResult{}, errors.New("upstream down")
+		}
+		return business.MediaResult{SearchTerm: term, ResultCount: limit}, nil
+	}
+	mockSearcher.EXPECT().FetchAndInsertMedia(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(search).Times(4)
+
+	outcomes := handler.SearchBatch(context.Background(), []business.SearchQuery{
+		{Term: "jack", Limit: 1},
+		{Term: "broken", Limit: 2},
+		{Term: "johnson", Limit: 3},
+		{Term: "beatles", Limit: 4},
+	})
+
+	assert.Equal(t, []business.SearchOutcome{
+		{Result: business.MediaResult{SearchTerm: "jack", ResultCount: 1}},
+		{Err: errors.New("upstream down")},
+		{Result: business.MediaResult{SearchTerm: "johnson", ResultCount: 3}},
+		{Result: business.MediaResult{SearchTerm: "beatles", ResultCount: 4}},
+	}, outcomes)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+}
+
+func TestSearchBatchCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearcher := mock.NewMockmediaSearcher(ctrl)
+	handler := business.NewSearchBatchHandler(mockSearcher, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	outcomes := handler.SearchBatch(ctx, []business.SearchQuery{{Term: "jack", Limit: 1}})
+
+	assert.Equal(t, []business.SearchOutcome{{Err: context.Canceled}}, outcomes)
+}
@@ -0,0 +1,59 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExportRow represents a single media item of a stored search, searches are exported as a row per item.
+type ExportRow struct {
+	ResultID   int64
+	SearchTerm string
+	FetchedAt  time.Time
+	// Position is the index of the item within the results of its search.
+	Position int
+	Media    Media
+}
+
+//go:generate mockgen -source=search_export.go -destination=mock/search_export.go -package=mock
+type (
+	// exportRepository defines the interface for streaming the stored searches.
+	exportRepository interface {
+		StreamMediaResults(ctx context.Context, filter HistoryFilter, fn func(MediaResult) error) error
+	}
+)
+
+type SearchExportHandler struct {
+	repo exportRepository
+}
+
+// NewSearchExportHandler creates a new instance of SearchExportHandler.
+func NewSearchExportHandler(repo exportRepository) SearchExportHandler {
+	return SearchExportHandler{repo: repo}
+}
+
+// ExportSearches passes the media items of the stored searches matching filter to fn, most recent search first.
+// Unlike the history, a zero filter.Limit exports every search. Searches without media have no row, an error
+// returned by fn stops the export and is returned as is.
+func (h SearchExportHandler) ExportSearches(ctx context.Context, filter HistoryFilter, fn func(ExportRow) error) error {
+	filter.Limit = max(filter.Limit, 0)
+	filter.Offset = max(filter.Offset, 0)
+	var fnErr error
+	err := h.repo.StreamMediaResults(ctx, filter, func(result MediaResult) error {
+		for i, media := range result.Media {
+			row := ExportRow{ResultID: result.ID, SearchTerm: result.SearchTerm, FetchedAt: result.FetchedAt, Position: i, Media: media}
+			if fnErr = fn(row); fnErr != nil {
+				return fnErr
+			}
+		}
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stream media results: %w", err)
+	}
+	return nil
+}
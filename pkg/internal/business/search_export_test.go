@@ -0,0 +1,91 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSearches(t *testing.T) {
+	fetchedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	stored := []business.MediaResult{
+		{ID: 2, SearchTerm: "jack johnson", Media: []business.Media{{TrackID: 1}, {TrackID: 2}}, FetchedAt: fetchedAt},
+		{ID: 1, SearchTerm: "nothing"},
+	}
+	// streamStored stubs StreamMediaResults, passing the stored results to fn.
+	streamStored := func(_ context.Context, _ business.HistoryFilter, fn func(business.MediaResult) error) error {
+		for _, r := range stored {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name          string
+		filter        business.HistoryFilter
+		fnErr         error
+		mockSetup     func(*mock.MockexportRepository)
+		expectedError string
+		expectedRows  []business.ExportRow
+	}{
+		{
+			name:   "one row per media item",
+			filter: business.HistoryFilter{Term: "jack johnson", Offset: -1},
+			mockSetup: func(repo *mock.MockexportRepository) {
+				repo.EXPECT().StreamMediaResults(gomock.Any(), business.HistoryFilter{Term: "jack johnson"}, gomock.Any()).DoAndReturn(streamStored)
+			},
+			expectedRows: []business.ExportRow{
+				{ResultID: 2, SearchTerm: "jack johnson", FetchedAt: fetchedAt, Position: 0, Media: business.Media{TrackID: 1}},
+				{ResultID: 2, SearchTerm: "jack johnson", FetchedAt: fetchedAt, Position: 1, Media: business.Media{TrackID: 2}},
+			},
+		},
+		{
+			name:  "fn error stops the export",
+			fnErr: errors.New("broken pipe"),
+			mockSetup: func(repo *mock.MockexportRepository) {
+				repo.EXPECT().StreamMediaResults(gomock.Any(), business.HistoryFilter{}, gomock.Any()).DoAndReturn(streamStored)
+			},
+			expectedError: "broken pipe",
+			expectedRows:  []business.ExportRow{{ResultID: 2, SearchTerm: "jack johnson", FetchedAt: fetchedAt, Position: 0, Media: business.Media{TrackID: 1}}},
+		},
+		{
+			name: "repository error",
+			mockSetup: func(repo *mock.MockexportRepository) {
+				repo.EXPECT().StreamMediaResults(gomock.Any(), business.HistoryFilter{}, gomock.Any()).Return(errors.New("db down"))
+			},
+			expectedError: "failed to stream media results: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockexportRepository(ctrl)
+			tt.mockSetup(mockRepo)
+			handler := business.NewSearchExportHandler(mockRepo)
+			var rows []business.ExportRow
+
+			err := handler.ExportSearches(context.Background(), tt.filter, func(row business.ExportRow) error {
+				rows = append(rows, row)
+				return tt.fnErr
+			})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedRows, rows)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package business
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+// durationTolerance is how far apart in milliseconds the durations of the same recording found by different
+// providers may be, providers round durations differently.
+const durationTolerance = 2000
+
+type (
+	// ProviderFailure reports a provider a federated search failed to search.
+	ProviderFailure struct {
+		Provider string
+		Err      error
+	}
+
+	// FederatedMediaResult represents the merged result of a search across several providers. Providers lists the
+	// providers searched and Failures the ones of them the search failed for.
+	FederatedMediaResult struct {
+		MediaResult
+		Providers []string
+		Failures  []ProviderFailure
+	}
+)
+
+// FetchAndInsertFederatedMedia fetches media by term from every given provider at once, merges their results,
+// inserts the merged result into the repository, and returns it. Providers are searched with up to limit results each
+// and bounded by the provider timeout of the policy, the ones failing are reported in the result rather than failing
+// the search. The search fails when every provider failed, or with ErrUnknownProvider when one isn't registered.
+func (h SearchMediaHandler) FetchAndInsertFederatedMedia(ctx context.Context, providers []string, term string, limit int) (FederatedMediaResult, error) {
+	providers = lo.Uniq(providers)
+	// no provider is searched when one of them is unknown.
+	for _, provider := range providers {
+		if !h.fetcher.HasProvider(provider) {
+			return FederatedMediaResult{}, fmt.Errorf("failed to fetch media: %w: %q", ErrUnknownProvider, provider)
+		}
+	}
+	results := make([]MediaResult, len(providers))
+	errs := make([]error, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := ctx
+			if h.policy.ProviderTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, h.policy.ProviderTimeout)
+				defer cancel()
+			}
+			results[i], errs[i] = h.fetcher.FetchMediaByTerm(ctx, provider, term, limit)
+		}()
+	}
+	wg.Wait()
+
+	federated := FederatedMediaResult{Providers: providers}
+	var lists [][]Media
+	for i, err := range errs {
+		if err != nil {
+			h.lgr.ErrorContext(ctx, "failed to fetch media", "provider", providers[i], "error", err)
+			federated.Failures = append(federated.Failures, ProviderFailure{Provider: providers[i], Err: err})
+			continue
+		}
+		lists = append(lists, results[i].Media)
+		// the merged result is as old as its oldest part.
+		if federated.FetchedAt.IsZero() || results[i].FetchedAt.Before(federated.FetchedAt) {
+			federated.FetchedAt = results[i].FetchedAt
+		}
+	}
+	if len(federated.Failures) == len(providers) {
+		return FederatedMediaResult{}, fmt.Errorf("failed to fetch media from every provider: %w", errors.Join(errs...))
+	}
+
+	federated.SearchTerm = term
+	federated.Media = mergeMedia(lists, limit)
+	federated.ResultCount = len(federated.Media)
+	federated.SearchKey = SearchKey(providers, term, limit, SearchFilter{})
+	federated.MediaResult = h.insertMedia(ctx, federated.MediaResult)
+	return federated, nil
+}
+
+// mergeMedia interleaves the media of lists, taking the next media of every list in turn, and keeps up to limit of
+// them. Media found by several providers are kept once, completed with what the later providers know about them.
+func mergeMedia(lists [][]Media, limit int) []Media {
+	var merged []Media
+	for i := 0; ; i++ {
+		more := false
+		for _, list := range lists {
+			if i >= len(list) {
+				continue
+			}
+			more = true
+			m := list[i]
+			if j := slices.IndexFunc(merged, func(kept Media) bool { return sameMedia(kept, m) }); j >= 0 {
+				merged[j] = completeMedia(merged[j], m)
+				continue
+			}
+			merged = append(merged, m)
+		}
+		if !more {
+			break
+		}
+	}
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
+
+// sameMedia reports whether a and b, found by different providers, are the same media. Tracks are the same when their
+// ISRCs match and collections when their UPCs do, otherwise media are the same when their normalized names, artists
+// and durations match.
+func sameMedia(a, b Media) bool {
+	if a.Source == b.Source || a.WrapperType != b.WrapperType || a.Kind != b.Kind {
+		return false
+	}
+	if a.ISRC != "" && b.ISRC != "" {
+		return a.ISRC == b.ISRC
+	}
+	if a.UPC != "" && b.UPC != "" {
+		return a.UPC == b.UPC
+	}
+	if normalize(mediaName(a)) != normalize(mediaName(b)) || normalize(a.ArtistName) != normalize(b.ArtistName) {
+		return false
+	}
+	if a.TrackTimeMillis == 0 || b.TrackTimeMillis == 0 {
+		return true
+	}
+	return max(a.TrackTimeMillis-b.TrackTimeMillis, b.TrackTimeMillis-a.TrackTimeMillis) <= durationTolerance
+}
+
+// mediaName returns the name of a track, or of a collection for the other media.
+func mediaName(m Media) string {
+	if m.TrackName != "" {
+		return m.TrackName
+	}
+	return m.CollectionName
+}
+
+// normalize lowers s and drops its bracketed parts, e.g. (Remastered), along with its punctuation.
+func normalize(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth = max(depth-1, 0)
+		case depth > 0:
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// completeMedia fills the fields kept lacks with the ones of dup, the same media found by another provider.
+func completeMedia(kept, dup Media) Media {
+	kept.ISRC = cmp.Or(kept.ISRC, dup.ISRC)
+	kept.UPC = cmp.Or(kept.UPC, dup.UPC)
+	kept.CollectionName = cmp.Or(kept.CollectionName, dup.CollectionName)
+	kept.ReleaseDate = cmp.Or(kept.ReleaseDate, dup.ReleaseDate)
+	kept.TrackTimeMillis = cmp.Or(kept.TrackTimeMillis, dup.TrackTimeMillis)
+	kept.PrimaryGenreName = cmp.Or(kept.PrimaryGenreName, dup.PrimaryGenreName)
+	kept.ArtworkURL30 = cmp.Or(kept.ArtworkURL30, dup.ArtworkURL30)
+	kept.ArtworkURL60 = cmp.Or(kept.ArtworkURL60, dup.ArtworkURL60)
+	kept.ArtworkURL100 = cmp.Or(kept.ArtworkURL100, dup.ArtworkURL100)
+	kept.ArtworkURL600 = cmp.Or(kept.ArtworkURL600, dup.ArtworkURL600)
+	kept.Video = cmp.Or(kept.Video, dup.Video)
+	if len(kept.Genres) == 0 {
+		kept.Genres = dup.Genres
+	}
+	return kept
+}
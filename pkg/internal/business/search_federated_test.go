@@ -0,0 +1,129 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAndInsertFederatedMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockmediaRepository(ctrl)
+	mockFetcher := mock.NewMockmediaFetcher(ctrl)
+	mockPublisher := mock.NewMockeventPublisher(ctrl)
+	mockLogger := mock.NewMocklogger(ctrl)
+
+	handler := business.NewSearchMediaHandler(mockRepo, mockFetcher, mockPublisher, business.SearchPolicy{ProviderTimeout: 50 * time.Millisecond}, mockLogger)
+
+	fetchedAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	itunes := business.MediaResult{
+		SearchTerm: "upside down",
+		FetchedAt:  fetchedAt,
+		Media: []business.Media{
+			{WrapperType: "track", Kind: "song", TrackID: 1, TrackName: "Upside Down", ArtistName: "Jack Johnson", TrackTimeMillis: 208643, ArtworkURL100: "https://is1.example/100.jpg", Source: "itunes"},
+			{WrapperType: "track", Kind: "song", TrackID: 2, TrackName: "Banana Pancakes", ArtistName: "Jack Johnson", TrackTimeMillis: 191000, Source: "itunes"},
+		},
+	}
+	knownProviders := func(names ...string) {
+		for _, name := range names {
+			mockFetcher.EXPECT().HasProvider(name).Return(true)
+		}
+	}
+	musicbrainz := business.MediaResult{
+		SearchTerm: "upside down",
+		FetchedAt:  fetchedAt.Add(time.Second),
+		Media: []business.Media{
+			{WrapperType: "track", Kind: "song", TrackName: "Upside Down (Album Version)", ArtistName: "Jack Johnson", TrackTimeMillis: 208000, ISRC: "USUM70604567", Source: "musicbrainz", SourceID: "rec1"},
+			{WrapperType: "track", Kind: "song", TrackName: "Upside Down", ArtistName: "Diana Ross", TrackTimeMillis: 245000, Source: "musicbrainz", SourceID: "rec2"},
+		},
+	}
+
+	t.Run("results are merged and de-duplicated", func(t *testing.T) {
+		knownProviders("itunes", "musicbrainz")
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "itunes", "upside down", 10).Return(itunes, nil)
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "musicbrainz", "upside down", 10).Return(musicbrainz, nil)
+		mockRepo.EXPECT().InsertMedia(gomock.Any(), gomock.Any()).Return(int64(7), nil)
+		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		res, err := handler.FetchAndInsertFederatedMedia(context.Background(), []string{"itunes", "musicbrainz", "itunes"}, "upside down", 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), res.ID)
+		assert.Equal(t, []string{"itunes", "musicbrainz"}, res.Providers)
+		assert.Empty(t, res.Failures)
+		assert.Equal(t, fetchedAt, res.FetchedAt)
+		assert.Equal(t, 3, res.ResultCount)
+		assert.Equal(t, []business.Media{
+			{WrapperType: "track", Kind: "song", TrackID: 1, TrackName: "Upside Down", ArtistName: "Jack Johnson", TrackTimeMillis: 208643, ArtworkURL100: "https://is1.example/100.jpg", ISRC: "USUM70604567", Source: "itunes"},
+			{WrapperType: "track", Kind: "song", TrackID: 2, TrackName: "Banana Pancakes", ArtistName: "Jack Johnson", TrackTimeMillis: 191000, Source: "itunes"},
+			{WrapperType: "track", Kind: "song", TrackName: "Upside Down", ArtistName: "Diana Ross", TrackTimeMillis: 245000, Source: "musicbrainz", SourceID: "rec2"},
+		}, res.Media)
+	})
+
+	t.Run("same isrc is the same recording", func(t *testing.T) {
+		tmdb := business.MediaResult{Media: []business.Media{
+			{WrapperType: "track", Kind: "song", TrackName: "Upside Down - Live", ArtistName: "J. Johnson", ISRC: "USUM70604567", Source: "tmdb"},
+		}}
+		knownProviders("musicbrainz", "tmdb")
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "musicbrainz", "upside down", 1).Return(musicbrainz, nil)
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "tmdb", "upside down", 1).Return(tmdb, nil)
+		mockRepo.EXPECT().InsertMedia(gomock.Any(), gomock.Any()).Return(int64(8), nil)
+		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		res, err := handler.FetchAndInsertFederatedMedia(context.Background(), []string{"musicbrainz", "tmdb"}, "upside down", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []business.Media{musicbrainz.Media[0]}, res.Media)
+	})
+
+	t.Run("failed providers are reported", func(t *testing.T) {
+		knownProviders("itunes", "musicbrainz")
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "itunes", "upside down", 10).Return(itunes, nil)
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "musicbrainz", "upside down", 10).
+			DoAndReturn(func(ctx context.Context, _, _ string, _ int) (business.MediaResult, error) {
+				<-ctx.Done()
+				return business.MediaResult{}, ctx.Err()
+			})
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to fetch media", "provider", "musicbrainz", "error", gomock.Any())
+		mockRepo.EXPECT().InsertMedia(gomock.Any(), gomock.Any()).Return(int64(9), nil)
+		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		res, err := handler.FetchAndInsertFederatedMedia(context.Background(), []string{"itunes", "musicbrainz"}, "upside down", 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, itunes.Media, res.Media)
+		require.Len(t, res.Failures, 1)
+		assert.Equal(t, "musicbrainz", res.Failures[0].Provider)
+		assert.ErrorIs(t, res.Failures[0].Err, context.DeadlineExceeded)
+	})
+
+	t.Run("every provider failed", func(t *testing.T) {
+		knownProviders("itunes", "musicbrainz")
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "itunes", "upside down", 10).Return(business.MediaResult{}, errors.New("itunes is down"))
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "musicbrainz", "upside down", 10).Return(business.MediaResult{}, errors.New("musicbrainz is down"))
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to fetch media", "provider", gomock.Any(), "error", gomock.Any()).Times(2)
+
+		_, err := handler.FetchAndInsertFederatedMedia(context.Background(), []string{"itunes", "musicbrainz"}, "upside down", 10)
+
+		assert.EqualError(t, err, "failed to fetch media from every provider: itunes is down\nmusicbrainz is down")
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		mockFetcher.EXPECT().HasProvider("itunes").Return(true)
+		mockFetcher.EXPECT().HasProvider("deezer").Return(false)
+
+		_, err := handler.FetchAndInsertFederatedMedia(context.Background(), []string{"itunes", "deezer", "musicbrainz"}, "upside down", 10)
+
+		assert.ErrorIs(t, err, business.ErrUnknownProvider)
+		assert.EqualError(t, err, `failed to fetch media: unknown provider: "deezer"`)
+	})
+}
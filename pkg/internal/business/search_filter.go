@@ -0,0 +1,84 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// ErrUnsupportedFilter is returned when a search is filtered in a way its provider can't search by, e.g. by media type
+// in a provider only knowing recordings.
+var ErrUnsupportedFilter = errors.New("unsupported filter")
+
+// PriceFilter narrows a search to free or paid apps.
+type PriceFilter string
+
+const (
+	PriceFree PriceFilter = "free"
+	PricePaid PriceFilter = "paid"
+)
+
+// SearchFilter narrows a search. Media restricts it upstream to a media type, e.g. music or software, while the
+// software filters are applied to the fetched media, only apps matching every one of them are kept.
+type SearchFilter struct {
+	Media string
+	// MinRating is the lowest average user rating of the apps kept, out of 5.
+	MinRating float64
+	// Device keeps the apps supporting a device whose name starts with it, e.g. iPad, regardless of case.
+	Device string
+	Price  PriceFilter
+}
+
+// filtersSoftware reports whether f has any software filter set.
+func (f SearchFilter) filtersSoftware() bool {
+	return f.MinRating > 0 || f.Device != "" || f.Price != ""
+}
+
+// match reports whether m matches the software filters of f, every media does when none is set.
+func (f SearchFilter) match(m Media, _ int) bool {
+	if !f.filtersSoftware() {
+		return true
+	}
+	s := m.Software
+	if s == nil || s.AverageUserRating < f.MinRating {
+		return false
+	}
+	if f.Device != "" && !slices.ContainsFunc(s.SupportedDevices, func(d string) bool {
+		return strings.HasPrefix(strings.ToLower(d), strings.ToLower(f.Device))
+	}) {
+		return false
+	}
+	switch f.Price {
+	case PriceFree:
+		return s.Price == 0
+	case PricePaid:
+		return s.Price > 0
+	}
+	return true
+}
+
+// FetchAndInsertFilteredMedia fetches media by term from the given provider, of the media type of filter if set, keeps
+// the ones matching filter, inserts them into the repository, and returns the result. Filters are applied to up to
+// limit fetched media, so fewer media may be returned. ErrUnsupportedFilter is returned when the provider doesn't
+// search by media type.
+func (h SearchMediaHandler) FetchAndInsertFilteredMedia(ctx context.Context, provider, term string, limit int, filter SearchFilter) (MediaResult, error) {
+	var mediaResult MediaResult
+	var err error
+	if filter.Media != "" {
+		mediaResult, err = h.fetcher.FetchMediaOfType(ctx, provider, term, filter.Media, limit)
+	} else {
+		mediaResult, err = h.fetcher.FetchMediaByTerm(ctx, provider, term, limit)
+	}
+	if err != nil {
+		h.lgr.ErrorContext(ctx, "failed to fetch media", "error", err)
+		return MediaResult{}, fmt.Errorf("failed to fetch media: %w", err)
+	}
+	mediaResult.Media = lo.Filter(mediaResult.Media, filter.match)
+	mediaResult.ResultCount = len(mediaResult.Media)
+	mediaResult.SearchKey = SearchKey([]string{provider}, term, limit, filter)
+	return h.insertMedia(ctx, mediaResult), nil
+}
@@ -0,0 +1,112 @@
+package business_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAndInsertFilteredMedia(t *testing.T) {
+	apps := business.MediaResult{
+		SearchTerm:  "notes",
+		ResultCount: 4,
+		Media: []business.Media{
+			{WrapperType: "software", TrackName: "Free Notes", Software: &business.Software{AverageUserRating: 4.6, SupportedDevices: []string{"iPhone15-iPhone15", "iPadAir2-iPadAir2"}}},
+			{WrapperType: "software", TrackName: "Paid Notes", Software: &business.Software{AverageUserRating: 4.8, SupportedDevices: []string{"iPhone15-iPhone15"}, Price: 2.99}},
+			{WrapperType: "software", TrackName: "Bad Notes", Software: &business.Software{AverageUserRating: 2.1, SupportedDevices: []string{"iPadAir2-iPadAir2"}}},
+			{WrapperType: "track", Kind: "song", TrackName: "Notes"},
+		},
+	}
+	tests := []struct {
+		name          string
+		filter        business.SearchFilter
+		expectedNames []string
+	}{
+		{
+			name:          "without software filters",
+			filter:        business.SearchFilter{Media: "software"},
+			expectedNames: []string{"Free Notes", "Paid Notes", "Bad Notes", "Notes"},
+		},
+		{
+			name:          "min rating",
+			filter:        business.SearchFilter{Media: "software", MinRating: 4.5},
+			expectedNames: []string{"Free Notes", "Paid Notes"},
+		},
+		{
+			name:          "device",
+			filter:        business.SearchFilter{Media: "software", Device: "ipad"},
+			expectedNames: []string{"Free Notes", "Bad Notes"},
+		},
+		{
+			name:          "free",
+			filter:        business.SearchFilter{Media: "software", Price: business.PriceFree},
+			expectedNames: []string{"Free Notes", "Bad Notes"},
+		},
+		{
+			name:          "every filter",
+			filter:        business.SearchFilter{Media: "software", MinRating: 4, Device: "iPhone", Price: business.PricePaid},
+			expectedNames: []string{"Paid Notes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepo := mock.NewMockmediaRepository(ctrl)
+			mockFetcher := mock.NewMockmediaFetcher(ctrl)
+			mockPublisher := mock.NewMockeventPublisher(ctrl)
+			handler := business.NewSearchMediaHandler(mockRepo, mockFetcher, mockPublisher, business.SearchPolicy{}, mock.NewMocklogger(ctrl))
+
+			mockFetcher.EXPECT().FetchMediaOfType(gomock.Any(), "", "notes", "software", 10).Return(apps, nil)
+			mockRepo.EXPECT().InsertMedia(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+			res, err := handler.FetchAndInsertFilteredMedia(context.Background(), "", "notes", 10, tt.filter)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNames, lo.Map(res.Media, func(m business.Media, _ int) string { return m.TrackName }))
+			assert.Equal(t, len(tt.expectedNames), res.ResultCount)
+		})
+	}
+
+	t.Run("without media type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mock.NewMockmediaRepository(ctrl)
+		mockFetcher := mock.NewMockmediaFetcher(ctrl)
+		mockPublisher := mock.NewMockeventPublisher(ctrl)
+		handler := business.NewSearchMediaHandler(mockRepo, mockFetcher, mockPublisher, business.SearchPolicy{}, mock.NewMocklogger(ctrl))
+
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "", "notes", 10).Return(apps, nil)
+		mockRepo.EXPECT().InsertMedia(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		res, err := handler.FetchAndInsertFilteredMedia(context.Background(), "", "notes", 10, business.SearchFilter{Price: business.PricePaid})
+
+		require.NoError(t, err)
+		assert.Len(t, res.Media, 1)
+	})
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockFetcher := mock.NewMockmediaFetcher(ctrl)
+		mockLogger := mock.NewMocklogger(ctrl)
+		handler := business.NewSearchMediaHandler(mock.NewMockmediaRepository(ctrl), mockFetcher, mock.NewMockeventPublisher(ctrl), business.SearchPolicy{}, mockLogger)
+
+		mockFetcher.EXPECT().FetchMediaOfType(gomock.Any(), "musicbrainz", "notes", "software", 10).
+			Return(business.MediaResult{}, fmt.Errorf("%w: musicbrainz doesn't search by media type", business.ErrUnsupportedFilter))
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to fetch media", "error", gomock.Any())
+
+		_, err := handler.FetchAndInsertFilteredMedia(context.Background(), "musicbrainz", "notes", 10, business.SearchFilter{Media: "software"})
+
+		assert.ErrorIs(t, err, business.ErrUnsupportedFilter)
+	})
+}
@@ -0,0 +1,55 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+// HistoryFilter narrows down the stored searches listed by ListSearchHistory, zero fields aren't filtered on.
+type HistoryFilter struct {
+	// Term matches the search term case-insensitively.
+	Term string
+	// Since and Until bound when the searches were stored, Since inclusively and Until exclusively.
+	Since time.Time
+	Until time.Time
+	// Limit is the number of searches returned, it defaults to 20 and is capped at 100.
+	Limit  int
+	Offset int
+}
+
+//go:generate mockgen -source=search_history.go -destination=mock/search_history.go -package=mock
+type (
+	// historyRepository defines the interface for reading the stored searches.
+	historyRepository interface {
+		ListMediaResults(ctx context.Context, filter HistoryFilter) ([]MediaResult, error)
+	}
+)
+
+type SearchHistoryHandler struct {
+	repo historyRepository
+}
+
+// NewSearchHistoryHandler creates a new instance of SearchHistoryHandler.
+func NewSearchHistoryHandler(repo historyRepository) SearchHistoryHandler {
+	return SearchHistoryHandler{repo: repo}
+}
+
+// ListSearchHistory returns the stored searches matching filter, most recent first.
+func (h SearchHistoryHandler) ListSearchHistory(ctx context.Context, filter HistoryFilter) ([]MediaResult, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultHistoryLimit
+	}
+	filter.Limit = min(filter.Limit, maxHistoryLimit)
+	filter.Offset = max(filter.Offset, 0)
+	results, err := h.repo.ListMediaResults(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media results: %w", err)
+	}
+	return results, nil
+}
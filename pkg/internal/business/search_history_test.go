@@ -0,0 +1,70 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSearchHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockhistoryRepository(ctrl)
+	handler := business.NewSearchHistoryHandler(mockRepo)
+
+	stored := []business.MediaResult{{ID: 1, SearchTerm: "jack johnson", ResultCount: 0, Media: []business.Media{}}}
+
+	tests := []struct {
+		name           string
+		filter         business.HistoryFilter
+		mockSetup      func()
+		expectedError  string
+		expectedResult []business.MediaResult
+	}{
+		{
+			name:   "defaults the limit",
+			filter: business.HistoryFilter{Term: "jack johnson"},
+			mockSetup: func() {
+				mockRepo.EXPECT().ListMediaResults(gomock.Any(), business.HistoryFilter{Term: "jack johnson", Limit: 20}).Return(stored, nil)
+			},
+			expectedResult: stored,
+		},
+		{
+			name:   "caps the limit and clamps the offset",
+			filter: business.HistoryFilter{Limit: 1000, Offset: -5},
+			mockSetup: func() {
+				mockRepo.EXPECT().ListMediaResults(gomock.Any(), business.HistoryFilter{Limit: 100}).Return(stored, nil)
+			},
+			expectedResult: stored,
+		},
+		{
+			name:   "repository error",
+			filter: business.HistoryFilter{Limit: 10, Offset: 10},
+			mockSetup: func() {
+				mockRepo.EXPECT().ListMediaResults(gomock.Any(), business.HistoryFilter{Limit: 10, Offset: 10}).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to list media results: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := handler.ListSearchHistory(context.Background(), tt.filter)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
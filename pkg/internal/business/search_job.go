@@ -0,0 +1,420 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// SearchJobStatus is the state of a search job.
+type SearchJobStatus string
+
+const (
+	SearchJobQueued    SearchJobStatus = "queued"
+	SearchJobRunning   SearchJobStatus = "running"
+	SearchJobSucceeded SearchJobStatus = "succeeded"
+	SearchJobFailed    SearchJobStatus = "failed"
+	SearchJobCanceled  SearchJobStatus = "canceled"
+)
+
+// finished reports whether the job reached a status it never leaves.
+func (s SearchJobStatus) finished() bool {
+	return s == SearchJobSucceeded || s == SearchJobFailed || s == SearchJobCanceled
+}
+
+// SearchJob represents searches run in the background by the job workers.
+type SearchJob struct {
+	ID int64
+	// Owner is the identity of the client which queued the job, jobs are only visible to their owner.
+	Owner    string
+	Status   SearchJobStatus
+	Searches []SearchQuery
+	// Results holds the outcome of every search in the order of Searches, searches not run yet have a zero result.
+	Results []SearchJobResult
+	// Attempts is the number of times the job was claimed by a worker, it fails once it reaches MaxAttempts.
+	Attempts    int
+	MaxAttempts int
+	// LastError is the error the last failed attempt ended with.
+	LastError string
+	// RunAt is when a queued job is due.
+	RunAt      time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// SearchJobResult represents the outcome of a single search of a job.
+type SearchJobResult struct {
+	// MediaResultID is the id of the media result stored by the search, zero until it succeeds.
+	MediaResultID int64
+	// Result is the stored media result, it is only loaded by GetSearchJob.
+	Result *MediaResult
+	// Error is the error the last attempt of the search failed with.
+	Error string
+}
+
+// SearchJobEventKind identifies what a search job event reports.
+type SearchJobEventKind string
+
+const (
+	// SearchJobEventResult reports the outcome of a single search.
+	SearchJobEventResult SearchJobEventKind = "result"
+	// SearchJobEventStatus reports the job started running or got queued for a retry.
+	SearchJobEventStatus SearchJobEventKind = "status"
+)
+
+// maxSearchJobEvents is the most events returned by a single poll.
+const maxSearchJobEvents = 100
+
+// SearchJobEvent represents the progress of a job, events of a job are ordered by their id.
+type SearchJobEvent struct {
+	ID    int64
+	JobID int64
+	Kind  SearchJobEventKind
+	// Position, Term and Limit identify the search of a result event, MediaResultID and ResultCount describe
+	// its stored result unless it failed with Error.
+	Position      int
+	Term          string
+	Limit         int
+	MediaResultID int64
+	ResultCount   int
+	// Status and Attempts describe the job after a status event, NextRunAt is when it is due again
+	// after failing with Error.
+	Status    SearchJobStatus
+	Attempts  int
+	NextRunAt *time.Time
+	Error     string
+	CreatedAt time.Time
+}
+
+// SearchJobProgress represents the events of a job following a given event.
+type SearchJobProgress struct {
+	// Job is the state of the job before the events were read.
+	Job    SearchJob
+	Events []SearchJobEvent
+	// Done reports whether the job finished and Events holds its last events.
+	Done bool
+}
+
+// SearchJobPolicy defines how search jobs are retried.
+type SearchJobPolicy struct {
+	// MaxAttempts is the number of times a job is run before it fails.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it doubles on every further retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Lease is how long a running job stays claimed without its worker renewing it,
+	// jobs of crashed workers are claimed again once their lease expires.
+	Lease time.Duration
+}
+
+// errSearchJobLost is the cause of the context of a running job that got canceled, or whose lease expired and which
+// got claimed again by another worker.
+var errSearchJobLost = errors.New("search job lost")
+
+//go:generate mockgen -source=search_job.go -destination=mock/search_job.go -package=mock
+type (
+	// searchJobRepository defines the interface for search job repository operations.
+	searchJobRepository interface {
+		InsertSearchJob(ctx context.Context, job SearchJob) (SearchJob, error)
+		GetSearchJob(ctx context.Context, id int64) (SearchJob, error)
+		ClaimSearchJob(ctx context.Context, now time.Time, lockedUntil time.Time) (SearchJob, error)
+		ExtendSearchJobLease(ctx context.Context, id int64, attempt int, lockedUntil time.Time) error
+		SaveSearchJobResults(ctx context.Context, id int64, attempt int, results map[int]SearchJobResult) error
+		RetrySearchJob(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error
+		FinishSearchJob(ctx context.Context, id int64, attempt int, status SearchJobStatus, lastError string, at time.Time) error
+		CancelSearchJob(ctx context.Context, owner string, id int64, at time.Time) (SearchJob, error)
+		AppendSearchJobEvents(ctx context.Context, events []SearchJobEvent) error
+		ListSearchJobEvents(ctx context.Context, jobID, afterID int64, limit int) ([]SearchJobEvent, error)
+	}
+	// mediaResultReader defines the interface for reading stored media results.
+	mediaResultReader interface {
+		GetMediaResults(ctx context.Context, ids []int64) ([]MediaResult, error)
+	}
+	// batchSearcher defines the interface for running the searches of a job.
+	batchSearcher interface {
+		SearchEach(ctx context.Context, queries []SearchQuery, fn func(i int, outcome SearchOutcome))
+	}
+)
+
+type SearchJobHandler struct {
+	repo      searchJobRepository
+	results   mediaResultReader
+	searcher  batchSearcher
+	publisher eventPublisher
+	policy    SearchJobPolicy
+	lgr       logger
+	now       func() time.Time
+}
+
+// NewSearchJobHandler creates a new instance of SearchJobHandler.
+func NewSearchJobHandler(repo searchJobRepository, results mediaResultReader, searcher batchSearcher, publisher eventPublisher, policy SearchJobPolicy, lgr logger) SearchJobHandler {
+	policy.MaxAttempts = max(policy.MaxAttempts, 1)
+	return SearchJobHandler{repo: repo, results: results, searcher: searcher, publisher: publisher, policy: policy, lgr: lgr, now: time.Now}
+}
+
+// EnqueueSearchJob queues a job of owner running the given searches, it is due right away.
+func (h SearchJobHandler) EnqueueSearchJob(ctx context.Context, owner string, queries []SearchQuery) (SearchJob, error) {
+	job, err := h.repo.InsertSearchJob(ctx, SearchJob{
+		Owner:       owner,
+		Status:      SearchJobQueued,
+		Searches:    queries,
+		MaxAttempts: h.policy.MaxAttempts,
+		RunAt:       h.now().UTC(),
+	})
+	if err != nil {
+		return SearchJob{}, fmt.Errorf("failed to insert search job: %w", err)
+	}
+	return job, nil
+}
+
+// GetSearchJob returns the job of owner with the given id along with the media results of its succeeded searches,
+// ErrNotFound is returned when owner doesn't own it.
+func (h SearchJobHandler) GetSearchJob(ctx context.Context, owner string, id int64) (SearchJob, error) {
+	job, err := h.getSearchJob(ctx, owner, id)
+	if err != nil {
+		return SearchJob{}, err
+	}
+	ids := lo.FilterMap(job.Results, func(r SearchJobResult, _ int) (int64, bool) {
+		return r.MediaResultID, r.MediaResultID != 0
+	})
+	if len(ids) == 0 {
+		return job, nil
+	}
+	results, err := h.results.GetMediaResults(ctx, ids)
+	if err != nil {
+		return SearchJob{}, fmt.Errorf("failed to get media results: %w", err)
+	}
+	byID := lo.KeyBy(results, func(r MediaResult) int64 { return r.ID })
+	for i, r := range job.Results {
+		if result, ok := byID[r.MediaResultID]; ok {
+			job.Results[i].Result = &result
+		}
+	}
+	return job, nil
+}
+
+// getSearchJob returns the job of owner with the given id, jobs of other owners are reported as not found.
+func (h SearchJobHandler) getSearchJob(ctx context.Context, owner string, id int64) (SearchJob, error) {
+	job, err := h.repo.GetSearchJob(ctx, id)
+	if err == nil && job.Owner != owner {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return SearchJob{}, fmt.Errorf("failed to get search job: %w", err)
+	}
+	return job, nil
+}
+
+// CancelSearchJob cancels a queued or running job of owner, running searches are stopped by the worker running them.
+// ErrConflict is returned when the job already finished.
+func (h SearchJobHandler) CancelSearchJob(ctx context.Context, owner string, id int64) (SearchJob, error) {
+	job, err := h.repo.CancelSearchJob(ctx, owner, id, h.now().UTC())
+	if err == nil {
+		h.publishFinished(ctx, job)
+		return job, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return SearchJob{}, fmt.Errorf("failed to cancel search job: %w", err)
+	}
+	// the job either doesn't exist, isn't owned by owner or already finished.
+	job, err = h.getSearchJob(ctx, owner, id)
+	if err != nil {
+		return SearchJob{}, err
+	}
+	return SearchJob{}, fmt.Errorf("%w: search job is already %s", ErrConflict, job.Status)
+}
+
+// ProcessNextSearchJob claims the next due job and runs its searches, it reports false when no job is due.
+// Searches that succeeded in earlier attempts aren't run again. A job whose searches didn't all succeed is
+// retried with an exponential backoff until it runs out of attempts, when ctx is done it is queued again right away.
+// The attempt the job was claimed for is its lease, the job is only updated while it still holds it.
+func (h SearchJobHandler) ProcessNextSearchJob(ctx context.Context) (bool, error) {
+	now := h.now().UTC()
+	job, err := h.repo.ClaimSearchJob(ctx, now, now.Add(h.policy.Lease))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim search job: %w", err)
+	}
+
+	// the searches are run for the owner of the job, their events are delivered to its webhooks.
+	runCtx, cancel := context.WithCancelCause(WithOwner(ctx, job.Owner))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		h.keepLease(runCtx, cancel, job.ID, job.Attempts)
+	}()
+	h.recordEvents(ctx, SearchJobEvent{JobID: job.ID, Kind: SearchJobEventStatus, Status: SearchJobRunning, Attempts: job.Attempts})
+	lastErr, err := h.runSearches(runCtx, job)
+	cancel(nil)
+	wg.Wait()
+
+	// the job isn't this worker's to update anymore.
+	if errors.Is(context.Cause(runCtx), errSearchJobLost) || errors.Is(err, ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to save search job results: %w", err)
+	}
+	// the job is recorded even when ctx is done so its progress isn't lost.
+	stopped := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+	var runAt *time.Time
+	switch {
+	case lastErr == nil:
+		job.Status, job.LastError = SearchJobSucceeded, ""
+		err = h.repo.FinishSearchJob(ctx, job.ID, job.Attempts, job.Status, job.LastError, h.now().UTC())
+	case stopped:
+		runAt = lo.ToPtr(h.now().UTC())
+		err = h.repo.RetrySearchJob(ctx, job.ID, job.Attempts, *runAt, lastErr.Error())
+	case job.Attempts >= job.MaxAttempts:
+		job.Status, job.LastError = SearchJobFailed, lastErr.Error()
+		err = h.repo.FinishSearchJob(ctx, job.ID, job.Attempts, job.Status, job.LastError, h.now().UTC())
+	default:
+		runAt = lo.ToPtr(h.now().UTC().Add(h.backoff(job.Attempts)))
+		err = h.repo.RetrySearchJob(ctx, job.ID, job.Attempts, *runAt, lastErr.Error())
+	}
+	// ErrNotFound means the job got canceled or lost its lease once its searches were done.
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return true, fmt.Errorf("failed to update search job: %w", err)
+	}
+	if err == nil && runAt == nil {
+		h.publishFinished(ctx, job)
+	}
+	if err == nil && runAt != nil {
+		h.recordEvents(ctx, SearchJobEvent{
+			JobID:     job.ID,
+			Kind:      SearchJobEventStatus,
+			Status:    SearchJobQueued,
+			Attempts:  job.Attempts,
+			NextRunAt: runAt,
+			Error:     lastErr.Error(),
+		})
+	}
+	return true, nil
+}
+
+// runSearches runs the searches of job that didn't succeed yet and stores the result of each as soon as it is known,
+// it returns the error of the last failed search along with the error storing a result, if any.
+func (h SearchJobHandler) runSearches(ctx context.Context, job SearchJob) (lastErr error, saveErr error) {
+	var positions []int
+	for i := range job.Searches {
+		if i >= len(job.Results) || job.Results[i].MediaResultID == 0 {
+			positions = append(positions, i)
+		}
+	}
+	queries := lo.Map(positions, func(i int, _ int) SearchQuery {
+		return job.Searches[i]
+	})
+	h.searcher.SearchEach(ctx, queries, func(i int, outcome SearchOutcome) {
+		if saveErr != nil || errors.Is(context.Cause(ctx), errSearchJobLost) {
+			return
+		}
+		result := SearchJobResult{MediaResultID: outcome.Result.ID}
+		switch {
+		case outcome.Err != nil:
+			lastErr = fmt.Errorf("failed to fetch and insert media: %w", outcome.Err)
+			result = SearchJobResult{Error: lastErr.Error()}
+		case outcome.Result.ID == 0:
+			// the search succeeded but its result wasn't stored, there is nothing to link the job to.
+			lastErr = errors.New("failed to store media result")
+			result = SearchJobResult{Error: lastErr.Error()}
+		}
+		// results are stored even once ctx is done so the progress isn't lost.
+		position := positions[i]
+		if err := h.repo.SaveSearchJobResults(context.WithoutCancel(ctx), job.ID, job.Attempts, map[int]SearchJobResult{position: result}); err != nil {
+			saveErr = err
+			return
+		}
+		h.recordEvents(ctx, SearchJobEvent{
+			JobID:         job.ID,
+			Kind:          SearchJobEventResult,
+			Position:      position,
+			Term:          queries[i].Term,
+			Limit:         queries[i].Limit,
+			MediaResultID: result.MediaResultID,
+			ResultCount:   outcome.Result.ResultCount,
+			Error:         result.Error,
+		})
+	})
+	return lastErr, saveErr
+}
+
+// publishFinished publishes the job.finished event of job, it is called once job reached a finished status.
+func (h SearchJobHandler) publishFinished(ctx context.Context, job SearchJob) {
+	publishEvent(ctx, h.publisher, h.lgr, Event{
+		Type:       EventJobFinished,
+		Owner:      job.Owner,
+		OccurredAt: h.now().UTC(),
+		Data: JobFinishedData{
+			ID:        job.ID,
+			Status:    job.Status,
+			Attempts:  job.Attempts,
+			LastError: job.LastError,
+		},
+	})
+}
+
+// recordEvents appends events to the job they belong to. Events only report progress, failing to append them
+// doesn't fail the job and streams still end with the final state of the job.
+func (h SearchJobHandler) recordEvents(ctx context.Context, events ...SearchJobEvent) {
+	if err := h.repo.AppendSearchJobEvents(context.WithoutCancel(ctx), events); err != nil {
+		h.lgr.ErrorContext(ctx, "failed to append search job events", "error", err)
+	}
+}
+
+// PollSearchJobEvents returns the events of the job of owner with the given id following the event with the given
+// id, at most maxSearchJobEvents of them, along with the state of the job. ErrNotFound is returned when owner doesn't
+// own the job.
+func (h SearchJobHandler) PollSearchJobEvents(ctx context.Context, owner string, id, afterEventID int64) (SearchJobProgress, error) {
+	// the job is read first, the events of a job read as finished are all stored already.
+	job, err := h.getSearchJob(ctx, owner, id)
+	if err != nil {
+		return SearchJobProgress{}, err
+	}
+	events, err := h.repo.ListSearchJobEvents(ctx, id, afterEventID, maxSearchJobEvents)
+	if err != nil {
+		return SearchJobProgress{}, fmt.Errorf("failed to list search job events: %w", err)
+	}
+	return SearchJobProgress{
+		Job:    job,
+		Events: events,
+		Done:   job.Status.finished() && len(events) < maxSearchJobEvents,
+	}, nil
+}
+
+// keepLease renews the lease of the given attempt of the running job until ctx is done, ctx is canceled when the job
+// got canceled or claimed by another worker. A failed renewal is retried on the next tick, the lease outlives a couple
+// of them.
+func (h SearchJobHandler) keepLease(ctx context.Context, cancel context.CancelCauseFunc, id int64, attempt int) {
+	ticker := time.NewTicker(max(h.policy.Lease/3, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := h.repo.ExtendSearchJobLease(ctx, id, attempt, h.now().UTC().Add(h.policy.Lease))
+			if errors.Is(err, ErrNotFound) {
+				cancel(errSearchJobLost)
+				return
+			}
+		}
+	}
+}
+
+// backoff returns the delay before retrying a job after its given attempt.
+func (h SearchJobHandler) backoff(attempt int) time.Duration {
+	delay := h.policy.Backoff
+	for i := 1; i < attempt && delay < h.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, h.policy.MaxBackoff)
+}
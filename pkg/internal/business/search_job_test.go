@@ -0,0 +1,413 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// searchEach returns a SearchEach stub reporting outcomes in order.
+func searchEach(outcomes ...business.SearchOutcome) func(context.Context, []business.SearchQuery, func(int, business.SearchOutcome)) {
+	return func(_ context.Context, _ []business.SearchQuery, fn func(int, business.SearchOutcome)) {
+		for i, outcome := range outcomes {
+			fn(i, outcome)
+		}
+	}
+}
+
+func TestProcessNextSearchJob(t *testing.T) {
+	policy := business.SearchJobPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 3 * time.Minute, Lease: time.Hour}
+	claimed := business.SearchJob{
+		ID:          1,
+		Owner:       "key:7",
+		Status:      business.SearchJobRunning,
+		Searches:    []business.SearchQuery{{Term: "jack", Limit: 5}, {Term: "johnson", Limit: 20}},
+		Results:     []business.SearchJobResult{{MediaResultID: 7}, {Error: "upstream down"}},
+		Attempts:    2,
+		MaxAttempts: 3,
+	}
+	tests := []struct {
+		name              string
+		mockSetup         func(*mock.MocksearchJobRepository, *mock.MockbatchSearcher)
+		expectedProcessed bool
+		expectedError     string
+		// expectedFinished is the status the published job.finished event reports, if any.
+		expectedFinished business.SearchJobStatus
+	}{
+		{
+			name: "no job due",
+			mockSetup: func(repo *mock.MocksearchJobRepository, _ *mock.MockbatchSearcher) {
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(business.SearchJob{}, business.ErrNotFound)
+			},
+		},
+		{
+			name: "claim error",
+			mockSetup: func(repo *mock.MocksearchJobRepository, _ *mock.MockbatchSearcher) {
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(business.SearchJob{}, errors.New("db down"))
+			},
+			expectedError: "failed to claim search job: db down",
+		},
+		{
+			name: "only pending searches are run",
+			mockSetup: func(repo *mock.MocksearchJobRepository, searcher *mock.MockbatchSearcher) {
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				searcher.EXPECT().SearchEach(gomock.Any(), []business.SearchQuery{{Term: "johnson", Limit: 20}}, gomock.Any()).
+					Do(func(ctx context.Context, queries []business.SearchQuery, fn func(int, business.SearchOutcome)) {
+						// the searches are run for the owner of the job.
+						assert.Equal(t, "key:7", business.OwnerFromContext(ctx))
+						searchEach(business.SearchOutcome{Result: business.MediaResult{ID: 8, ResultCount: 3}})(ctx, queries, fn)
+					})
+				repo.EXPECT().AppendSearchJobEvents(gomock.Any(), []business.SearchJobEvent{
+					{JobID: 1, Kind: business.SearchJobEventStatus, Status: business.SearchJobRunning, Attempts: 2},
+				}).Return(nil)
+				repo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 2, map[int]business.SearchJobResult{1: {MediaResultID: 8}}).Return(nil)
+				repo.EXPECT().AppendSearchJobEvents(gomock.Any(), []business.SearchJobEvent{
+					{JobID: 1, Kind: business.SearchJobEventResult, Position: 1, Term: "johnson", Limit: 20, MediaResultID: 8, ResultCount: 3},
+				}).Return(nil)
+				repo.EXPECT().FinishSearchJob(gomock.Any(), int64(1), 2, business.SearchJobSucceeded, "", gomock.Any()).Return(nil)
+			},
+			expectedProcessed: true,
+			expectedFinished:  business.SearchJobSucceeded,
+		},
+		{
+			name: "failed searches are retried with backoff",
+			mockSetup: func(repo *mock.MocksearchJobRepository, searcher *mock.MockbatchSearcher) {
+				job := claimed
+				job.Attempts = 2
+				job.MaxAttempts = 4
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+				searcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).Do(searchEach(business.SearchOutcome{Err: errors.New("upstream down")}))
+				repo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 2, map[int]business.SearchJobResult{
+					1: {Error: "failed to fetch and insert media: upstream down"},
+				}).Return(nil)
+				repo.EXPECT().RetrySearchJob(gomock.Any(), int64(1), 2, gomock.Any(), "failed to fetch and insert media: upstream down").
+					DoAndReturn(func(_ context.Context, _ int64, _ int, runAt time.Time, _ string) error {
+						// the second retry waits twice the base backoff.
+						assert.WithinDuration(t, time.Now().Add(2*time.Minute), runAt, 5*time.Second)
+						return nil
+					})
+				repo.EXPECT().AppendSearchJobEvents(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events []business.SearchJobEvent) error {
+						// the last event tells streams when the job is due again.
+						if assert.Len(t, events, 1) && events[0].Kind == business.SearchJobEventStatus && events[0].Status == business.SearchJobQueued {
+							assert.Equal(t, "failed to fetch and insert media: upstream down", events[0].Error)
+							assert.NotNil(t, events[0].NextRunAt)
+						}
+						return nil
+					}).Times(3)
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "job fails once it runs out of attempts",
+			mockSetup: func(repo *mock.MocksearchJobRepository, searcher *mock.MockbatchSearcher) {
+				job := claimed
+				job.Attempts = 3
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+				searcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).Do(searchEach(business.SearchOutcome{Result: business.MediaResult{SearchTerm: "johnson"}}))
+				repo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 3, map[int]business.SearchJobResult{
+					1: {Error: "failed to store media result"},
+				}).Return(nil)
+				repo.EXPECT().FinishSearchJob(gomock.Any(), int64(1), 3, business.SearchJobFailed, "failed to store media result", gomock.Any()).Return(nil)
+			},
+			expectedProcessed: true,
+			expectedFinished:  business.SearchJobFailed,
+		},
+		{
+			name: "job canceled once its searches were done",
+			mockSetup: func(repo *mock.MocksearchJobRepository, searcher *mock.MockbatchSearcher) {
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				searcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).Do(searchEach(business.SearchOutcome{Result: business.MediaResult{ID: 8}}))
+				repo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 2, gomock.Any()).Return(nil)
+				repo.EXPECT().FinishSearchJob(gomock.Any(), int64(1), 2, business.SearchJobSucceeded, "", gomock.Any()).Return(business.ErrNotFound)
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "job claimed by another worker once its lease expired",
+			mockSetup: func(repo *mock.MocksearchJobRepository, searcher *mock.MockbatchSearcher) {
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				searcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).Do(searchEach(business.SearchOutcome{Result: business.MediaResult{ID: 8}}))
+				repo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 2, gomock.Any()).Return(business.ErrNotFound)
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "save error",
+			mockSetup: func(repo *mock.MocksearchJobRepository, searcher *mock.MockbatchSearcher) {
+				repo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				searcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).Do(searchEach(business.SearchOutcome{Result: business.MediaResult{ID: 8}}))
+				repo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 2, gomock.Any()).Return(errors.New("db down"))
+			},
+			expectedProcessed: true,
+			expectedError:     "failed to save search job results: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMocksearchJobRepository(ctrl)
+			mockSearcher := mock.NewMockbatchSearcher(ctrl)
+			mockPublisher := mock.NewMockeventPublisher(ctrl)
+			tt.mockSetup(mockRepo, mockSearcher)
+			if tt.expectedFinished != "" {
+				mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event business.Event) error {
+					assert.Equal(t, business.EventJobFinished, event.Type)
+					assert.Equal(t, "key:7", event.Owner)
+					assert.Equal(t, tt.expectedFinished, event.Data.(business.JobFinishedData).Status)
+					return nil
+				})
+			}
+			// events only report progress, cases not checking them accept any.
+			mockRepo.EXPECT().AppendSearchJobEvents(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			handler := business.NewSearchJobHandler(mockRepo, mock.NewMockmediaResultReader(ctrl), mockSearcher, mockPublisher, policy, mock.NewMocklogger(ctrl))
+
+			processed, err := handler.ProcessNextSearchJob(context.Background())
+
+			assert.Equal(t, tt.expectedProcessed, processed)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProcessNextSearchJobCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMocksearchJobRepository(ctrl)
+	mockSearcher := mock.NewMockbatchSearcher(ctrl)
+	handler := business.NewSearchJobHandler(mockRepo, mock.NewMockmediaResultReader(ctrl), mockSearcher, mock.NewMockeventPublisher(ctrl), business.SearchJobPolicy{Lease: 3 * time.Millisecond}, mock.NewMocklogger(ctrl))
+
+	mockRepo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(business.SearchJob{ID: 1, Searches: []business.SearchQuery{{Term: "jack", Limit: 5}}, Attempts: 1}, nil)
+	mockRepo.EXPECT().ExtendSearchJobLease(gomock.Any(), int64(1), 1, gomock.Any()).Return(business.ErrNotFound)
+	mockRepo.EXPECT().AppendSearchJobEvents(gomock.Any(), gomock.Any()).Return(nil)
+	mockSearcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, _ []business.SearchQuery, fn func(int, business.SearchOutcome)) {
+			// the searches run until the worker notices the job got canceled, their outcome isn't stored.
+			<-ctx.Done()
+			fn(0, business.SearchOutcome{Err: ctx.Err()})
+		})
+
+	processed, err := handler.ProcessNextSearchJob(context.Background())
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+}
+
+func TestProcessNextSearchJobStopped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMocksearchJobRepository(ctrl)
+	mockSearcher := mock.NewMockbatchSearcher(ctrl)
+	handler := business.NewSearchJobHandler(mockRepo, mock.NewMockmediaResultReader(ctrl), mockSearcher, mock.NewMockeventPublisher(ctrl), business.SearchJobPolicy{Backoff: time.Hour, MaxBackoff: time.Hour, Lease: time.Hour}, mock.NewMocklogger(ctrl))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockRepo.EXPECT().ClaimSearchJob(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(business.SearchJob{ID: 1, Searches: []business.SearchQuery{{Term: "jack", Limit: 5}}, Attempts: 1, MaxAttempts: 3}, nil)
+	mockRepo.EXPECT().AppendSearchJobEvents(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	mockSearcher.EXPECT().SearchEach(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, _ []business.SearchQuery, fn func(int, business.SearchOutcome)) {
+			cancel()
+			fn(0, business.SearchOutcome{Err: ctx.Err()})
+		})
+	mockRepo.EXPECT().SaveSearchJobResults(gomock.Any(), int64(1), 1, gomock.Any()).Return(nil)
+	mockRepo.EXPECT().RetrySearchJob(gomock.Any(), int64(1), 1, gomock.Any(), "failed to fetch and insert media: context canceled").
+		DoAndReturn(func(_ context.Context, _ int64, _ int, runAt time.Time, _ string) error {
+			// a job stopped by shutdown is due right away instead of waiting for its backoff.
+			assert.WithinDuration(t, time.Now(), runAt, 5*time.Second)
+			return nil
+		})
+
+	processed, err := handler.ProcessNextSearchJob(ctx)
+
+	assert.True(t, processed)
+	assert.NoError(t, err)
+}
+
+func TestGetSearchJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMocksearchJobRepository(ctrl)
+	mockResults := mock.NewMockmediaResultReader(ctrl)
+	handler := business.NewSearchJobHandler(mockRepo, mockResults, mock.NewMockbatchSearcher(ctrl), mock.NewMockeventPublisher(ctrl), business.SearchJobPolicy{}, mock.NewMocklogger(ctrl))
+
+	mockRepo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{
+		ID:       1,
+		Owner:    "key:7",
+		Status:   business.SearchJobRunning,
+		Searches: []business.SearchQuery{{Term: "jack", Limit: 5}, {Term: "johnson", Limit: 20}},
+		Results:  []business.SearchJobResult{{}, {MediaResultID: 7}},
+	}, nil)
+	mockResults.EXPECT().GetMediaResults(gomock.Any(), []int64{7}).Return([]business.MediaResult{{ID: 7, SearchTerm: "johnson"}}, nil)
+
+	job, err := handler.GetSearchJob(context.Background(), "key:7", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []business.SearchJobResult{{}, {MediaResultID: 7, Result: &business.MediaResult{ID: 7, SearchTerm: "johnson"}}}, job.Results)
+}
+
+func TestGetSearchJob_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMocksearchJobRepository(ctrl)
+	handler := business.NewSearchJobHandler(mockRepo, mock.NewMockmediaResultReader(ctrl), mock.NewMockbatchSearcher(ctrl), mock.NewMockeventPublisher(ctrl), business.SearchJobPolicy{}, mock.NewMocklogger(ctrl))
+
+	mockRepo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Owner: "key:8"}, nil)
+
+	_, err := handler.GetSearchJob(context.Background(), "key:7", 1)
+
+	assert.ErrorIs(t, err, business.ErrNotFound)
+}
+
+func TestCancelSearchJob(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSetup     func(*mock.MocksearchJobRepository, *mock.MockeventPublisher)
+		expectedError string
+		expectedJob   business.SearchJob
+	}{
+		{
+			name: "canceled",
+			mockSetup: func(repo *mock.MocksearchJobRepository, publisher *mock.MockeventPublisher) {
+				repo.EXPECT().CancelSearchJob(gomock.Any(), "key:7", int64(1), gomock.Any()).Return(business.SearchJob{ID: 1, Owner: "key:7", Status: business.SearchJobCanceled}, nil)
+				publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event business.Event) error {
+					assert.Equal(t, "key:7", event.Owner)
+					assert.Equal(t, business.JobFinishedData{ID: 1, Status: business.SearchJobCanceled}, event.Data)
+					return nil
+				})
+			},
+			expectedJob: business.SearchJob{ID: 1, Owner: "key:7", Status: business.SearchJobCanceled},
+		},
+		{
+			name: "already finished",
+			mockSetup: func(repo *mock.MocksearchJobRepository, _ *mock.MockeventPublisher) {
+				repo.EXPECT().CancelSearchJob(gomock.Any(), "key:7", int64(1), gomock.Any()).Return(business.SearchJob{}, business.ErrNotFound)
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Owner: "key:7", Status: business.SearchJobSucceeded}, nil)
+			},
+			expectedError: "conflict: search job is already succeeded",
+		},
+		{
+			name: "unknown job",
+			mockSetup: func(repo *mock.MocksearchJobRepository, _ *mock.MockeventPublisher) {
+				repo.EXPECT().CancelSearchJob(gomock.Any(), "key:7", int64(1), gomock.Any()).Return(business.SearchJob{}, business.ErrNotFound)
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{}, business.ErrNotFound)
+			},
+			expectedError: "failed to get search job: not found",
+		},
+		{
+			name: "job of another client",
+			mockSetup: func(repo *mock.MocksearchJobRepository, _ *mock.MockeventPublisher) {
+				repo.EXPECT().CancelSearchJob(gomock.Any(), "key:7", int64(1), gomock.Any()).Return(business.SearchJob{}, business.ErrNotFound)
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Owner: "key:8", Status: business.SearchJobRunning}, nil)
+			},
+			expectedError: "failed to get search job: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMocksearchJobRepository(ctrl)
+			mockPublisher := mock.NewMockeventPublisher(ctrl)
+			tt.mockSetup(mockRepo, mockPublisher)
+			handler := business.NewSearchJobHandler(mockRepo, mock.NewMockmediaResultReader(ctrl), mock.NewMockbatchSearcher(ctrl), mockPublisher, business.SearchJobPolicy{}, mock.NewMocklogger(ctrl))
+
+			job, err := handler.CancelSearchJob(context.Background(), "key:7", 1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedJob, job)
+			}
+		})
+	}
+}
+
+func TestPollSearchJobEvents(t *testing.T) {
+	events := []business.SearchJobEvent{{ID: 4, JobID: 1, Kind: business.SearchJobEventResult, Term: "jack", MediaResultID: 7}}
+	tests := []struct {
+		name             string
+		mockSetup        func(*mock.MocksearchJobRepository)
+		expectedError    string
+		expectedProgress business.SearchJobProgress
+	}{
+		{
+			name: "running job",
+			mockSetup: func(repo *mock.MocksearchJobRepository) {
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Status: business.SearchJobRunning}, nil)
+				repo.EXPECT().ListSearchJobEvents(gomock.Any(), int64(1), int64(3), 100).Return(events, nil)
+			},
+			expectedProgress: business.SearchJobProgress{Job: business.SearchJob{ID: 1, Status: business.SearchJobRunning}, Events: events},
+		},
+		{
+			name: "finished job",
+			mockSetup: func(repo *mock.MocksearchJobRepository) {
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Status: business.SearchJobSucceeded}, nil)
+				repo.EXPECT().ListSearchJobEvents(gomock.Any(), int64(1), int64(3), 100).Return(events, nil)
+			},
+			expectedProgress: business.SearchJobProgress{Job: business.SearchJob{ID: 1, Status: business.SearchJobSucceeded}, Events: events, Done: true},
+		},
+		{
+			name: "unknown job",
+			mockSetup: func(repo *mock.MocksearchJobRepository) {
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{}, business.ErrNotFound)
+			},
+			expectedError: "failed to get search job: not found",
+		},
+		{
+			name: "job of another client",
+			mockSetup: func(repo *mock.MocksearchJobRepository) {
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Owner: "key:8", Status: business.SearchJobRunning}, nil)
+			},
+			expectedError: "failed to get search job: not found",
+		},
+		{
+			name: "list error",
+			mockSetup: func(repo *mock.MocksearchJobRepository) {
+				repo.EXPECT().GetSearchJob(gomock.Any(), int64(1)).Return(business.SearchJob{ID: 1, Status: business.SearchJobRunning}, nil)
+				repo.EXPECT().ListSearchJobEvents(gomock.Any(), int64(1), int64(3), 100).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to list search job events: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMocksearchJobRepository(ctrl)
+			tt.mockSetup(mockRepo)
+			handler := business.NewSearchJobHandler(mockRepo, mock.NewMockmediaResultReader(ctrl), mock.NewMockbatchSearcher(ctrl), mock.NewMockeventPublisher(ctrl), business.SearchJobPolicy{}, mock.NewMocklogger(ctrl))
+
+			progress, err := handler.PollSearchJobEvents(context.Background(), "", 1, 3)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedProgress, progress)
+			}
+		})
+	}
+}
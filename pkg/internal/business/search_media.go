@@ -2,9 +2,19 @@ package business
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
 )
 
+// ErrUnknownProvider is returned when media is searched from a provider that isn't registered.
+var ErrUnknownProvider = errors.New("unknown provider")
+
 // Media represents a single media item with various attributes.
 type Media struct {
 	WrapperType            string
@@ -34,6 +44,50 @@ type Media struct {
 	ArtworkURL600          string
 	GenreIDs               []string
 	Genres                 []string
+	// Source is the name of the provider the media was found by, e.g. itunes.
+	Source string
+	// SourceID is the id of the media at its source when it isn't numeric, e.g. a MusicBrainz id.
+	SourceID string
+	// ISRC identifies the recording of a track and UPC the release of a collection, when known.
+	ISRC string
+	UPC  string
+	// Software holds the App Store details of apps, it is nil for the other media.
+	Software *Software
+	// Video holds the details of movies, tv seasons and tv episodes, it is nil for the other media.
+	Video *Video
+}
+
+// Software represents the App Store details of an app.
+type Software struct {
+	BundleID          string
+	AverageUserRating float64
+	UserRatingCount   int
+	ScreenshotURLs    []string
+	// SupportedDevices lists the devices the app runs on, e.g. iPhone15-iPhone15 or iPadAir2-iPadAir2.
+	SupportedDevices []string
+	FileSizeBytes    int64
+	MinimumOSVersion string
+	SellerName       string
+	// Price is zero for free apps, in the currency of the media.
+	Price          float64
+	FormattedPrice string
+}
+
+// Video represents the details of a movie, a tv season or a tv episode. Prices are in the currency of the media,
+// zero when not on sale.
+type Video struct {
+	LongDescription    string
+	ShortDescription   string
+	TrackPrice         float64
+	TrackRentalPrice   float64
+	TrackHDPrice       float64
+	TrackHDRentalPrice float64
+	CollectionPrice    float64
+	CollectionHDPrice  float64
+	// SeasonNumber is the number of the season of tv seasons and episodes and EpisodeNumber the number of an episode
+	// in its season, zero when unknown.
+	SeasonNumber  int
+	EpisodeNumber int
 }
 
 // MediaResult represents the result user searched for.
@@ -42,6 +96,26 @@ type MediaResult struct {
 	SearchTerm  string
 	Media       []Media
 	ResultCount int
+	// FetchedAt is when the result was fetched from upstream.
+	FetchedAt time.Time
+	// SearchKey identifies the search the result was fetched for, see SearchKey.
+	SearchKey string
+}
+
+// SearchKey returns the key identifying a search of term from the given providers, an empty provider being the
+// default one, searches with the same key return the same result until upstream changes.
+func SearchKey(providers []string, term string, limit int, filter SearchFilter) string {
+	v := url.Values{}
+	v.Set("providers", strings.Join(lo.Uniq(providers), ","))
+	v.Set("term", term)
+	v.Set("limit", strconv.Itoa(limit))
+	if filter != (SearchFilter{}) {
+		v.Set("media", filter.Media)
+		v.Set("min_rating", strconv.FormatFloat(filter.MinRating, 'f', -1, 64))
+		v.Set("device", filter.Device)
+		v.Set("price", string(filter.Price))
+	}
+	return v.Encode()
 }
 
 //go:generate mockgen -source=search_media.go -destination=mock/search_media.go -package=mock
@@ -49,10 +123,13 @@ type (
 	// mediaRepository defines the interface for media repository operations.
 	mediaRepository interface {
 		InsertMedia(ctx context.Context, media MediaResult) (int64, error)
+		GetLatestMediaResult(ctx context.Context, searchKey string, since time.Time) (MediaResult, error)
 	}
-	// mediaFetcher defines the interface for fetching media.
+	// mediaFetcher defines the interface for fetching media, an empty provider fetches from the default one.
 	mediaFetcher interface {
-		FetchMediaByTerm(ctx context.Context, term string, limit int) (MediaResult, error)
+		HasProvider(name string) bool
+		FetchMediaByTerm(ctx context.Context, provider, term string, limit int) (MediaResult, error)
+		FetchMediaOfType(ctx context.Context, provider, term, media string, limit int) (MediaResult, error)
 	}
 	// logger logging error.
 	logger interface {
@@ -60,32 +137,73 @@ type (
 	}
 )
 
+// SearchPolicy defines how searches are run, ProviderTimeout bounds the search sent to every provider of a federated
+// search, it isn't bounded when zero.
+type SearchPolicy struct {
+	ProviderTimeout time.Duration
+}
+
 type SearchMediaHandler struct {
-	repo    mediaRepository
-	fetcher mediaFetcher
-	lgr     logger
+	repo      mediaRepository
+	fetcher   mediaFetcher
+	publisher eventPublisher
+	policy    SearchPolicy
+	lgr       logger
 }
 
 // NewSearchMediaHandler creates a new instance of SearchMediaHandler.
-func NewSearchMediaHandler(repo mediaRepository, fetcher mediaFetcher, lgr logger) SearchMediaHandler {
-	return SearchMediaHandler{repo: repo, fetcher: fetcher, lgr: lgr}
+func NewSearchMediaHandler(repo mediaRepository, fetcher mediaFetcher, publisher eventPublisher, policy SearchPolicy, lgr logger) SearchMediaHandler {
+	return SearchMediaHandler{repo: repo, fetcher: fetcher, publisher: publisher, policy: policy, lgr: lgr}
 }
 
-// FetchAndInsertMedia fetches media by term, inserts it into the repository, and returns the result.
+// FetchAndInsertMedia fetches media by term from the default provider, inserts it into the repository, and returns
+// the result.
 func (h SearchMediaHandler) FetchAndInsertMedia(ctx context.Context, term string, limit int) (MediaResult, error) {
+	return h.FetchAndInsertMediaFrom(ctx, "", term, limit)
+}
+
+// FetchAndInsertMediaFrom fetches media by term from the given provider, inserts it into the repository, and returns
+// the result. ErrUnknownProvider is returned when the provider isn't registered.
+func (h SearchMediaHandler) FetchAndInsertMediaFrom(ctx context.Context, provider, term string, limit int) (MediaResult, error) {
 	// Fetch media by term
-	mediaResult, err := h.fetcher.FetchMediaByTerm(ctx, term, limit)
+	mediaResult, err := h.fetcher.FetchMediaByTerm(ctx, provider, term, limit)
 	if err != nil {
 		h.lgr.ErrorContext(ctx, "failed to fetch media", "error", err)
 		return MediaResult{}, fmt.Errorf("failed to fetch media: %w", err)
 	}
+	mediaResult.SearchKey = SearchKey([]string{provider}, term, limit, SearchFilter{})
+	return h.insertMedia(ctx, mediaResult), nil
+}
+
+// LatestMedia returns the latest result stored since the given time for the search identified by searchKey, without
+// searching upstream. ErrNotFound is returned when there is none.
+func (h SearchMediaHandler) LatestMedia(ctx context.Context, searchKey string, since time.Time) (MediaResult, error) {
+	res, err := h.repo.GetLatestMediaResult(ctx, searchKey, since)
+	if err != nil {
+		return MediaResult{}, fmt.Errorf("failed to get latest media result: %w", err)
+	}
+	return res, nil
+}
 
-	// Insert media into the repository
+// insertMedia inserts the fetched mediaResult into the repository and returns it along with its id.
+func (h SearchMediaHandler) insertMedia(ctx context.Context, mediaResult MediaResult) MediaResult {
 	id, err := h.repo.InsertMedia(ctx, mediaResult)
 	// If we failed to insert to db, it is ok to return to requester the result.
 	if err != nil {
 		h.lgr.ErrorContext(ctx, "failed to insert media", "error", err)
+		return mediaResult
 	}
 	mediaResult.ID = id
-	return mediaResult, nil
+	publishEvent(ctx, h.publisher, h.lgr, Event{
+		Type:       EventSearchStored,
+		Owner:      OwnerFromContext(ctx),
+		OccurredAt: time.Now().UTC(),
+		Data: SearchStoredData{
+			ID:          id,
+			SearchTerm:  mediaResult.SearchTerm,
+			ResultCount: mediaResult.ResultCount,
+			FetchedAt:   mediaResult.FetchedAt,
+		},
+	})
+	return mediaResult
 }
@@ -3,8 +3,10 @@ package business_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
 	"testing"
+	"time"
 
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	"github.com/golang/mock/gomock"
@@ -17,9 +19,10 @@ func TestFetchAndInsertMedia(t *testing.T) {
 
 	mockRepo := mock.NewMockmediaRepository(ctrl)
 	mockFetcher := mock.NewMockmediaFetcher(ctrl)
+	mockPublisher := mock.NewMockeventPublisher(ctrl)
 	mockLogger := mock.NewMocklogger(ctrl)
 
-	handler := business.NewSearchMediaHandler(mockRepo, mockFetcher, mockLogger)
+	handler := business.NewSearchMediaHandler(mockRepo, mockFetcher, mockPublisher, business.SearchPolicy{}, mockLogger)
 
 	tests := []struct {
 		name           string
@@ -34,7 +37,7 @@ func TestFetchAndInsertMedia(t *testing.T) {
 			term:  "test",
 			limit: 1,
 			mockSetup: func() {
-				mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "test", 1).Return(business.MediaResult{
+				mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "", "test", 1).Return(business.MediaResult{
 					SearchTerm:  "test",
 					ResultCount: 1,
 					Media: []business.Media{
@@ -49,6 +52,12 @@ func TestFetchAndInsertMedia(t *testing.T) {
 					},
 				}, nil)
 				mockRepo.EXPECT().InsertMedia(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event business.Event) error {
+					assert.Equal(t, business.EventSearchStored, event.Type)
+					assert.Equal(t, "key:7", event.Owner)
+					assert.Equal(t, business.SearchStoredData{ID: 1, SearchTerm: "test", ResultCount: 1}, event.Data)
+					return nil
+				})
 			},
 			expectedError: "",
 			expectedResult: business.MediaResult{
@@ -65,6 +74,7 @@ func TestFetchAndInsertMedia(t *testing.T) {
 						TrackName:   "Test Track",
 					},
 				},
+				SearchKey: "limit=1&providers=&term=test",
 			},
 		},
 		{
@@ -72,7 +82,7 @@ func TestFetchAndInsertMedia(t *testing.T) {
 			term:  "test",
 			limit: 1,
 			mockSetup: func() {
-				mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "test", 1).Return(business.MediaResult{}, errors.New("fetch error"))
+				mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "", "test", 1).Return(business.MediaResult{}, errors.New("fetch error"))
 				mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to fetch media", "error", errors.New("fetch error"))
 			},
 			expectedError:  "failed to fetch media: fetch error",
@@ -83,7 +93,7 @@ func TestFetchAndInsertMedia(t *testing.T) {
 			term:  "test",
 			limit: 1,
 			mockSetup: func() {
-				mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "test", 1).Return(business.MediaResult{
+				mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "", "test", 1).Return(business.MediaResult{
 					SearchTerm:  "test",
 					ResultCount: 1,
 					Media: []business.Media{
@@ -115,6 +125,7 @@ func TestFetchAndInsertMedia(t *testing.T) {
 						TrackName:   "Test Track",
 					},
 				},
+				SearchKey: "limit=1&providers=&term=test",
 			},
 		},
 	}
@@ -123,7 +134,7 @@ func TestFetchAndInsertMedia(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			result, err := handler.FetchAndInsertMedia(context.Background(), tt.term, tt.limit)
+			result, err := handler.FetchAndInsertMedia(business.WithOwner(context.Background(), "key:7"), tt.term, tt.limit)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -135,3 +146,73 @@ func TestFetchAndInsertMedia(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchAndInsertMediaFrom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockmediaRepository(ctrl)
+	mockFetcher := mock.NewMockmediaFetcher(ctrl)
+	mockPublisher := mock.NewMockeventPublisher(ctrl)
+	mockLogger := mock.NewMocklogger(ctrl)
+
+	handler := business.NewSearchMediaHandler(mockRepo, mockFetcher, mockPublisher, business.SearchPolicy{}, mockLogger)
+
+	t.Run("fetched from the provider", func(t *testing.T) {
+		result := business.MediaResult{
+			SearchTerm:  "upside down",
+			ResultCount: 1,
+			Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackName: "Upside Down", Source: "musicbrainz", SourceID: "a8f8f34e"}},
+		}
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "musicbrainz", "upside down", 5).Return(result, nil)
+		stored := result
+		stored.SearchKey = "limit=5&providers=musicbrainz&term=upside+down"
+		mockRepo.EXPECT().InsertMedia(gomock.Any(), stored).Return(int64(3), nil)
+		mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		res, err := handler.FetchAndInsertMediaFrom(context.Background(), "musicbrainz", "upside down", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.ID)
+		assert.Equal(t, "musicbrainz", res.Media[0].Source)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		mockFetcher.EXPECT().FetchMediaByTerm(gomock.Any(), "deezer", "upside down", 5).
+			Return(business.MediaResult{}, fmt.Errorf("%w: deezer", business.ErrUnknownProvider))
+		mockLogger.EXPECT().ErrorContext(gomock.Any(), "failed to fetch media", "error", gomock.Any())
+
+		_, err := handler.FetchAndInsertMediaFrom(context.Background(), "deezer", "upside down", 5)
+
+		assert.ErrorIs(t, err, business.ErrUnknownProvider)
+	})
+}
+
+func TestLatestMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockmediaRepository(ctrl)
+	handler := business.NewSearchMediaHandler(mockRepo, mock.NewMockmediaFetcher(ctrl), mock.NewMockeventPublisher(ctrl), business.SearchPolicy{}, mock.NewMocklogger(ctrl))
+	since := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	key := business.SearchKey([]string{"itunes", "musicbrainz", "itunes"}, "jack", 20, business.SearchFilter{})
+	assert.Equal(t, "limit=20&providers=itunes%2Cmusicbrainz&term=jack", key)
+
+	t.Run("stored result", func(t *testing.T) {
+		stored := business.MediaResult{ID: 4, SearchTerm: "jack", FetchedAt: since.Add(time.Minute), SearchKey: key}
+		mockRepo.EXPECT().GetLatestMediaResult(gomock.Any(), key, since).Return(stored, nil)
+
+		res, err := handler.LatestMedia(context.Background(), key, since)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stored, res)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetLatestMediaResult(gomock.Any(), key, since).Return(business.MediaResult{}, business.ErrNotFound)
+
+		_, err := handler.LatestMedia(context.Background(), key, since)
+
+		assert.ErrorIs(t, err, business.ErrNotFound)
+	})
+}
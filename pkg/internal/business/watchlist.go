@@ -0,0 +1,261 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	defaultReleasesLimit = 20
+	maxReleasesLimit     = 100
+)
+
+// Watchlist represents artists a user subscribed to, their new releases are detected by the watchlist checker.
+type Watchlist struct {
+	ID int64
+	// Owner is the identity of the client which created the watchlist, watchlists are only visible to their owner.
+	Owner     string
+	Name      string
+	Artists   []WatchedArtist
+	CreatedAt time.Time
+}
+
+// WatchedArtist represents an artist subscribed to by at least one watchlist, artists are checked once
+// whatever the number of watchlists subscribed to them.
+type WatchedArtist struct {
+	ArtistID int
+	// ArtistName is known once the artist was checked.
+	ArtistName string
+	// LastReleaseDate is the date of the most recent release seen so far, releases after it are new.
+	LastReleaseDate *time.Time
+	// CheckedAt is when the artist was last checked, the first check only records LastReleaseDate.
+	CheckedAt *time.Time
+	// SubscribedAt is when the watchlist subscribed to the artist, zero for artists claimed by the checker.
+	SubscribedAt time.Time
+	// Owners are the owners of the watchlists subscribed to the artist, only set for artists claimed by the checker.
+	Owners []string
+}
+
+// Release represents a collection of a watched artist released after the ones seen on an earlier check.
+type Release struct {
+	ID                int64
+	ArtistID          int
+	ArtistName        string
+	CollectionID      int
+	CollectionName    string
+	ReleaseDate       time.Time
+	CollectionViewURL string
+	ArtworkURL100     string
+	DetectedAt        time.Time
+}
+
+// ArtistCheck represents the outcome of checking an artist for new releases.
+type ArtistCheck struct {
+	ArtistID        int
+	ArtistName      string
+	LastReleaseDate *time.Time
+	Releases        []Release
+	CheckedAt       time.Time
+}
+
+// WatchlistPolicy defines how watched artists are checked for new releases.
+type WatchlistPolicy struct {
+	// CheckInterval is how long an artist goes unchecked after a check, failed or not.
+	CheckInterval time.Duration
+	// BatchSize is the most artists claimed by a single check.
+	BatchSize int
+	// Entities are the iTunes entities looked up for releases, e.g. album and song.
+	Entities []string
+}
+
+//go:generate mockgen -source=watchlist.go -destination=mock/watchlist.go -package=mock
+type (
+	// watchlistRepository defines the interface for watchlist repository operations.
+	watchlistRepository interface {
+		InsertWatchlist(ctx context.Context, owner, name string, artistIDs []int) (Watchlist, error)
+		GetWatchlist(ctx context.Context, owner string, id int64) (Watchlist, error)
+		AddWatchlistArtist(ctx context.Context, owner string, id int64, artistID int) error
+		RemoveWatchlistArtist(ctx context.Context, owner string, id int64, artistID int) error
+		ListWatchlistReleases(ctx context.Context, owner string, id int64, limit, offset int) ([]Release, error)
+		ClaimDueArtists(ctx context.Context, now, nextCheckAt time.Time, limit int) ([]WatchedArtist, error)
+		SaveArtistCheck(ctx context.Context, check ArtistCheck) error
+	}
+	// releaseLookup defines the interface for looking up the releases of an artist.
+	releaseLookup interface {
+		LookupArtistReleases(ctx context.Context, artistID int, entity string) ([]Media, error)
+	}
+)
+
+type WatchlistHandler struct {
+	repo      watchlistRepository
+	lookup    releaseLookup
+	publisher eventPublisher
+	policy    WatchlistPolicy
+	lgr       logger
+	now       func() time.Time
+}
+
+// NewWatchlistHandler creates a new instance of WatchlistHandler.
+func NewWatchlistHandler(repo watchlistRepository, lookup releaseLookup, publisher eventPublisher, policy WatchlistPolicy, lgr logger) WatchlistHandler {
+	policy.BatchSize = max(policy.BatchSize, 1)
+	return WatchlistHandler{repo: repo, lookup: lookup, publisher: publisher, policy: policy, lgr: lgr, now: time.Now}
+}
+
+// CreateWatchlist creates a watchlist of owner subscribed to the artists with the given iTunes ids.
+func (h WatchlistHandler) CreateWatchlist(ctx context.Context, owner, name string, artistIDs []int) (Watchlist, error) {
+	watchlist, err := h.repo.InsertWatchlist(ctx, owner, name, lo.Uniq(artistIDs))
+	if err != nil {
+		return Watchlist{}, fmt.Errorf("failed to insert watchlist: %w", err)
+	}
+	return watchlist, nil
+}
+
+// GetWatchlist returns the watchlist of owner with the given id along with the artists it subscribed to,
+// ErrNotFound is returned when owner doesn't own it.
+func (h WatchlistHandler) GetWatchlist(ctx context.Context, owner string, id int64) (Watchlist, error) {
+	watchlist, err := h.repo.GetWatchlist(ctx, owner, id)
+	if err != nil {
+		return Watchlist{}, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	return watchlist, nil
+}
+
+// SubscribeArtist subscribes a watchlist of owner to the artist with the given iTunes id, subscribing twice is a no-op.
+func (h WatchlistHandler) SubscribeArtist(ctx context.Context, owner string, id int64, artistID int) (Watchlist, error) {
+	if err := h.repo.AddWatchlistArtist(ctx, owner, id, artistID); err != nil {
+		return Watchlist{}, fmt.Errorf("failed to add watchlist artist: %w", err)
+	}
+	return h.GetWatchlist(ctx, owner, id)
+}
+
+// UnsubscribeArtist unsubscribes a watchlist of owner from an artist, ErrNotFound is returned when it wasn't
+// subscribed.
+func (h WatchlistHandler) UnsubscribeArtist(ctx context.Context, owner string, id int64, artistID int) (Watchlist, error) {
+	if err := h.repo.RemoveWatchlistArtist(ctx, owner, id, artistID); err != nil {
+		return Watchlist{}, fmt.Errorf("failed to remove watchlist artist: %w", err)
+	}
+	return h.GetWatchlist(ctx, owner, id)
+}
+
+// ListWatchlistReleases returns the releases of the artists of a watchlist of owner detected since it subscribed
+// to them, most recent first. The limit defaults to 20 and is capped at 100.
+func (h WatchlistHandler) ListWatchlistReleases(ctx context.Context, owner string, id int64, limit, offset int) ([]Release, error) {
+	if _, err := h.GetWatchlist(ctx, owner, id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultReleasesLimit
+	}
+	releases, err := h.repo.ListWatchlistReleases(ctx, owner, id, min(limit, maxReleasesLimit), max(offset, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watchlist releases: %w", err)
+	}
+	return releases, nil
+}
+
+// CheckDueArtists claims the artists due for a check and records their new releases, it returns the number
+// of artists claimed. Artists failing to be checked are logged and checked again after the check interval.
+func (h WatchlistHandler) CheckDueArtists(ctx context.Context) (int, error) {
+	now := h.now().UTC()
+	artists, err := h.repo.ClaimDueArtists(ctx, now, now.Add(h.policy.CheckInterval), h.policy.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due artists: %w", err)
+	}
+	for _, artist := range artists {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := h.checkArtist(ctx, artist); err != nil {
+			h.lgr.ErrorContext(ctx, "failed to check artist releases", "artist_id", artist.ArtistID, "error", err.Error())
+		}
+	}
+	return len(artists), nil
+}
+
+// checkArtist looks up the releases of artist and stores the ones newer than its last seen release.
+func (h WatchlistHandler) checkArtist(ctx context.Context, artist WatchedArtist) error {
+	var media []Media
+	for _, entity := range h.policy.Entities {
+		m, err := h.lookup.LookupArtistReleases(ctx, artist.ArtistID, entity)
+		if err != nil {
+			return fmt.Errorf("failed to lookup %s releases: %w", entity, err)
+		}
+		media = append(media, m...)
+	}
+	check := detectReleases(artist, media)
+	check.CheckedAt = h.now().UTC()
+	if err := h.repo.SaveArtistCheck(ctx, check); err != nil {
+		return fmt.Errorf("failed to save artist check: %w", err)
+	}
+	// artists are checked once for every watchlist, each owner watching the artist is told about its releases.
+	for _, release := range check.Releases {
+		for _, owner := range artist.Owners {
+			publishEvent(ctx, h.publisher, h.lgr, Event{
+				Type:       EventReleaseDetected,
+				Owner:      owner,
+				OccurredAt: check.CheckedAt,
+				Data: ReleaseDetectedData{
+					ArtistID:          release.ArtistID,
+					ArtistName:        release.ArtistName,
+					CollectionID:      release.CollectionID,
+					CollectionName:    release.CollectionName,
+					ReleaseDate:       release.ReleaseDate,
+					CollectionViewURL: release.CollectionViewURL,
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// detectReleases groups media by collection, dated by their earliest item, and returns the collections released
+// after the last seen release of artist, oldest first. Nothing is new on the first check of an artist.
+func detectReleases(artist WatchedArtist, media []Media) ArtistCheck {
+	check := ArtistCheck{ArtistID: artist.ArtistID, ArtistName: artist.ArtistName, LastReleaseDate: artist.LastReleaseDate}
+	collections := make(map[int]Release)
+	for _, m := range media {
+		releaseDate, err := time.Parse(time.RFC3339, m.ReleaseDate)
+		if m.CollectionID == 0 || err != nil {
+			continue
+		}
+		if m.ArtistName != "" && m.ArtistID == artist.ArtistID {
+			check.ArtistName = m.ArtistName
+		}
+		release, ok := collections[m.CollectionID]
+		if ok && !releaseDate.Before(release.ReleaseDate) {
+			continue
+		}
+		if !ok {
+			release = Release{
+				ArtistID:          artist.ArtistID,
+				CollectionID:      m.CollectionID,
+				CollectionName:    m.CollectionName,
+				CollectionViewURL: m.CollectionViewURL,
+				ArtworkURL100:     m.ArtworkURL100,
+			}
+		}
+		release.ReleaseDate = releaseDate.UTC()
+		collections[m.CollectionID] = release
+	}
+
+	for _, release := range collections {
+		release.ArtistName = check.ArtistName
+		if artist.CheckedAt != nil && (artist.LastReleaseDate == nil || release.ReleaseDate.After(*artist.LastReleaseDate)) {
+			check.Releases = append(check.Releases, release)
+		}
+		if check.LastReleaseDate == nil || release.ReleaseDate.After(*check.LastReleaseDate) {
+			check.LastReleaseDate = lo.ToPtr(release.ReleaseDate)
+		}
+	}
+	slices.SortFunc(check.Releases, func(a, b Release) int {
+		if c := a.ReleaseDate.Compare(b.ReleaseDate); c != 0 {
+			return c
+		}
+		return a.CollectionID - b.CollectionID
+	})
+	return check
+}
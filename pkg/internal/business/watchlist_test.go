@@ -0,0 +1,229 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDueArtists(t *testing.T) {
+	policy := business.WatchlistPolicy{CheckInterval: time.Hour, BatchSize: 10, Entities: []string{"album", "song"}}
+	lastSeen := time.Date(2026, 10, 1, 7, 0, 0, 0, time.UTC)
+	owners := []string{"key:7", "sub:alice"}
+	checked := business.WatchedArtist{ArtistID: 10, ArtistName: "Jack Johnson", LastReleaseDate: lo.ToPtr(lastSeen), CheckedAt: lo.ToPtr(lastSeen), Owners: owners}
+	albums := []business.Media{
+		{WrapperType: "collection", ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 1, CollectionName: "Old", ReleaseDate: "2026-09-01T07:00:00Z"},
+		{WrapperType: "collection", ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 2, CollectionName: "New", ReleaseDate: "2026-10-16T07:00:00Z"},
+		{WrapperType: "collection", ArtistID: 10, CollectionID: 3, CollectionName: "Undated"},
+	}
+	songs := []business.Media{
+		// songs of an album may be released before it, the album is dated by its earliest song.
+		{WrapperType: "track", ArtistID: 10, CollectionID: 2, CollectionName: "New", ReleaseDate: "2026-10-09T07:00:00Z"},
+		{WrapperType: "track", ArtistID: 10, CollectionID: 4, CollectionName: "Single", ReleaseDate: "2026-10-02T07:00:00Z"},
+	}
+	tests := []struct {
+		name            string
+		mockSetup       func(*mock.MockwatchlistRepository, *mock.MockreleaseLookup, *mock.Mocklogger)
+		expectedClaimed int
+		expectedCheck   *business.ArtistCheck
+		expectedError   string
+	}{
+		{
+			name: "claim error",
+			mockSetup: func(repo *mock.MockwatchlistRepository, _ *mock.MockreleaseLookup, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimDueArtists(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to claim due artists: db down",
+		},
+		{
+			name: "new releases are detected",
+			mockSetup: func(repo *mock.MockwatchlistRepository, lookup *mock.MockreleaseLookup, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimDueArtists(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]business.WatchedArtist{checked}, nil)
+				lookup.EXPECT().LookupArtistReleases(gomock.Any(), 10, "album").Return(albums, nil)
+				lookup.EXPECT().LookupArtistReleases(gomock.Any(), 10, "song").Return(songs, nil)
+			},
+			expectedClaimed: 1,
+			expectedCheck: &business.ArtistCheck{
+				ArtistID:        10,
+				ArtistName:      "Jack Johnson",
+				LastReleaseDate: lo.ToPtr(time.Date(2026, 10, 9, 7, 0, 0, 0, time.UTC)),
+				Releases: []business.Release{
+					{ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 4, CollectionName: "Single", ReleaseDate: time.Date(2026, 10, 2, 7, 0, 0, 0, time.UTC)},
+					{ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 2, CollectionName: "New", ReleaseDate: time.Date(2026, 10, 9, 7, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+		{
+			name: "first check only records the last release",
+			mockSetup: func(repo *mock.MockwatchlistRepository, lookup *mock.MockreleaseLookup, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimDueArtists(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]business.WatchedArtist{{ArtistID: 10}}, nil)
+				lookup.EXPECT().LookupArtistReleases(gomock.Any(), 10, "album").Return(albums, nil)
+				lookup.EXPECT().LookupArtistReleases(gomock.Any(), 10, "song").Return(nil, nil)
+			},
+			expectedClaimed: 1,
+			expectedCheck: &business.ArtistCheck{
+				ArtistID:        10,
+				ArtistName:      "Jack Johnson",
+				LastReleaseDate: lo.ToPtr(time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "lookup error is logged",
+			mockSetup: func(repo *mock.MockwatchlistRepository, lookup *mock.MockreleaseLookup, lgr *mock.Mocklogger) {
+				repo.EXPECT().ClaimDueArtists(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]business.WatchedArtist{checked}, nil)
+				lookup.EXPECT().LookupArtistReleases(gomock.Any(), 10, "album").Return(nil, errors.New("upstream down"))
+				lgr.EXPECT().ErrorContext(gomock.Any(), "failed to check artist releases", "artist_id", 10, "error", "failed to lookup album releases: upstream down")
+			},
+			expectedClaimed: 1,
+		},
+		{
+			name: "save error is logged",
+			mockSetup: func(repo *mock.MockwatchlistRepository, lookup *mock.MockreleaseLookup, lgr *mock.Mocklogger) {
+				repo.EXPECT().ClaimDueArtists(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]business.WatchedArtist{checked}, nil)
+				lookup.EXPECT().LookupArtistReleases(gomock.Any(), 10, gomock.Any()).Return(nil, nil).Times(2)
+				repo.EXPECT().SaveArtistCheck(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+				lgr.EXPECT().ErrorContext(gomock.Any(), "failed to check artist releases", "artist_id", 10, "error", "failed to save artist check: db down")
+			},
+			expectedClaimed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockwatchlistRepository(ctrl)
+			lookup := mock.NewMockreleaseLookup(ctrl)
+			lgr := mock.NewMocklogger(ctrl)
+			publisher := mock.NewMockeventPublisher(ctrl)
+			tt.mockSetup(repo, lookup, lgr)
+			if tt.expectedCheck != nil {
+				// every detected release is published to every owner watching the artist, oldest first.
+				for _, release := range tt.expectedCheck.Releases {
+					for _, owner := range owners {
+						publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event business.Event) error {
+							assert.Equal(t, business.EventReleaseDetected, event.Type)
+							assert.Equal(t, owner, event.Owner)
+							assert.Equal(t, release.CollectionID, event.Data.(business.ReleaseDetectedData).CollectionID)
+							return nil
+						})
+					}
+				}
+				repo.EXPECT().SaveArtistCheck(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, check business.ArtistCheck) error {
+					assert.False(t, check.CheckedAt.IsZero())
+					check.CheckedAt = time.Time{}
+					assert.Equal(t, *tt.expectedCheck, check)
+					return nil
+				})
+			}
+			handler := business.NewWatchlistHandler(repo, lookup, publisher, policy, lgr)
+
+			claimed, err := handler.CheckDueArtists(context.Background())
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedClaimed, claimed)
+		})
+	}
+}
+
+func TestListWatchlistReleases(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		offset        int
+		mockSetup     func(*mock.MockwatchlistRepository)
+		expectedError string
+	}{
+		{
+			name:  "default limit",
+			limit: 0,
+			mockSetup: func(repo *mock.MockwatchlistRepository) {
+				repo.EXPECT().GetWatchlist(gomock.Any(), "key:7", int64(1)).Return(business.Watchlist{ID: 1}, nil)
+				repo.EXPECT().ListWatchlistReleases(gomock.Any(), "key:7", int64(1), 20, 0).Return([]business.Release{{ID: 1}}, nil)
+			},
+		},
+		{
+			name:   "capped limit",
+			limit:  500,
+			offset: -1,
+			mockSetup: func(repo *mock.MockwatchlistRepository) {
+				repo.EXPECT().GetWatchlist(gomock.Any(), "key:7", int64(1)).Return(business.Watchlist{ID: 1}, nil)
+				repo.EXPECT().ListWatchlistReleases(gomock.Any(), "key:7", int64(1), 100, 0).Return([]business.Release{{ID: 1}}, nil)
+			},
+		},
+		{
+			name: "unknown watchlist",
+			mockSetup: func(repo *mock.MockwatchlistRepository) {
+				repo.EXPECT().GetWatchlist(gomock.Any(), "key:7", int64(1)).Return(business.Watchlist{}, business.ErrNotFound)
+			},
+			expectedError: "failed to get watchlist: not found",
+		},
+		{
+			name: "list error",
+			mockSetup: func(repo *mock.MockwatchlistRepository) {
+				repo.EXPECT().GetWatchlist(gomock.Any(), "key:7", int64(1)).Return(business.Watchlist{ID: 1}, nil)
+				repo.EXPECT().ListWatchlistReleases(gomock.Any(), "key:7", int64(1), 20, 0).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to list watchlist releases: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockwatchlistRepository(ctrl)
+			tt.mockSetup(repo)
+			handler := business.NewWatchlistHandler(repo, nil, nil, business.WatchlistPolicy{}, nil)
+
+			releases, err := handler.ListWatchlistReleases(context.Background(), "key:7", 1, tt.limit, tt.offset)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []business.Release{{ID: 1}}, releases)
+		})
+	}
+}
+
+func TestSubscribeArtist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockwatchlistRepository(ctrl)
+	handler := business.NewWatchlistHandler(repo, nil, nil, business.WatchlistPolicy{}, nil)
+
+	t.Run("subscribed", func(t *testing.T) {
+		watchlist := business.Watchlist{ID: 1, Artists: []business.WatchedArtist{{ArtistID: 10}}}
+		repo.EXPECT().AddWatchlistArtist(gomock.Any(), "key:7", int64(1), 10).Return(nil)
+		repo.EXPECT().GetWatchlist(gomock.Any(), "key:7", int64(1)).Return(watchlist, nil)
+
+		got, err := handler.SubscribeArtist(context.Background(), "key:7", 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, watchlist, got)
+	})
+
+	t.Run("unknown watchlist", func(t *testing.T) {
+		repo.EXPECT().AddWatchlistArtist(gomock.Any(), "key:7", int64(2), 10).Return(business.ErrNotFound)
+
+		_, err := handler.SubscribeArtist(context.Background(), "key:7", 2, 10)
+
+		assert.ErrorIs(t, err, business.ErrNotFound)
+	})
+}
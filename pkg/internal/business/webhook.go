@@ -0,0 +1,285 @@
+package business
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+	webhookSecretBytes  = 24
+
+	defaultWebhookDeliveriesLimit = 20
+	maxWebhookDeliveriesLimit     = 100
+)
+
+// WebhookDeliveryStatus is the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivering WebhookDeliveryStatus = "delivering"
+	WebhookDeliveryDelivered  WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead is the state of a delivery that ran out of attempts, it is only sent again when redelivered.
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// Webhook represents a customer endpoint subscribed to events.
+type Webhook struct {
+	ID int64
+	// Owner is the identity of the client which subscribed, webhooks are only visible to their owner.
+	Owner string
+	URL   string
+	// Secret signs the payloads sent to URL.
+	Secret    string
+	Events    []EventType
+	CreatedAt time.Time
+}
+
+// WebhookDelivery represents an event sent to a webhook.
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID int64
+	Event     EventType
+	// Payload is the json body sent to the webhook.
+	Payload json.RawMessage
+	Status  WebhookDeliveryStatus
+	// Attempts is the number of times the delivery was claimed by a worker, it is dead once it reaches MaxAttempts.
+	Attempts int
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	// URL and Secret are the ones of the webhook, they are only loaded by ClaimWebhookDelivery.
+	URL    string
+	Secret string
+	// Log holds the attempts of the delivery oldest first, it is only loaded by ListWebhookDeliveries.
+	Log []WebhookAttempt
+}
+
+// WebhookAttempt represents a single attempt of sending a delivery.
+type WebhookAttempt struct {
+	DeliveryID int64
+	// StatusCode is the status the webhook answered with, zero when it couldn't be reached.
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+// WebhookPolicy defines how webhook deliveries are retried.
+type WebhookPolicy struct {
+	// MaxAttempts is the number of times a delivery is sent before it is dead.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it doubles on every further retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Lease is how long a delivery stays claimed, deliveries of crashed workers are claimed again once it expires.
+	Lease time.Duration
+}
+
+// webhookPayload represents the json body sent to webhooks.
+type webhookPayload struct {
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+//go:generate mockgen -source=webhook.go -destination=mock/webhook.go -package=mock
+type (
+	// webhookRepository defines the interface for webhook repository operations.
+	webhookRepository interface {
+		InsertWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+		GetWebhook(ctx context.Context, owner string, id int64) (Webhook, error)
+		DeleteWebhook(ctx context.Context, owner string, id int64) error
+		EnqueueWebhookDeliveries(ctx context.Context, owner string, event EventType, payload []byte, at time.Time) (int, error)
+		ListWebhookDeliveries(ctx context.Context, owner string, webhookID int64, status WebhookDeliveryStatus, limit, offset int) ([]WebhookDelivery, error)
+		GetWebhookDelivery(ctx context.Context, owner string, webhookID, id int64) (WebhookDelivery, error)
+		RedeliverWebhookDelivery(ctx context.Context, owner string, webhookID, id int64, at time.Time) (WebhookDelivery, error)
+	}
+	// webhookDeliveryRepository defines the interface for the repository operations of the delivery workers.
+	webhookDeliveryRepository interface {
+		ClaimWebhookDelivery(ctx context.Context, now time.Time, lockedUntil time.Time) (WebhookDelivery, error)
+		RecordWebhookAttempt(ctx context.Context, delivery WebhookDelivery, attempt WebhookAttempt) error
+	}
+	// webhookSender defines the interface for sending signed payloads to webhooks.
+	webhookSender interface {
+		Send(ctx context.Context, url, secret string, deliveryID int64, event string, payload []byte) (int, error)
+	}
+)
+
+type WebhookHandler struct {
+	repo webhookRepository
+	now  func() time.Time
+}
+
+// NewWebhookHandler creates a new instance of WebhookHandler.
+func NewWebhookHandler(repo webhookRepository) WebhookHandler {
+	return WebhookHandler{repo: repo, now: time.Now}
+}
+
+// CreateWebhook subscribes url to the given events on behalf of owner, the returned webhook holds the secret its
+// payloads are signed with.
+func (h WebhookHandler) CreateWebhook(ctx context.Context, owner, url string, events []EventType) (Webhook, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return Webhook{}, err
+	}
+	webhook, err := h.repo.InsertWebhook(ctx, Webhook{Owner: owner, URL: url, Secret: secret, Events: lo.Uniq(events)})
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to insert webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// GetWebhook returns the webhook of owner with the given id, ErrNotFound is returned when owner doesn't own it.
+func (h WebhookHandler) GetWebhook(ctx context.Context, owner string, id int64) (Webhook, error) {
+	webhook, err := h.repo.GetWebhook(ctx, owner, id)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes the webhook of owner with the given id along with its deliveries.
+func (h WebhookHandler) DeleteWebhook(ctx context.Context, owner string, id int64) error {
+	if err := h.repo.DeleteWebhook(ctx, owner, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries lists the deliveries of the webhook of owner with the given id newest first, along with
+// their attempts. An empty status lists deliveries of any status.
+func (h WebhookHandler) ListWebhookDeliveries(ctx context.Context, owner string, id int64, status WebhookDeliveryStatus, limit, offset int) ([]WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+	limit = min(limit, maxWebhookDeliveriesLimit)
+	if _, err := h.repo.GetWebhook(ctx, owner, id); err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	deliveries, err := h.repo.ListWebhookDeliveries(ctx, owner, id, status, limit, max(offset, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhook queues a delivery of the webhook of owner with the given id again with a fresh set of attempts,
+// it is due right away. ErrConflict is returned while the delivery is being sent.
+func (h WebhookHandler) RedeliverWebhook(ctx context.Context, owner string, id, deliveryID int64) (WebhookDelivery, error) {
+	delivery, err := h.repo.RedeliverWebhookDelivery(ctx, owner, id, deliveryID, h.now().UTC())
+	if err == nil {
+		return delivery, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return WebhookDelivery{}, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+	// the delivery either doesn't exist or is being sent.
+	if _, err := h.repo.GetWebhookDelivery(ctx, owner, id, deliveryID); err != nil {
+		return WebhookDelivery{}, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return WebhookDelivery{}, fmt.Errorf("%w: webhook delivery is being delivered", ErrConflict)
+}
+
+// Publish queues a delivery of event to every webhook of its owner subscribed to its type.
+func (h WebhookHandler) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(webhookPayload{Type: event.Type, OccurredAt: event.OccurredAt.UTC(), Data: event.Data})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	if _, err := h.repo.EnqueueWebhookDeliveries(ctx, event.Owner, event.Type, payload, h.now().UTC()); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+type WebhookDeliveryHandler struct {
+	repo   webhookDeliveryRepository
+	sender webhookSender
+	policy WebhookPolicy
+	lgr    logger
+	now    func() time.Time
+}
+
+// NewWebhookDeliveryHandler creates a new instance of WebhookDeliveryHandler.
+func NewWebhookDeliveryHandler(repo webhookDeliveryRepository, sender webhookSender, policy WebhookPolicy, lgr logger) WebhookDeliveryHandler {
+	policy.MaxAttempts = max(policy.MaxAttempts, 1)
+	return WebhookDeliveryHandler{repo: repo, sender: sender, policy: policy, lgr: lgr, now: time.Now}
+}
+
+// DeliverNextWebhook claims the next due delivery and sends it, it reports false when no delivery is due.
+// A failed delivery is retried with an exponential backoff until it runs out of attempts and is dead,
+// when ctx is done it is queued again right away.
+func (h WebhookDeliveryHandler) DeliverNextWebhook(ctx context.Context) (bool, error) {
+	now := h.now().UTC()
+	delivery, err := h.repo.ClaimWebhookDelivery(ctx, now, now.Add(h.policy.Lease))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+
+	start := h.now()
+	statusCode, sendErr := h.sender.Send(ctx, delivery.URL, delivery.Secret, delivery.ID, string(delivery.Event), delivery.Payload)
+	attempt := WebhookAttempt{DeliveryID: delivery.ID, StatusCode: statusCode, Duration: h.now().Sub(start)}
+	// the attempt is recorded even when ctx is done so it isn't lost.
+	stopped := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+	now = h.now().UTC()
+	delivery.LastStatusCode, delivery.LastError = statusCode, ""
+	switch {
+	case sendErr == nil:
+		delivery.Status = WebhookDeliveryDelivered
+		delivery.DeliveredAt = lo.ToPtr(now)
+	case stopped:
+		delivery.Status = WebhookDeliveryPending
+		delivery.NextAttemptAt = now
+	case delivery.Attempts >= h.policy.MaxAttempts:
+		delivery.Status = WebhookDeliveryDead
+	default:
+		delivery.Status = WebhookDeliveryPending
+		delivery.NextAttemptAt = now.Add(h.backoff(delivery.Attempts))
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+		delivery.LastError = attempt.Error
+	}
+	// ErrNotFound means the webhook got deleted while the delivery was sent.
+	if err := h.repo.RecordWebhookAttempt(ctx, delivery, attempt); err != nil && !errors.Is(err, ErrNotFound) {
+		return true, fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+	if delivery.Status == WebhookDeliveryDead {
+		h.lgr.ErrorContext(ctx, "webhook delivery ran out of attempts", "delivery_id", delivery.ID, "error", delivery.LastError)
+	}
+	return true, nil
+}
+
+// backoff returns the delay before retrying a delivery after its given attempt.
+func (h WebhookDeliveryHandler) backoff(attempt int) time.Duration {
+	delay := h.policy.Backoff
+	for i := 1; i < attempt && delay < h.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, h.policy.MaxBackoff)
+}
+
+// generateWebhookSecret returns a new webhook secret.
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,235 @@
+package business_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockwebhookRepository(ctrl)
+	handler := business.NewWebhookHandler(repo)
+
+	repo.EXPECT().InsertWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, webhook business.Webhook) (business.Webhook, error) {
+		assert.True(t, strings.HasPrefix(webhook.Secret, "whsec_"))
+		assert.Equal(t, "key:7", webhook.Owner)
+		assert.Equal(t, []business.EventType{business.EventSearchStored}, webhook.Events)
+		webhook.ID = 1
+		return webhook, nil
+	})
+
+	webhook, err := handler.CreateWebhook(context.Background(), "key:7", "https://example.com/hooks", []business.EventType{business.EventSearchStored, business.EventSearchStored})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), webhook.ID)
+	assert.NotEmpty(t, webhook.Secret)
+}
+
+func TestPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockwebhookRepository(ctrl)
+	handler := business.NewWebhookHandler(repo)
+	occurredAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().EnqueueWebhookDeliveries(gomock.Any(), "key:7", business.EventJobFinished, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ business.EventType, payload []byte, _ time.Time) (int, error) {
+			assert.JSONEq(t, `{"type":"job.finished","occurred_at":"2026-10-19T12:00:00Z","data":{"id":1,"status":"succeeded","attempts":1}}`, string(payload))
+			return 2, nil
+		})
+
+	err := handler.Publish(context.Background(), business.Event{
+		Type:       business.EventJobFinished,
+		Owner:      "key:7",
+		OccurredAt: occurredAt,
+		Data:       business.JobFinishedData{ID: 1, Status: business.SearchJobSucceeded, Attempts: 1},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSetup     func(*mock.MockwebhookRepository)
+		expectedError string
+	}{
+		{
+			name: "redelivered",
+			mockSetup: func(repo *mock.MockwebhookRepository) {
+				repo.EXPECT().RedeliverWebhookDelivery(gomock.Any(), "key:7", int64(1), int64(2), gomock.Any()).
+					Return(business.WebhookDelivery{ID: 2, WebhookID: 1, Status: business.WebhookDeliveryPending}, nil)
+			},
+		},
+		{
+			name: "being delivered",
+			mockSetup: func(repo *mock.MockwebhookRepository) {
+				repo.EXPECT().RedeliverWebhookDelivery(gomock.Any(), "key:7", int64(1), int64(2), gomock.Any()).Return(business.WebhookDelivery{}, business.ErrNotFound)
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), "key:7", int64(1), int64(2)).Return(business.WebhookDelivery{ID: 2, Status: business.WebhookDeliveryDelivering}, nil)
+			},
+			expectedError: "conflict: webhook delivery is being delivered",
+		},
+		{
+			name: "unknown delivery",
+			mockSetup: func(repo *mock.MockwebhookRepository) {
+				repo.EXPECT().RedeliverWebhookDelivery(gomock.Any(), "key:7", int64(1), int64(2), gomock.Any()).Return(business.WebhookDelivery{}, business.ErrNotFound)
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), "key:7", int64(1), int64(2)).Return(business.WebhookDelivery{}, business.ErrNotFound)
+			},
+			expectedError: "failed to get webhook delivery: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockwebhookRepository(ctrl)
+			tt.mockSetup(repo)
+			handler := business.NewWebhookHandler(repo)
+
+			delivery, err := handler.RedeliverWebhook(context.Background(), "key:7", 1, 2)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, business.WebhookDeliveryPending, delivery.Status)
+		})
+	}
+}
+
+func TestDeliverNextWebhook(t *testing.T) {
+	policy := business.WebhookPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour, Lease: time.Minute}
+	claimed := business.WebhookDelivery{
+		ID:       2,
+		Event:    business.EventSearchStored,
+		Payload:  []byte(`{"type":"search.stored"}`),
+		Status:   business.WebhookDeliveryDelivering,
+		Attempts: 2,
+		URL:      "https://example.com/hooks",
+		Secret:   "whsec_test",
+	}
+	tests := []struct {
+		name              string
+		mockSetup         func(*mock.MockwebhookDeliveryRepository, *mock.MockwebhookSender, *mock.Mocklogger)
+		expectedProcessed bool
+		expectedError     string
+	}{
+		{
+			name: "no delivery due",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, _ *mock.MockwebhookSender, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(business.WebhookDelivery{}, business.ErrNotFound)
+			},
+		},
+		{
+			name: "claim error",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, _ *mock.MockwebhookSender, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(business.WebhookDelivery{}, errors.New("db down"))
+			},
+			expectedError: "failed to claim webhook delivery: db down",
+		},
+		{
+			name: "delivered",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, sender *mock.MockwebhookSender, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				sender.EXPECT().Send(gomock.Any(), "https://example.com/hooks", "whsec_test", int64(2), "search.stored", []byte(claimed.Payload)).Return(204, nil)
+				repo.EXPECT().RecordWebhookAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, delivery business.WebhookDelivery, attempt business.WebhookAttempt) error {
+						assert.Equal(t, business.WebhookDeliveryDelivered, delivery.Status)
+						assert.NotNil(t, delivery.DeliveredAt)
+						assert.Equal(t, 204, attempt.StatusCode)
+						assert.Empty(t, attempt.Error)
+						return nil
+					})
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "failed delivery is retried with backoff",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, sender *mock.MockwebhookSender, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(500, errors.New("unexpected status code 500"))
+				repo.EXPECT().RecordWebhookAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, delivery business.WebhookDelivery, attempt business.WebhookAttempt) error {
+						assert.Equal(t, business.WebhookDeliveryPending, delivery.Status)
+						// the second retry waits twice the base backoff.
+						assert.WithinDuration(t, time.Now().Add(2*time.Minute), delivery.NextAttemptAt, 5*time.Second)
+						assert.Equal(t, 500, delivery.LastStatusCode)
+						assert.Equal(t, "unexpected status code 500", attempt.Error)
+						return nil
+					})
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "delivery is dead once it runs out of attempts",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, sender *mock.MockwebhookSender, lgr *mock.Mocklogger) {
+				delivery := claimed
+				delivery.Attempts = 3
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(delivery, nil)
+				sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errors.New("connection refused"))
+				repo.EXPECT().RecordWebhookAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, delivery business.WebhookDelivery, _ business.WebhookAttempt) error {
+						assert.Equal(t, business.WebhookDeliveryDead, delivery.Status)
+						return nil
+					})
+				lgr.EXPECT().ErrorContext(gomock.Any(), "webhook delivery ran out of attempts", "delivery_id", int64(2), "error", "connection refused")
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "deleted webhook",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, sender *mock.MockwebhookSender, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(200, nil)
+				repo.EXPECT().RecordWebhookAttempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(business.ErrNotFound)
+			},
+			expectedProcessed: true,
+		},
+		{
+			name: "record error",
+			mockSetup: func(repo *mock.MockwebhookDeliveryRepository, sender *mock.MockwebhookSender, _ *mock.Mocklogger) {
+				repo.EXPECT().ClaimWebhookDelivery(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed, nil)
+				sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(200, nil)
+				repo.EXPECT().RecordWebhookAttempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+			},
+			expectedProcessed: true,
+			expectedError:     "failed to record webhook attempt: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockwebhookDeliveryRepository(ctrl)
+			sender := mock.NewMockwebhookSender(ctrl)
+			lgr := mock.NewMocklogger(ctrl)
+			tt.mockSetup(repo, sender, lgr)
+			handler := business.NewWebhookDeliveryHandler(repo, sender, policy, lgr)
+
+			processed, err := handler.DeliverNextWebhook(context.Background())
+
+			assert.Equal(t, tt.expectedProcessed, processed)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package export writes exported searches as CSV, NDJSON or Parquet.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Format is a supported export format.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+// parquetRowGroupSize is the number of rows buffered before a parquet row group is written out.
+const parquetRowGroupSize = 10000
+
+// ErrUnsupportedFormat is returned for unknown export formats.
+var ErrUnsupportedFormat = fmt.Errorf("format should be one of %s, %s or %s", FormatCSV, FormatNDJSON, FormatParquet)
+
+// ParseFormat returns the format named s, ErrUnsupportedFormat is returned for unknown ones.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return f, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType returns the media type of files of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+// record is an exported row, its json and parquet tags name the columns of every format.
+type record struct {
+	ResultID               int64     `json:"result_id" parquet:"result_id"`
+	SearchTerm             string    `json:"search_term" parquet:"search_term"`
+	FetchedAt              time.Time `json:"fetched_at" parquet:"fetched_at,timestamp(millisecond)"`
+	Position               int       `json:"position" parquet:"position"`
+	WrapperType            string    `json:"wrapper_type" parquet:"wrapper_type"`
+	Kind                   string    `json:"kind" parquet:"kind"`
+	ArtistID               int       `json:"artist_id" parquet:"artist_id"`
+	CollectionID           int       `json:"collection_id" parquet:"collection_id"`
+	TrackID                int       `json:"track_id" parquet:"track_id"`
+	ArtistName             string    `json:"artist_name" parquet:"artist_name"`
+	CollectionName         string    `json:"collection_name" parquet:"collection_name"`
+	TrackName              string    `json:"track_name" parquet:"track_name"`
+	ArtistViewURL          string    `json:"artist_view_url" parquet:"artist_view_url"`
+	CollectionViewURL      string    `json:"collection_view_url" parquet:"collection_view_url"`
+	FeedURL                string    `json:"feed_url" parquet:"feed_url"`
+	TrackViewURL           string    `json:"track_view_url" parquet:"track_view_url"`
+	ArtworkURL30           string    `json:"artwork_url_30" parquet:"artwork_url_30"`
+	ArtworkURL60           string    `json:"artwork_url_60" parquet:"artwork_url_60"`
+	ArtworkURL100          string    `json:"artwork_url_100" parquet:"artwork_url_100"`
+	ArtworkURL600          string    `json:"artwork_url_600" parquet:"artwork_url_600"`
+	ReleaseDate            string    `json:"release_date" parquet:"release_date"`
+	CollectionExplicitness string    `json:"collection_explicitness" parquet:"collection_explicitness"`
+	TrackExplicitness      string    `json:"track_explicitness" parquet:"track_explicitness"`
+	TrackCount             int       `json:"track_count" parquet:"track_count"`
+	TrackTimeMillis        int       `json:"track_time_millis" parquet:"track_time_millis"`
+	Country                string    `json:"country" parquet:"country"`
+	Currency               string    `json:"currency" parquet:"currency"`
+	PrimaryGenreName       string    `json:"primary_genre_name" parquet:"primary_genre_name"`
+	ContentAdvisoryRating  string    `json:"content_advisory_rating" parquet:"content_advisory_rating"`
+	GenreIDs               []string  `json:"genre_ids" parquet:"genre_ids,list"`
+	Genres                 []string  `json:"genres" parquet:"genres,list"`
+}
+
+// newRecord flattens row into a record.
+func newRecord(row business.ExportRow) record {
+	m := row.Media
+	return record{
+		ResultID:               row.ResultID,
+		SearchTerm:             row.SearchTerm,
+		FetchedAt:              row.FetchedAt.UTC(),
+		Position:               row.Position,
+		WrapperType:            m.WrapperType,
+		Kind:                   m.Kind,
+		ArtistID:               m.ArtistID,
+		CollectionID:           m.CollectionID,
+		TrackID:                m.TrackID,
+		ArtistName:             m.ArtistName,
+		CollectionName:         m.CollectionName,
+		TrackName:              m.TrackName,
+		ArtistViewURL:          m.ArtistViewURL,
+		CollectionViewURL:      m.CollectionViewURL,
+		FeedURL:                m.FeedURL,
+		TrackViewURL:           m.TrackViewURL,
+		ArtworkURL30:           m.ArtworkURL30,
+		ArtworkURL60:           m.ArtworkURL60,
+		ArtworkURL100:          m.ArtworkURL100,
+		ArtworkURL600:          m.ArtworkURL600,
+		ReleaseDate:            m.ReleaseDate,
+		CollectionExplicitness: m.CollectionExplicitness,
+		TrackExplicitness:      m.TrackExplicitness,
+		TrackCount:             m.TrackCount,
+		TrackTimeMillis:        m.TrackTimeMillis,
+		Country:                m.Country,
+		Currency:               m.Currency,
+		PrimaryGenreName:       m.PrimaryGenreName,
+		ContentAdvisoryRating:  m.ContentAdvisoryRating,
+		GenreIDs:               m.GenreIDs,
+		Genres:                 m.Genres,
+	}
+}
+
+// Writer writes exported rows, Close must be called once every row is written to complete the file.
+type Writer interface {
+	Write(row business.ExportRow) error
+	Close() error
+}
+
+// NewWriter returns a writer of the given format writing to w.
+func NewWriter(f Format, w io.Writer) (Writer, error) {
+	switch f {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		buf := bufio.NewWriter(w)
+		return &ndjsonWriter{buf: buf, enc: json.NewEncoder(buf)}, nil
+	case FormatParquet:
+		return &parquetWriter{w: parquet.NewGenericWriter[record](w,
+			parquet.Compression(&parquet.Snappy),
+			parquet.MaxRowsPerRowGroup(parquetRowGroupSize),
+		)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// csvWriter writes a header row followed by a row per record, lists are joined with semicolons.
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// csvColumns are the header of csv exports, the json names of the record fields.
+var csvColumns = func() []string {
+	t := reflect.TypeOf(record{})
+	columns := make([]string, t.NumField())
+	for i := range columns {
+		columns[i] = t.Field(i).Tag.Get("json")
+	}
+	return columns
+}()
+
+func (cw *csvWriter) Write(row business.ExportRow) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(newRecord(row))
+	values := make([]string, v.NumField())
+	for i := range values {
+		switch f := v.Field(i).Interface().(type) {
+		case string:
+			values[i] = f
+		case int:
+			values[i] = strconv.Itoa(f)
+		case int64:
+			values[i] = strconv.FormatInt(f, 10)
+		case time.Time:
+			values[i] = f.Format(time.RFC3339)
+		case []string:
+			values[i] = strings.Join(f, ";")
+		}
+	}
+	if err := cw.w.Write(values); err != nil {
+		return fmt.Errorf("failed to write csv row: %w", err)
+	}
+	return nil
+}
+
+// writeHeader writes the header once, an export without rows still has one.
+func (cw *csvWriter) writeHeader() error {
+	if cw.wroteHeader {
+		return nil
+	}
+	cw.wroteHeader = true
+	if err := cw.w.Write(csvColumns); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	return nil
+}
+
+func (cw *csvWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv rows: %w", err)
+	}
+	return nil
+}
+
+// ndjsonWriter writes a JSON object per line.
+type ndjsonWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (nw *ndjsonWriter) Write(row business.ExportRow) error {
+	if err := nw.enc.Encode(newRecord(row)); err != nil {
+		return fmt.Errorf("failed to write ndjson row: %w", err)
+	}
+	return nil
+}
+
+func (nw *ndjsonWriter) Close() error {
+	if err := nw.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush ndjson rows: %w", err)
+	}
+	return nil
+}
+
+// parquetWriter writes records in row groups of parquetRowGroupSize rows, the footer is written on Close.
+type parquetWriter struct {
+	w *parquet.GenericWriter[record]
+}
+
+func (pw *parquetWriter) Write(row business.ExportRow) error {
+	if _, err := pw.w.Write([]record{newRecord(row)}); err != nil {
+		return fmt.Errorf("failed to write parquet row: %w", err)
+	}
+	return nil
+}
+
+func (pw *parquetWriter) Close() error {
+	if err := pw.w.Close(); err != nil {
+		return fmt.Errorf("failed to close parquet file: %w", err)
+	}
+	return nil
+}
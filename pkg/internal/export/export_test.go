@@ -0,0 +1,99 @@
+package export_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/export"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var row = business.ExportRow{
+	ResultID:   7,
+	SearchTerm: "jack johnson",
+	FetchedAt:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	Position:   1,
+	Media: business.Media{
+		WrapperType: "track",
+		Kind:        "song",
+		TrackID:     456,
+		TrackName:   "Upside Down, Live",
+		GenreIDs:    []string{"21", "34"},
+		Genres:      []string{"Rock", "Music"},
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := export.ParseFormat("CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, export.FormatCSV, f)
+
+	_, err = export.ParseFormat("xml")
+	assert.ErrorIs(t, err, export.ErrUnsupportedFormat)
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatCSV, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(row))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "result_id,search_term,fetched_at,position,wrapper_type,kind,"))
+	assert.True(t, strings.HasSuffix(lines[0], ",genre_ids,genres"))
+	assert.True(t, strings.HasPrefix(lines[1], `7,jack johnson,2026-10-19T12:00:00Z,1,track,song,0,0,456,,,"Upside Down, Live",`))
+	assert.True(t, strings.HasSuffix(lines[1], ",21;34,Rock;Music"))
+}
+
+func TestCSVWriterWithoutRows(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatCSV, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatNDJSON, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(row))
+	require.NoError(t, w.Write(row))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"result_id":7,"search_term":"jack johnson","fetched_at":"2026-10-19T12:00:00Z","position":1,`)
+	assert.Contains(t, lines[0], `"genre_ids":["21","34"],"genres":["Rock","Music"]}`)
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatParquet, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(row))
+	require.NoError(t, w.Close())
+
+	type exported struct {
+		ResultID   int64     `parquet:"result_id"`
+		SearchTerm string    `parquet:"search_term"`
+		FetchedAt  time.Time `parquet:"fetched_at,timestamp(millisecond)"`
+		TrackName  string    `parquet:"track_name"`
+		Genres     []string  `parquet:"genres,list"`
+	}
+	rows, err := parquet.Read[exported](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, []exported{{ResultID: 7, SearchTerm: "jack johnson", FetchedAt: row.FetchedAt, TrackName: "Upside Down, Live", Genres: []string{"Rock", "Music"}}}, rows)
+}
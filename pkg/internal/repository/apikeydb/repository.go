@@ -0,0 +1,161 @@
+package apikeydb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+// APIKey represents a stored api key.
+type APIKey struct {
+	ID          int64        `db:"id"`
+	Name        string       `db:"name"`
+	Prefix      string       `db:"prefix"`
+	KeyHash     []byte       `db:"key_hash"`
+	MinuteQuota int          `db:"minute_quota"`
+	DailyQuota  int          `db:"daily_quota"`
+	RevokedAt   sql.NullTime `db:"revoked_at"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, minute_quota, daily_quota, revoked_at, created_at, updated_at`
+
+// APIKeyRepositoryImpl is the implementation of the api key repository.
+type APIKeyRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepositoryImpl.
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepositoryImpl {
+	return &APIKeyRepositoryImpl{db: db}
+}
+
+// mapDBToBusinessModel maps an APIKey to a business.APIKey.
+func mapDBToBusinessModel(key APIKey) business.APIKey {
+	k := business.APIKey{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		MinuteQuota: key.MinuteQuota,
+		DailyQuota:  key.DailyQuota,
+		CreatedAt:   key.CreatedAt,
+	}
+	if key.RevokedAt.Valid {
+		k.RevokedAt = lo.ToPtr(key.RevokedAt.Time)
+	}
+	return k
+}
+
+// InsertAPIKey inserts a new api key with the hash of its raw value.
+func (repo *APIKeyRepositoryImpl) InsertAPIKey(ctx context.Context, key business.APIKey, hash []byte) (business.APIKey, error) {
+	query := `
+		INSERT INTO api_key (name, prefix, key_hash, minute_quota, daily_quota, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + apiKeyColumns
+	now := time.Now().UTC()
+	var dbKey APIKey
+	if err := repo.db.QueryRowxContext(ctx, query, key.Name, key.Prefix, hash, key.MinuteQuota, key.DailyQuota, now, now).StructScan(&dbKey); err != nil {
+		return business.APIKey{}, fmt.Errorf("failed to insert api key to db: %w", err)
+	}
+	return mapDBToBusinessModel(dbKey), nil
+}
+
+// GetAPIKeyByHash returns the api key with the given hash, business.ErrNotFound is returned when there is none.
+func (repo *APIKeyRepositoryImpl) GetAPIKeyByHash(ctx context.Context, hash []byte) (business.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE key_hash = $1`
+	var dbKey APIKey
+	if err := repo.db.GetContext(ctx, &dbKey, query, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.APIKey{}, business.ErrNotFound
+		}
+		return business.APIKey{}, fmt.Errorf("failed to get api key from db: %w", err)
+	}
+	return mapDBToBusinessModel(dbKey), nil
+}
+
+// ListAPIKeys lists all api keys ordered by creation.
+func (repo *APIKeyRepositoryImpl) ListAPIKeys(ctx context.Context) ([]business.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key ORDER BY id`
+	var dbKeys []APIKey
+	if err := repo.db.SelectContext(ctx, &dbKeys, query); err != nil {
+		return nil, fmt.Errorf("failed to list api keys from db: %w", err)
+	}
+	return lo.Map(dbKeys, func(k APIKey, _ int) business.APIKey {
+		return mapDBToBusinessModel(k)
+	}), nil
+}
+
+// UpdateAPIKeyHash replaces the prefix and hash of a non revoked api key.
+func (repo *APIKeyRepositoryImpl) UpdateAPIKeyHash(ctx context.Context, id int64, prefix string, hash []byte) (business.APIKey, error) {
+	query := `
+		UPDATE api_key SET prefix = $2, key_hash = $3, updated_at = $4
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING ` + apiKeyColumns
+	var dbKey APIKey
+	if err := repo.db.QueryRowxContext(ctx, query, id, prefix, hash, time.Now().UTC()).StructScan(&dbKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.APIKey{}, business.ErrNotFound
+		}
+		return business.APIKey{}, fmt.Errorf("failed to update api key in db: %w", err)
+	}
+	return mapDBToBusinessModel(dbKey), nil
+}
+
+// RevokeAPIKey marks an api key as revoked, revoking an already revoked key keeps its original revocation time.
+func (repo *APIKeyRepositoryImpl) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE api_key SET revoked_at = COALESCE(revoked_at, $2), updated_at = $2 WHERE id = $1`
+	res, err := repo.db.ExecContext(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key in db: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key in db: %w", err)
+	}
+	if affected == 0 {
+		return business.ErrNotFound
+	}
+	return nil
+}
+
+// IncrementAPIKeyUsage counts one request in every given window and returns the resulting count per window.
+// The increments are atomic so the counts are shared by every replica.
+func (repo *APIKeyRepositoryImpl) IncrementAPIKeyUsage(ctx context.Context, id int64, windows map[business.QuotaWindow]time.Time) (map[business.QuotaWindow]int, error) {
+	query := `
+		INSERT INTO api_key_usage (api_key_id, window_kind, window_start, request_count)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (api_key_id, window_kind, window_start)
+		DO UPDATE SET request_count = api_key_usage.request_count + 1
+		RETURNING request_count
+	`
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin api key usage tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	counts := make(map[business.QuotaWindow]int, len(windows))
+	// windows are incremented in a fixed order so concurrent transactions never deadlock.
+	for _, window := range []business.QuotaWindow{business.QuotaWindowMinute, business.QuotaWindowDay} {
+		start, ok := windows[window]
+		if !ok {
+			continue
+		}
+		var count int
+		if err := tx.QueryRowContext(ctx, query, id, string(window), start).Scan(&count); err != nil {
+			return nil, fmt.Errorf("failed to increment api key usage in db: %w", err)
+		}
+		counts[window] = count
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit api key usage tx: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,189 @@
+package apikeydb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/apikeydb"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyColumns = []string{"id", "name", "prefix", "key_hash", "minute_quota", "daily_quota", "revoked_at", "created_at", "updated_at"}
+
+func TestGetAPIKeyByHash(t *testing.T) {
+	now := time.Now().UTC()
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+		expectedKey   business.APIKey
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM api_key WHERE key_hash").
+					WithArgs([]byte("hash")).
+					WillReturnRows(sqlmock.NewRows(apiKeyColumns).AddRow(1, "ingestion", "msk_ab", []byte("hash"), 60, 1000, nil, now, now))
+			},
+			expectedKey: business.APIKey{ID: 1, Name: "ingestion", Prefix: "msk_ab", MinuteQuota: 60, DailyQuota: 1000, CreatedAt: now},
+		},
+		{
+			name: "not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM api_key WHERE key_hash").
+					WithArgs([]byte("hash")).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM api_key WHERE key_hash").
+					WithArgs([]byte("hash")).
+					WillReturnError(fmt.Errorf("query error"))
+			},
+			expectedError: "failed to get api key from db: query error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := apikeydb.NewAPIKeyRepository(sqlx.NewDb(db, "sqlmock"))
+			key, err := repo.GetAPIKeyByHash(context.Background(), []byte("hash"))
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedKey, key)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "successful revoke",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_key SET revoked_at").
+					WithArgs(int64(1), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "unknown key",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_key SET revoked_at").
+					WithArgs(int64(1), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := apikeydb.NewAPIKeyRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.RevokeAPIKey(context.Background(), 1, time.Now())
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIncrementAPIKeyUsage(t *testing.T) {
+	minute := time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedCounts map[business.QuotaWindow]int
+	}{
+		{
+			name: "successful increment",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO api_key_usage").
+					WithArgs(int64(1), "minute", minute).
+					WillReturnRows(sqlmock.NewRows([]string{"request_count"}).AddRow(3))
+				mock.ExpectQuery("INSERT INTO api_key_usage").
+					WithArgs(int64(1), "day", day).
+					WillReturnRows(sqlmock.NewRows([]string{"request_count"}).AddRow(42))
+				mock.ExpectCommit()
+			},
+			expectedCounts: map[business.QuotaWindow]int{
+				business.QuotaWindowMinute: 3,
+				business.QuotaWindowDay:    42,
+			},
+		},
+		{
+			name: "increment error rolls back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO api_key_usage").
+					WithArgs(int64(1), "minute", minute).
+					WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to increment api key usage in db: insert error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := apikeydb.NewAPIKeyRepository(sqlx.NewDb(db, "sqlmock"))
+			counts, err := repo.IncrementAPIKeyUsage(context.Background(), 1, map[business.QuotaWindow]time.Time{
+				business.QuotaWindowMinute: minute,
+				business.QuotaWindowDay:    day,
+			})
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCounts, counts)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package feedfetcher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/feed"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/samber/lo"
+)
+
+// FeedFetcher fetches podcast feeds through the feed client.
+type FeedFetcher struct {
+	client *feed.Client
+}
+
+// NewFeedFetcher creates a new instance of FeedFetcher.
+func NewFeedFetcher(client *feed.Client) *FeedFetcher {
+	return &FeedFetcher{client: client}
+}
+
+// FetchFeed fetches the podcast feed at url, conditionally on the validators etag and lastModified of an earlier fetch.
+func (f *FeedFetcher) FetchFeed(ctx context.Context, url, etag, lastModified string) (business.Feed, error) {
+	fetched, err := f.client.Fetch(ctx, url, etag, lastModified)
+	if err != nil {
+		return business.Feed{}, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	return business.Feed{
+		Title:        fetched.Title,
+		Episodes:     lo.Map(fetched.Items, mapItem),
+		ETag:         fetched.ETag,
+		LastModified: fetched.LastModified,
+		NotModified:  fetched.NotModified,
+	}, nil
+}
+
+// mapItem maps a feed item to a business.Episode.
+func mapItem(i feed.Item, _ int) business.Episode {
+	episode := business.Episode{
+		GUID:            i.GUID,
+		Title:           i.Title,
+		Description:     i.Description,
+		Link:            i.Link,
+		AudioURL:        i.EnclosureURL,
+		AudioType:       i.EnclosureType,
+		DurationSeconds: int(i.Duration.Seconds()),
+	}
+	if !i.PublishedAt.IsZero() {
+		episode.PublishedAt = lo.ToPtr(i.PublishedAt)
+	}
+	return episode
+}
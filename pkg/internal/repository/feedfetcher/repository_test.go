@@ -0,0 +1,62 @@
+package feedfetcher_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/feed"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/feedfetcher"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestFetchFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`<rss><channel><title>The Daily</title>
+			<item><guid>ep-1</guid><title>Episode 1</title><pubDate>Mon, 19 Oct 2026 10:00:00 +0000</pubDate>
+			<itunes:duration xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">90</itunes:duration>
+			<enclosure url="https://cdn.example.com/1.mp3" type="audio/mpeg"/></item>
+			<item><guid>ep-0</guid></item>
+		</channel></rss>`))
+	}))
+	defer srv.Close()
+	fetcher := feedfetcher.NewFeedFetcher(feed.NewClient(trace.NewTracerProvider(), "media-scout-test/1.0", time.Second, feed.WithPrivateAddresses()))
+
+	t.Run("fetched", func(t *testing.T) {
+		f, err := fetcher.FetchFeed(context.Background(), srv.URL, "", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, business.Feed{
+			Title: "The Daily",
+			ETag:  `"v1"`,
+			Episodes: []business.Episode{
+				{
+					GUID:            "ep-1",
+					Title:           "Episode 1",
+					AudioURL:        "https://cdn.example.com/1.mp3",
+					AudioType:       "audio/mpeg",
+					PublishedAt:     lo.ToPtr(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)),
+					DurationSeconds: 90,
+				},
+				{GUID: "ep-0"},
+			},
+		}, f)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		_, err := fetcher.FetchFeed(context.Background(), srv.URL+"/missing", "", "")
+
+		assert.EqualError(t, err, "failed to fetch feed: received non-200 response code: 404")
+	})
+}
@@ -0,0 +1,377 @@
+package jobdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+// Search represents a single search of a stored job.
+type Search struct {
+	Term  string `json:"term"`
+	Limit int    `json:"limit"`
+}
+
+type Searches []Search
+
+// Scan implements the sql.Scanner interface for Searches.
+func (s *Searches) Scan(src any) error {
+	if src == nil {
+		*s = Searches{}
+		return nil
+	}
+	v, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid data received, expected []byte got %T", src)
+	}
+	if err := json.Unmarshal(v, s); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON from bytes: %w", err)
+	}
+	return nil
+}
+
+// SearchJob represents a stored search job.
+type SearchJob struct {
+	ID          int64          `db:"id"`
+	Owner       string         `db:"owner"`
+	Status      string         `db:"status"`
+	Searches    Searches       `db:"searches"` // JSONB field
+	Attempts    int            `db:"attempts"`
+	MaxAttempts int            `db:"max_attempts"`
+	LastError   sql.NullString `db:"last_error"`
+	RunAt       time.Time      `db:"run_at"`
+	LockedUntil sql.NullTime   `db:"locked_until"`
+	StartedAt   sql.NullTime   `db:"started_at"`
+	FinishedAt  sql.NullTime   `db:"finished_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+// SearchJobResult represents the stored outcome of a single search of a job.
+type SearchJobResult struct {
+	Position      int            `db:"position"`
+	MediaResultID sql.NullInt64  `db:"media_result_id"`
+	Error         sql.NullString `db:"error"`
+}
+
+// EventData represents the payload of a stored search job event.
+type EventData struct {
+	Position      int        `json:"position,omitempty"`
+	Term          string     `json:"term,omitempty"`
+	Limit         int        `json:"limit,omitempty"`
+	MediaResultID int64      `json:"media_result_id,omitempty"`
+	ResultCount   int        `json:"result_count,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	Attempts      int        `json:"attempts,omitempty"`
+	NextRunAt     *time.Time `json:"next_run_at,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// Scan implements the sql.Scanner interface for EventData.
+func (d *EventData) Scan(src any) error {
+	if src == nil {
+		*d = EventData{}
+		return nil
+	}
+	v, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("invalid data received, expected []byte got %T", src)
+	}
+	if err := json.Unmarshal(v, d); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON from bytes: %w", err)
+	}
+	return nil
+}
+
+// SearchJobEvent represents a stored event of a search job.
+type SearchJobEvent struct {
+	ID        int64     `db:"id"`
+	JobID     int64     `db:"job_id"`
+	Kind      string    `db:"kind"`
+	Data      EventData `db:"data"` // JSONB field
+	CreatedAt time.Time `db:"created_at"`
+}
+
+const searchJobColumns = `id, owner, status, searches, attempts, max_attempts, last_error, run_at, locked_until, started_at, finished_at, created_at, updated_at`
+
+// SearchJobRepositoryImpl is the implementation of the search job repository, it is a queue workers claim
+// jobs from with FOR UPDATE SKIP LOCKED so every job is run by a single worker at a time. Every claim counts an
+// attempt, the attempt number is the lease of the worker: a running job is only updated for its current attempt so
+// a worker whose lease expired can't write over the worker which claimed the job again.
+type SearchJobRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewSearchJobRepository creates a new instance of SearchJobRepositoryImpl.
+func NewSearchJobRepository(db *sqlx.DB) *SearchJobRepositoryImpl {
+	return &SearchJobRepositoryImpl{db: db}
+}
+
+// mapDBToBusinessModel maps a SearchJob and its stored results to a business.SearchJob.
+func mapDBToBusinessModel(job SearchJob, results []SearchJobResult) business.SearchJob {
+	j := business.SearchJob{
+		ID:     job.ID,
+		Owner:  job.Owner,
+		Status: business.SearchJobStatus(job.Status),
+		Searches: lo.Map(job.Searches, func(s Search, _ int) business.SearchQuery {
+			return business.SearchQuery{Term: s.Term, Limit: s.Limit}
+		}),
+		Results:     make([]business.SearchJobResult, len(job.Searches)),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError.String,
+		RunAt:       job.RunAt,
+		CreatedAt:   job.CreatedAt,
+	}
+	if job.StartedAt.Valid {
+		j.StartedAt = lo.ToPtr(job.StartedAt.Time)
+	}
+	if job.FinishedAt.Valid {
+		j.FinishedAt = lo.ToPtr(job.FinishedAt.Time)
+	}
+	for _, r := range results {
+		if r.Position < 0 || r.Position >= len(j.Results) {
+			continue
+		}
+		j.Results[r.Position] = business.SearchJobResult{MediaResultID: r.MediaResultID.Int64, Error: r.Error.String}
+	}
+	return j
+}
+
+// withResults loads the stored results of job and maps both to a business.SearchJob.
+func (repo *SearchJobRepositoryImpl) withResults(ctx context.Context, job SearchJob) (business.SearchJob, error) {
+	query := `SELECT position, media_result_id, error FROM search_job_result WHERE job_id = $1 ORDER BY position`
+	var results []SearchJobResult
+	if err := repo.db.SelectContext(ctx, &results, query, job.ID); err != nil {
+		return business.SearchJob{}, fmt.Errorf("failed to get search job results from db: %w", err)
+	}
+	return mapDBToBusinessModel(job, results), nil
+}
+
+// InsertSearchJob inserts a new search job.
+func (repo *SearchJobRepositoryImpl) InsertSearchJob(ctx context.Context, job business.SearchJob) (business.SearchJob, error) {
+	searches, err := json.Marshal(lo.Map(job.Searches, func(s business.SearchQuery, _ int) Search {
+		return Search{Term: s.Term, Limit: s.Limit}
+	}))
+	if err != nil {
+		return business.SearchJob{}, fmt.Errorf("failed to marshal searches: %w", err)
+	}
+	query := `
+		INSERT INTO search_job (owner, status, searches, max_attempts, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + searchJobColumns
+	now := time.Now().UTC()
+	var dbJob SearchJob
+	if err := repo.db.QueryRowxContext(ctx, query, job.Owner, string(job.Status), searches, job.MaxAttempts, job.RunAt, now, now).StructScan(&dbJob); err != nil {
+		return business.SearchJob{}, fmt.Errorf("failed to insert search job to db: %w", err)
+	}
+	return mapDBToBusinessModel(dbJob, nil), nil
+}
+
+// GetSearchJob returns the search job with the given id, business.ErrNotFound is returned when there is none.
+func (repo *SearchJobRepositoryImpl) GetSearchJob(ctx context.Context, id int64) (business.SearchJob, error) {
+	query := `SELECT ` + searchJobColumns + ` FROM search_job WHERE id = $1`
+	var dbJob SearchJob
+	if err := repo.db.GetContext(ctx, &dbJob, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.SearchJob{}, business.ErrNotFound
+		}
+		return business.SearchJob{}, fmt.Errorf("failed to get search job from db: %w", err)
+	}
+	return repo.withResults(ctx, dbJob)
+}
+
+// ClaimSearchJob marks the oldest due job as running until lockedUntil and counts the attempt, jobs locked by
+// another worker are skipped. Running jobs whose lease expired are claimed again. business.ErrNotFound is returned
+// when no job is due.
+func (repo *SearchJobRepositoryImpl) ClaimSearchJob(ctx context.Context, now time.Time, lockedUntil time.Time) (business.SearchJob, error) {
+	query := `
+		UPDATE search_job
+		SET status = 'running', attempts = attempts + 1, locked_until = $2, started_at = COALESCE(started_at, $1), updated_at = $1
+		WHERE id = (
+			SELECT id FROM search_job
+			WHERE (status = 'queued' AND run_at <= $1) OR (status = 'running' AND locked_until < $1)
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + searchJobColumns
+	var dbJob SearchJob
+	if err := repo.db.QueryRowxContext(ctx, query, now, lockedUntil).StructScan(&dbJob); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.SearchJob{}, business.ErrNotFound
+		}
+		return business.SearchJob{}, fmt.Errorf("failed to claim search job in db: %w", err)
+	}
+	return repo.withResults(ctx, dbJob)
+}
+
+// ExtendSearchJobLease keeps the given attempt of a running job claimed until lockedUntil, business.ErrNotFound is
+// returned when the job isn't running that attempt anymore.
+func (repo *SearchJobRepositoryImpl) ExtendSearchJobLease(ctx context.Context, id int64, attempt int, lockedUntil time.Time) error {
+	query := `UPDATE search_job SET locked_until = $3 WHERE id = $1 AND attempts = $2 AND status = 'running'`
+	return repo.execRunning(ctx, "failed to extend search job lease in db", query, id, attempt, lockedUntil)
+}
+
+// SaveSearchJobResults stores the results of the given attempt of a running job by position, replacing the results of
+// earlier attempts. business.ErrNotFound is returned when the job isn't running that attempt anymore.
+func (repo *SearchJobRepositoryImpl) SaveSearchJobResults(ctx context.Context, id int64, attempt int, results map[int]business.SearchJobResult) error {
+	// the job is locked until the results are stored so it can't be claimed again meanwhile.
+	lease := `SELECT id FROM search_job WHERE id = $1 AND attempts = $2 AND status = 'running' FOR UPDATE`
+	query := `
+		INSERT INTO search_job_result (job_id, position, media_result_id, error, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (job_id, position)
+		DO UPDATE SET media_result_id = EXCLUDED.media_result_id, error = EXCLUDED.error, updated_at = EXCLUDED.updated_at
+	`
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin search job results tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked int64
+	if err := tx.GetContext(ctx, &locked, lease, id, attempt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock search job in db: %w", err)
+	}
+	now := time.Now().UTC()
+	for position, result := range results {
+		mediaResultID := sql.NullInt64{Int64: result.MediaResultID, Valid: result.MediaResultID != 0}
+		resultErr := sql.NullString{String: result.Error, Valid: result.Error != ""}
+		if _, err := tx.ExecContext(ctx, query, id, position, mediaResultID, resultErr, now); err != nil {
+			return fmt.Errorf("failed to save search job result in db: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit search job results tx: %w", err)
+	}
+	return nil
+}
+
+// RetrySearchJob queues the given attempt of a running job again, due at runAt.
+func (repo *SearchJobRepositoryImpl) RetrySearchJob(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error {
+	query := `
+		UPDATE search_job SET status = 'queued', run_at = $3, last_error = $4, locked_until = NULL, updated_at = $5
+		WHERE id = $1 AND attempts = $2 AND status = 'running'
+	`
+	return repo.execRunning(ctx, "failed to retry search job in db", query, id, attempt, runAt, lastError, time.Now().UTC())
+}
+
+// FinishSearchJob ends the given attempt of a running job with the given status, lastError is only stored when set.
+func (repo *SearchJobRepositoryImpl) FinishSearchJob(ctx context.Context, id int64, attempt int, status business.SearchJobStatus, lastError string, at time.Time) error {
+	query := `
+		UPDATE search_job
+		SET status = $3, last_error = COALESCE(NULLIF($4, ''), last_error), locked_until = NULL, finished_at = $5, updated_at = $5
+		WHERE id = $1 AND attempts = $2 AND status = 'running'
+	`
+	return repo.execRunning(ctx, "failed to finish search job in db", query, id, attempt, string(status), lastError, at)
+}
+
+// CancelSearchJob cancels a queued or running job of owner, business.ErrNotFound is returned when there is no such job.
+func (repo *SearchJobRepositoryImpl) CancelSearchJob(ctx context.Context, owner string, id int64, at time.Time) (business.SearchJob, error) {
+	query := `
+		UPDATE search_job SET status = 'canceled', locked_until = NULL, finished_at = $2, updated_at = $2
+		WHERE id = $1 AND owner = $3 AND status IN ('queued', 'running')
+		RETURNING ` + searchJobColumns
+	var dbJob SearchJob
+	if err := repo.db.QueryRowxContext(ctx, query, id, at, owner).StructScan(&dbJob); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.SearchJob{}, business.ErrNotFound
+		}
+		return business.SearchJob{}, fmt.Errorf("failed to cancel search job in db: %w", err)
+	}
+	return repo.withResults(ctx, dbJob)
+}
+
+// execRunning runs an update of a running job, business.ErrNotFound is returned when the job isn't running the
+// attempt updated.
+func (repo *SearchJobRepositoryImpl) execRunning(ctx context.Context, failure, query string, args ...any) error {
+	res, err := repo.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", failure, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", failure, err)
+	}
+	if affected == 0 {
+		return business.ErrNotFound
+	}
+	return nil
+}
+
+// AppendSearchJobEvents stores events in the given order so their ids follow it.
+func (repo *SearchJobRepositoryImpl) AppendSearchJobEvents(ctx context.Context, events []business.SearchJobEvent) error {
+	query := `INSERT INTO search_job_event (job_id, kind, data, created_at) VALUES ($1, $2, $3, $4)`
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin search job events tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	for _, event := range events {
+		data, err := json.Marshal(EventData{
+			Position:      event.Position,
+			Term:          event.Term,
+			Limit:         event.Limit,
+			MediaResultID: event.MediaResultID,
+			ResultCount:   event.ResultCount,
+			Status:        string(event.Status),
+			Attempts:      event.Attempts,
+			NextRunAt:     event.NextRunAt,
+			Error:         event.Error,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal search job event: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, query, event.JobID, string(event.Kind), data, now); err != nil {
+			return fmt.Errorf("failed to insert search job event to db: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit search job events tx: %w", err)
+	}
+	return nil
+}
+
+// ListSearchJobEvents returns up to limit events of a job following the event with id afterID, oldest first.
+func (repo *SearchJobRepositoryImpl) ListSearchJobEvents(ctx context.Context, jobID, afterID int64, limit int) ([]business.SearchJobEvent, error) {
+	query := `
+		SELECT id, job_id, kind, data, created_at FROM search_job_event
+		WHERE job_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+	var events []SearchJobEvent
+	if err := repo.db.SelectContext(ctx, &events, query, jobID, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list search job events from db: %w", err)
+	}
+	return lo.Map(events, func(e SearchJobEvent, _ int) business.SearchJobEvent {
+		return business.SearchJobEvent{
+			ID:            e.ID,
+			JobID:         e.JobID,
+			Kind:          business.SearchJobEventKind(e.Kind),
+			Position:      e.Data.Position,
+			Term:          e.Data.Term,
+			Limit:         e.Data.Limit,
+			MediaResultID: e.Data.MediaResultID,
+			ResultCount:   e.Data.ResultCount,
+			Status:        business.SearchJobStatus(e.Data.Status),
+			Attempts:      e.Data.Attempts,
+			NextRunAt:     e.Data.NextRunAt,
+			Error:         e.Data.Error,
+			CreatedAt:     e.CreatedAt,
+		}
+	}), nil
+}
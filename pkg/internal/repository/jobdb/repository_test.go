@@ -0,0 +1,395 @@
+package jobdb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/jobdb"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	searchJobColumns = []string{"id", "owner", "status", "searches", "attempts", "max_attempts", "last_error", "run_at", "locked_until", "started_at", "finished_at", "created_at", "updated_at"}
+	resultColumns    = []string{"position", "media_result_id", "error"}
+)
+
+func TestGetSearchJob(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	searches := []byte(`[{"term":"jack","limit":5},{"term":"johnson","limit":20},{"term":"broken","limit":1}]`)
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+		expectedJob   business.SearchJob
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM search_job WHERE id").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(searchJobColumns).AddRow(1, "key:7", "queued", searches, 1, 3, "upstream down", now, nil, now, nil, now, now))
+				mock.ExpectQuery("SELECT position, media_result_id, error FROM search_job_result WHERE job_id").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(resultColumns).AddRow(0, 7, nil).AddRow(2, nil, "upstream down"))
+			},
+			expectedJob: business.SearchJob{
+				ID:       1,
+				Owner:    "key:7",
+				Status:   business.SearchJobQueued,
+				Searches: []business.SearchQuery{{Term: "jack", Limit: 5}, {Term: "johnson", Limit: 20}, {Term: "broken", Limit: 1}},
+				Results: []business.SearchJobResult{
+					{MediaResultID: 7},
+					{},
+					{Error: "upstream down"},
+				},
+				Attempts:    1,
+				MaxAttempts: 3,
+				LastError:   "upstream down",
+				RunAt:       now,
+				StartedAt:   lo.ToPtr(now),
+				CreatedAt:   now,
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM search_job WHERE id").
+					WithArgs(int64(1)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "results query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM search_job WHERE id").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(searchJobColumns).AddRow(1, "key:7", "queued", searches, 0, 3, nil, now, nil, nil, nil, now, now))
+				mock.ExpectQuery("FROM search_job_result").WillReturnError(fmt.Errorf("query error"))
+			},
+			expectedError: "failed to get search job results from db: query error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := jobdb.NewSearchJobRepository(sqlx.NewDb(db, "sqlmock"))
+			job, err := repo.GetSearchJob(context.Background(), 1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedJob, job)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestClaimSearchJob(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(time.Minute)
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+		expectedJob   business.SearchJob
+	}{
+		{
+			name: "claimed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE search_job\s+SET status = 'running', attempts = attempts \+ 1(.+)FOR UPDATE SKIP LOCKED`).
+					WithArgs(now, lockedUntil).
+					WillReturnRows(sqlmock.NewRows(searchJobColumns).AddRow(4, "key:7", "running", []byte(`[{"term":"jack","limit":5}]`), 1, 3, nil, now, lockedUntil, now, nil, now, now))
+				mock.ExpectQuery("FROM search_job_result WHERE job_id").
+					WithArgs(int64(4)).
+					WillReturnRows(sqlmock.NewRows(resultColumns))
+			},
+			expectedJob: business.SearchJob{
+				ID:          4,
+				Owner:       "key:7",
+				Status:      business.SearchJobRunning,
+				Searches:    []business.SearchQuery{{Term: "jack", Limit: 5}},
+				Results:     []business.SearchJobResult{{}},
+				Attempts:    1,
+				MaxAttempts: 3,
+				RunAt:       now,
+				StartedAt:   lo.ToPtr(now),
+				CreatedAt:   now,
+			},
+		},
+		{
+			name: "no job due",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE search_job").
+					WithArgs(now, lockedUntil).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE search_job").
+					WithArgs(now, lockedUntil).
+					WillReturnError(fmt.Errorf("query error"))
+			},
+			expectedError: "failed to claim search job in db: query error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := jobdb.NewSearchJobRepository(sqlx.NewDb(db, "sqlmock"))
+			job, err := repo.ClaimSearchJob(context.Background(), now, lockedUntil)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedJob, job)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCancelSearchJob(t *testing.T) {
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "canceled",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE search_job SET status = 'canceled'(.+)WHERE id = \$1 AND owner = \$3`).
+					WithArgs(int64(1), at, "key:7").
+					WillReturnRows(sqlmock.NewRows(searchJobColumns).AddRow(1, "key:7", "canceled", []byte(`[]`), 1, 3, nil, at, nil, at, at, at, at))
+				mock.ExpectQuery("FROM search_job_result WHERE job_id").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(resultColumns))
+			},
+		},
+		{
+			name: "finished or owned by another client",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE search_job").
+					WithArgs(int64(1), at, "key:7").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := jobdb.NewSearchJobRepository(sqlx.NewDb(db, "sqlmock"))
+			job, err := repo.CancelSearchJob(context.Background(), "key:7", 1, at)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, business.SearchJobCanceled, job.Status)
+				assert.Equal(t, "key:7", job.Owner)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSaveSearchJobResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "successful save",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM search_job WHERE id = \\$1 AND attempts = \\$2 AND status = 'running' FOR UPDATE").
+					WithArgs(int64(1), 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO search_job_result (.+) ON CONFLICT").
+					WithArgs(int64(1), 0, sql.NullInt64{Int64: 7, Valid: true}, sql.NullString{}, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "lease lost",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM search_job").WithArgs(int64(1), 2).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "exec error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM search_job").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO search_job_result").WillReturnError(fmt.Errorf("exec error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to save search job result in db: exec error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := jobdb.NewSearchJobRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.SaveSearchJobResults(context.Background(), 1, 2, map[int]business.SearchJobResult{0: {MediaResultID: 7}})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFinishSearchJob(t *testing.T) {
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "successful finish",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE search_job(.+)WHERE id = \\$1 AND attempts = \\$2 AND status = 'running'").
+					WithArgs(int64(1), 2, "failed", "upstream down", at).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "job isn't running the attempt",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE search_job").
+					WithArgs(int64(1), 2, "failed", "upstream down", at).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "exec error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE search_job").WillReturnError(fmt.Errorf("exec error"))
+			},
+			expectedError: "failed to finish search job in db: exec error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := jobdb.NewSearchJobRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.FinishSearchJob(context.Background(), 1, 2, business.SearchJobFailed, "upstream down", at)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListSearchJobEvents(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedEvents []business.SearchJobEvent
+	}{
+		{
+			name: "successful list",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM search_job_event WHERE job_id = \\$1 AND id > \\$2 ORDER BY id LIMIT \\$3").
+					WithArgs(int64(1), int64(3), 100).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "job_id", "kind", "data", "created_at"}).
+						AddRow(4, 1, "status", []byte(`{"status":"running","attempts":1}`), now).
+						AddRow(5, 1, "result", []byte(`{"position":1,"term":"jack","limit":5,"media_result_id":7,"result_count":2}`), now))
+			},
+			expectedEvents: []business.SearchJobEvent{
+				{ID: 4, JobID: 1, Kind: business.SearchJobEventStatus, Status: business.SearchJobRunning, Attempts: 1, CreatedAt: now},
+				{ID: 5, JobID: 1, Kind: business.SearchJobEventResult, Position: 1, Term: "jack", Limit: 5, MediaResultID: 7, ResultCount: 2, CreatedAt: now},
+			},
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM search_job_event").WillReturnError(fmt.Errorf("query error"))
+			},
+			expectedError: "failed to list search job events from db: query error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := jobdb.NewSearchJobRepository(sqlx.NewDb(db, "sqlmock"))
+			events, err := repo.ListSearchJobEvents(context.Background(), 1, 3, 100)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedEvents, events)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,45 +2,85 @@ package mediadb
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/outboxdb"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/samber/lo"
 )
 
 // Media represents a single media item with various attributes.
 type Media struct {
-	WrapperType            string   `json:"wrapperType"`
-	Kind                   string   `json:"kind"`
-	ArtistID               int      `json:"artistId"`
-	CollectionID           int      `json:"collectionId"`
-	TrackID                int      `json:"trackId"`
-	ArtistName             string   `json:"artistName"`
-	CollectionName         string   `json:"collectionName"`
-	TrackName              string   `json:"trackName"`
-	ArtistViewURL          string   `json:"artistViewUrl"`
-	CollectionViewURL      string   `json:"collectionViewUrl"`
-	FeedURL                string   `json:"feedUrl"`
-	TrackViewURL           string   `json:"trackViewUrl"`
-	ArtworkURL30           string   `json:"artworkUrl30"`
-	ArtworkURL60           string   `json:"artworkUrl60"`
-	ArtworkURL100          string   `json:"artworkUrl100"`
-	ReleaseDate            string   `json:"releaseDate"`
-	CollectionExplicitness string   `json:"collectionExplicitness"`
-	TrackExplicitness      string   `json:"trackExplicitness"`
-	TrackCount             int      `json:"trackCount"`
-	TrackTimeMillis        int      `json:"trackTimeMillis"`
-	Country                string   `json:"country"`
-	Currency               string   `json:"currency"`
-	PrimaryGenreName       string   `json:"primaryGenreName"`
-	ContentAdvisoryRating  string   `json:"contentAdvisoryRating"`
-	ArtworkURL600          string   `json:"artworkUrl600"`
-	GenreIDs               []string `json:"genreIds"`
-	Genres                 []string `json:"genres"`
+	WrapperType            string    `json:"wrapperType"`
+	Kind                   string    `json:"kind"`
+	ArtistID               int       `json:"artistId"`
+	CollectionID           int       `json:"collectionId"`
+	TrackID                int       `json:"trackId"`
+	ArtistName             string    `json:"artistName"`
+	CollectionName         string    `json:"collectionName"`
+	TrackName              string    `json:"trackName"`
+	ArtistViewURL          string    `json:"artistViewUrl"`
+	CollectionViewURL      string    `json:"collectionViewUrl"`
+	FeedURL                string    `json:"feedUrl"`
+	TrackViewURL           string    `json:"trackViewUrl"`
+	ArtworkURL30           string    `json:"artworkUrl30"`
+	ArtworkURL60           string    `json:"artworkUrl60"`
+	ArtworkURL100          string    `json:"artworkUrl100"`
+	ReleaseDate            string    `json:"releaseDate"`
+	CollectionExplicitness string    `json:"collectionExplicitness"`
+	TrackExplicitness      string    `json:"trackExplicitness"`
+	TrackCount             int       `json:"trackCount"`
+	TrackTimeMillis        int       `json:"trackTimeMillis"`
+	Country                string    `json:"country"`
+	Currency               string    `json:"currency"`
+	PrimaryGenreName       string    `json:"primaryGenreName"`
+	ContentAdvisoryRating  string    `json:"contentAdvisoryRating"`
+	ArtworkURL600          string    `json:"artworkUrl600"`
+	GenreIDs               []string  `json:"genreIds"`
+	Genres                 []string  `json:"genres"`
+	Source                 string    `json:"source,omitempty"`
+	SourceID               string    `json:"sourceId,omitempty"`
+	ISRC                   string    `json:"isrc,omitempty"`
+	UPC                    string    `json:"upc,omitempty"`
+	Software               *Software `json:"software,omitempty"`
+	Video                  *Video    `json:"video,omitempty"`
+}
+
+// Video represents the stored details of a movie, a tv season or a tv episode, its fields match business.Video.
+type Video struct {
+	LongDescription    string  `json:"longDescription,omitempty"`
+	ShortDescription   string  `json:"shortDescription,omitempty"`
+	TrackPrice         float64 `json:"trackPrice,omitempty"`
+	TrackRentalPrice   float64 `json:"trackRentalPrice,omitempty"`
+	TrackHDPrice       float64 `json:"trackHdPrice,omitempty"`
+	TrackHDRentalPrice float64 `json:"trackHdRentalPrice,omitempty"`
+	CollectionPrice    float64 `json:"collectionPrice,omitempty"`
+	CollectionHDPrice  float64 `json:"collectionHdPrice,omitempty"`
+	SeasonNumber       int     `json:"seasonNumber,omitempty"`
+	EpisodeNumber      int     `json:"episodeNumber,omitempty"`
+}
+
+// Software represents the stored App Store details of an app, its fields match business.Software.
+type Software struct {
+	BundleID          string   `json:"bundleId"`
+	AverageUserRating float64  `json:"averageUserRating"`
+	UserRatingCount   int      `json:"userRatingCount"`
+	ScreenshotURLs    []string `json:"screenshotUrls"`
+	SupportedDevices  []string `json:"supportedDevices"`
+	FileSizeBytes     int64    `json:"fileSizeBytes"`
+	MinimumOSVersion  string   `json:"minimumOsVersion"`
+	SellerName        string   `json:"sellerName"`
+	Price             float64  `json:"price"`
+	FormattedPrice    string   `json:"formattedPrice"`
 }
 
 type Medias []Media
@@ -74,18 +114,35 @@ type MediaResult struct {
 	SearchTerm  string    `db:"search_term"`
 	Media       Medias    `db:"returned_result"` // JSONB field
 	ResultCount int       `db:"result_count"`
+	SearchKey   string    `db:"search_key"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
 
 // MediaRepositoryImpl is the implementation of MediaRepository.
 type MediaRepositoryImpl struct {
-	db *sqlx.DB
+	db           *sqlx.DB
+	outboxEvents bool
+}
+
+// Option configures a MediaRepositoryImpl.
+type Option func(repo *MediaRepositoryImpl)
+
+// WithOutboxEvents makes InsertMedia write the domain events of every stored search to the outbox, they are only
+// deleted once relayed so it should only be set along with a relay.
+func WithOutboxEvents() Option {
+	return func(repo *MediaRepositoryImpl) {
+		repo.outboxEvents = true
+	}
 }
 
 // NewMediaRepository creates a new instance of MediaRepository.
-func NewMediaRepository(db *sqlx.DB) *MediaRepositoryImpl {
-	return &MediaRepositoryImpl{db: db}
+func NewMediaRepository(db *sqlx.DB, opts ...Option) *MediaRepositoryImpl {
+	repo := &MediaRepositoryImpl{db: db}
+	for _, opt := range opts {
+		opt(repo)
+	}
+	return repo
 }
 
 // mapBusinessToDBModel maps a business.MediaResult to a MediaResult.
@@ -122,29 +179,229 @@ func mapBusinessToDBModel(media business.MediaResult) MediaResult {
 				ArtworkURL600:          m.ArtworkURL600,
 				GenreIDs:               m.GenreIDs,
 				Genres:                 m.Genres,
+				Source:                 m.Source,
+				SourceID:               m.SourceID,
+				ISRC:                   m.ISRC,
+				UPC:                    m.UPC,
+				Software:               (*Software)(m.Software),
+				Video:                  (*Video)(m.Video),
 			}
 		}),
 		ResultCount: media.ResultCount,
+		SearchKey:   media.SearchKey,
 	}
 }
 
-// InsertMedia inserts a new media result into the database.
+// InsertMedia inserts a new media result into the database along with its domain events when WithOutboxEvents is
+// set, both are written in a single transaction so the events are only relayed once the result is stored.
 func (repo *MediaRepositoryImpl) InsertMedia(ctx context.Context, media business.MediaResult) (int64, error) {
 	dbMedia := mapBusinessToDBModel(media)
 	mediaResult, err := json.Marshal(dbMedia.Media)
 	if err != nil {
 		return 0, fmt.Errorf("failed to marshal media result: %w", err)
 	}
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin insert media tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	query := `
-		INSERT INTO media_result (search_term, returned_result, created_at, updated_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO media_result (search_term, returned_result, search_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id
 	`
 	var id int64
-
-	if err = repo.db.QueryRowContext(ctx, query, dbMedia.SearchTerm, mediaResult, time.Now().UTC(), time.Now().UTC()).Scan(&id); err != nil {
+	now := time.Now().UTC()
+	if err = tx.QueryRowContext(ctx, query, dbMedia.SearchTerm, mediaResult, dbMedia.SearchKey, now, now).Scan(&id); err != nil {
 		return 0, fmt.Errorf("failed to insert media to db: %w", err)
 	}
 
+	if repo.outboxEvents {
+		media.ID = id
+		events, err := business.NewSearchEvents(media, now)
+		if err != nil {
+			return 0, fmt.Errorf("failed to build search events: %w", err)
+		}
+		if err := outboxdb.InsertOutboxEvents(ctx, tx, events); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit insert media tx: %w", err)
+	}
 	return id, nil
 }
+
+// mapDBModelToBusiness maps a stored MediaResult to a business.MediaResult, the result is considered fetched when stored.
+func mapDBModelToBusiness(media MediaResult) business.MediaResult {
+	return business.MediaResult{
+		ID:         media.ID,
+		SearchTerm: media.SearchTerm,
+		Media: lo.Map(media.Media, func(m Media, _ int) business.Media {
+			return business.Media{
+				WrapperType:            m.WrapperType,
+				Kind:                   m.Kind,
+				ArtistID:               m.ArtistID,
+				CollectionID:           m.CollectionID,
+				TrackID:                m.TrackID,
+				ArtistName:             m.ArtistName,
+				CollectionName:         m.CollectionName,
+				TrackName:              m.TrackName,
+				ArtistViewURL:          m.ArtistViewURL,
+				CollectionViewURL:      m.CollectionViewURL,
+				FeedURL:                m.FeedURL,
+				TrackViewURL:           m.TrackViewURL,
+				ArtworkURL30:           m.ArtworkURL30,
+				ArtworkURL60:           m.ArtworkURL60,
+				ArtworkURL100:          m.ArtworkURL100,
+				ReleaseDate:            m.ReleaseDate,
+				CollectionExplicitness: m.CollectionExplicitness,
+				TrackExplicitness:      m.TrackExplicitness,
+				TrackCount:             m.TrackCount,
+				TrackTimeMillis:        m.TrackTimeMillis,
+				Country:                m.Country,
+				Currency:               m.Currency,
+				PrimaryGenreName:       m.PrimaryGenreName,
+				ContentAdvisoryRating:  m.ContentAdvisoryRating,
+				ArtworkURL600:          m.ArtworkURL600,
+				GenreIDs:               m.GenreIDs,
+				Genres:                 m.Genres,
+				Source:                 m.Source,
+				SourceID:               m.SourceID,
+				ISRC:                   m.ISRC,
+				UPC:                    m.UPC,
+				Software:               (*business.Software)(m.Software),
+				Video:                  (*business.Video)(m.Video),
+			}
+		}),
+		ResultCount: len(media.Media),
+		FetchedAt:   media.CreatedAt,
+		SearchKey:   media.SearchKey,
+	}
+}
+
+// GetLatestMediaResult returns the latest media result of the search identified by searchKey stored since the given
+// time, business.ErrNotFound is returned when there is none.
+func (repo *MediaRepositoryImpl) GetLatestMediaResult(ctx context.Context, searchKey string, since time.Time) (business.MediaResult, error) {
+	query := `
+		SELECT id, COALESCE(search_term, '') AS search_term, returned_result, search_key, created_at, updated_at
+		FROM media_result
+		WHERE search_key = $1 AND created_at >= $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	var row MediaResult
+	if err := repo.db.GetContext(ctx, &row, query, searchKey, since.UTC()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.MediaResult{}, business.ErrNotFound
+		}
+		return business.MediaResult{}, fmt.Errorf("failed to get latest media result from db: %w", err)
+	}
+	return mapDBModelToBusiness(row), nil
+}
+
+// historyConditions returns the where clause and args matching filter, args are numbered from 1.
+func historyConditions(filter business.HistoryFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Term != "" {
+		add("lower(search_term) = lower(?)", filter.Term)
+	}
+	if !filter.Since.IsZero() {
+		add("created_at >= ?", filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		add("created_at < ?", filter.Until.UTC())
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// ListMediaResults lists the stored media results matching filter, most recent first.
+func (repo *MediaRepositoryImpl) ListMediaResults(ctx context.Context, filter business.HistoryFilter) ([]business.MediaResult, error) {
+	where, args := historyConditions(filter)
+	query := fmt.Sprintf(`
+		SELECT id, COALESCE(search_term, '') AS search_term, returned_result, created_at, updated_at
+		FROM media_result
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	var rows []MediaResult
+	if err := repo.db.SelectContext(ctx, &rows, query, append(args, filter.Limit, filter.Offset)...); err != nil {
+		return nil, fmt.Errorf("failed to list media results from db: %w", err)
+	}
+	return lo.Map(rows, func(r MediaResult, _ int) business.MediaResult {
+		return mapDBModelToBusiness(r)
+	}), nil
+}
+
+// exportFetchSize is the number of rows fetched from the export cursor at once.
+const exportFetchSize = 500
+
+// StreamMediaResults passes the stored media results matching filter to fn, most recent first. Rows are read
+// through a cursor exportFetchSize at a time so memory doesn't grow with the export, a zero filter.Limit
+// streams every result. An error returned by fn stops the stream and is returned as is.
+func (repo *MediaRepositoryImpl) StreamMediaResults(ctx context.Context, filter business.HistoryFilter, fn func(business.MediaResult) error) error {
+	where, args := historyConditions(filter)
+	// LIMIT NULL is the same as no limit.
+	limit := sql.NullInt64{Int64: int64(filter.Limit), Valid: filter.Limit > 0}
+	query := fmt.Sprintf(`
+		DECLARE media_result_export NO SCROLL CURSOR FOR
+		SELECT id, COALESCE(search_term, '') AS search_term, returned_result, created_at, updated_at
+		FROM media_result
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	// cursors only live within a transaction, it is rolled back as nothing is written.
+	tx, err := repo.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin export tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, query, append(args, limit, filter.Offset)...); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+	fetch := fmt.Sprintf(`FETCH %d FROM media_result_export`, exportFetchSize)
+	for {
+		var rows []MediaResult
+		if err := tx.SelectContext(ctx, &rows, fetch); err != nil {
+			return fmt.Errorf("failed to fetch media results from export cursor: %w", err)
+		}
+		for _, r := range rows {
+			if err := fn(mapDBModelToBusiness(r)); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+// GetMediaResults returns the stored media results with the given ids, unknown ids are skipped.
+func (repo *MediaRepositoryImpl) GetMediaResults(ctx context.Context, ids []int64) ([]business.MediaResult, error) {
+	query := `
+		SELECT id, COALESCE(search_term, '') AS search_term, returned_result, created_at, updated_at
+		FROM media_result
+		WHERE id = ANY($1)
+		ORDER BY id
+	`
+	var rows []MediaResult
+	if err := repo.db.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to get media results from db: %w", err)
+	}
+	return lo.Map(rows, func(r MediaResult, _ int) business.MediaResult {
+		return mapDBModelToBusiness(r)
+	}), nil
+}
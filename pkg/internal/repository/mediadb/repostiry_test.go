@@ -2,15 +2,19 @@ package mediadb_test
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediadb"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,6 +22,7 @@ func TestInsertMedia(t *testing.T) {
 	tests := []struct {
 		name          string
 		mockSetup     func(sqlmock.Sqlmock)
+		opts          []mediadb.Option
 		request       business.MediaResult
 		expectedError string
 		expectedID    int64
@@ -25,9 +30,39 @@ func TestInsertMedia(t *testing.T) {
 		{
 			name: "successful insert",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO media_result").
-					WithArgs("test", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs("test", sqlmock.AnyArg(), "term=test", sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			request: business.MediaResult{
+				SearchTerm: "test",
+				SearchKey:  "term=test",
+				Media: []business.Media{
+					{
+						WrapperType: "track",
+						Kind:        "song",
+						ArtistID:    123,
+					},
+				},
+			},
+			expectedError: "",
+			expectedID:    1,
+		},
+		{
+			name: "successful insert with outbox events",
+			opts: []mediadb.Option{mediadb.WithOutboxEvents()},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO media_result").
+					WithArgs("test", sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				// a search.performed event followed by a media.discovered one.
+				mock.ExpectExec("INSERT INTO outbox_event").
+					WithArgs(pq.Array([]string{"search.performed", "media.discovered"}), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
 			},
 			request: business.MediaResult{
 				SearchTerm: "test",
@@ -45,9 +80,11 @@ func TestInsertMedia(t *testing.T) {
 		{
 			name: "insert error",
 			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO media_result").
-					WithArgs("test", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WithArgs("test", sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
 			},
 			request: business.MediaResult{
 				SearchTerm: "test",
@@ -62,6 +99,30 @@ func TestInsertMedia(t *testing.T) {
 			expectedError: "failed to insert media to db: insert error",
 			expectedID:    0,
 		},
+		{
+			name: "outbox error rolls back the insert",
+			opts: []mediadb.Option{mediadb.WithOutboxEvents()},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO media_result").
+					WithArgs("test", sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO outbox_event").WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+			},
+			request: business.MediaResult{
+				SearchTerm: "test",
+				Media: []business.Media{
+					{
+						WrapperType: "track",
+						Kind:        "song",
+						ArtistID:    123,
+					},
+				},
+			},
+			expectedError: "failed to insert outbox events to db: insert error",
+			expectedID:    0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -74,7 +135,7 @@ func TestInsertMedia(t *testing.T) {
 
 			tt.mockSetup(mock)
 
-			repo := mediadb.NewMediaRepository(sqlxDB)
+			repo := mediadb.NewMediaRepository(sqlxDB, tt.opts...)
 			id, err := repo.InsertMedia(context.Background(), tt.request)
 
 			if tt.expectedError != "" {
@@ -90,6 +151,203 @@ func TestInsertMedia(t *testing.T) {
 	}
 }
 
+func TestListMediaResults(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "search_term", "returned_result", "created_at", "updated_at"}
+
+	tests := []struct {
+		name           string
+		filter         business.HistoryFilter
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedResult []business.MediaResult
+	}{
+		{
+			name:   "filtered by term and time range",
+			filter: business.HistoryFilter{Term: "Jack", Since: createdAt.Add(-time.Hour), Until: createdAt.Add(time.Hour), Limit: 10, Offset: 5},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM media_result\s+WHERE lower\(search_term\) = lower\(\$1\) AND created_at >= \$2 AND created_at < \$3\s+ORDER BY created_at DESC, id DESC\s+LIMIT \$4 OFFSET \$5`).
+					WithArgs("Jack", createdAt.Add(-time.Hour), createdAt.Add(time.Hour), 10, 5).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, "jack", []byte(`[{"wrapperType":"track","kind":"song","trackId":456}]`), createdAt, createdAt))
+			},
+			expectedResult: []business.MediaResult{
+				{
+					ID:          2,
+					SearchTerm:  "jack",
+					Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456}},
+					ResultCount: 1,
+					FetchedAt:   createdAt,
+				},
+			},
+		},
+		{
+			name:   "unfiltered",
+			filter: business.HistoryFilter{Limit: 20},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM media_result\s+ORDER BY created_at DESC, id DESC\s+LIMIT \$1 OFFSET \$2`).
+					WithArgs(20, 0).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "", nil, createdAt, createdAt))
+			},
+			expectedResult: []business.MediaResult{{ID: 1, Media: []business.Media{}, FetchedAt: createdAt}},
+		},
+		{
+			name:   "query error",
+			filter: business.HistoryFilter{Limit: 20},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM media_result").WillReturnError(fmt.Errorf("select error"))
+			},
+			expectedError: "failed to list media results from db: select error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := mediadb.NewMediaRepository(sqlx.NewDb(db, "sqlmock"))
+			result, err := repo.ListMediaResults(context.Background(), tt.filter)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetMediaResults(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "search_term", "returned_result", "created_at", "updated_at"}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedResult []business.MediaResult
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM media_result\s+WHERE id = ANY\(\$1\)`).
+					WithArgs(pq.Array([]int64{1, 2})).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, "jack", []byte(`[{"wrapperType":"track","kind":"song","trackId":456}]`), createdAt, createdAt))
+			},
+			expectedResult: []business.MediaResult{
+				{
+					ID:          2,
+					SearchTerm:  "jack",
+					Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456}},
+					ResultCount: 1,
+					FetchedAt:   createdAt,
+				},
+			},
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM media_result").WillReturnError(fmt.Errorf("select error"))
+			},
+			expectedError: "failed to get media results from db: select error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := mediadb.NewMediaRepository(sqlx.NewDb(db, "sqlmock"))
+			result, err := repo.GetMediaResults(context.Background(), []int64{1, 2})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetLatestMediaResult(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	since := createdAt.Add(-5 * time.Minute)
+	columns := []string{"id", "search_term", "returned_result", "search_key", "created_at", "updated_at"}
+
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedResult business.MediaResult
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM media_result\s+WHERE search_key = \$1 AND created_at >= \$2\s+ORDER BY created_at DESC, id DESC\s+LIMIT 1`).
+					WithArgs("term=jack", since).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, "jack", []byte(`[{"wrapperType":"track","kind":"song","trackId":456}]`), "term=jack", createdAt, createdAt))
+			},
+			expectedResult: business.MediaResult{
+				ID:          2,
+				SearchTerm:  "jack",
+				Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456}},
+				ResultCount: 1,
+				FetchedAt:   createdAt,
+				SearchKey:   "term=jack",
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM media_result").WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM media_result").WillReturnError(fmt.Errorf("select error"))
+			},
+			expectedError: "failed to get latest media result from db: select error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := mediadb.NewMediaRepository(sqlx.NewDb(db, "sqlmock"))
+			result, err := repo.GetLatestMediaResult(context.Background(), "term=jack", since)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestMedias_Scan(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -186,3 +444,88 @@ func TestMedias_Value(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamMediaResults(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "search_term", "returned_result", "created_at", "updated_at"}
+	// fullBatch fills a whole fetch so the cursor is read again.
+	fullBatch := sqlmock.NewRows(columns)
+	for i := range 500 {
+		fullBatch.AddRow(1000-i, "jack", []byte(`[]`), createdAt, createdAt)
+	}
+
+	tests := []struct {
+		name          string
+		filter        business.HistoryFilter
+		fnErr         error
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+		expectedCount int
+	}{
+		{
+			name:   "fetches until a partial batch",
+			filter: business.HistoryFilter{Term: "jack", Offset: 5},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DECLARE media_result_export NO SCROLL CURSOR FOR\s+SELECT (.+) FROM media_result\s+WHERE lower\(search_term\) = lower\(\$1\)\s+ORDER BY created_at DESC, id DESC\s+LIMIT \$2 OFFSET \$3`).
+					WithArgs("jack", sql.NullInt64{}, 5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`FETCH 500 FROM media_result_export`).WillReturnRows(fullBatch)
+				mock.ExpectQuery(`FETCH 500 FROM media_result_export`).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "jack", []byte(`[{"trackId":456}]`), createdAt, createdAt))
+				mock.ExpectRollback()
+			},
+			expectedCount: 501,
+		},
+		{
+			name:   "fn error stops the stream",
+			filter: business.HistoryFilter{Limit: 10},
+			fnErr:  errors.New("broken pipe"),
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DECLARE media_result_export`).
+					WithArgs(sql.NullInt64{Int64: 10, Valid: true}, 0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`FETCH 500 FROM media_result_export`).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "jack", []byte(`[]`), createdAt, createdAt))
+				mock.ExpectRollback()
+			},
+			expectedError: "broken pipe",
+			expectedCount: 1,
+		},
+		{
+			name: "declare error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DECLARE media_result_export`).WillReturnError(fmt.Errorf("declare error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to declare export cursor: declare error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := mediadb.NewMediaRepository(sqlx.NewDb(db, "sqlmock"))
+			var count int
+			err = repo.StreamMediaResults(context.Background(), tt.filter, func(business.MediaResult) error {
+				count++
+				return tt.fnErr
+			})
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCount, count)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -35,6 +35,81 @@ func (m *MocksearcherClient) EXPECT() *MocksearcherClientMockRecorder {
 	return m.recorder
 }
 
+// Lookup mocks base method.
+func (m *MocksearcherClient) Lookup(ctx context.Context, ids ...int) (itunes.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Lookup", varargs...)
+	ret0, _ := ret[0].(itunes.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MocksearcherClientMockRecorder) Lookup(ctx interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MocksearcherClient)(nil).Lookup), varargs...)
+}
+
+// LookupEntity mocks base method.
+func (m *MocksearcherClient) LookupEntity(ctx context.Context, entity string, limit int, ids ...int) (itunes.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, entity, limit}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LookupEntity", varargs...)
+	ret0, _ := ret[0].(itunes.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupEntity indicates an expected call of LookupEntity.
+func (mr *MocksearcherClientMockRecorder) LookupEntity(ctx, entity, limit interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, entity, limit}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupEntity", reflect.TypeOf((*MocksearcherClient)(nil).LookupEntity), varargs...)
+}
+
+// LookupInCountry mocks base method.
+func (m *MocksearcherClient) LookupInCountry(ctx context.Context, country string, ids ...int) (itunes.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, country}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LookupInCountry", varargs...)
+	ret0, _ := ret[0].(itunes.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupInCountry indicates an expected call of LookupInCountry.
+func (mr *MocksearcherClientMockRecorder) LookupInCountry(ctx, country interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, country}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupInCountry", reflect.TypeOf((*MocksearcherClient)(nil).LookupInCountry), varargs...)
+}
+
+// LookupTVSeason mocks base method.
+func (m *MocksearcherClient) LookupTVSeason(ctx context.Context, collectionID int) (itunes.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupTVSeason", ctx, collectionID)
+	ret0, _ := ret[0].(itunes.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupTVSeason indicates an expected call of LookupTVSeason.
+func (mr *MocksearcherClientMockRecorder) LookupTVSeason(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupTVSeason", reflect.TypeOf((*MocksearcherClient)(nil).LookupTVSeason), ctx, collectionID)
+}
+
 // Search mocks base method.
 func (m *MocksearcherClient) Search(ctx context.Context, term string, limit int) (itunes.SearchResponse, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +124,18 @@ func (mr *MocksearcherClientMockRecorder) Search(ctx, term, limit interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MocksearcherClient)(nil).Search), ctx, term, limit)
 }
+
+// SearchMedia mocks base method.
+func (m *MocksearcherClient) SearchMedia(ctx context.Context, term, media string, limit int) (itunes.SearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMedia", ctx, term, media, limit)
+	ret0, _ := ret[0].(itunes.SearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMedia indicates an expected call of SearchMedia.
+func (mr *MocksearcherClientMockRecorder) SearchMedia(ctx, term, media, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMedia", reflect.TypeOf((*MocksearcherClient)(nil).SearchMedia), ctx, term, media, limit)
+}
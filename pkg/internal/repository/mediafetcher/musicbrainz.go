@@ -0,0 +1,82 @@
+package mediafetcher
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/musicbrainz"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/samber/lo"
+)
+
+const (
+	// MusicBrainzProvider is the name of the MusicBrainz provider.
+	MusicBrainzProvider = "musicbrainz"
+	// maxMusicBrainzLimit is the most recordings searched at once, the upper bound of the MusicBrainz API.
+	maxMusicBrainzLimit = 100
+	musicBrainzURL      = "https://musicbrainz.org"
+)
+
+// MusicBrainzFetcher is a provider searching recordings in MusicBrainz.
+type MusicBrainzFetcher struct {
+	client *musicbrainz.Client
+}
+
+// NewMusicBrainzFetcher creates a new instance of MusicBrainzFetcher.
+func NewMusicBrainzFetcher(client *musicbrainz.Client) *MusicBrainzFetcher {
+	return &MusicBrainzFetcher{client: client}
+}
+
+// Name returns the name of the MusicBrainz provider.
+func (f *MusicBrainzFetcher) Name() string {
+	return MusicBrainzProvider
+}
+
+// Search fetches the recordings matching term from MusicBrainz as songs.
+func (f *MusicBrainzFetcher) Search(ctx context.Context, term string, limit int) ([]business.Media, error) {
+	response, err := f.client.SearchRecordings(ctx, term, min(limit, maxMusicBrainzLimit))
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(response.Recordings, mapRecording), nil
+}
+
+// mapRecording maps a MusicBrainz recording to a business.Media, the recording is described by the first
+// release it appears on and its first credited artist. Its tags are its genres, the most voted one first.
+func mapRecording(r musicbrainz.Recording, _ int) business.Media {
+	media := business.Media{
+		WrapperType:     "track",
+		Kind:            "song",
+		TrackName:       r.Title,
+		TrackViewURL:    musicBrainzURL + "/recording/" + r.ID,
+		TrackTimeMillis: r.Length,
+		ReleaseDate:     r.FirstReleaseDate,
+		Source:          MusicBrainzProvider,
+		SourceID:        r.ID,
+	}
+	if len(r.ArtistCredit) > 0 {
+		var name strings.Builder
+		for _, credit := range r.ArtistCredit {
+			name.WriteString(credit.Name + credit.JoinPhrase)
+		}
+		media.ArtistName = name.String()
+		media.ArtistViewURL = musicBrainzURL + "/artist/" + r.ArtistCredit[0].Artist.ID
+	}
+	if len(r.ISRCs) > 0 {
+		media.ISRC = r.ISRCs[0]
+	}
+	if len(r.Releases) > 0 {
+		media.CollectionName = r.Releases[0].Title
+		media.CollectionViewURL = musicBrainzURL + "/release/" + r.Releases[0].ID
+		media.Country = r.Releases[0].Country
+	}
+	if len(r.Tags) > 0 {
+		tags := slices.Clone(r.Tags)
+		slices.SortStableFunc(tags, func(a, b musicbrainz.Tag) int { return cmp.Compare(b.Count, a.Count) })
+		media.Genres = lo.Map(tags, func(t musicbrainz.Tag, _ int) string { return t.Name })
+		media.PrimaryGenreName = media.Genres[0]
+	}
+	return media
+}
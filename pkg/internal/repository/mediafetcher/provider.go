@@ -0,0 +1,104 @@
+package mediafetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+)
+
+// Provider is a source media is searched in, e.g. iTunes or MusicBrainz. Providers map their own payloads to
+// business.Media and set its Source to their name.
+type Provider interface {
+	Name() string
+	Search(ctx context.Context, term string, limit int) ([]business.Media, error)
+}
+
+// MediaTypeSearcher is a Provider able to restrict a search to a media type, e.g. music or software.
+type MediaTypeSearcher interface {
+	SearchMediaType(ctx context.Context, term, media string, limit int) ([]business.Media, error)
+}
+
+// Registry holds the providers media can be searched in by name.
+type Registry struct {
+	providers map[string]Provider
+	names     []string
+}
+
+// NewRegistry creates a new instance of Registry, the first provider is the default one.
+// A provider registered twice replaces the first one under its name.
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		if _, ok := r.providers[p.Name()]; !ok {
+			r.names = append(r.names, p.Name())
+		}
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+// Names returns the names of the registered providers, the default one first.
+func (r *Registry) Names() []string {
+	return r.names
+}
+
+// HasProvider reports whether a provider is registered under name.
+func (r *Registry) HasProvider(name string) bool {
+	_, ok := r.providers[name]
+	return ok
+}
+
+// Provider returns the provider with the given name, the default one when name is empty.
+func (r *Registry) Provider(name string) (Provider, error) {
+	if name == "" && len(r.names) > 0 {
+		name = r.names[0]
+	}
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", business.ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// FetchMediaByTerm searches term in the provider with the given name, the default one when provider is empty.
+func (r *Registry) FetchMediaByTerm(ctx context.Context, provider, term string, limit int) (business.MediaResult, error) {
+	p, err := r.Provider(provider)
+	if err != nil {
+		return business.MediaResult{}, err
+	}
+	media, err := p.Search(ctx, term, limit)
+	if err != nil {
+		return business.MediaResult{}, fmt.Errorf("failed to fetch media by term from %s: %w", p.Name(), err)
+	}
+	return newMediaResult(term, media), nil
+}
+
+// FetchMediaOfType searches term among the media of the given type in the provider with the given name, the default
+// one when provider is empty. business.ErrUnsupportedFilter is returned when the provider doesn't search by media type.
+func (r *Registry) FetchMediaOfType(ctx context.Context, provider, term, media string, limit int) (business.MediaResult, error) {
+	p, err := r.Provider(provider)
+	if err != nil {
+		return business.MediaResult{}, err
+	}
+	searcher, ok := p.(MediaTypeSearcher)
+	if !ok {
+		return business.MediaResult{}, fmt.Errorf("%w: %s doesn't search by media type", business.ErrUnsupportedFilter, p.Name())
+	}
+	found, err := searcher.SearchMediaType(ctx, term, media, limit)
+	if err != nil {
+		return business.MediaResult{}, fmt.Errorf("failed to fetch %s media by term from %s: %w", media, p.Name(), err)
+	}
+	return newMediaResult(term, found), nil
+}
+
+// newMediaResult returns the result of searching term, fetched now.
+func newMediaResult(term string, media []business.Media) business.MediaResult {
+	return business.MediaResult{
+		SearchTerm:  term,
+		ResultCount: len(media),
+		FetchedAt:   time.Now().UTC(),
+		Media:       media,
+	}
+}
@@ -0,0 +1,286 @@
+package mediafetcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/musicbrainz"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/tmdb"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRegistry_FetchMediaByTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	mb := newMusicBrainzServer(t)
+	registry := mediafetcher.NewRegistry(
+		mediafetcher.NewMediaFetcher(mockClient),
+		mediafetcher.NewMusicBrainzFetcher(musicbrainz.NewClient(trace.NewTracerProvider(), mb.URL, "media-scout-test/1.0")),
+	)
+
+	assert.Equal(t, []string{"itunes", "musicbrainz"}, registry.Names())
+	assert.True(t, registry.HasProvider("musicbrainz"))
+	assert.False(t, registry.HasProvider("spotify"))
+
+	t.Run("default provider", func(t *testing.T) {
+		mockClient.EXPECT().Search(gomock.Any(), "test", 1).Return(itunes.SearchResponse{
+			ResultCount: 1,
+			Results:     []itunes.Media{{WrapperType: "track", Kind: "song", TrackID: 456}},
+		}, nil)
+
+		result, err := registry.FetchMediaByTerm(context.Background(), "", "test", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "test", result.SearchTerm)
+		assert.Equal(t, 1, result.ResultCount)
+		assert.Equal(t, []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456, Source: "itunes"}}, result.Media)
+		assert.False(t, result.FetchedAt.IsZero())
+	})
+
+	t.Run("named provider", func(t *testing.T) {
+		result, err := registry.FetchMediaByTerm(context.Background(), "musicbrainz", "test", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.ResultCount)
+		assert.Equal(t, "musicbrainz", result.Media[0].Source)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := registry.FetchMediaByTerm(context.Background(), "spotify", "test", 1)
+
+		assert.ErrorIs(t, err, business.ErrUnknownProvider)
+	})
+}
+
+func TestRegistry_FetchMediaOfType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	mb := newMusicBrainzServer(t)
+	registry := mediafetcher.NewRegistry(
+		mediafetcher.NewMediaFetcher(mockClient),
+		mediafetcher.NewMusicBrainzFetcher(musicbrainz.NewClient(trace.NewTracerProvider(), mb.URL, "media-scout-test/1.0")),
+	)
+
+	t.Run("software is mapped", func(t *testing.T) {
+		mockClient.EXPECT().SearchMedia(gomock.Any(), "notes", "software", 1).Return(itunes.SearchResponse{
+			ResultCount: 1,
+			Results: []itunes.Media{{
+				WrapperType:       "software",
+				Kind:              "software",
+				TrackID:           1,
+				TrackName:         "Notes",
+				BundleID:          "com.example.notes",
+				AverageUserRating: 4.5,
+				UserRatingCount:   1200,
+				ScreenshotURLs:    []string{"https://is1.example/1.png"},
+				SupportedDevices:  []string{"iPhone15-iPhone15", "iPadAir2-iPadAir2"},
+				FileSizeBytes:     "104857600",
+				MinimumOSVersion:  "16.0",
+				SellerName:        "Example Inc.",
+				Price:             2.99,
+				FormattedPrice:    "$2.99",
+			}},
+		}, nil)
+
+		result, err := registry.FetchMediaOfType(context.Background(), "", "notes", "software", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []business.Media{{
+			WrapperType: "software",
+			Kind:        "software",
+			TrackID:     1,
+			TrackName:   "Notes",
+			Source:      "itunes",
+			Software: &business.Software{
+				BundleID:          "com.example.notes",
+				AverageUserRating: 4.5,
+				UserRatingCount:   1200,
+				ScreenshotURLs:    []string{"https://is1.example/1.png"},
+				SupportedDevices:  []string{"iPhone15-iPhone15", "iPadAir2-iPadAir2"},
+				FileSizeBytes:     104857600,
+				MinimumOSVersion:  "16.0",
+				SellerName:        "Example Inc.",
+				Price:             2.99,
+				FormattedPrice:    "$2.99",
+			},
+		}}, result.Media)
+	})
+
+	t.Run("provider without media types", func(t *testing.T) {
+		_, err := registry.FetchMediaOfType(context.Background(), "musicbrainz", "notes", "software", 1)
+
+		assert.ErrorIs(t, err, business.ErrUnsupportedFilter)
+	})
+}
+
+func TestMusicBrainzFetcher_Search(t *testing.T) {
+	t.Run("recordings are mapped to songs", func(t *testing.T) {
+		srv := newMusicBrainzServer(t)
+		fetcher := mediafetcher.NewMusicBrainzFetcher(musicbrainz.NewClient(trace.NewTracerProvider(), srv.URL, "media-scout-test/1.0"))
+
+		media, err := fetcher.Search(context.Background(), "test", 500)
+
+		require.NoError(t, err)
+		assert.Equal(t, []business.Media{{
+			WrapperType:       "track",
+			Kind:              "song",
+			ArtistName:        "Test Artist feat. Guest",
+			CollectionName:    "Test Album",
+			TrackName:         "Test Track",
+			ArtistViewURL:     "https://musicbrainz.org/artist/a1",
+			CollectionViewURL: "https://musicbrainz.org/release/r1",
+			TrackViewURL:      "https://musicbrainz.org/recording/rec1",
+			ReleaseDate:       "2026-10-16",
+			TrackTimeMillis:   215000,
+			Country:           "GB",
+			PrimaryGenreName:  "rock",
+			Genres:            []string{"rock", "pop"},
+			Source:            "musicbrainz",
+			SourceID:          "rec1",
+			ISRC:              "GBAYE2600001",
+		}}, media)
+	})
+
+	t.Run("non-200 response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+		fetcher := mediafetcher.NewMusicBrainzFetcher(musicbrainz.NewClient(trace.NewTracerProvider(), srv.URL, "media-scout-test/1.0"))
+
+		_, err := fetcher.Search(context.Background(), "test", 1)
+
+		assert.EqualError(t, err, "received non-200 response code: 503")
+	})
+}
+
+func TestTMDBFetcher_Search(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/multi" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		response := tmdb.SearchResponse{TotalPages: 2}
+		if page == "1" {
+			response.Results = []tmdb.Result{
+				{ID: 1, MediaType: "person", Name: "Test Person"},
+				{ID: 2, MediaType: "movie", Title: "Test Movie", ReleaseDate: "2026-10-16", PosterPath: "/poster.jpg", GenreIDs: []int{18}},
+			}
+		} else {
+			response.Results = []tmdb.Result{
+				{ID: 3, MediaType: "tv", Name: "Test Show", FirstAirDate: "2025-01-01", OriginCountry: []string{"US"}},
+				{ID: 4, MediaType: "movie", Title: "Other Movie"},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer srv.Close()
+
+	t.Run("pages are fetched until the limit is reached", func(t *testing.T) {
+		pages = nil
+		fetcher := mediafetcher.NewTMDBFetcher(tmdb.NewClient(trace.NewTracerProvider(), srv.URL, "token"))
+
+		media, err := fetcher.Search(context.Background(), "test", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, pages)
+		assert.Equal(t, []business.Media{
+			{
+				WrapperType:   "track",
+				Kind:          "feature-movie",
+				TrackID:       2,
+				TrackName:     "Test Movie",
+				TrackViewURL:  "https://www.themoviedb.org/movie/2",
+				ArtworkURL100: "https://image.tmdb.org/t/p/w92/poster.jpg",
+				ArtworkURL600: "https://image.tmdb.org/t/p/w500/poster.jpg",
+				ReleaseDate:   "2026-10-16",
+				GenreIDs:      []string{"18"},
+				Source:        "tmdb",
+				SourceID:      "2",
+			},
+			{
+				WrapperType:       "collection",
+				Kind:              "tv-show",
+				CollectionID:      3,
+				CollectionName:    "Test Show",
+				CollectionViewURL: "https://www.themoviedb.org/tv/3",
+				ReleaseDate:       "2025-01-01",
+				Country:           "US",
+				GenreIDs:          []string{},
+				Source:            "tmdb",
+				SourceID:          "3",
+			},
+		}, media)
+	})
+
+	t.Run("search stops at the last page", func(t *testing.T) {
+		pages = nil
+		fetcher := mediafetcher.NewTMDBFetcher(tmdb.NewClient(trace.NewTracerProvider(), srv.URL, "token"))
+
+		media, err := fetcher.Search(context.Background(), "test", 50)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, pages)
+		assert.Len(t, media, 3)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		fetcher := mediafetcher.NewTMDBFetcher(tmdb.NewClient(trace.NewTracerProvider(), srv.URL, "invalid"))
+
+		_, err := fetcher.Search(context.Background(), "test", 1)
+
+		assert.EqualError(t, err, "received non-200 response code: 401")
+	})
+}
+
+// newMusicBrainzServer starts a fake MusicBrainz API answering recording searches with a single recording,
+// it rejects requests without a user agent like the real one.
+func newMusicBrainzServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/recording" || r.Header.Get("User-Agent") != "media-scout-test/1.0" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.Equal(t, "json", r.URL.Query().Get("fmt"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, limit, 100)
+		_ = json.NewEncoder(w).Encode(musicbrainz.SearchResponse{
+			Count: 1,
+			Recordings: []musicbrainz.Recording{{
+				ID:               "rec1",
+				Title:            "Test Track",
+				Length:           215000,
+				FirstReleaseDate: "2026-10-16",
+				ArtistCredit: []musicbrainz.ArtistCredit{
+					{Name: "Test Artist", JoinPhrase: " feat. ", Artist: musicbrainz.Artist{ID: "a1"}},
+					{Name: "Guest", Artist: musicbrainz.Artist{ID: "a2"}},
+				},
+				Releases: []musicbrainz.Release{{ID: "r1", Title: "Test Album", Country: "GB"}},
+				Tags:     []musicbrainz.Tag{{Name: "pop", Count: 1}, {Name: "rock", Count: 3}},
+				ISRCs:    []string{"GBAYE2600001"},
+			}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
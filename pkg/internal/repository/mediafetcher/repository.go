@@ -3,16 +3,35 @@ package mediafetcher
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	"github.com/samber/lo"
 )
 
+const (
+	// maxArtistReleases is the most media looked up per artist, the upper bound of the iTunes API.
+	maxArtistReleases = 200
+	// ITunesProvider is the name of the iTunes provider.
+	ITunesProvider = "itunes"
+)
+
+// seasonNumberPattern matches the season number iTunes puts at the end of the collection name of tv seasons and
+// episodes, e.g. "Breaking Bad, Season 2".
+var seasonNumberPattern = regexp.MustCompile(`(?i)\bseason (\d+)`)
+
 //go:generate mockgen -source=repository.go -destination=mock/repository.go -package=mock
 type (
 	searcherClient interface {
 		Search(ctx context.Context, term string, limit int) (itunes.SearchResponse, error)
+		SearchMedia(ctx context.Context, term, media string, limit int) (itunes.SearchResponse, error)
+		Lookup(ctx context.Context, ids ...int) (itunes.SearchResponse, error)
+		LookupEntity(ctx context.Context, entity string, limit int, ids ...int) (itunes.SearchResponse, error)
+		LookupTVSeason(ctx context.Context, collectionID int) (itunes.SearchResponse, error)
+		LookupInCountry(ctx context.Context, country string, ids ...int) (itunes.SearchResponse, error)
 	}
 )
 
@@ -26,47 +45,178 @@ func NewMediaFetcher(client searcherClient) *MediaFetcher {
 	return &MediaFetcher{client: client}
 }
 
-func (s *MediaFetcher) FetchMediaByTerm(ctx context.Context, term string, limit int) (business.MediaResult, error) {
+// Name returns the name of the iTunes provider.
+func (s *MediaFetcher) Name() string {
+	return ITunesProvider
+}
+
+// Search fetches the media matching term from iTunes.
+func (s *MediaFetcher) Search(ctx context.Context, term string, limit int) ([]business.Media, error) {
 	response, err := s.client.Search(ctx, term, limit)
 	if err != nil {
-		return business.MediaResult{}, fmt.Errorf("failed to fetch media by term: %w", err)
+		return nil, err
+	}
+	return lo.Map(response.Results, mapMedia), nil
+}
+
+// SearchMediaType fetches the media of the given iTunes media type, e.g. music or software, matching term from iTunes.
+func (s *MediaFetcher) SearchMediaType(ctx context.Context, term, media string, limit int) ([]business.Media, error) {
+	response, err := s.client.SearchMedia(ctx, term, media, limit)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(response.Results, mapMedia), nil
+}
+
+// LookupMediaByID fetches the media with the given iTunes id, the result is empty when the id is unknown.
+func (s *MediaFetcher) LookupMediaByID(ctx context.Context, id int) (business.MediaResult, error) {
+	return s.LookupMediaByIDs(ctx, []int{id})
+}
+
+// LookupMediaByIDs fetches the media with the given iTunes ids in a single call, unknown ids are left out of the result.
+func (s *MediaFetcher) LookupMediaByIDs(ctx context.Context, ids []int) (business.MediaResult, error) {
+	response, err := s.client.Lookup(ctx, ids...)
+	if err != nil {
+		return business.MediaResult{}, fmt.Errorf("failed to lookup media by id: %w", err)
+	}
+
+	return business.MediaResult{
+		ResultCount: response.ResultCount,
+		FetchedAt:   time.Now().UTC(),
+		Media:       lo.Map(response.Results, mapMedia),
+	}, nil
+}
+
+// LookupArtistReleases fetches the most recent media of the given entity, e.g. album or song, by the artist with
+// the given iTunes id. The artist itself is left out of the result.
+func (s *MediaFetcher) LookupArtistReleases(ctx context.Context, artistID int, entity string) ([]business.Media, error) {
+	response, err := s.client.LookupEntity(ctx, entity, maxArtistReleases, artistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup artist releases: %w", err)
 	}
+	releases := lo.Filter(response.Results, func(m itunes.Media, _ int) bool {
+		return m.WrapperType != "artist"
+	})
+	return lo.Map(releases, mapMedia), nil
+}
 
-	mediaResult := business.MediaResult{
-		SearchTerm:  term,
+// LookupTVSeason fetches the tv season with the given iTunes collection id along with its episodes, the result is
+// empty when the id is unknown.
+func (s *MediaFetcher) LookupTVSeason(ctx context.Context, collectionID int) (business.MediaResult, error) {
+	response, err := s.client.LookupTVSeason(ctx, collectionID)
+	if err != nil {
+		return business.MediaResult{}, fmt.Errorf("failed to lookup tv season: %w", err)
+	}
+	return business.MediaResult{
 		ResultCount: response.ResultCount,
-		Media: lo.Map(response.Results, func(m itunes.Media, _ int) business.Media {
-			return business.Media{
-				WrapperType:            m.WrapperType,
-				Kind:                   m.Kind,
-				ArtistID:               m.ArtistID,
-				CollectionID:           m.CollectionID,
-				TrackID:                m.TrackID,
-				ArtistName:             m.ArtistName,
-				CollectionName:         m.CollectionName,
-				TrackName:              m.TrackName,
-				ArtistViewURL:          m.ArtistViewURL,
-				CollectionViewURL:      m.CollectionViewURL,
-				FeedURL:                m.FeedURL,
-				TrackViewURL:           m.TrackViewURL,
-				ArtworkURL30:           m.ArtworkURL30,
-				ArtworkURL60:           m.ArtworkURL60,
-				ArtworkURL100:          m.ArtworkURL100,
-				ReleaseDate:            m.ReleaseDate,
-				CollectionExplicitness: m.CollectionExplicitness,
-				TrackExplicitness:      m.TrackExplicitness,
-				TrackCount:             m.TrackCount,
-				TrackTimeMillis:        m.TrackTimeMillis,
-				Country:                m.Country,
-				Currency:               m.Currency,
-				PrimaryGenreName:       m.PrimaryGenreName,
-				ContentAdvisoryRating:  m.ContentAdvisoryRating,
-				ArtworkURL600:          m.ArtworkURL600,
-				GenreIDs:               m.GenreIDs,
-				Genres:                 m.Genres,
-			}
-		}),
+		FetchedAt:   time.Now().UTC(),
+		Media:       lo.Map(response.Results, mapMedia),
+	}, nil
+}
+
+// LookupOffer fetches the media with the given iTunes id as sold in the storefront of country, business.ErrNotFound is
+// returned when it isn't sold there. Apps are priced by their price, tracks by their track price and collections by
+// their collection price.
+func (s *MediaFetcher) LookupOffer(ctx context.Context, id int, country string) (business.Offer, error) {
+	response, err := s.client.LookupInCountry(ctx, country, id)
+	if err != nil {
+		return business.Offer{}, fmt.Errorf("failed to lookup media in %s storefront: %w", country, err)
+	}
+	if len(response.Results) == 0 {
+		return business.Offer{}, fmt.Errorf("%w: media %d in %s storefront", business.ErrNotFound, id, country)
+	}
+	m := response.Results[0]
+	offer := business.Offer{Media: mapMedia(m, 0), Price: m.CollectionPrice, Currency: m.Currency}
+	switch {
+	case m.WrapperType == "software":
+		offer.Price = m.Price
+	case m.WrapperType == "track" && m.TrackID == id:
+		offer.Price = m.TrackPrice
+	}
+	return offer, nil
+}
+
+// mapMedia maps an iTunes media item to a business.Media.
+func mapMedia(m itunes.Media, _ int) business.Media {
+	return business.Media{
+		WrapperType:            m.WrapperType,
+		Kind:                   m.Kind,
+		ArtistID:               m.ArtistID,
+		CollectionID:           m.CollectionID,
+		TrackID:                m.TrackID,
+		ArtistName:             m.ArtistName,
+		CollectionName:         m.CollectionName,
+		TrackName:              m.TrackName,
+		ArtistViewURL:          m.ArtistViewURL,
+		CollectionViewURL:      m.CollectionViewURL,
+		FeedURL:                m.FeedURL,
+		TrackViewURL:           m.TrackViewURL,
+		ArtworkURL30:           m.ArtworkURL30,
+		ArtworkURL60:           m.ArtworkURL60,
+		ArtworkURL100:          m.ArtworkURL100,
+		ReleaseDate:            m.ReleaseDate,
+		CollectionExplicitness: m.CollectionExplicitness,
+		TrackExplicitness:      m.TrackExplicitness,
+		TrackCount:             m.TrackCount,
+		TrackTimeMillis:        m.TrackTimeMillis,
+		Country:                m.Country,
+		Currency:               m.Currency,
+		PrimaryGenreName:       m.PrimaryGenreName,
+		ContentAdvisoryRating:  m.ContentAdvisoryRating,
+		ArtworkURL600:          m.ArtworkURL600,
+		GenreIDs:               m.GenreIDs,
+		Genres:                 m.Genres,
+		Source:                 ITunesProvider,
+		Software:               mapSoftware(m),
+		Video:                  mapVideo(m),
+	}
+}
+
+// mapVideo maps the details of an iTunes movie, tv season or tv episode to a business.Video, it returns nil for the
+// other media.
+func mapVideo(m itunes.Media) *business.Video {
+	video := &business.Video{
+		LongDescription:    m.LongDescription,
+		ShortDescription:   m.ShortDescription,
+		TrackPrice:         m.TrackPrice,
+		TrackRentalPrice:   m.TrackRentalPrice,
+		TrackHDPrice:       m.TrackHDPrice,
+		TrackHDRentalPrice: m.TrackHDRentalPrice,
+		CollectionPrice:    m.CollectionPrice,
+		CollectionHDPrice:  m.CollectionHDPrice,
 	}
+	switch {
+	case m.Kind == "feature-movie":
+		return video
+	case m.Kind == "tv-episode":
+		video.EpisodeNumber = m.TrackNumber
+	case m.CollectionType == "TV Season":
+	default:
+		return nil
+	}
+	if match := seasonNumberPattern.FindStringSubmatch(m.CollectionName); match != nil {
+		video.SeasonNumber, _ = strconv.Atoi(match[1])
+	}
+	return video
+}
 
-	return mediaResult, nil
+// mapSoftware maps the App Store details of an iTunes software item to a business.Software, it returns nil for the
+// other media. A malformed file size is left out.
+func mapSoftware(m itunes.Media) *business.Software {
+	if m.WrapperType != "software" {
+		return nil
+	}
+	fileSize, _ := strconv.ParseInt(m.FileSizeBytes, 10, 64)
+	return &business.Software{
+		BundleID:          m.BundleID,
+		AverageUserRating: m.AverageUserRating,
+		UserRatingCount:   m.UserRatingCount,
+		ScreenshotURLs:    m.ScreenshotURLs,
+		SupportedDevices:  m.SupportedDevices,
+		FileSizeBytes:     fileSize,
+		MinimumOSVersion:  m.MinimumOSVersion,
+		SellerName:        m.SellerName,
+		Price:             m.Price,
+		FormattedPrice:    m.FormattedPrice,
+	}
 }
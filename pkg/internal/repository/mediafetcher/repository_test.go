@@ -10,9 +10,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
-func TestFetchMediaByTerm(t *testing.T) {
+func TestSearch(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -20,15 +21,15 @@ func TestFetchMediaByTerm(t *testing.T) {
 	fetcher := mediafetcher.NewMediaFetcher(mockClient)
 
 	tests := []struct {
-		name           string
-		term           string
-		limit          int
-		mockSetup      func()
-		expectedError  string
-		expectedResult business.MediaResult
+		name          string
+		term          string
+		limit         int
+		mockSetup     func()
+		expectedError string
+		expectedMedia []business.Media
 	}{
 		{
-			name:  "successful fetch",
+			name:  "successful search",
 			term:  "test",
 			limit: 1,
 			mockSetup: func() {
@@ -46,31 +47,26 @@ func TestFetchMediaByTerm(t *testing.T) {
 					},
 				}, nil)
 			},
-			expectedError: "",
-			expectedResult: business.MediaResult{
-				SearchTerm:  "test",
-				ResultCount: 1,
-				Media: []business.Media{
-					{
-						WrapperType: "track",
-						Kind:        "song",
-						ArtistID:    123,
-						TrackID:     456,
-						ArtistName:  "Test Artist",
-						TrackName:   "Test Track",
-					},
+			expectedMedia: []business.Media{
+				{
+					WrapperType: "track",
+					Kind:        "song",
+					ArtistID:    123,
+					TrackID:     456,
+					ArtistName:  "Test Artist",
+					TrackName:   "Test Track",
+					Source:      "itunes",
 				},
 			},
 		},
 		{
-			name:  "fetch error",
+			name:  "search error",
 			term:  "test",
 			limit: 1,
 			mockSetup: func() {
 				mockClient.EXPECT().Search(gomock.Any(), "test", 1).Return(itunes.SearchResponse{}, errors.New("search error"))
 			},
-			expectedError:  "failed to fetch media by term: search error",
-			expectedResult: business.MediaResult{},
+			expectedError: "search error",
 		},
 	}
 
@@ -78,15 +74,251 @@ func TestFetchMediaByTerm(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			result, err := fetcher.FetchMediaByTerm(context.Background(), tt.term, tt.limit)
+			media, err := fetcher.Search(context.Background(), tt.term, tt.limit)
 
 			if tt.expectedError != "" {
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.EqualError(t, err, tt.expectedError)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMedia, media)
+			}
+		})
+	}
+}
+
+func TestLookupMediaByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	fetcher := mediafetcher.NewMediaFetcher(mockClient)
+
+	tests := []struct {
+		name           string
+		id             int
+		mockSetup      func()
+		expectedError  string
+		expectedResult business.MediaResult
+	}{
+		{
+			name: "successful lookup",
+			id:   456,
+			mockSetup: func() {
+				mockClient.EXPECT().Lookup(gomock.Any(), 456).Return(itunes.SearchResponse{
+					ResultCount: 1,
+					Results:     []itunes.Media{{WrapperType: "track", Kind: "song", TrackID: 456, TrackName: "Test Track"}},
+				}, nil)
+			},
+			expectedResult: business.MediaResult{
+				ResultCount: 1,
+				Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456, TrackName: "Test Track", Source: "itunes"}},
+			},
+		},
+		{
+			name: "unknown id",
+			id:   1,
+			mockSetup: func() {
+				mockClient.EXPECT().Lookup(gomock.Any(), 1).Return(itunes.SearchResponse{}, nil)
+			},
+			expectedResult: business.MediaResult{Media: []business.Media{}},
+		},
+		{
+			name: "lookup error",
+			id:   456,
+			mockSetup: func() {
+				mockClient.EXPECT().Lookup(gomock.Any(), 456).Return(itunes.SearchResponse{}, errors.New("lookup error"))
+			},
+			expectedError: "failed to lookup media by id: lookup error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			result, err := fetcher.LookupMediaByID(context.Background(), tt.id)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				result.FetchedAt = time.Time{}
 				assert.Equal(t, tt.expectedResult, result)
 			}
 		})
 	}
 }
+
+func TestLookupMediaByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	fetcher := mediafetcher.NewMediaFetcher(mockClient)
+
+	mockClient.EXPECT().Lookup(gomock.Any(), 10, 456).Return(itunes.SearchResponse{
+		ResultCount: 2,
+		Results: []itunes.Media{
+			{WrapperType: "artist", ArtistID: 10, ArtistName: "Test Artist"},
+			{WrapperType: "track", Kind: "song", TrackID: 456, TrackName: "Test Track"},
+		},
+	}, nil)
+
+	result, err := fetcher.LookupMediaByIDs(context.Background(), []int{10, 456})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ResultCount)
+	assert.Equal(t, []business.Media{
+		{WrapperType: "artist", ArtistID: 10, ArtistName: "Test Artist", Source: "itunes"},
+		{WrapperType: "track", Kind: "song", TrackID: 456, TrackName: "Test Track", Source: "itunes"},
+	}, result.Media)
+}
+
+func TestLookupArtistReleases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	fetcher := mediafetcher.NewMediaFetcher(mockClient)
+
+	t.Run("artist is left out", func(t *testing.T) {
+		mockClient.EXPECT().LookupEntity(gomock.Any(), "album", 200, 10).Return(itunes.SearchResponse{
+			ResultCount: 2,
+			Results: []itunes.Media{
+				{WrapperType: "artist", ArtistID: 10, ArtistName: "Test Artist"},
+				{WrapperType: "collection", ArtistID: 10, CollectionID: 20, CollectionName: "Test Album", ReleaseDate: "2026-10-16T07:00:00Z"},
+			},
+		}, nil)
+
+		media, err := fetcher.LookupArtistReleases(context.Background(), 10, "album")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []business.Media{
+			{WrapperType: "collection", ArtistID: 10, CollectionID: 20, CollectionName: "Test Album", ReleaseDate: "2026-10-16T07:00:00Z", Source: "itunes"},
+		}, media)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		mockClient.EXPECT().LookupEntity(gomock.Any(), "song", 200, 10).Return(itunes.SearchResponse{}, errors.New("client error"))
+
+		_, err := fetcher.LookupArtistReleases(context.Background(), 10, "song")
+
+		assert.EqualError(t, err, "failed to lookup artist releases: client error")
+	})
+}
+
+func TestLookupTVSeason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	fetcher := mediafetcher.NewMediaFetcher(mockClient)
+
+	t.Run("season and episodes carry video details", func(t *testing.T) {
+		mockClient.EXPECT().LookupTVSeason(gomock.Any(), 20).Return(itunes.SearchResponse{
+			ResultCount: 2,
+			Results: []itunes.Media{
+				{WrapperType: "collection", CollectionType: "TV Season", CollectionID: 20, CollectionName: "Test Show, Season 2", CollectionPrice: 19.99, CollectionHDPrice: 24.99, LongDescription: "The second season."},
+				{WrapperType: "track", Kind: "tv-episode", CollectionID: 20, TrackID: 30, CollectionName: "Test Show, Season 2", TrackNumber: 3, TrackPrice: 2.99, TrackHDPrice: 3.99, ContentAdvisoryRating: "TV-14"},
+			},
+		}, nil)
+
+		result, err := fetcher.LookupTVSeason(context.Background(), 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []business.Media{
+			{WrapperType: "collection", CollectionID: 20, CollectionName: "Test Show, Season 2", Source: "itunes", Video: &business.Video{
+				LongDescription: "The second season.", CollectionPrice: 19.99, CollectionHDPrice: 24.99, SeasonNumber: 2,
+			}},
+			{WrapperType: "track", Kind: "tv-episode", CollectionID: 20, TrackID: 30, CollectionName: "Test Show, Season 2", ContentAdvisoryRating: "TV-14", Source: "itunes", Video: &business.Video{
+				TrackPrice: 2.99, TrackHDPrice: 3.99, SeasonNumber: 2, EpisodeNumber: 3,
+			}},
+		}, result.Media)
+	})
+
+	t.Run("movies carry video details", func(t *testing.T) {
+		mockClient.EXPECT().LookupTVSeason(gomock.Any(), 40).Return(itunes.SearchResponse{
+			ResultCount: 1,
+			Results:     []itunes.Media{{WrapperType: "track", Kind: "feature-movie", TrackID: 40, TrackRentalPrice: 3.99, TrackHDRentalPrice: 4.99}},
+		}, nil)
+
+		result, err := fetcher.LookupTVSeason(context.Background(), 40)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []business.Media{
+			{WrapperType: "track", Kind: "feature-movie", TrackID: 40, Source: "itunes", Video: &business.Video{TrackRentalPrice: 3.99, TrackHDRentalPrice: 4.99}},
+		}, result.Media)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		mockClient.EXPECT().LookupTVSeason(gomock.Any(), 20).Return(itunes.SearchResponse{}, errors.New("client error"))
+
+		_, err := fetcher.LookupTVSeason(context.Background(), 20)
+
+		assert.EqualError(t, err, "failed to lookup tv season: client error")
+	})
+}
+
+func TestLookupOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock.NewMocksearcherClient(ctrl)
+	fetcher := mediafetcher.NewMediaFetcher(mockClient)
+
+	tests := []struct {
+		name          string
+		id            int
+		response      itunes.SearchResponse
+		clientErr     error
+		expectedError string
+		expectedPrice float64
+	}{
+		{
+			name:          "track priced by its track price",
+			id:            456,
+			response:      itunes.SearchResponse{ResultCount: 1, Results: []itunes.Media{{WrapperType: "track", Kind: "song", TrackID: 456, TrackPrice: 0.99, CollectionPrice: 9.99, Currency: "GBP"}}},
+			expectedPrice: 0.99,
+		},
+		{
+			name:          "collection priced by its collection price",
+			id:            20,
+			response:      itunes.SearchResponse{ResultCount: 1, Results: []itunes.Media{{WrapperType: "collection", CollectionID: 20, CollectionPrice: 9.99, Currency: "GBP"}}},
+			expectedPrice: 9.99,
+		},
+		{
+			name:          "app priced by its price",
+			id:            30,
+			response:      itunes.SearchResponse{ResultCount: 1, Results: []itunes.Media{{WrapperType: "software", Kind: "software", TrackID: 30, Price: 2.49, Currency: "GBP"}}},
+			expectedPrice: 2.49,
+		},
+		{
+			name:          "not sold in the storefront",
+			id:            456,
+			expectedError: "not found: media 456 in gb storefront",
+		},
+		{
+			name:          "client error",
+			id:            456,
+			clientErr:     errors.New("client error"),
+			expectedError: "failed to lookup media in gb storefront: client error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient.EXPECT().LookupInCountry(gomock.Any(), "gb", tt.id).Return(tt.response, tt.clientErr)
+
+			offer, err := fetcher.LookupOffer(context.Background(), tt.id, "gb")
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPrice, offer.Price)
+				assert.Equal(t, "GBP", offer.Currency)
+				assert.Equal(t, "itunes", offer.Media.Source)
+			}
+		})
+	}
+}
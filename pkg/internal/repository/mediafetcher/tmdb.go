@@ -0,0 +1,95 @@
+package mediafetcher
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/tmdb"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/samber/lo"
+)
+
+const (
+	// TMDBProvider is the name of the TMDB provider.
+	TMDBProvider = "tmdb"
+	// maxTMDBPages is the most pages fetched by a search, TMDB pages hold 20 results.
+	maxTMDBPages  = 10
+	tmdbURL       = "https://www.themoviedb.org"
+	tmdbImagesURL = "https://image.tmdb.org/t/p"
+)
+
+// TMDBFetcher is a provider searching movies and tv shows in TMDB.
+type TMDBFetcher struct {
+	client *tmdb.Client
+}
+
+// NewTMDBFetcher creates a new instance of TMDBFetcher.
+func NewTMDBFetcher(client *tmdb.Client) *TMDBFetcher {
+	return &TMDBFetcher{client: client}
+}
+
+// Name returns the name of the TMDB provider.
+func (f *TMDBFetcher) Name() string {
+	return TMDBProvider
+}
+
+// Search fetches the movies and tv shows matching term from TMDB, the people matching it are left out.
+// Pages are fetched until limit media were found or the results ran out.
+func (f *TMDBFetcher) Search(ctx context.Context, term string, limit int) ([]business.Media, error) {
+	media := make([]business.Media, 0, limit)
+	for page := 1; page <= maxTMDBPages && len(media) < limit; page++ {
+		response, err := f.client.SearchMulti(ctx, term, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range response.Results {
+			if result.MediaType != tmdb.MediaTypeMovie && result.MediaType != tmdb.MediaTypeTV {
+				continue
+			}
+			media = append(media, mapTMDBResult(result))
+		}
+		if page >= response.TotalPages {
+			break
+		}
+	}
+	if len(media) > limit {
+		media = media[:limit]
+	}
+	return media, nil
+}
+
+// mapTMDBResult maps a TMDB movie to a feature movie track and a TMDB tv show to a tv show collection, their overview
+// is kept as their long description.
+func mapTMDBResult(r tmdb.Result) business.Media {
+	media := business.Media{
+		Source:   TMDBProvider,
+		SourceID: strconv.Itoa(r.ID),
+		GenreIDs: lo.Map(r.GenreIDs, func(id int, _ int) string { return strconv.Itoa(id) }),
+	}
+	if r.MediaType == tmdb.MediaTypeMovie {
+		media.WrapperType = "track"
+		media.Kind = "feature-movie"
+		media.TrackID = r.ID
+		media.TrackName = r.Title
+		media.TrackViewURL = tmdbURL + "/movie/" + media.SourceID
+		media.ReleaseDate = r.ReleaseDate
+	} else {
+		media.WrapperType = "collection"
+		media.Kind = "tv-show"
+		media.CollectionID = r.ID
+		media.CollectionName = r.Name
+		media.CollectionViewURL = tmdbURL + "/tv/" + media.SourceID
+		media.ReleaseDate = r.FirstAirDate
+	}
+	if r.PosterPath != "" {
+		media.ArtworkURL100 = tmdbImagesURL + "/w92" + r.PosterPath
+		media.ArtworkURL600 = tmdbImagesURL + "/w500" + r.PosterPath
+	}
+	if r.Overview != "" {
+		media.Video = &business.Video{LongDescription: r.Overview}
+	}
+	if len(r.OriginCountry) > 0 {
+		media.Country = r.OriginCountry[0]
+	}
+	return media
+}
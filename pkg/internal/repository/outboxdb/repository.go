@@ -0,0 +1,112 @@
+package outboxdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+)
+
+// Event represents a stored outbox event.
+type Event struct {
+	ID            int64          `db:"id"`
+	Type          string         `db:"type"`
+	Payload       []byte         `db:"payload"` // JSONB field
+	Attempts      int            `db:"attempts"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	LockedUntil   sql.NullTime   `db:"locked_until"`
+	LastError     sql.NullString `db:"last_error"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+const eventColumns = `id, type, payload, attempts, next_attempt_at, locked_until, last_error, created_at`
+
+// InsertOutboxEvents writes events to the outbox through tx, so they are only relayed once the change they report
+// is committed along with them. Events are due right away and get increasing ids in their given order.
+func InsertOutboxEvents(ctx context.Context, tx sqlx.ExecerContext, events []business.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	query := `
+		INSERT INTO outbox_event (type, payload, next_attempt_at, created_at)
+		SELECT e.type, e.payload::jsonb, e.created_at, e.created_at
+		FROM unnest($1::varchar[], $2::text[], $3::timestamp[]) WITH ORDINALITY AS e(type, payload, created_at, position)
+		ORDER BY e.position
+	`
+	types := lo.Map(events, func(e business.OutboxEvent, _ int) string { return string(e.Type) })
+	payloads := lo.Map(events, func(e business.OutboxEvent, _ int) string { return string(e.Payload) })
+	createdAt := lo.Map(events, func(e business.OutboxEvent, _ int) time.Time { return e.CreatedAt.UTC() })
+	if _, err := tx.ExecContext(ctx, query, pq.Array(types), pq.Array(payloads), pq.Array(createdAt)); err != nil {
+		return fmt.Errorf("failed to insert outbox events to db: %w", err)
+	}
+	return nil
+}
+
+// OutboxRepositoryImpl is the implementation of the outbox repository, events are a queue relays claim
+// from with FOR UPDATE SKIP LOCKED so every event is published by a single relay at a time.
+type OutboxRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepositoryImpl.
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepositoryImpl {
+	return &OutboxRepositoryImpl{db: db}
+}
+
+// mapEvent maps an Event to a business.OutboxEvent.
+func mapEvent(e Event, _ int) business.OutboxEvent {
+	return business.OutboxEvent{
+		ID:        e.ID,
+		Type:      business.DomainEventType(e.Type),
+		Payload:   e.Payload,
+		Attempts:  e.Attempts,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// ClaimOutboxEvents locks up to limit of the oldest due events until lockedUntil and counts the attempt,
+// events locked by another relay are skipped. Events whose lease expired are claimed again.
+func (repo *OutboxRepositoryImpl) ClaimOutboxEvents(ctx context.Context, limit int, now, lockedUntil time.Time) ([]business.OutboxEvent, error) {
+	query := `
+		UPDATE outbox_event
+		SET attempts = attempts + 1, locked_until = $3
+		WHERE id IN (
+			SELECT id FROM outbox_event
+			WHERE next_attempt_at <= $2 AND (locked_until IS NULL OR locked_until < $2)
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + eventColumns
+	var rows []Event
+	if err := repo.db.SelectContext(ctx, &rows, query, limit, now, lockedUntil); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events in db: %w", err)
+	}
+	return lo.Map(rows, mapEvent), nil
+}
+
+// DeleteOutboxEvents removes the published events with the given ids from the outbox.
+func (repo *OutboxRepositoryImpl) DeleteOutboxEvents(ctx context.Context, ids []int64) error {
+	if _, err := repo.db.ExecContext(ctx, `DELETE FROM outbox_event WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to delete outbox events from db: %w", err)
+	}
+	return nil
+}
+
+// ReleaseOutboxEvents unlocks the claimed events with the given ids so they are claimed again at nextAttemptAt.
+func (repo *OutboxRepositoryImpl) ReleaseOutboxEvents(ctx context.Context, ids []int64, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE outbox_event
+		SET locked_until = NULL, next_attempt_at = $2, last_error = $3
+		WHERE id = ANY($1)
+	`
+	if _, err := repo.db.ExecContext(ctx, query, pq.Array(ids), nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to release outbox events in db: %w", err)
+	}
+	return nil
+}
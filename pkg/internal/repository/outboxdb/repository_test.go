@@ -0,0 +1,147 @@
+package outboxdb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/outboxdb"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var eventColumns = []string{"id", "type", "payload", "attempts", "next_attempt_at", "locked_until", "last_error", "created_at"}
+
+func TestInsertOutboxEvents(t *testing.T) {
+	now := time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)
+	events := []business.OutboxEvent{
+		{Type: business.DomainEventSearchPerformed, Payload: []byte(`{"media_result_id":1}`), CreatedAt: now},
+		{Type: business.DomainEventMediaDiscovered, Payload: []byte(`{"media_result_id":1,"track_id":2}`), CreatedAt: now},
+	}
+	tests := []struct {
+		name          string
+		events        []business.OutboxEvent
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name:   "successful insert",
+			events: events,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO outbox_event (.+) FROM unnest(.+) WITH ORDINALITY").
+					WithArgs(
+						pq.Array([]string{"search.performed", "media.discovered"}),
+						pq.Array([]string{`{"media_result_id":1}`, `{"media_result_id":1,"track_id":2}`}),
+						pq.Array([]time.Time{now, now}),
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:      "no events",
+			mockSetup: func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:   "insert error",
+			events: events,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO outbox_event").WillReturnError(fmt.Errorf("db down"))
+			},
+			expectedError: "failed to insert outbox events to db: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			err = outboxdb.InsertOutboxEvents(context.Background(), sqlx.NewDb(db, "sqlmock"), tt.events)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestClaimOutboxEvents(t *testing.T) {
+	now := time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(time.Minute)
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedEvents []business.OutboxEvent
+	}{
+		{
+			name: "successful claim",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE outbox_event SET attempts = attempts \\+ 1(.+)FOR UPDATE SKIP LOCKED").
+					WithArgs(10, now, lockedUntil).
+					WillReturnRows(sqlmock.NewRows(eventColumns).
+						AddRow(1, "search.performed", []byte(`{"media_result_id":1}`), 1, now, lockedUntil, nil, now))
+			},
+			expectedEvents: []business.OutboxEvent{
+				{ID: 1, Type: business.DomainEventSearchPerformed, Payload: []byte(`{"media_result_id":1}`), Attempts: 1, CreatedAt: now},
+			},
+		},
+		{
+			name: "claim error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE outbox_event").WillReturnError(fmt.Errorf("db down"))
+			},
+			expectedError: "failed to claim outbox events in db: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := outboxdb.NewOutboxRepository(sqlx.NewDb(db, "sqlmock"))
+			events, err := repo.ClaimOutboxEvents(context.Background(), 10, now, lockedUntil)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedEvents, events)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReleaseOutboxEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	next := time.Date(2026, 10, 19, 16, 0, 1, 0, time.UTC)
+	mock.ExpectExec("UPDATE outbox_event SET locked_until = NULL").
+		WithArgs(pq.Array([]int64{2, 3}), next, "nats: timeout").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM outbox_event WHERE id = ANY").
+		WithArgs(pq.Array([]int64{1})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := outboxdb.NewOutboxRepository(sqlx.NewDb(db, "sqlmock"))
+	assert.NoError(t, repo.ReleaseOutboxEvents(context.Background(), []int64{2, 3}, "nats: timeout", next))
+	assert.NoError(t, repo.DeleteOutboxEvents(context.Background(), []int64{1}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,181 @@
+package podcastdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+)
+
+// Podcast represents a stored podcast.
+type Podcast struct {
+	CollectionID int            `db:"collection_id"`
+	Title        sql.NullString `db:"title"`
+	FeedURL      string         `db:"feed_url"`
+	ETag         sql.NullString `db:"etag"`
+	LastModified sql.NullString `db:"last_modified"`
+	RefreshedAt  sql.NullTime   `db:"refreshed_at"`
+}
+
+// Episode represents a stored episode of a podcast.
+type Episode struct {
+	ID              int64          `db:"id"`
+	CollectionID    int            `db:"collection_id"`
+	GUID            string         `db:"guid"`
+	Title           sql.NullString `db:"title"`
+	Description     sql.NullString `db:"description"`
+	Link            sql.NullString `db:"link"`
+	AudioURL        sql.NullString `db:"audio_url"`
+	AudioType       sql.NullString `db:"audio_type"`
+	PublishedAt     sql.NullTime   `db:"published_at"`
+	DurationSeconds int            `db:"duration_seconds"`
+}
+
+const podcastColumns = `collection_id, title, feed_url, etag, last_modified, refreshed_at`
+
+// PodcastRepositoryImpl is the implementation of the podcast repository, the refresher claims due podcasts
+// with FOR UPDATE SKIP LOCKED so every feed is refreshed by a single replica at a time.
+type PodcastRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewPodcastRepository creates a new instance of PodcastRepositoryImpl.
+func NewPodcastRepository(db *sqlx.DB) *PodcastRepositoryImpl {
+	return &PodcastRepositoryImpl{db: db}
+}
+
+// mapPodcast maps a Podcast to a business.Podcast.
+func mapPodcast(p Podcast, _ int) business.Podcast {
+	podcast := business.Podcast{
+		CollectionID: p.CollectionID,
+		Title:        p.Title.String,
+		FeedURL:      p.FeedURL,
+		ETag:         p.ETag.String,
+		LastModified: p.LastModified.String,
+	}
+	if p.RefreshedAt.Valid {
+		podcast.RefreshedAt = lo.ToPtr(p.RefreshedAt.Time)
+	}
+	return podcast
+}
+
+// GetPodcast returns the podcast with the given collection id, business.ErrNotFound is returned when there is none.
+func (repo *PodcastRepositoryImpl) GetPodcast(ctx context.Context, collectionID int) (business.Podcast, error) {
+	var podcast Podcast
+	if err := repo.db.GetContext(ctx, &podcast, `SELECT `+podcastColumns+` FROM podcast WHERE collection_id = $1`, collectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.Podcast{}, business.ErrNotFound
+		}
+		return business.Podcast{}, fmt.Errorf("failed to get podcast from db: %w", err)
+	}
+	return mapPodcast(podcast, 0), nil
+}
+
+// InsertPodcast inserts a new podcast due for a refresh right away and returns it, the podcast already stored is
+// returned when another request inserted it first.
+func (repo *PodcastRepositoryImpl) InsertPodcast(ctx context.Context, podcast business.Podcast) (business.Podcast, error) {
+	query := `
+		INSERT INTO podcast (collection_id, title, feed_url, next_refresh_at, created_at) VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (collection_id) DO NOTHING
+	`
+	if _, err := repo.db.ExecContext(ctx, query, podcast.CollectionID, podcast.Title, podcast.FeedURL, time.Now().UTC()); err != nil {
+		return business.Podcast{}, fmt.Errorf("failed to insert podcast to db: %w", err)
+	}
+	return repo.GetPodcast(ctx, podcast.CollectionID)
+}
+
+// ListPodcastEpisodes returns the episodes of a podcast, most recent first and undated ones last.
+func (repo *PodcastRepositoryImpl) ListPodcastEpisodes(ctx context.Context, collectionID int, limit, offset int) ([]business.Episode, error) {
+	query := `
+		SELECT id, collection_id, guid, title, description, link, audio_url, audio_type, published_at, duration_seconds
+		FROM podcast_episode
+		WHERE collection_id = $1
+		ORDER BY published_at DESC NULLS LAST, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	var episodes []Episode
+	if err := repo.db.SelectContext(ctx, &episodes, query, collectionID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list podcast episodes from db: %w", err)
+	}
+	return lo.Map(episodes, func(e Episode, _ int) business.Episode {
+		episode := business.Episode{
+			ID:              e.ID,
+			CollectionID:    e.CollectionID,
+			GUID:            e.GUID,
+			Title:           e.Title.String,
+			Description:     e.Description.String,
+			Link:            e.Link.String,
+			AudioURL:        e.AudioURL.String,
+			AudioType:       e.AudioType.String,
+			DurationSeconds: e.DurationSeconds,
+		}
+		if e.PublishedAt.Valid {
+			episode.PublishedAt = lo.ToPtr(e.PublishedAt.Time)
+		}
+		return episode
+	}), nil
+}
+
+// ClaimDuePodcasts pushes the next refresh of up to limit podcasts due at now back to nextRefreshAt and returns them,
+// podcasts claimed by another replica are skipped.
+func (repo *PodcastRepositoryImpl) ClaimDuePodcasts(ctx context.Context, now, nextRefreshAt time.Time, limit int) ([]business.Podcast, error) {
+	query := `
+		UPDATE podcast SET next_refresh_at = $2
+		WHERE collection_id IN (
+			SELECT collection_id FROM podcast
+			WHERE next_refresh_at <= $1
+			ORDER BY next_refresh_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + podcastColumns
+	var podcasts []Podcast
+	if err := repo.db.SelectContext(ctx, &podcasts, query, now, nextRefreshAt, limit); err != nil {
+		return nil, fmt.Errorf("failed to claim due podcasts in db: %w", err)
+	}
+	return lo.Map(podcasts, mapPodcast), nil
+}
+
+// SavePodcastRefresh upserts the episodes of a refreshed feed by GUID along with the validators of the feed and
+// returns the refreshed podcast.
+func (repo *PodcastRepositoryImpl) SavePodcastRefresh(ctx context.Context, refresh business.PodcastRefresh) (business.Podcast, error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return business.Podcast{}, fmt.Errorf("failed to begin podcast refresh tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		INSERT INTO podcast_episode (collection_id, guid, title, description, link, audio_url, audio_type, published_at, duration_seconds, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (collection_id, guid) DO UPDATE SET
+			title = EXCLUDED.title, description = EXCLUDED.description, link = EXCLUDED.link, audio_url = EXCLUDED.audio_url,
+			audio_type = EXCLUDED.audio_type, published_at = EXCLUDED.published_at, duration_seconds = EXCLUDED.duration_seconds
+	`
+	for _, e := range refresh.Episodes {
+		publishedAt := sql.NullTime{Time: lo.FromPtr(e.PublishedAt), Valid: e.PublishedAt != nil}
+		if _, err := tx.ExecContext(ctx, query, refresh.CollectionID, e.GUID, e.Title, e.Description, e.Link, e.AudioURL, e.AudioType, publishedAt, e.DurationSeconds, refresh.RefreshedAt); err != nil {
+			return business.Podcast{}, fmt.Errorf("failed to upsert podcast episode to db: %w", err)
+		}
+	}
+	query = `
+		UPDATE podcast SET title = COALESCE(NULLIF($2, ''), title), etag = $3, last_modified = $4, refreshed_at = $5, next_refresh_at = $6
+		WHERE collection_id = $1
+		RETURNING ` + podcastColumns
+	var podcast Podcast
+	if err := tx.GetContext(ctx, &podcast, query, refresh.CollectionID, refresh.Title, refresh.ETag, refresh.LastModified, refresh.RefreshedAt, refresh.NextRefreshAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.Podcast{}, business.ErrNotFound
+		}
+		return business.Podcast{}, fmt.Errorf("failed to update podcast in db: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return business.Podcast{}, fmt.Errorf("failed to commit podcast refresh tx: %w", err)
+	}
+	return mapPodcast(podcast, 0), nil
+}
@@ -0,0 +1,167 @@
+package podcastdb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/podcastdb"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var podcastColumns = []string{"collection_id", "title", "feed_url", "etag", "last_modified", "refreshed_at"}
+
+func TestGetPodcast(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		mockSetup       func(sqlmock.Sqlmock)
+		expectedError   string
+		expectedPodcast business.Podcast
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM podcast WHERE collection_id").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(podcastColumns).AddRow(5, "The Daily", "https://example.com/feed", `"v1"`, nil, now))
+			},
+			expectedPodcast: business.Podcast{CollectionID: 5, Title: "The Daily", FeedURL: "https://example.com/feed", ETag: `"v1"`, RefreshedAt: lo.ToPtr(now)},
+		},
+		{
+			name: "not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM podcast WHERE collection_id").WithArgs(5).WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM podcast WHERE collection_id").WithArgs(5).WillReturnError(fmt.Errorf("query error"))
+			},
+			expectedError: "failed to get podcast from db: query error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := podcastdb.NewPodcastRepository(sqlx.NewDb(db, "sqlmock"))
+			podcast, err := repo.GetPodcast(context.Background(), 5)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPodcast, podcast)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListPodcastEpisodes(t *testing.T) {
+	publishedAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("FROM podcast_episode").
+		WithArgs(5, 20, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "collection_id", "guid", "title", "description", "link", "audio_url", "audio_type", "published_at", "duration_seconds"}).
+			AddRow(2, 5, "ep-2", "Episode 2", "Second", nil, "https://cdn.example.com/2.mp3", "audio/mpeg", publishedAt, 3723).
+			AddRow(1, 5, "ep-1", "Episode 1", nil, nil, nil, nil, nil, 0))
+
+	repo := podcastdb.NewPodcastRepository(sqlx.NewDb(db, "sqlmock"))
+	episodes, err := repo.ListPodcastEpisodes(context.Background(), 5, 20, 40)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []business.Episode{
+		{ID: 2, CollectionID: 5, GUID: "ep-2", Title: "Episode 2", Description: "Second", AudioURL: "https://cdn.example.com/2.mp3", AudioType: "audio/mpeg", PublishedAt: lo.ToPtr(publishedAt), DurationSeconds: 3723},
+		{ID: 1, CollectionID: 5, GUID: "ep-1", Title: "Episode 1"},
+	}, episodes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSavePodcastRefresh(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	refresh := business.PodcastRefresh{
+		CollectionID:  5,
+		Title:         "The Daily",
+		ETag:          `"v2"`,
+		Episodes:      []business.Episode{{GUID: "ep-3", Title: "Episode 3", PublishedAt: lo.ToPtr(now)}},
+		RefreshedAt:   now,
+		NextRefreshAt: now.Add(time.Hour),
+	}
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "successful save",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO podcast_episode").
+					WithArgs(5, "ep-3", "Episode 3", "", "", "", "", sql.NullTime{Time: now, Valid: true}, 0, now).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectQuery("UPDATE podcast SET").
+					WithArgs(5, "The Daily", `"v2"`, "", now, now.Add(time.Hour)).
+					WillReturnRows(sqlmock.NewRows(podcastColumns).AddRow(5, "The Daily", "https://example.com/feed", `"v2"`, nil, now))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "upsert error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO podcast_episode").WillReturnError(fmt.Errorf("exec error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to upsert podcast episode to db: exec error",
+		},
+		{
+			name: "unknown podcast",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO podcast_episode").WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectQuery("UPDATE podcast SET").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := podcastdb.NewPodcastRepository(sqlx.NewDb(db, "sqlmock"))
+			podcast, err := repo.SavePodcastRefresh(context.Background(), refresh)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, business.Podcast{CollectionID: 5, Title: "The Daily", FeedURL: "https://example.com/feed", ETag: `"v2"`, RefreshedAt: lo.ToPtr(now)}, podcast)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package watchlistdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+)
+
+// Watchlist represents a stored watchlist.
+type Watchlist struct {
+	ID        int64     `db:"id"`
+	Owner     string    `db:"owner"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// WatchedArtist represents a stored artist checked for new releases, SubscribedAt is only set when it is read
+// through a watchlist and Owners when it is claimed.
+type WatchedArtist struct {
+	ArtistID        int            `db:"artist_id"`
+	ArtistName      sql.NullString `db:"artist_name"`
+	LastReleaseDate sql.NullTime   `db:"last_release_date"`
+	CheckedAt       sql.NullTime   `db:"checked_at"`
+	SubscribedAt    sql.NullTime   `db:"subscribed_at"`
+	Owners          pq.StringArray `db:"owners"`
+}
+
+// Release represents a stored release of a watched artist.
+type Release struct {
+	ID                int64          `db:"id"`
+	ArtistID          int            `db:"artist_id"`
+	ArtistName        sql.NullString `db:"artist_name"`
+	CollectionID      int            `db:"collection_id"`
+	CollectionName    sql.NullString `db:"collection_name"`
+	ReleaseDate       time.Time      `db:"release_date"`
+	CollectionViewURL sql.NullString `db:"collection_view_url"`
+	ArtworkURL100     sql.NullString `db:"artwork_url_100"`
+	DetectedAt        time.Time      `db:"detected_at"`
+}
+
+const releaseColumns = `r.id, r.artist_id, r.artist_name, r.collection_id, r.collection_name, r.release_date, r.collection_view_url, r.artwork_url_100, r.detected_at`
+
+// WatchlistRepositoryImpl is the implementation of the watchlist repository, the checker claims due artists
+// with FOR UPDATE SKIP LOCKED so every artist is checked by a single replica at a time.
+type WatchlistRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewWatchlistRepository creates a new instance of WatchlistRepositoryImpl.
+func NewWatchlistRepository(db *sqlx.DB) *WatchlistRepositoryImpl {
+	return &WatchlistRepositoryImpl{db: db}
+}
+
+// mapArtist maps a WatchedArtist to a business.WatchedArtist.
+func mapArtist(a WatchedArtist, _ int) business.WatchedArtist {
+	artist := business.WatchedArtist{ArtistID: a.ArtistID, ArtistName: a.ArtistName.String, SubscribedAt: a.SubscribedAt.Time, Owners: a.Owners}
+	if a.LastReleaseDate.Valid {
+		artist.LastReleaseDate = lo.ToPtr(a.LastReleaseDate.Time)
+	}
+	if a.CheckedAt.Valid {
+		artist.CheckedAt = lo.ToPtr(a.CheckedAt.Time)
+	}
+	return artist
+}
+
+// InsertWatchlist inserts a new watchlist of owner subscribed to the given artists, artists nobody watched yet are
+// due for a check right away.
+func (repo *WatchlistRepositoryImpl) InsertWatchlist(ctx context.Context, owner, name string, artistIDs []int) (business.Watchlist, error) {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return business.Watchlist{}, fmt.Errorf("failed to begin watchlist tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	var id int64
+	if err := tx.QueryRowxContext(ctx, `INSERT INTO watchlist (owner, name, created_at) VALUES ($1, $2, $3) RETURNING id`, owner, name, now).Scan(&id); err != nil {
+		return business.Watchlist{}, fmt.Errorf("failed to insert watchlist to db: %w", err)
+	}
+	for _, artistID := range artistIDs {
+		if err := subscribe(ctx, tx, id, artistID, now); err != nil {
+			return business.Watchlist{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return business.Watchlist{}, fmt.Errorf("failed to commit watchlist tx: %w", err)
+	}
+	return repo.GetWatchlist(ctx, owner, id)
+}
+
+// subscribe subscribes the watchlist with the given id to an artist, subscribing twice is a no-op.
+func subscribe(ctx context.Context, tx *sqlx.Tx, id int64, artistID int, now time.Time) error {
+	query := `INSERT INTO watched_artist (artist_id, next_check_at, created_at) VALUES ($1, $2, $2) ON CONFLICT (artist_id) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, artistID, now); err != nil {
+		return fmt.Errorf("failed to insert watched artist to db: %w", err)
+	}
+	query = `INSERT INTO watchlist_artist (watchlist_id, artist_id, created_at) VALUES ($1, $2, $3) ON CONFLICT (watchlist_id, artist_id) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, id, artistID, now); err != nil {
+		return fmt.Errorf("failed to insert watchlist artist to db: %w", err)
+	}
+	return nil
+}
+
+// GetWatchlist returns the watchlist of owner with the given id along with its artists in the order they were
+// subscribed to, business.ErrNotFound is returned when there is none.
+func (repo *WatchlistRepositoryImpl) GetWatchlist(ctx context.Context, owner string, id int64) (business.Watchlist, error) {
+	var dbWatchlist Watchlist
+	if err := repo.db.GetContext(ctx, &dbWatchlist, `SELECT id, owner, name, created_at FROM watchlist WHERE id = $1 AND owner = $2`, id, owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.Watchlist{}, business.ErrNotFound
+		}
+		return business.Watchlist{}, fmt.Errorf("failed to get watchlist from db: %w", err)
+	}
+	query := `
+		SELECT a.artist_id, a.artist_name, a.last_release_date, a.checked_at, wa.created_at AS subscribed_at
+		FROM watchlist_artist wa JOIN watched_artist a ON a.artist_id = wa.artist_id
+		WHERE wa.watchlist_id = $1
+		ORDER BY wa.created_at, wa.artist_id
+	`
+	var artists []WatchedArtist
+	if err := repo.db.SelectContext(ctx, &artists, query, id); err != nil {
+		return business.Watchlist{}, fmt.Errorf("failed to get watchlist artists from db: %w", err)
+	}
+	return business.Watchlist{
+		ID:        dbWatchlist.ID,
+		Owner:     dbWatchlist.Owner,
+		Name:      dbWatchlist.Name,
+		Artists:   lo.Map(artists, mapArtist),
+		CreatedAt: dbWatchlist.CreatedAt,
+	}, nil
+}
+
+// AddWatchlistArtist subscribes a watchlist of owner to an artist, business.ErrNotFound is returned when there is no
+// such watchlist.
+func (repo *WatchlistRepositoryImpl) AddWatchlistArtist(ctx context.Context, owner string, id int64, artistID int) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin watchlist artist tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM watchlist WHERE id = $1 AND owner = $2 FOR SHARE)`, id, owner); err != nil {
+		return fmt.Errorf("failed to get watchlist from db: %w", err)
+	}
+	if !exists {
+		return business.ErrNotFound
+	}
+	if err := subscribe(ctx, tx, id, artistID, time.Now().UTC()); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit watchlist artist tx: %w", err)
+	}
+	return nil
+}
+
+// RemoveWatchlistArtist unsubscribes a watchlist of owner from an artist, business.ErrNotFound is returned when it
+// wasn't subscribed. The artist stops being checked once no watchlist is subscribed to it.
+func (repo *WatchlistRepositoryImpl) RemoveWatchlistArtist(ctx context.Context, owner string, id int64, artistID int) error {
+	query := `
+		DELETE FROM watchlist_artist
+		WHERE watchlist_id = $1 AND artist_id = $2 AND watchlist_id IN (SELECT id FROM watchlist WHERE owner = $3)
+	`
+	res, err := repo.db.ExecContext(ctx, query, id, artistID, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist artist from db: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist artist from db: %w", err)
+	}
+	if affected == 0 {
+		return business.ErrNotFound
+	}
+	return nil
+}
+
+// ListWatchlistReleases returns the releases of the artists of a watchlist of owner detected since it subscribed to
+// them, most recent first.
+func (repo *WatchlistRepositoryImpl) ListWatchlistReleases(ctx context.Context, owner string, id int64, limit, offset int) ([]business.Release, error) {
+	query := `
+		SELECT ` + releaseColumns + `
+		FROM artist_release r
+		JOIN watchlist_artist wa ON wa.artist_id = r.artist_id
+		JOIN watchlist w ON w.id = wa.watchlist_id
+		WHERE wa.watchlist_id = $1 AND w.owner = $4 AND r.detected_at >= wa.created_at
+		ORDER BY r.release_date DESC, r.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	var releases []Release
+	if err := repo.db.SelectContext(ctx, &releases, query, id, limit, offset, owner); err != nil {
+		return nil, fmt.Errorf("failed to list watchlist releases from db: %w", err)
+	}
+	return lo.Map(releases, func(r Release, _ int) business.Release {
+		return business.Release{
+			ID:                r.ID,
+			ArtistID:          r.ArtistID,
+			ArtistName:        r.ArtistName.String,
+			CollectionID:      r.CollectionID,
+			CollectionName:    r.CollectionName.String,
+			ReleaseDate:       r.ReleaseDate,
+			CollectionViewURL: r.CollectionViewURL.String,
+			ArtworkURL100:     r.ArtworkURL100.String,
+			DetectedAt:        r.DetectedAt,
+		}
+	}), nil
+}
+
+// ClaimDueArtists pushes the next check of up to limit artists due at now back to nextCheckAt and returns them along
+// with the owners of the watchlists subscribed to them, artists claimed by another replica or without a watchlist
+// subscribed to them are skipped.
+func (repo *WatchlistRepositoryImpl) ClaimDueArtists(ctx context.Context, now, nextCheckAt time.Time, limit int) ([]business.WatchedArtist, error) {
+	query := `
+		UPDATE watched_artist SET next_check_at = $2
+		WHERE artist_id IN (
+			SELECT a.artist_id FROM watched_artist a
+			WHERE a.next_check_at <= $1 AND EXISTS (SELECT 1 FROM watchlist_artist wa WHERE wa.artist_id = a.artist_id)
+			ORDER BY a.next_check_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING artist_id, artist_name, last_release_date, checked_at, ARRAY(
+			SELECT DISTINCT w.owner FROM watchlist_artist wa JOIN watchlist w ON w.id = wa.watchlist_id
+			WHERE wa.artist_id = watched_artist.artist_id
+			ORDER BY w.owner
+		) AS owners
+	`
+	var artists []WatchedArtist
+	if err := repo.db.SelectContext(ctx, &artists, query, now, nextCheckAt, limit); err != nil {
+		return nil, fmt.Errorf("failed to claim due artists in db: %w", err)
+	}
+	return lo.Map(artists, mapArtist), nil
+}
+
+// SaveArtistCheck stores the new releases of a checked artist along with its last seen release, releases
+// already stored by an earlier check are skipped.
+func (repo *WatchlistRepositoryImpl) SaveArtistCheck(ctx context.Context, check business.ArtistCheck) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin artist check tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		INSERT INTO artist_release (artist_id, artist_name, collection_id, collection_name, release_date, collection_view_url, artwork_url_100, detected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (artist_id, collection_id) DO NOTHING
+	`
+	for _, r := range check.Releases {
+		if _, err := tx.ExecContext(ctx, query, check.ArtistID, r.ArtistName, r.CollectionID, r.CollectionName, r.ReleaseDate, r.CollectionViewURL, r.ArtworkURL100, check.CheckedAt); err != nil {
+			return fmt.Errorf("failed to insert artist release to db: %w", err)
+		}
+	}
+	query = `
+		UPDATE watched_artist SET artist_name = COALESCE(NULLIF($2, ''), artist_name), last_release_date = $3, checked_at = $4
+		WHERE artist_id = $1
+	`
+	lastReleaseDate := sql.NullTime{Time: lo.FromPtr(check.LastReleaseDate), Valid: check.LastReleaseDate != nil}
+	if _, err := tx.ExecContext(ctx, query, check.ArtistID, check.ArtistName, lastReleaseDate, check.CheckedAt); err != nil {
+		return fmt.Errorf("failed to update watched artist in db: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit artist check tx: %w", err)
+	}
+	return nil
+}
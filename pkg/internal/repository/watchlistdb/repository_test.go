@@ -0,0 +1,247 @@
+package watchlistdb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/watchlistdb"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var artistColumns = []string{"artist_id", "artist_name", "last_release_date", "checked_at", "subscribed_at"}
+
+func TestGetWatchlist(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name              string
+		mockSetup         func(sqlmock.Sqlmock)
+		expectedError     string
+		expectedWatchlist business.Watchlist
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, owner, name, created_at FROM watchlist WHERE id").
+					WithArgs(int64(1), "key:7").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "owner", "name", "created_at"}).AddRow(1, "key:7", "favorites", now))
+				mock.ExpectQuery("FROM watchlist_artist wa JOIN watched_artist a").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(artistColumns).
+						AddRow(10, "Jack Johnson", now, now, now).
+						AddRow(20, nil, nil, nil, now))
+			},
+			expectedWatchlist: business.Watchlist{
+				ID:    1,
+				Owner: "key:7",
+				Name:  "favorites",
+				Artists: []business.WatchedArtist{
+					{ArtistID: 10, ArtistName: "Jack Johnson", LastReleaseDate: lo.ToPtr(now), CheckedAt: lo.ToPtr(now), SubscribedAt: now},
+					{ArtistID: 20, SubscribedAt: now},
+				},
+				CreatedAt: now,
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM watchlist WHERE id").WithArgs(int64(1), "key:7").WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "artists query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM watchlist WHERE id").
+					WithArgs(int64(1), "key:7").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "owner", "name", "created_at"}).AddRow(1, "key:7", "favorites", now))
+				mock.ExpectQuery("FROM watchlist_artist").WillReturnError(fmt.Errorf("query error"))
+			},
+			expectedError: "failed to get watchlist artists from db: query error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := watchlistdb.NewWatchlistRepository(sqlx.NewDb(db, "sqlmock"))
+			watchlist, err := repo.GetWatchlist(context.Background(), "key:7", 1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWatchlist, watchlist)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddWatchlistArtist(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "successful add",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT EXISTS").WithArgs(int64(1), "key:7").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec("INSERT INTO watched_artist").WithArgs(10, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO watchlist_artist").WithArgs(int64(1), 10, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "unknown watchlist",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT EXISTS").WithArgs(int64(1), "key:7").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "insert error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT EXISTS").WithArgs(int64(1), "key:7").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec("INSERT INTO watched_artist").WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to insert watched artist to db: insert error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := watchlistdb.NewWatchlistRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.AddWatchlistArtist(context.Background(), "key:7", 1, 10)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestClaimDueArtists(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	next := now.Add(time.Hour)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE watched_artist SET next_check_at (.+) FOR UPDATE SKIP LOCKED").
+		WithArgs(now, next, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"artist_id", "artist_name", "last_release_date", "checked_at", "owners"}).
+			AddRow(10, "Jack Johnson", now, now, "{key:7,sub:alice}").
+			AddRow(20, nil, nil, nil, "{}"))
+
+	repo := watchlistdb.NewWatchlistRepository(sqlx.NewDb(db, "sqlmock"))
+	artists, err := repo.ClaimDueArtists(context.Background(), now, next, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []business.WatchedArtist{
+		{ArtistID: 10, ArtistName: "Jack Johnson", LastReleaseDate: lo.ToPtr(now), CheckedAt: lo.ToPtr(now), Owners: []string{"key:7", "sub:alice"}},
+		{ArtistID: 20, Owners: []string{}},
+	}, artists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveArtistCheck(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	released := time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC)
+	check := business.ArtistCheck{
+		ArtistID:        10,
+		ArtistName:      "Jack Johnson",
+		LastReleaseDate: lo.ToPtr(released),
+		Releases:        []business.Release{{ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 2, CollectionName: "New", ReleaseDate: released}},
+		CheckedAt:       now,
+	}
+	tests := []struct {
+		name          string
+		check         business.ArtistCheck
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name:  "successful save",
+			check: check,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO artist_release (.+) ON CONFLICT").
+					WithArgs(10, "Jack Johnson", 2, "New", released, "", "", now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE watched_artist SET artist_name").
+					WithArgs(10, "Jack Johnson", sql.NullTime{Time: released, Valid: true}, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "artist without releases",
+			check: business.ArtistCheck{ArtistID: 10, CheckedAt: now},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE watched_artist SET artist_name").
+					WithArgs(10, "", sql.NullTime{}, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "insert error",
+			check: check,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO artist_release").WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to insert artist release to db: insert error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := watchlistdb.NewWatchlistRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.SaveArtistCheck(context.Background(), tt.check)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package webhookdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+)
+
+// Subscription represents a stored webhook subscription.
+type Subscription struct {
+	ID        int64          `db:"id"`
+	Owner     string         `db:"owner"`
+	URL       string         `db:"url"`
+	Secret    string         `db:"secret"`
+	Events    pq.StringArray `db:"events"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+// Delivery represents a stored webhook delivery, URL and Secret are only set when it is claimed.
+type Delivery struct {
+	ID             int64          `db:"id"`
+	SubscriptionID int64          `db:"subscription_id"`
+	Event          string         `db:"event"`
+	Payload        []byte         `db:"payload"` // JSONB field
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	LastStatusCode sql.NullInt64  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	URL            sql.NullString `db:"url"`
+	Secret         sql.NullString `db:"secret"`
+}
+
+// Attempt represents a stored attempt of a webhook delivery.
+type Attempt struct {
+	DeliveryID int64          `db:"delivery_id"`
+	StatusCode sql.NullInt64  `db:"status_code"`
+	Error      sql.NullString `db:"error"`
+	DurationMS int64          `db:"duration_ms"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+const (
+	subscriptionColumns = `id, owner, url, secret, events, created_at, updated_at`
+	deliveryColumns     = `id, subscription_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at`
+)
+
+// WebhookRepositoryImpl is the implementation of the webhook repository, deliveries are a queue workers claim
+// from with FOR UPDATE SKIP LOCKED so every delivery is sent by a single worker at a time.
+type WebhookRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepositoryImpl.
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{db: db}
+}
+
+// mapSubscription maps a Subscription to a business.Webhook.
+func mapSubscription(s Subscription) business.Webhook {
+	return business.Webhook{
+		ID:     s.ID,
+		Owner:  s.Owner,
+		URL:    s.URL,
+		Secret: s.Secret,
+		Events: lo.Map(s.Events, func(e string, _ int) business.EventType {
+			return business.EventType(e)
+		}),
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// mapDelivery maps a Delivery to a business.WebhookDelivery.
+func mapDelivery(d Delivery, _ int) business.WebhookDelivery {
+	delivery := business.WebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.SubscriptionID,
+		Event:          business.EventType(d.Event),
+		Payload:        d.Payload,
+		Status:         business.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: int(d.LastStatusCode.Int64),
+		LastError:      d.LastError.String,
+		CreatedAt:      d.CreatedAt,
+		URL:            d.URL.String,
+		Secret:         d.Secret.String,
+	}
+	if d.DeliveredAt.Valid {
+		delivery.DeliveredAt = lo.ToPtr(d.DeliveredAt.Time)
+	}
+	return delivery
+}
+
+// InsertWebhook inserts a new webhook subscription.
+func (repo *WebhookRepositoryImpl) InsertWebhook(ctx context.Context, webhook business.Webhook) (business.Webhook, error) {
+	query := `
+		INSERT INTO webhook_subscription (owner, url, secret, events, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING ` + subscriptionColumns
+	events := lo.Map(webhook.Events, func(e business.EventType, _ int) string { return string(e) })
+	var s Subscription
+	if err := repo.db.QueryRowxContext(ctx, query, webhook.Owner, webhook.URL, webhook.Secret, pq.Array(events), time.Now().UTC()).StructScan(&s); err != nil {
+		return business.Webhook{}, fmt.Errorf("failed to insert webhook to db: %w", err)
+	}
+	return mapSubscription(s), nil
+}
+
+// GetWebhook returns the webhook of owner with the given id, business.ErrNotFound is returned when there is none.
+func (repo *WebhookRepositoryImpl) GetWebhook(ctx context.Context, owner string, id int64) (business.Webhook, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscription WHERE id = $1 AND owner = $2`
+	var s Subscription
+	if err := repo.db.GetContext(ctx, &s, query, id, owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.Webhook{}, business.ErrNotFound
+		}
+		return business.Webhook{}, fmt.Errorf("failed to get webhook from db: %w", err)
+	}
+	return mapSubscription(s), nil
+}
+
+// DeleteWebhook deletes the webhook of owner with the given id along with its deliveries, business.ErrNotFound
+// is returned when there is none.
+func (repo *WebhookRepositoryImpl) DeleteWebhook(ctx context.Context, owner string, id int64) error {
+	res, err := repo.db.ExecContext(ctx, `DELETE FROM webhook_subscription WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook from db: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook from db: %w", err)
+	}
+	if affected == 0 {
+		return business.ErrNotFound
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues a delivery of payload to every webhook of owner subscribed to event, due at the
+// given time, and returns the number of queued deliveries.
+func (repo *WebhookRepositoryImpl) EnqueueWebhookDeliveries(ctx context.Context, owner string, event business.EventType, payload []byte, at time.Time) (int, error) {
+	query := `
+		INSERT INTO webhook_delivery (subscription_id, event, payload, status, next_attempt_at, created_at, updated_at)
+		SELECT id, $1, $2, 'pending', $3, $3, $3 FROM webhook_subscription WHERE $1 = ANY(events) AND owner = $4
+	`
+	res, err := repo.db.ExecContext(ctx, query, string(event), payload, at, owner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries in db: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries in db: %w", err)
+	}
+	return int(affected), nil
+}
+
+// ListWebhookDeliveries returns up to limit deliveries of a webhook of owner newest first along with their
+// attempts, an empty status matches any.
+func (repo *WebhookRepositoryImpl) ListWebhookDeliveries(ctx context.Context, owner string, webhookID int64, status business.WebhookDeliveryStatus, limit, offset int) ([]business.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + ` FROM webhook_delivery
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		AND subscription_id IN (SELECT id FROM webhook_subscription WHERE owner = $5)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4
+	`
+	var rows []Delivery
+	if err := repo.db.SelectContext(ctx, &rows, query, webhookID, string(status), limit, offset, owner); err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries from db: %w", err)
+	}
+	deliveries := lo.Map(rows, mapDelivery)
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	query = `
+		SELECT delivery_id, status_code, error, duration_ms, created_at FROM webhook_delivery_attempt
+		WHERE delivery_id = ANY($1)
+		ORDER BY id
+	`
+	var attempts []Attempt
+	ids := lo.Map(rows, func(d Delivery, _ int) int64 { return d.ID })
+	if err := repo.db.SelectContext(ctx, &attempts, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to list webhook delivery attempts from db: %w", err)
+	}
+	byDelivery := lo.GroupBy(attempts, func(a Attempt) int64 { return a.DeliveryID })
+	for i, d := range deliveries {
+		deliveries[i].Log = lo.Map(byDelivery[d.ID], func(a Attempt, _ int) business.WebhookAttempt {
+			return business.WebhookAttempt{
+				DeliveryID: a.DeliveryID,
+				StatusCode: int(a.StatusCode.Int64),
+				Error:      a.Error.String,
+				Duration:   time.Duration(a.DurationMS) * time.Millisecond,
+				CreatedAt:  a.CreatedAt,
+			}
+		})
+	}
+	return deliveries, nil
+}
+
+// GetWebhookDelivery returns the delivery with the given id of a webhook of owner, business.ErrNotFound is
+// returned when there is none.
+func (repo *WebhookRepositoryImpl) GetWebhookDelivery(ctx context.Context, owner string, webhookID, id int64) (business.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + ` FROM webhook_delivery
+		WHERE id = $2 AND subscription_id = $1
+		AND subscription_id IN (SELECT id FROM webhook_subscription WHERE owner = $3)
+	`
+	var d Delivery
+	if err := repo.db.GetContext(ctx, &d, query, webhookID, id, owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.WebhookDelivery{}, business.ErrNotFound
+		}
+		return business.WebhookDelivery{}, fmt.Errorf("failed to get webhook delivery from db: %w", err)
+	}
+	return mapDelivery(d, 0), nil
+}
+
+// RedeliverWebhookDelivery queues a delivery of a webhook of owner again with its attempts reset, due at the
+// given time. business.ErrNotFound is returned when there is no such delivery or it is being delivered.
+func (repo *WebhookRepositoryImpl) RedeliverWebhookDelivery(ctx context.Context, owner string, webhookID, id int64, at time.Time) (business.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_delivery
+		SET status = 'pending', attempts = 0, next_attempt_at = $3, locked_until = NULL, delivered_at = NULL, updated_at = $3
+		WHERE id = $2 AND subscription_id = $1 AND status <> 'delivering'
+		AND subscription_id IN (SELECT id FROM webhook_subscription WHERE owner = $4)
+		RETURNING ` + deliveryColumns
+	var d Delivery
+	if err := repo.db.QueryRowxContext(ctx, query, webhookID, id, at, owner).StructScan(&d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.WebhookDelivery{}, business.ErrNotFound
+		}
+		return business.WebhookDelivery{}, fmt.Errorf("failed to redeliver webhook delivery in db: %w", err)
+	}
+	return mapDelivery(d, 0), nil
+}
+
+// ClaimWebhookDelivery marks the oldest due delivery as delivering until lockedUntil and counts the attempt,
+// deliveries locked by another worker are skipped. Deliveries whose lease expired are claimed again.
+// business.ErrNotFound is returned when no delivery is due.
+func (repo *WebhookRepositoryImpl) ClaimWebhookDelivery(ctx context.Context, now time.Time, lockedUntil time.Time) (business.WebhookDelivery, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_delivery
+			SET status = 'delivering', attempts = attempts + 1, locked_until = $2, updated_at = $1
+			WHERE id = (
+				SELECT id FROM webhook_delivery
+				WHERE (status = 'pending' AND next_attempt_at <= $1) OR (status = 'delivering' AND locked_until < $1)
+				ORDER BY next_attempt_at, id
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + deliveryColumns + `
+		)
+		SELECT claimed.*, s.url, s.secret FROM claimed
+		JOIN webhook_subscription s ON s.id = claimed.subscription_id
+	`
+	var d Delivery
+	if err := repo.db.QueryRowxContext(ctx, query, now, lockedUntil).StructScan(&d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return business.WebhookDelivery{}, business.ErrNotFound
+		}
+		return business.WebhookDelivery{}, fmt.Errorf("failed to claim webhook delivery in db: %w", err)
+	}
+	return mapDelivery(d, 0), nil
+}
+
+// RecordWebhookAttempt stores the outcome of an attempt of a claimed delivery along with the attempt,
+// business.ErrNotFound is returned when the delivery isn't being delivered anymore.
+func (repo *WebhookRepositoryImpl) RecordWebhookAttempt(ctx context.Context, delivery business.WebhookDelivery, attempt business.WebhookAttempt) error {
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin webhook attempt tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	statusCode := sql.NullInt64{Int64: int64(attempt.StatusCode), Valid: attempt.StatusCode != 0}
+	query := `
+		UPDATE webhook_delivery
+		SET status = $2, next_attempt_at = $3, locked_until = NULL, last_status_code = $4, last_error = $5, delivered_at = $6, updated_at = $7
+		WHERE id = $1 AND status = 'delivering'
+	`
+	res, err := tx.ExecContext(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.NextAttemptAt,
+		statusCode,
+		sql.NullString{String: delivery.LastError, Valid: delivery.LastError != ""},
+		sql.NullTime{Time: lo.FromPtr(delivery.DeliveredAt), Valid: delivery.DeliveredAt != nil},
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery in db: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery in db: %w", err)
+	}
+	if affected == 0 {
+		return business.ErrNotFound
+	}
+
+	query = `INSERT INTO webhook_delivery_attempt (delivery_id, status_code, error, duration_ms, created_at) VALUES ($1, $2, $3, $4, $5)`
+	attemptErr := sql.NullString{String: attempt.Error, Valid: attempt.Error != ""}
+	if _, err := tx.ExecContext(ctx, query, delivery.ID, statusCode, attemptErr, attempt.Duration.Milliseconds(), now); err != nil {
+		return fmt.Errorf("failed to insert webhook attempt to db: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit webhook attempt tx: %w", err)
+	}
+	return nil
+}
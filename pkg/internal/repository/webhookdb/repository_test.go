@@ -0,0 +1,296 @@
+package webhookdb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/webhookdb"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	subscriptionColumns = []string{"id", "owner", "url", "secret", "events", "created_at", "updated_at"}
+	deliveryColumns     = []string{"id", "subscription_id", "event", "payload", "status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "created_at"}
+)
+
+func TestGetWebhook(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		mockSetup       func(sqlmock.Sqlmock)
+		expectedError   string
+		expectedWebhook business.Webhook
+	}{
+		{
+			name: "successful get",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM webhook_subscription WHERE id = (.+) AND owner").
+					WithArgs(int64(1), "key:7").
+					WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(1, "key:7", "https://example.com/hooks", "whsec_test", "{search.stored,job.finished}", now, now))
+			},
+			expectedWebhook: business.Webhook{
+				ID:        1,
+				Owner:     "key:7",
+				URL:       "https://example.com/hooks",
+				Secret:    "whsec_test",
+				Events:    []business.EventType{business.EventSearchStored, business.EventJobFinished},
+				CreatedAt: now,
+			},
+		},
+		{
+			name: "not found or owned by another client",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM webhook_subscription WHERE id = (.+) AND owner").
+					WithArgs(int64(1), "key:7").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := webhookdb.NewWebhookRepository(sqlx.NewDb(db, "sqlmock"))
+			webhook, err := repo.GetWebhook(context.Background(), "key:7", 1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWebhook, webhook)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{name: "successful delete", affected: 1},
+		{name: "not found or owned by another client", affected: 0, expectedError: business.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec("DELETE FROM webhook_subscription WHERE id = (.+) AND owner").
+				WithArgs(int64(1), "key:7").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			repo := webhookdb.NewWebhookRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.DeleteWebhook(context.Background(), "key:7", 1)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEnqueueWebhookDeliveries(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"type":"search.stored"}`)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO webhook_delivery (.+) SELECT (.+) FROM webhook_subscription WHERE (.+) = ANY\\(events\\) AND owner = (.+)").
+		WithArgs("search.stored", payload, now, "key:7").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := webhookdb.NewWebhookRepository(sqlx.NewDb(db, "sqlmock"))
+	queued, err := repo.EnqueueWebhookDeliveries(context.Background(), "key:7", business.EventSearchStored, payload, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, queued)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM webhook_delivery WHERE subscription_id (.+) FROM webhook_subscription WHERE owner").
+		WithArgs(int64(1), "dead", 20, 0, "key:7").
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).
+			AddRow(3, 1, "job.finished", []byte(`{}`), "dead", 2, now, 500, "unexpected status code 500", nil, now).
+			AddRow(2, 1, "search.stored", []byte(`{}`), "dead", 1, now, nil, "connection refused", nil, now))
+	mock.ExpectQuery("FROM webhook_delivery_attempt WHERE delivery_id = ANY").
+		WithArgs(pq.Array([]int64{3, 2})).
+		WillReturnRows(sqlmock.NewRows([]string{"delivery_id", "status_code", "error", "duration_ms", "created_at"}).
+			AddRow(3, 500, "unexpected status code 500", 120, now).
+			AddRow(2, nil, "connection refused", 3, now).
+			AddRow(3, 500, "unexpected status code 500", 80, now))
+
+	repo := webhookdb.NewWebhookRepository(sqlx.NewDb(db, "sqlmock"))
+	deliveries, err := repo.ListWebhookDeliveries(context.Background(), "key:7", 1, business.WebhookDeliveryDead, 20, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []business.WebhookDelivery{
+		{
+			ID: 3, WebhookID: 1, Event: business.EventJobFinished, Payload: []byte(`{}`), Status: business.WebhookDeliveryDead,
+			Attempts: 2, NextAttemptAt: now, LastStatusCode: 500, LastError: "unexpected status code 500", CreatedAt: now,
+			Log: []business.WebhookAttempt{
+				{DeliveryID: 3, StatusCode: 500, Error: "unexpected status code 500", Duration: 120 * time.Millisecond, CreatedAt: now},
+				{DeliveryID: 3, StatusCode: 500, Error: "unexpected status code 500", Duration: 80 * time.Millisecond, CreatedAt: now},
+			},
+		},
+		{
+			ID: 2, WebhookID: 1, Event: business.EventSearchStored, Payload: []byte(`{}`), Status: business.WebhookDeliveryDead,
+			Attempts: 1, NextAttemptAt: now, LastError: "connection refused", CreatedAt: now,
+			Log: []business.WebhookAttempt{
+				{DeliveryID: 2, Error: "connection refused", Duration: 3 * time.Millisecond, CreatedAt: now},
+			},
+		},
+	}, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimWebhookDelivery(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	lockedUntil := now.Add(time.Minute)
+	tests := []struct {
+		name             string
+		mockSetup        func(sqlmock.Sqlmock)
+		expectedError    string
+		expectedDelivery business.WebhookDelivery
+	}{
+		{
+			name: "successful claim",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE webhook_delivery SET status = 'delivering'(.+) FOR UPDATE SKIP LOCKED (.+) JOIN webhook_subscription").
+					WithArgs(now, lockedUntil).
+					WillReturnRows(sqlmock.NewRows(append(deliveryColumns, "url", "secret")).
+						AddRow(2, 1, "search.stored", []byte(`{}`), "delivering", 1, now, nil, nil, nil, now, "https://example.com/hooks", "whsec_test"))
+			},
+			expectedDelivery: business.WebhookDelivery{
+				ID:            2,
+				WebhookID:     1,
+				Event:         business.EventSearchStored,
+				Payload:       []byte(`{}`),
+				Status:        business.WebhookDeliveryDelivering,
+				Attempts:      1,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+				URL:           "https://example.com/hooks",
+				Secret:        "whsec_test",
+			},
+		},
+		{
+			name: "no delivery due",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE webhook_delivery").WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := webhookdb.NewWebhookRepository(sqlx.NewDb(db, "sqlmock"))
+			delivery, err := repo.ClaimWebhookDelivery(context.Background(), now, lockedUntil)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDelivery, delivery)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRecordWebhookAttempt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	delivered := business.WebhookDelivery{ID: 2, Status: business.WebhookDeliveryDelivered, NextAttemptAt: now, LastStatusCode: 204, DeliveredAt: lo.ToPtr(now)}
+	attempt := business.WebhookAttempt{DeliveryID: 2, StatusCode: 204, Duration: 40 * time.Millisecond}
+	tests := []struct {
+		name          string
+		mockSetup     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "successful record",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE webhook_delivery SET status (.+) WHERE id = (.+) AND status = 'delivering'").
+					WithArgs(int64(2), "delivered", now, sql.NullInt64{Int64: 204, Valid: true}, sql.NullString{}, sql.NullTime{Time: now, Valid: true}, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_delivery_attempt").
+					WithArgs(int64(2), sql.NullInt64{Int64: 204, Valid: true}, sql.NullString{}, int64(40), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "delivery not delivering anymore",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE webhook_delivery").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError: business.ErrNotFound.Error(),
+		},
+		{
+			name: "insert error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE webhook_delivery").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO webhook_delivery_attempt").WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to insert webhook attempt to db: insert error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			repo := webhookdb.NewWebhookRepository(sqlx.NewDb(db, "sqlmock"))
+			err = repo.RecordWebhookAttempt(context.Background(), delivered, attempt)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
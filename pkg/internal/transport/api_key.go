@@ -0,0 +1,247 @@
+package transport
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/jwt"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=api_key.go -destination=mock/api_key.go -package=mock
+type (
+	apiKeyHandler interface {
+		CreateAPIKey(ctx context.Context, name string, minuteQuota, dailyQuota int) (business.APIKey, string, error)
+		ListAPIKeys(ctx context.Context) ([]business.APIKey, error)
+		RotateAPIKey(ctx context.Context, id int64) (business.APIKey, string, error)
+		RevokeAPIKey(ctx context.Context, id int64) error
+	}
+	authenticator interface {
+		Authenticate(ctx context.Context, raw string) (business.APIKey, business.Quota, error)
+	}
+	tokenVerifier interface {
+		Verify(ctx context.Context, raw string) (jwt.Identity, error)
+	}
+)
+
+type (
+	// CreateAPIKeyRequest represents the received request to create an api key.
+	CreateAPIKeyRequest struct {
+		Name        string `json:"name"`
+		MinuteQuota int    `json:"minute_quota"`
+		DailyQuota  int    `json:"daily_quota"`
+	}
+
+	// APIKeyRequest represents a received request targeting a single api key.
+	APIKeyRequest struct {
+		ID int64
+	}
+
+	// APIKey represents an api key without its secret.
+	APIKey struct {
+		ID          int64      `json:"id"`
+		Name        string     `json:"name"`
+		Prefix      string     `json:"prefix"`
+		MinuteQuota int        `json:"minute_quota"`
+		DailyQuota  int        `json:"daily_quota"`
+		RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+		CreatedAt   time.Time  `json:"created_at"`
+	}
+
+	// APIKeySecretResponse represents an api key along with its raw value, returned once on creation and rotation.
+	APIKeySecretResponse struct {
+		APIKey
+		Key string `json:"key"`
+	}
+
+	// ListAPIKeysResponse represents the listed api keys.
+	ListAPIKeysResponse struct {
+		APIKeys []APIKey `json:"api_keys"`
+	}
+
+	// RevokeAPIKeyResponse represents the response of a revoked api key.
+	RevokeAPIKeyResponse struct{}
+)
+
+// AuthInfo carries the credentials of a request and, once authenticated, the identity behind them.
+// Transports put a pointer to it in the context so the outcome of authentication can be read back when encoding.
+type AuthInfo struct {
+	// APIKey is the raw api key sent by the client.
+	APIKey string
+	// Key is the authenticated api key.
+	Key business.APIKey
+	// Quota is the state of the quota of the authenticated api key.
+	Quota business.Quota
+	// BearerToken is the raw bearer token sent by the client.
+	BearerToken string
+	// Identity is the identity behind the verified bearer token.
+	Identity jwt.Identity
+}
+
+type authInfoKey struct{}
+
+// ContextWithAuthInfo returns a copy of ctx carrying info.
+func ContextWithAuthInfo(ctx context.Context, info *AuthInfo) context.Context {
+	return context.WithValue(ctx, authInfoKey{}, info)
+}
+
+// AuthInfoFromContext returns the auth info put in ctx by the transport.
+func AuthInfoFromContext(ctx context.Context) (*AuthInfo, bool) {
+	info, ok := ctx.Value(authInfoKey{}).(*AuthInfo)
+	return info, ok
+}
+
+// authIdentity returns the identity of the authenticated client of a request: its api key, then its token
+// subject. It is empty when the request wasn't authenticated.
+func authIdentity(ctx context.Context) string {
+	info, ok := AuthInfoFromContext(ctx)
+	switch {
+	case !ok:
+		return ""
+	case info.Key.ID != 0:
+		return "key:" + strconv.FormatInt(info.Key.ID, 10)
+	case info.Identity.Subject != "":
+		return "sub:" + info.Identity.Subject
+	}
+	return ""
+}
+
+// MakeAPIKeyMiddleware function to make a middleware authenticating requests by api key and enforcing its quotas.
+func MakeAPIKeyMiddleware(auth authenticator) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			info, ok := AuthInfoFromContext(ctx)
+			if !ok {
+				return nil, business.ErrUnauthorized
+			}
+			key, quota, err := auth.Authenticate(ctx, info.APIKey)
+			info.Quota = quota
+			if err != nil {
+				return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+			}
+			info.Key = key
+			return next(business.WithOwner(ctx, authIdentity(ctx)), request)
+		}
+	}
+}
+
+// MakeAdminTokenMiddleware function to make a middleware only letting through requests carrying token as their
+// bearer token, every request is rejected when token is empty.
+func MakeAdminTokenMiddleware(token string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			info, ok := AuthInfoFromContext(ctx)
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(info.BearerToken), []byte(token)) != 1 {
+				return nil, business.ErrUnauthorized
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// MakeJWTMiddleware function to make a middleware authenticating requests by bearer token.
+// Requests without a bearer token are passed to fallback when given, e.g. the api key middleware, and rejected otherwise.
+// Tokens missing any of the required scopes are rejected with business.ErrForbidden.
+func MakeJWTMiddleware(verifier tokenVerifier, fallback endpoint.Middleware, requiredScopes ...string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		var fallbackEp endpoint.Endpoint
+		if fallback != nil {
+			fallbackEp = fallback(next)
+		}
+		return func(ctx context.Context, request any) (any, error) {
+			info, ok := AuthInfoFromContext(ctx)
+			if !ok {
+				return nil, business.ErrUnauthorized
+			}
+			if info.BearerToken == "" {
+				if fallbackEp != nil {
+					return fallbackEp(ctx, request)
+				}
+				return nil, business.ErrUnauthorized
+			}
+			identity, err := verifier.Verify(ctx, info.BearerToken)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", business.ErrUnauthorized, err)
+			}
+			if !identity.HasScopes(requiredScopes...) {
+				return nil, fmt.Errorf("%w: token lacks required scopes", business.ErrForbidden)
+			}
+			info.Identity = identity
+			return next(business.WithOwner(ctx, authIdentity(ctx)), request)
+		}
+	}
+}
+
+func mapAPIKey(k business.APIKey) APIKey {
+	return APIKey{
+		ID:          k.ID,
+		Name:        k.Name,
+		Prefix:      k.Prefix,
+		MinuteQuota: k.MinuteQuota,
+		DailyQuota:  k.DailyQuota,
+		RevokedAt:   k.RevokedAt,
+		CreatedAt:   k.CreatedAt,
+	}
+}
+
+// MakeCreateAPIKeyEndpoint function to make create api key endpoint call.
+func MakeCreateAPIKeyEndpoint(handler apiKeyHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(CreateAPIKeyRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse create api key request")
+		}
+		key, raw, err := handler.CreateAPIKey(ctx, body.Name, body.MinuteQuota, body.DailyQuota)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create api key: %w", err)
+		}
+		return APIKeySecretResponse{APIKey: mapAPIKey(key), Key: raw}, nil
+	}
+}
+
+// MakeListAPIKeysEndpoint function to make list api keys endpoint call.
+func MakeListAPIKeysEndpoint(handler apiKeyHandler) endpoint.Endpoint {
+	return func(ctx context.Context, _ any) (any, error) {
+		keys, err := handler.ListAPIKeys(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list api keys: %w", err)
+		}
+		return ListAPIKeysResponse{APIKeys: lo.Map(keys, func(k business.APIKey, _ int) APIKey {
+			return mapAPIKey(k)
+		})}, nil
+	}
+}
+
+// MakeRotateAPIKeyEndpoint function to make rotate api key endpoint call.
+func MakeRotateAPIKeyEndpoint(handler apiKeyHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(APIKeyRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse rotate api key request")
+		}
+		key, raw, err := handler.RotateAPIKey(ctx, body.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rotate api key: %w", err)
+		}
+		return APIKeySecretResponse{APIKey: mapAPIKey(key), Key: raw}, nil
+	}
+}
+
+// MakeRevokeAPIKeyEndpoint function to make revoke api key endpoint call.
+func MakeRevokeAPIKeyEndpoint(handler apiKeyHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(APIKeyRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse revoke api key request")
+		}
+		if err := handler.RevokeAPIKey(ctx, body.ID); err != nil {
+			return nil, fmt.Errorf("failed to revoke api key: %w", err)
+		}
+		return RevokeAPIKeyResponse{}, nil
+	}
+}
@@ -0,0 +1,231 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/NawafSwe/media-scout-service/pkg/jwt"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeAPIKeyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuth := mock.NewMockauthenticator(ctrl)
+	// next responds with the owner the calls are made for.
+	next := func(ctx context.Context, _ any) (any, error) { return business.OwnerFromContext(ctx), nil }
+	ep := transport.MakeAPIKeyMiddleware(mockAuth)(next)
+	reset := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name             string
+		info             *transport.AuthInfo
+		mockSetup        func()
+		expectedError    error
+		expectedResponse any
+		expectedQuota    business.Quota
+	}{
+		{
+			name:          "no auth info in context",
+			mockSetup:     func() {},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name: "authenticated key reaches the endpoint",
+			info: &transport.AuthInfo{APIKey: "msk_valid"},
+			mockSetup: func() {
+				mockAuth.EXPECT().Authenticate(gomock.Any(), "msk_valid").
+					Return(business.APIKey{ID: 1}, business.Quota{Limit: 10, Remaining: 9, Reset: reset}, nil)
+			},
+			expectedResponse: "key:1",
+			expectedQuota:    business.Quota{Limit: 10, Remaining: 9, Reset: reset},
+		},
+		{
+			name: "exceeded quota keeps the quota for the response headers",
+			info: &transport.AuthInfo{APIKey: "msk_valid"},
+			mockSetup: func() {
+				mockAuth.EXPECT().Authenticate(gomock.Any(), "msk_valid").
+					Return(business.APIKey{ID: 1}, business.Quota{Limit: 10, Remaining: 0, Reset: reset}, business.ErrQuotaExceeded)
+			},
+			expectedError: business.ErrQuotaExceeded,
+			expectedQuota: business.Quota{Limit: 10, Remaining: 0, Reset: reset},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			ctx := context.Background()
+			if tt.info != nil {
+				ctx = transport.ContextWithAuthInfo(ctx, tt.info)
+			}
+
+			response, err := ep(ctx, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+			if tt.info != nil {
+				assert.Equal(t, tt.expectedQuota, tt.info.Quota)
+			}
+		})
+	}
+}
+
+func TestMakeAdminTokenMiddleware(t *testing.T) {
+	next := func(context.Context, any) (any, error) { return "ok", nil }
+
+	tests := []struct {
+		name          string
+		token         string
+		info          *transport.AuthInfo
+		expectedError error
+	}{
+		{
+			name:          "no auth info in context",
+			token:         "s3cret",
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name:          "missing token",
+			token:         "s3cret",
+			info:          &transport.AuthInfo{},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name:          "wrong token",
+			token:         "s3cret",
+			info:          &transport.AuthInfo{BearerToken: "guess"},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name:          "no admin token configured",
+			info:          &transport.AuthInfo{},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name:  "admin token reaches the endpoint",
+			token: "s3cret",
+			info:  &transport.AuthInfo{BearerToken: "s3cret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.info != nil {
+				ctx = transport.ContextWithAuthInfo(ctx, tt.info)
+			}
+			ep := transport.MakeAdminTokenMiddleware(tt.token)(next)
+
+			response, err := ep(ctx, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ok", response)
+			}
+		})
+	}
+}
+
+func TestMakeJWTMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVerifier := mock.NewMocktokenVerifier(ctrl)
+	next := func(context.Context, any) (any, error) { return "ok", nil }
+	fallback := func(endpoint.Endpoint) endpoint.Endpoint {
+		return func(context.Context, any) (any, error) { return "fallback", nil }
+	}
+
+	tests := []struct {
+		name             string
+		info             *transport.AuthInfo
+		fallback         endpoint.Middleware
+		mockSetup        func()
+		expectedError    error
+		expectedResponse any
+		expectedIdentity jwt.Identity
+	}{
+		{
+			name:          "no auth info in context",
+			mockSetup:     func() {},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name:          "missing token without fallback",
+			info:          &transport.AuthInfo{},
+			mockSetup:     func() {},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name:             "missing token uses the fallback",
+			info:             &transport.AuthInfo{APIKey: "msk_valid"},
+			fallback:         fallback,
+			mockSetup:        func() {},
+			expectedResponse: "fallback",
+		},
+		{
+			name: "invalid token",
+			info: &transport.AuthInfo{BearerToken: "token"},
+			mockSetup: func() {
+				mockVerifier.EXPECT().Verify(gomock.Any(), "token").Return(jwt.Identity{}, errors.New("invalid token: token is expired"))
+			},
+			expectedError: business.ErrUnauthorized,
+		},
+		{
+			name: "missing scope",
+			info: &transport.AuthInfo{BearerToken: "token"},
+			mockSetup: func() {
+				mockVerifier.EXPECT().Verify(gomock.Any(), "token").Return(jwt.Identity{Subject: "user-1", Scopes: []string{"profile"}}, nil)
+			},
+			expectedError: business.ErrForbidden,
+		},
+		{
+			name:     "verified token reaches the endpoint",
+			info:     &transport.AuthInfo{BearerToken: "token"},
+			fallback: fallback,
+			mockSetup: func() {
+				mockVerifier.EXPECT().Verify(gomock.Any(), "token").Return(jwt.Identity{Subject: "user-1", Scopes: []string{"media:read", "profile"}}, nil)
+			},
+			expectedResponse: "ok",
+			expectedIdentity: jwt.Identity{Subject: "user-1", Scopes: []string{"media:read", "profile"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			ctx := context.Background()
+			if tt.info != nil {
+				ctx = transport.ContextWithAuthInfo(ctx, tt.info)
+			}
+			ep := transport.MakeJWTMiddleware(mockVerifier, tt.fallback, "media:read")(next)
+
+			response, err := ep(ctx, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+			if tt.info != nil {
+				assert.Equal(t, tt.expectedIdentity, tt.info.Identity)
+			}
+		})
+	}
+}
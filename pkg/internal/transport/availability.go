@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=availability.go -destination=mock/availability.go -package=mock
+type availabilityHandler interface {
+	CheckAvailability(ctx context.Context, id int, countries []string) (business.Availability, error)
+}
+
+type (
+	// CheckAvailabilityRequest represents the received request to check the availability of a media in the storefronts
+	// of the given countries.
+	CheckAvailabilityRequest struct {
+		ID        int
+		Countries []string
+	}
+
+	// StorefrontAvailability represents the availability and price of a media in a single storefront.
+	StorefrontAvailability struct {
+		Country         string   `json:"country"`
+		Available       bool     `json:"available"`
+		Price           float64  `json:"price"`
+		Currency        string   `json:"currency,omitempty"`
+		NormalizedPrice *float64 `json:"normalized_price,omitempty"`
+		Error           string   `json:"error,omitempty"`
+	}
+
+	// CheckAvailabilityResponse represents the availability of a media across storefronts, in the order they were
+	// requested in.
+	CheckAvailabilityResponse struct {
+		ID           int                      `json:"id"`
+		Media        *Media                   `json:"media,omitempty"`
+		BaseCurrency string                   `json:"base_currency,omitempty"`
+		Storefronts  []StorefrontAvailability `json:"storefronts"`
+	}
+)
+
+// MakeCheckAvailabilityEndpoint function to make check availability endpoint call.
+func MakeCheckAvailabilityEndpoint(handler availabilityHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(CheckAvailabilityRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse check availability request")
+		}
+		res, err := handler.CheckAvailability(ctx, body.ID, body.Countries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+		response := CheckAvailabilityResponse{
+			ID:           res.ID,
+			BaseCurrency: res.BaseCurrency,
+			Storefronts: lo.Map(res.Storefronts, func(s business.StorefrontAvailability, _ int) StorefrontAvailability {
+				storefront := StorefrontAvailability{
+					Country:         s.Country,
+					Available:       s.Available,
+					Price:           s.Price,
+					Currency:        s.Currency,
+					NormalizedPrice: s.NormalizedPrice,
+				}
+				if s.Err != nil {
+					storefront.Error = s.Err.Error()
+				}
+				return storefront
+			}),
+		}
+		if res.Media != nil {
+			response.Media = lo.ToPtr(mapMedia(*res.Media, 0))
+		}
+		return response, nil
+	}
+}
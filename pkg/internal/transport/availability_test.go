@@ -0,0 +1,86 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeCheckAvailabilityEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockavailabilityHandler(ctrl)
+	endpoint := transport.MakeCheckAvailabilityEndpoint(mockHandler)
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "successful check",
+			request: transport.CheckAvailabilityRequest{ID: 456, Countries: []string{"us", "sa", "gb"}},
+			mockSetup: func() {
+				mockHandler.EXPECT().CheckAvailability(gomock.Any(), 456, []string{"us", "sa", "gb"}).Return(business.Availability{
+					ID:           456,
+					Media:        &business.Media{WrapperType: "track", Kind: "song", TrackID: 456},
+					BaseCurrency: "USD",
+					Storefronts: []business.StorefrontAvailability{
+						{Country: "us", Available: true, Price: 1.29, Currency: "USD", NormalizedPrice: lo.ToPtr(1.29)},
+						{Country: "sa"},
+						{Country: "gb", Err: errors.New("upstream down")},
+					},
+				}, nil)
+			},
+			expectedResponse: transport.CheckAvailabilityResponse{
+				ID:           456,
+				Media:        &transport.Media{WrapperType: "track", Kind: "song", TrackID: 456},
+				BaseCurrency: "USD",
+				Storefronts: []transport.StorefrontAvailability{
+					{Country: "us", Available: true, Price: 1.29, Currency: "USD", NormalizedPrice: lo.ToPtr(1.29)},
+					{Country: "sa"},
+					{Country: "gb", Error: "upstream down"},
+				},
+			},
+		},
+		{
+			name:    "check error",
+			request: transport.CheckAvailabilityRequest{ID: 456, Countries: []string{"us"}},
+			mockSetup: func() {
+				mockHandler.EXPECT().CheckAvailability(gomock.Any(), 456, []string{"us"}).Return(business.Availability{}, errors.New("upstream down"))
+			},
+			expectedError: "failed to check availability: upstream down",
+		},
+		{
+			name:          "invalid request",
+			request:       "invalid",
+			mockSetup:     func() {},
+			expectedError: "failed to parse check availability request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+		})
+	}
+}
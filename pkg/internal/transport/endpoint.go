@@ -2,53 +2,115 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	"github.com/go-kit/kit/endpoint"
 	"github.com/samber/lo"
 )
 
+// ErrInvalidRequest is returned by transports when a request can't be decoded.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// Reasons a provider of a federated search failed for, the errors of providers aren't returned to clients since they
+// may leak the internals of the provider, they are logged instead.
+const (
+	ProviderFailureTimeout     = "timeout"
+	ProviderFailureUnavailable = "unavailable"
+)
+
 //go:generate mockgen -source=endpoint.go -destination=mock/endpoint.go -package=mock
 type handler interface {
-	FetchAndInsertMedia(ctx context.Context, term string, limit int) (business.MediaResult, error)
+	FetchAndInsertMediaFrom(ctx context.Context, provider, term string, limit int) (business.MediaResult, error)
+	FetchAndInsertFilteredMedia(ctx context.Context, provider, term string, limit int, filter business.SearchFilter) (business.MediaResult, error)
+	FetchAndInsertFederatedMedia(ctx context.Context, providers []string, term string, limit int) (business.FederatedMediaResult, error)
 }
 type (
 	// SearchMediaRequest represents the received request to search for media.
 	SearchMediaRequest struct {
 		Term  string
 		Limit int
+		// Provider is the name of the provider searched, the default one when empty.
+		Provider string
+		// Providers are the names of the providers a federated search is sent to, it is federated when set.
+		Providers []string
+		// Filter narrows the search to a media type and the apps matching the software filters, none when zero.
+		Filter business.SearchFilter
 	}
 
 	// Media represents a single media item with various attributes.
 	Media struct {
-		WrapperType            string   `json:"wrapperType"`
-		Kind                   string   `json:"kind"`
-		ArtistID               int      `json:"artistId"`
-		CollectionID           int      `json:"collectionId"`
-		TrackID                int      `json:"trackId"`
-		ArtistName             string   `json:"artistName"`
-		CollectionName         string   `json:"collectionName"`
-		TrackName              string   `json:"trackName"`
-		ArtistViewURL          string   `json:"artistViewUrl"`
-		CollectionViewURL      string   `json:"collectionViewUrl"`
-		FeedURL                string   `json:"feedUrl"`
-		TrackViewURL           string   `json:"trackViewUrl"`
-		ArtworkURL30           string   `json:"artworkUrl30"`
-		ArtworkURL60           string   `json:"artworkUrl60"`
-		ArtworkURL100          string   `json:"artworkUrl100"`
-		ReleaseDate            string   `json:"releaseDate"`
-		CollectionExplicitness string   `json:"collectionExplicitness"`
-		TrackExplicitness      string   `json:"trackExplicitness"`
-		TrackCount             int      `json:"trackCount"`
-		TrackTimeMillis        int      `json:"trackTimeMillis"`
-		Country                string   `json:"country"`
-		Currency               string   `json:"currency"`
-		PrimaryGenreName       string   `json:"primaryGenreName"`
-		ContentAdvisoryRating  string   `json:"contentAdvisoryRating"`
-		ArtworkURL600          string   `json:"artworkUrl600"`
-		GenreIDs               []string `json:"genreIds"`
-		Genres                 []string `json:"genres"`
+		WrapperType            string    `json:"wrapperType"`
+		Kind                   string    `json:"kind"`
+		ArtistID               int       `json:"artistId"`
+		CollectionID           int       `json:"collectionId"`
+		TrackID                int       `json:"trackId"`
+		ArtistName             string    `json:"artistName"`
+		CollectionName         string    `json:"collectionName"`
+		TrackName              string    `json:"trackName"`
+		ArtistViewURL          string    `json:"artistViewUrl"`
+		CollectionViewURL      string    `json:"collectionViewUrl"`
+		FeedURL                string    `json:"feedUrl"`
+		TrackViewURL           string    `json:"trackViewUrl"`
+		ArtworkURL30           string    `json:"artworkUrl30"`
+		ArtworkURL60           string    `json:"artworkUrl60"`
+		ArtworkURL100          string    `json:"artworkUrl100"`
+		ReleaseDate            string    `json:"releaseDate"`
+		CollectionExplicitness string    `json:"collectionExplicitness"`
+		TrackExplicitness      string    `json:"trackExplicitness"`
+		TrackCount             int       `json:"trackCount"`
+		TrackTimeMillis        int       `json:"trackTimeMillis"`
+		Country                string    `json:"country"`
+		Currency               string    `json:"currency"`
+		PrimaryGenreName       string    `json:"primaryGenreName"`
+		ContentAdvisoryRating  string    `json:"contentAdvisoryRating"`
+		ArtworkURL600          string    `json:"artworkUrl600"`
+		GenreIDs               []string  `json:"genreIds"`
+		Genres                 []string  `json:"genres"`
+		Source                 string    `json:"source,omitempty"`
+		SourceID               string    `json:"sourceId,omitempty"`
+		ISRC                   string    `json:"isrc,omitempty"`
+		UPC                    string    `json:"upc,omitempty"`
+		Software               *Software `json:"software,omitempty"`
+		Video                  *Video    `json:"video,omitempty"`
+	}
+
+	// Software represents the App Store details of an app.
+	Software struct {
+		BundleID          string   `json:"bundleId"`
+		AverageUserRating float64  `json:"averageUserRating"`
+		UserRatingCount   int      `json:"userRatingCount"`
+		ScreenshotURLs    []string `json:"screenshotUrls"`
+		SupportedDevices  []string `json:"supportedDevices"`
+		FileSizeBytes     int64    `json:"fileSizeBytes"`
+		MinimumOSVersion  string   `json:"minimumOsVersion"`
+		SellerName        string   `json:"sellerName"`
+		Price             float64  `json:"price"`
+		FormattedPrice    string   `json:"formattedPrice"`
+	}
+
+	// Video represents the details of a movie, a tv season or a tv episode, the fields which don't apply to it are
+	// left out.
+	Video struct {
+		LongDescription    string  `json:"longDescription,omitempty"`
+		ShortDescription   string  `json:"shortDescription,omitempty"`
+		TrackPrice         float64 `json:"trackPrice,omitempty"`
+		TrackRentalPrice   float64 `json:"trackRentalPrice,omitempty"`
+		TrackHDPrice       float64 `json:"trackHdPrice,omitempty"`
+		TrackHDRentalPrice float64 `json:"trackHdRentalPrice,omitempty"`
+		CollectionPrice    float64 `json:"collectionPrice,omitempty"`
+		CollectionHDPrice  float64 `json:"collectionHdPrice,omitempty"`
+		SeasonNumber       int     `json:"seasonNumber,omitempty"`
+		EpisodeNumber      int     `json:"episodeNumber,omitempty"`
+	}
+
+	// ProviderFailure represents a provider a federated search failed to search, Error is the reason it failed for,
+	// ProviderFailureTimeout or ProviderFailureUnavailable.
+	ProviderFailure struct {
+		Provider string `json:"provider"`
+		Error    string `json:"error"`
 	}
 
 	// SearchMediaResponse represents the media user searched for.
@@ -57,6 +119,11 @@ type (
 		SearchTerm  string  `json:"search_term"`
 		ResultCount int     `json:"result_count"`
 		Media       []Media `json:"media"`
+		// Providers lists the providers of a federated search and FailedProviders the ones of them which failed.
+		Providers       []string          `json:"providers,omitempty"`
+		FailedProviders []ProviderFailure `json:"failed_providers,omitempty"`
+		// FetchedAt is when the result was fetched from upstream, it drives the freshness of cached responses.
+		FetchedAt time.Time `json:"-"`
 	}
 )
 
@@ -68,48 +135,97 @@ func MakeSearchMediaEndpoint(handler handler) endpoint.Endpoint {
 			return nil, fmt.Errorf("failed to parse search media request")
 		}
 
-		res, err := handler.FetchAndInsertMedia(ctx, body.Term, body.Limit)
+		if len(body.Providers) > 0 {
+			res, err := handler.FetchAndInsertFederatedMedia(ctx, body.Providers, body.Term, body.Limit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch and insert media: %w", err)
+			}
+			response := NewSearchMediaResponse(res.MediaResult)
+			response.Providers = res.Providers
+			response.FailedProviders = lo.Map(res.Failures, func(f business.ProviderFailure, _ int) ProviderFailure {
+				return ProviderFailure{Provider: f.Provider, Error: providerFailureReason(f.Err)}
+			})
+			return response, nil
+		}
+
+		var res business.MediaResult
+		var err error
+		if body.Filter != (business.SearchFilter{}) {
+			res, err = handler.FetchAndInsertFilteredMedia(ctx, body.Provider, body.Term, body.Limit, body.Filter)
+		} else {
+			res, err = handler.FetchAndInsertMediaFrom(ctx, body.Provider, body.Term, body.Limit)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch and insert media: %w", err)
 		}
 
-		media := lo.Map(res.Media, func(m business.Media, _ int) Media {
-			return Media{
-				WrapperType:            m.WrapperType,
-				Kind:                   m.Kind,
-				ArtistID:               m.ArtistID,
-				CollectionID:           m.CollectionID,
-				TrackID:                m.TrackID,
-				ArtistName:             m.ArtistName,
-				CollectionName:         m.CollectionName,
-				TrackName:              m.TrackName,
-				ArtistViewURL:          m.ArtistViewURL,
-				CollectionViewURL:      m.CollectionViewURL,
-				FeedURL:                m.FeedURL,
-				TrackViewURL:           m.TrackViewURL,
-				ArtworkURL30:           m.ArtworkURL30,
-				ArtworkURL60:           m.ArtworkURL60,
-				ArtworkURL100:          m.ArtworkURL100,
-				ReleaseDate:            m.ReleaseDate,
-				CollectionExplicitness: m.CollectionExplicitness,
-				TrackExplicitness:      m.TrackExplicitness,
-				TrackCount:             m.TrackCount,
-				TrackTimeMillis:        m.TrackTimeMillis,
-				Country:                m.Country,
-				Currency:               m.Currency,
-				PrimaryGenreName:       m.PrimaryGenreName,
-				ContentAdvisoryRating:  m.ContentAdvisoryRating,
-				ArtworkURL600:          m.ArtworkURL600,
-				GenreIDs:               m.GenreIDs,
-				Genres:                 m.Genres,
-			}
-		})
-
-		return SearchMediaResponse{
-			ID:          res.ID,
-			SearchTerm:  res.SearchTerm,
-			ResultCount: res.ResultCount,
-			Media:       media,
-		}, nil
+		return NewSearchMediaResponse(res), nil
+	}
+}
+
+// SearchKey returns the key identifying the search r asks for, see business.SearchKey.
+func (r SearchMediaRequest) SearchKey() string {
+	if len(r.Providers) > 0 {
+		return business.SearchKey(r.Providers, r.Term, r.Limit, business.SearchFilter{})
+	}
+	return business.SearchKey([]string{r.Provider}, r.Term, r.Limit, r.Filter)
+}
+
+// NewSearchMediaResponse maps a business.MediaResult to its SearchMediaResponse.
+func NewSearchMediaResponse(res business.MediaResult) SearchMediaResponse {
+	return SearchMediaResponse{
+		ID:          res.ID,
+		SearchTerm:  res.SearchTerm,
+		ResultCount: res.ResultCount,
+		Media:       lo.Map(res.Media, mapMedia),
+		FetchedAt:   res.FetchedAt,
+	}
+}
+
+// providerFailureReason returns the reason err failed a provider for.
+func providerFailureReason(err error) string {
+	var timeout interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &timeout) && timeout.Timeout() {
+		return ProviderFailureTimeout
+	}
+	return ProviderFailureUnavailable
+}
+
+// mapMedia maps a business.Media to its transport representation.
+func mapMedia(m business.Media, _ int) Media {
+	return Media{
+		WrapperType:            m.WrapperType,
+		Kind:                   m.Kind,
+		ArtistID:               m.ArtistID,
+		CollectionID:           m.CollectionID,
+		TrackID:                m.TrackID,
+		ArtistName:             m.ArtistName,
+		CollectionName:         m.CollectionName,
+		TrackName:              m.TrackName,
+		ArtistViewURL:          m.ArtistViewURL,
+		CollectionViewURL:      m.CollectionViewURL,
+		FeedURL:                m.FeedURL,
+		TrackViewURL:           m.TrackViewURL,
+		ArtworkURL30:           m.ArtworkURL30,
+		ArtworkURL60:           m.ArtworkURL60,
+		ArtworkURL100:          m.ArtworkURL100,
+		ReleaseDate:            m.ReleaseDate,
+		CollectionExplicitness: m.CollectionExplicitness,
+		TrackExplicitness:      m.TrackExplicitness,
+		TrackCount:             m.TrackCount,
+		TrackTimeMillis:        m.TrackTimeMillis,
+		Country:                m.Country,
+		Currency:               m.Currency,
+		PrimaryGenreName:       m.PrimaryGenreName,
+		ContentAdvisoryRating:  m.ContentAdvisoryRating,
+		ArtworkURL600:          m.ArtworkURL600,
+		GenreIDs:               m.GenreIDs,
+		Genres:                 m.Genres,
+		Source:                 m.Source,
+		SourceID:               m.SourceID,
+		ISRC:                   m.ISRC,
+		UPC:                    m.UPC,
+		Software:               (*Software)(m.Software),
+		Video:                  (*Video)(m.Video),
 	}
 }
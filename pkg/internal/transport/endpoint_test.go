@@ -3,6 +3,7 @@ package transport_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
 	"testing"
@@ -33,7 +34,7 @@ func TestMakeSearchMediaEndpoint(t *testing.T) {
 				Limit: 1,
 			},
 			mockSetup: func() {
-				mockHandler.EXPECT().FetchAndInsertMedia(gomock.Any(), "test", 1).Return(business.MediaResult{
+				mockHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "test", 1).Return(business.MediaResult{
 					ID:          1,
 					SearchTerm:  "test",
 					ResultCount: 1,
@@ -66,6 +67,100 @@ func TestMakeSearchMediaEndpoint(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "successful fetch from provider",
+			request: transport.SearchMediaRequest{
+				Term:     "test",
+				Limit:    1,
+				Provider: "musicbrainz",
+			},
+			mockSetup: func() {
+				mockHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "musicbrainz", "test", 1).Return(business.MediaResult{
+					ID:          2,
+					SearchTerm:  "test",
+					ResultCount: 1,
+					Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackName: "Test Track", Source: "musicbrainz", SourceID: "rec1"}},
+				}, nil)
+			},
+			expectedResponse: transport.SearchMediaResponse{
+				ID:          2,
+				SearchTerm:  "test",
+				ResultCount: 1,
+				Media:       []transport.Media{{WrapperType: "track", Kind: "song", TrackName: "Test Track", Source: "musicbrainz", SourceID: "rec1"}},
+			},
+		},
+		{
+			name: "successful filtered fetch",
+			request: transport.SearchMediaRequest{
+				Term:   "notes",
+				Limit:  1,
+				Filter: business.SearchFilter{Media: "software", MinRating: 4, Price: business.PriceFree},
+			},
+			mockSetup: func() {
+				mockHandler.EXPECT().FetchAndInsertFilteredMedia(gomock.Any(), "", "notes", 1, business.SearchFilter{Media: "software", MinRating: 4, Price: business.PriceFree}).Return(business.MediaResult{
+					ID:          4,
+					SearchTerm:  "notes",
+					ResultCount: 1,
+					Media: []business.Media{{WrapperType: "software", Kind: "software", TrackName: "Notes", Software: &business.Software{
+						BundleID: "com.example.notes", AverageUserRating: 4.5, SupportedDevices: []string{"iPadAir2-iPadAir2"}, FormattedPrice: "Free",
+					}}},
+				}, nil)
+			},
+			expectedResponse: transport.SearchMediaResponse{
+				ID:          4,
+				SearchTerm:  "notes",
+				ResultCount: 1,
+				Media: []transport.Media{{WrapperType: "software", Kind: "software", TrackName: "Notes", Software: &transport.Software{
+					BundleID: "com.example.notes", AverageUserRating: 4.5, SupportedDevices: []string{"iPadAir2-iPadAir2"}, FormattedPrice: "Free",
+				}}},
+			},
+		},
+		{
+			name: "successful federated fetch",
+			request: transport.SearchMediaRequest{
+				Term:      "test",
+				Limit:     1,
+				Providers: []string{"itunes", "musicbrainz"},
+			},
+			mockSetup: func() {
+				mockHandler.EXPECT().FetchAndInsertFederatedMedia(gomock.Any(), []string{"itunes", "musicbrainz"}, "test", 1).Return(business.FederatedMediaResult{
+					MediaResult: business.MediaResult{
+						ID:          3,
+						SearchTerm:  "test",
+						ResultCount: 1,
+						Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackName: "Test Track", ISRC: "USUM70400001", Source: "itunes"}},
+					},
+					Providers: []string{"itunes", "musicbrainz"},
+					Failures: []business.ProviderFailure{
+						{Provider: "musicbrainz", Err: errors.New("musicbrainz is down: dial tcp 10.0.0.7:443: connection refused")},
+						{Provider: "tmdb", Err: fmt.Errorf("failed to fetch media by term from tmdb: %w", context.DeadlineExceeded)},
+					},
+				}, nil)
+			},
+			expectedResponse: transport.SearchMediaResponse{
+				ID:          3,
+				SearchTerm:  "test",
+				ResultCount: 1,
+				Media:       []transport.Media{{WrapperType: "track", Kind: "song", TrackName: "Test Track", ISRC: "USUM70400001", Source: "itunes"}},
+				Providers:   []string{"itunes", "musicbrainz"},
+				FailedProviders: []transport.ProviderFailure{
+					{Provider: "musicbrainz", Error: transport.ProviderFailureUnavailable},
+					{Provider: "tmdb", Error: transport.ProviderFailureTimeout},
+				},
+			},
+		},
+		{
+			name: "federated fetch error",
+			request: transport.SearchMediaRequest{
+				Term:      "test",
+				Limit:     1,
+				Providers: []string{"itunes"},
+			},
+			mockSetup: func() {
+				mockHandler.EXPECT().FetchAndInsertFederatedMedia(gomock.Any(), []string{"itunes"}, "test", 1).Return(business.FederatedMediaResult{}, errors.New("fetch error"))
+			},
+			expectedError: "failed to fetch and insert media: fetch error",
+		},
 		{
 			name: "fetch error",
 			request: transport.SearchMediaRequest{
@@ -73,7 +168,7 @@ func TestMakeSearchMediaEndpoint(t *testing.T) {
 				Limit: 1,
 			},
 			mockSetup: func() {
-				mockHandler.EXPECT().FetchAndInsertMedia(gomock.Any(), "test", 1).Return(business.MediaResult{}, errors.New("fetch error"))
+				mockHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "test", 1).Return(business.MediaResult{}, errors.New("fetch error"))
 			},
 			expectedError:    "failed to fetch and insert media: fetch error",
 			expectedResponse: transport.SearchMediaResponse{},
@@ -0,0 +1,145 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// defaultListSize is the size assumed for lists whose field, or an enclosing one, has no limit argument.
+const defaultListSize = 20
+
+// Limits bounds the queries accepted by the schema, a zero value disables the matching check.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields a query may select.
+	MaxDepth int
+	// MaxComplexity is the most fields a query may resolve. Every field costs one and the fields selected
+	// below a list are counted once per item, lists are sized by the nearest limit argument or defaultListSize.
+	MaxComplexity int
+}
+
+// costWalker computes the depth and complexity of the operation of a document.
+type costWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// checkLimits returns an error when the operation of doc exceeds limits, introspection fields are not counted.
+func checkLimits(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]any, limits Limits) error {
+	if limits.MaxDepth <= 0 && limits.MaxComplexity <= 0 {
+		return nil
+	}
+	w := costWalker{schema: schema, fragments: make(map[string]*ast.FragmentDefinition), variables: variables}
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			w.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	complexity, depth := w.selectionSet(operation.SelectionSet, schema.QueryType(), defaultListSize, nil)
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, limits.MaxDepth)
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, limits.MaxComplexity)
+	}
+	return nil
+}
+
+// selectionSet returns the complexity and depth of set selected on parent, size is the size of the nearest list
+// below and spreads lists the fragments being expanded, which guards against fragment cycles.
+func (w costWalker) selectionSet(set *ast.SelectionSet, parent graphql.Type, size int, spreads []string) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+	complexity, depth := 0, 0
+	for _, selection := range set.Selections {
+		var c, d int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c, d = w.field(selection, parent, size, spreads)
+		case *ast.InlineFragment:
+			typ := parent
+			if selection.TypeCondition != nil {
+				typ = w.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			c, d = w.selectionSet(selection.SelectionSet, typ, size, spreads)
+		case *ast.FragmentSpread:
+			fragment, ok := w.fragments[selection.Name.Value]
+			if !ok || slices.Contains(spreads, fragment.Name.Value) {
+				continue
+			}
+			c, d = w.selectionSet(fragment.SelectionSet, w.schema.Type(fragment.TypeCondition.Name.Value), size, append(spreads, fragment.Name.Value))
+		}
+		complexity += c
+		depth = max(depth, d)
+	}
+	return complexity, depth
+}
+
+// field returns the complexity and depth of a selected field.
+func (w costWalker) field(field *ast.Field, parent graphql.Type, size int, spreads []string) (int, int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return 1, 1
+	}
+	def, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+	if limit := w.limitArgument(field); limit > 0 {
+		size = limit
+	}
+
+	typ, multiplier := graphql.Type(def.Type), 1
+	if nonNull, ok := typ.(*graphql.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	if list, ok := typ.(*graphql.List); ok {
+		typ, multiplier, size = list.OfType, size, defaultListSize
+	}
+	if nonNull, ok := typ.(*graphql.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	complexity, depth := w.selectionSet(field.SelectionSet, typ, size, spreads)
+	return 1 + multiplier*complexity, 1 + depth
+}
+
+// limitArgument returns the value of the limit argument of field, zero when it has none.
+func (w costWalker) limitArgument(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			limit, _ := strconv.Atoi(value.Value)
+			return limit
+		case *ast.Variable:
+			switch limit := w.variables[value.Name.Value].(type) {
+			case int:
+				return limit
+			case float64:
+				return int(limit)
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/graphql"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/graphql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearch := mock.NewMocksearchHandler(ctrl)
+	mockLookup := mock.NewMocklookupHandler(ctrl)
+	mockHistory := mock.NewMockhistoryHandler(ctrl)
+	schema, err := graphql.NewSchema(mockSearch, mockLookup, mockHistory, graphql.Limits{MaxDepth: 4, MaxComplexity: 50})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		request       graphql.Request
+		mockSetup     func()
+		expectedError string
+	}{
+		{
+			name:    "within limits",
+			request: graphql.Request{Query: `{ search(term: "jack", limit: 10) { id media { trackName trackId } } }`},
+			mockSetup: func() {
+				mockSearch.EXPECT().FetchAndInsertMedia(gomock.Any(), "jack", 10).Return(business.MediaResult{}, nil)
+			},
+		},
+		{
+			name:          "too deep",
+			request:       graphql.Request{Query: `{ search(term: "jack", limit: 1) { media { collection { artist { name } } } } }`},
+			mockSetup:     func() {},
+			expectedError: "query depth 5 exceeds the limit of 4",
+		},
+		{
+			name:          "lists are sized by the limit argument",
+			request:       graphql.Request{Query: `{ search(term: "jack", limit: 30) { media { trackName trackId } } }`},
+			mockSetup:     func() {},
+			expectedError: "query complexity 62 exceeds the limit of 50",
+		},
+		{
+			name:          "limit passed as a variable",
+			request:       graphql.Request{Query: `query($n: Int) { history(limit: $n) { id searchTerm } }`, Variables: map[string]any{"n": float64(30)}},
+			mockSetup:     func() {},
+			expectedError: "query complexity 61 exceeds the limit of 50",
+		},
+		{
+			name:          "lists default to twenty items",
+			request:       graphql.Request{Query: `{ history { id searchTerm resultCount } }`},
+			mockSetup:     func() {},
+			expectedError: "query complexity 61 exceeds the limit of 50",
+		},
+		{
+			name:          "fragments are expanded",
+			request:       graphql.Request{Query: `{ search(term: "jack", limit: 30) { ...result } } fragment result on SearchResult { media { trackName trackId } }`},
+			mockSetup:     func() {},
+			expectedError: "query complexity 62 exceeds the limit of 50",
+		},
+		{
+			name:      "introspection is not counted",
+			request:   graphql.Request{Query: `{ __schema { types { name fields { name type { name ofType { name } } } } } }`},
+			mockSetup: func() {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			res := schema.Execute(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.True(t, res.Rejected)
+				require.Len(t, res.Result.Errors, 1)
+				assert.Equal(t, tt.expectedError, res.Result.Errors[0].Message)
+			} else {
+				assert.False(t, res.Rejected)
+				assert.Empty(t, res.Result.Errors)
+			}
+		})
+	}
+}
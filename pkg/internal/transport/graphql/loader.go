@@ -0,0 +1,106 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+)
+
+type loaderContextKey struct{}
+
+// loaded is the outcome of looking a single id up.
+type loaded struct {
+	media business.Media
+	found bool
+	err   error
+}
+
+// mediaLoader batches the lookups of a single request. Ids are queued while a level of the query is resolved
+// and looked up together the first time one of them is needed, each id is looked up at most once.
+type mediaLoader struct {
+	ctx     context.Context
+	lookup  lookupHandler
+	mu      sync.Mutex
+	pending []int
+	results map[int]loaded
+}
+
+func newMediaLoader(ctx context.Context, lookup lookupHandler) *mediaLoader {
+	return &mediaLoader{ctx: ctx, lookup: lookup, results: make(map[int]loaded)}
+}
+
+// contextWithLoader returns a copy of ctx carrying the loader.
+func contextWithLoader(ctx context.Context, loader *mediaLoader) context.Context {
+	return context.WithValue(ctx, loaderContextKey{}, loader)
+}
+
+// loaderFromContext returns the loader carried by ctx.
+func loaderFromContext(ctx context.Context) *mediaLoader {
+	loader, _ := ctx.Value(loaderContextKey{}).(*mediaLoader)
+	return loader
+}
+
+// load queues id and returns a thunk resolving to the item with that id, or nil when it is unknown.
+func (l *mediaLoader) load(id int) func() (any, error) {
+	l.mu.Lock()
+	if _, ok := l.results[id]; !ok {
+		l.results[id] = loaded{}
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			l.flush()
+		}
+		result := l.results[id]
+		if result.err != nil {
+			return nil, result.err
+		}
+		if !result.found {
+			return nil, nil
+		}
+		return result.media, nil
+	}
+}
+
+// flush looks every queued id up, it must be called with the lock held.
+// Ids are sorted as fields are not resolved in a stable order, which keeps upstream calls deterministic.
+func (l *mediaLoader) flush() {
+	ids := l.pending
+	l.pending = nil
+	slices.Sort(ids)
+
+	media, err := l.lookup.LookupMediaBatch(l.ctx, ids)
+	if err != nil {
+		err = fmt.Errorf("failed to lookup media: %w", err)
+		for _, id := range ids {
+			l.results[id] = loaded{err: err}
+		}
+		return
+	}
+	for _, m := range media {
+		id := mediaID(m)
+		if _, queued := l.results[id]; queued {
+			l.results[id] = loaded{media: m, found: true}
+		}
+	}
+}
+
+// mediaID returns the iTunes id of an item of a lookup, artists and collections are identified by
+// their own id rather than a track id.
+func mediaID(m business.Media) int {
+	switch m.WrapperType {
+	case "artist":
+		return m.ArtistID
+	case "collection":
+		return m.CollectionID
+	default:
+		return m.TrackID
+	}
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schema.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocksearchHandler is a mock of searchHandler interface.
+type MocksearchHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearchHandlerMockRecorder
+}
+
+// MocksearchHandlerMockRecorder is the mock recorder for MocksearchHandler.
+type MocksearchHandlerMockRecorder struct {
+	mock *MocksearchHandler
+}
+
+// NewMocksearchHandler creates a new mock instance.
+func NewMocksearchHandler(ctrl *gomock.Controller) *MocksearchHandler {
+	mock := &MocksearchHandler{ctrl: ctrl}
+	mock.recorder = &MocksearchHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksearchHandler) EXPECT() *MocksearchHandlerMockRecorder {
+	return m.recorder
+}
+
+// FetchAndInsertMedia mocks base method.
+func (m *MocksearchHandler) FetchAndInsertMedia(ctx context.Context, term string, limit int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndInsertMedia", ctx, term, limit)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAndInsertMedia indicates an expected call of FetchAndInsertMedia.
+func (mr *MocksearchHandlerMockRecorder) FetchAndInsertMedia(ctx, term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndInsertMedia", reflect.TypeOf((*MocksearchHandler)(nil).FetchAndInsertMedia), ctx, term, limit)
+}
+
+// MocklookupHandler is a mock of lookupHandler interface.
+type MocklookupHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MocklookupHandlerMockRecorder
+}
+
+// MocklookupHandlerMockRecorder is the mock recorder for MocklookupHandler.
+type MocklookupHandlerMockRecorder struct {
+	mock *MocklookupHandler
+}
+
+// NewMocklookupHandler creates a new mock instance.
+func NewMocklookupHandler(ctrl *gomock.Controller) *MocklookupHandler {
+	mock := &MocklookupHandler{ctrl: ctrl}
+	mock.recorder = &MocklookupHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklookupHandler) EXPECT() *MocklookupHandlerMockRecorder {
+	return m.recorder
+}
+
+// LookupMediaBatch mocks base method.
+func (m *MocklookupHandler) LookupMediaBatch(ctx context.Context, ids []int) ([]business.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupMediaBatch", ctx, ids)
+	ret0, _ := ret[0].([]business.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupMediaBatch indicates an expected call of LookupMediaBatch.
+func (mr *MocklookupHandlerMockRecorder) LookupMediaBatch(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupMediaBatch", reflect.TypeOf((*MocklookupHandler)(nil).LookupMediaBatch), ctx, ids)
+}
+
+// MockhistoryHandler is a mock of historyHandler interface.
+type MockhistoryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockhistoryHandlerMockRecorder
+}
+
+// MockhistoryHandlerMockRecorder is the mock recorder for MockhistoryHandler.
+type MockhistoryHandlerMockRecorder struct {
+	mock *MockhistoryHandler
+}
+
+// NewMockhistoryHandler creates a new mock instance.
+func NewMockhistoryHandler(ctrl *gomock.Controller) *MockhistoryHandler {
+	mock := &MockhistoryHandler{ctrl: ctrl}
+	mock.recorder = &MockhistoryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhistoryHandler) EXPECT() *MockhistoryHandlerMockRecorder {
+	return m.recorder
+}
+
+// ListSearchHistory mocks base method.
+func (m *MockhistoryHandler) ListSearchHistory(ctx context.Context, filter business.HistoryFilter) ([]business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSearchHistory", ctx, filter)
+	ret0, _ := ret[0].([]business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSearchHistory indicates an expected call of ListSearchHistory.
+func (mr *MockhistoryHandlerMockRecorder) ListSearchHistory(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearchHistory", reflect.TypeOf((*MockhistoryHandler)(nil).ListSearchHistory), ctx, filter)
+}
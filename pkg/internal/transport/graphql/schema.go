@@ -0,0 +1,335 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/graphql-go/graphql"
+)
+
+const defaultSearchLimit = 20
+
+//go:generate mockgen -source=schema.go -destination=mock/schema.go -package=mock
+type (
+	// searchHandler defines the interface for searching media.
+	searchHandler interface {
+		FetchAndInsertMedia(ctx context.Context, term string, limit int) (business.MediaResult, error)
+	}
+	// lookupHandler defines the interface for looking media up by id in batches.
+	lookupHandler interface {
+		LookupMediaBatch(ctx context.Context, ids []int) ([]business.Media, error)
+	}
+	// historyHandler defines the interface for listing past searches.
+	historyHandler interface {
+		ListSearchHistory(ctx context.Context, filter business.HistoryFilter) ([]business.MediaResult, error)
+	}
+)
+
+// Schema is the executable graphql schema of the media api.
+type Schema struct {
+	schema graphql.Schema
+	lookup lookupHandler
+	limits Limits
+}
+
+// NewSchema creates the graphql schema resolving searches with search, past searches with history
+// and media, artists and collections with lookup. Queries exceeding limits are rejected before execution.
+func NewSchema(search searchHandler, lookup lookupHandler, history historyHandler, limits Limits) (Schema, error) {
+	artist := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Artist",
+		Description: "An artist of the iTunes store.",
+		Fields: graphql.Fields{
+			"id":               intField(func(m business.Media) int { return m.ArtistID }),
+			"name":             stringField(func(m business.Media) string { return m.ArtistName }),
+			"primaryGenreName": stringField(func(m business.Media) string { return m.PrimaryGenreName }),
+		},
+	})
+	collection := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Collection",
+		Description: "A collection of the iTunes store, e.g. an album.",
+		Fields: graphql.Fields{
+			"id":               intField(func(m business.Media) int { return m.CollectionID }),
+			"name":             stringField(func(m business.Media) string { return m.CollectionName }),
+			"viewUrl":          stringField(func(m business.Media) string { return m.CollectionViewURL }),
+			"artworkUrl100":    stringField(func(m business.Media) string { return m.ArtworkURL100 }),
+			"artworkUrl600":    stringField(func(m business.Media) string { return m.ArtworkURL600 }),
+			"trackCount":       intField(func(m business.Media) int { return m.TrackCount }),
+			"releaseDate":      stringField(func(m business.Media) string { return m.ReleaseDate }),
+			"explicitness":     stringField(func(m business.Media) string { return m.CollectionExplicitness }),
+			"primaryGenreName": stringField(func(m business.Media) string { return m.PrimaryGenreName }),
+			"country":          stringField(func(m business.Media) string { return m.Country }),
+			"currency":         stringField(func(m business.Media) string { return m.Currency }),
+			"artist":           loadField(artist, func(m business.Media) int { return m.ArtistID }),
+		},
+	})
+	software := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Software",
+		Description: "The App Store details of an app.",
+		Fields: graphql.Fields{
+			"bundleId":          softwareField(graphql.String, func(s business.Software) any { return s.BundleID }),
+			"averageUserRating": softwareField(graphql.Float, func(s business.Software) any { return s.AverageUserRating }),
+			"userRatingCount":   softwareField(graphql.Int, func(s business.Software) any { return s.UserRatingCount }),
+			"screenshotUrls":    softwareField(stringList, func(s business.Software) any { return s.ScreenshotURLs }),
+			"supportedDevices":  softwareField(stringList, func(s business.Software) any { return s.SupportedDevices }),
+			// graphql ints are 32-bit, apps may be larger than 2GB.
+			"fileSizeBytes":    softwareField(graphql.Float, func(s business.Software) any { return float64(s.FileSizeBytes) }),
+			"minimumOsVersion": softwareField(graphql.String, func(s business.Software) any { return s.MinimumOSVersion }),
+			"sellerName":       softwareField(graphql.String, func(s business.Software) any { return s.SellerName }),
+			"price":            softwareField(graphql.Float, func(s business.Software) any { return s.Price }),
+			"formattedPrice":   softwareField(graphql.String, func(s business.Software) any { return s.FormattedPrice }),
+		},
+	})
+	video := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Video",
+		Description: "The details of a movie, a tv season or a tv episode, prices are zero when not on sale.",
+		Fields: graphql.Fields{
+			"longDescription":    videoField(graphql.String, func(v business.Video) any { return v.LongDescription }),
+			"shortDescription":   videoField(graphql.String, func(v business.Video) any { return v.ShortDescription }),
+			"trackPrice":         videoField(graphql.Float, func(v business.Video) any { return v.TrackPrice }),
+			"trackRentalPrice":   videoField(graphql.Float, func(v business.Video) any { return v.TrackRentalPrice }),
+			"trackHdPrice":       videoField(graphql.Float, func(v business.Video) any { return v.TrackHDPrice }),
+			"trackHdRentalPrice": videoField(graphql.Float, func(v business.Video) any { return v.TrackHDRentalPrice }),
+			"collectionPrice":    videoField(graphql.Float, func(v business.Video) any { return v.CollectionPrice }),
+			"collectionHdPrice":  videoField(graphql.Float, func(v business.Video) any { return v.CollectionHDPrice }),
+			"seasonNumber":       videoField(graphql.Int, func(v business.Video) any { return v.SeasonNumber }),
+			"episodeNumber":      videoField(graphql.Int, func(v business.Video) any { return v.EpisodeNumber }),
+		},
+	})
+	media := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Media",
+		Description: "A single media item of the iTunes store.",
+		Fields: graphql.Fields{
+			"wrapperType":            stringField(func(m business.Media) string { return m.WrapperType }),
+			"kind":                   stringField(func(m business.Media) string { return m.Kind }),
+			"artistId":               intField(func(m business.Media) int { return m.ArtistID }),
+			"collectionId":           intField(func(m business.Media) int { return m.CollectionID }),
+			"trackId":                intField(func(m business.Media) int { return m.TrackID }),
+			"artistName":             stringField(func(m business.Media) string { return m.ArtistName }),
+			"collectionName":         stringField(func(m business.Media) string { return m.CollectionName }),
+			"trackName":              stringField(func(m business.Media) string { return m.TrackName }),
+			"artistViewUrl":          stringField(func(m business.Media) string { return m.ArtistViewURL }),
+			"collectionViewUrl":      stringField(func(m business.Media) string { return m.CollectionViewURL }),
+			"feedUrl":                stringField(func(m business.Media) string { return m.FeedURL }),
+			"trackViewUrl":           stringField(func(m business.Media) string { return m.TrackViewURL }),
+			"artworkUrl30":           stringField(func(m business.Media) string { return m.ArtworkURL30 }),
+			"artworkUrl60":           stringField(func(m business.Media) string { return m.ArtworkURL60 }),
+			"artworkUrl100":          stringField(func(m business.Media) string { return m.ArtworkURL100 }),
+			"artworkUrl600":          stringField(func(m business.Media) string { return m.ArtworkURL600 }),
+			"releaseDate":            stringField(func(m business.Media) string { return m.ReleaseDate }),
+			"collectionExplicitness": stringField(func(m business.Media) string { return m.CollectionExplicitness }),
+			"trackExplicitness":      stringField(func(m business.Media) string { return m.TrackExplicitness }),
+			"trackCount":             intField(func(m business.Media) int { return m.TrackCount }),
+			"trackTimeMillis":        intField(func(m business.Media) int { return m.TrackTimeMillis }),
+			"country":                stringField(func(m business.Media) string { return m.Country }),
+			"currency":               stringField(func(m business.Media) string { return m.Currency }),
+			"primaryGenreName":       stringField(func(m business.Media) string { return m.PrimaryGenreName }),
+			"contentAdvisoryRating":  stringField(func(m business.Media) string { return m.ContentAdvisoryRating }),
+			"genreIds":               stringsField(func(m business.Media) []string { return m.GenreIDs }),
+			"genres":                 stringsField(func(m business.Media) []string { return m.Genres }),
+			"source":                 stringField(func(m business.Media) string { return m.Source }),
+			"sourceId":               stringField(func(m business.Media) string { return m.SourceID }),
+			"isrc":                   stringField(func(m business.Media) string { return m.ISRC }),
+			"upc":                    stringField(func(m business.Media) string { return m.UPC }),
+			"software": &graphql.Field{
+				Type:        software,
+				Description: "Only set on apps.",
+				Resolve:     mediaResolver(func(m business.Media) any { return m.Software }),
+			},
+			"video": &graphql.Field{
+				Type:        video,
+				Description: "Only set on movies, tv seasons and tv episodes.",
+				Resolve:     mediaResolver(func(m business.Media) any { return m.Video }),
+			},
+			"artist":     loadField(artist, func(m business.Media) int { return m.ArtistID }),
+			"collection": loadField(collection, func(m business.Media) int { return m.CollectionID }),
+		},
+	})
+	searchResult := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "SearchResult",
+		Description: "The media found for a search term.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.ID),
+				Resolve: resultResolver(func(r business.MediaResult) any { return r.ID }),
+			},
+			"searchTerm": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.String),
+				Resolve: resultResolver(func(r business.MediaResult) any { return r.SearchTerm }),
+			},
+			"resultCount": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Int),
+				Resolve: resultResolver(func(r business.MediaResult) any { return r.ResultCount }),
+			},
+			"fetchedAt": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.DateTime),
+				Resolve: resultResolver(func(r business.MediaResult) any { return r.FetchedAt }),
+			},
+			"media": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(media))),
+				Resolve: resultResolver(func(r business.MediaResult) any { return r.Media }),
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"search": &graphql.Field{
+				Type:        graphql.NewNonNull(searchResult),
+				Description: "Searches the iTunes store for the term, the result is recorded in the search history.",
+				Args: graphql.FieldConfigArgument{
+					"term":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultSearchLimit},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					term, _ := p.Args["term"].(string)
+					if term == "" {
+						return nil, fmt.Errorf("term shouldn't be empty")
+					}
+					limit, _ := p.Args["limit"].(int)
+					if limit <= 0 {
+						limit = defaultSearchLimit
+					}
+					result, err := search.FetchAndInsertMedia(p.Context, term, limit)
+					if err != nil {
+						return nil, fmt.Errorf("failed to fetch and insert media: %w", err)
+					}
+					return result, nil
+				},
+			},
+			"history": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(searchResult))),
+				Description: "Lists past searches, most recent first.",
+				Args: graphql.FieldConfigArgument{
+					"term":   &graphql.ArgumentConfig{Type: graphql.String},
+					"since":  &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"until":  &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					filter := business.HistoryFilter{}
+					filter.Term, _ = p.Args["term"].(string)
+					filter.Since, _ = p.Args["since"].(time.Time)
+					filter.Until, _ = p.Args["until"].(time.Time)
+					filter.Limit, _ = p.Args["limit"].(int)
+					filter.Offset, _ = p.Args["offset"].(int)
+					results, err := history.ListSearchHistory(p.Context, filter)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list search history: %w", err)
+					}
+					return results, nil
+				},
+			},
+			"media":      lookupField(media, "Looks a media item up by its iTunes track id."),
+			"artist":     lookupField(artist, "Looks an artist up by its iTunes id."),
+			"collection": lookupField(collection, "Looks a collection up by its iTunes id."),
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to build graphql schema: %w", err)
+	}
+	return Schema{schema: schema, lookup: lookup, limits: limits}, nil
+}
+
+// stringField returns a field resolving a string attribute of a media item.
+func stringField(get func(m business.Media) string) *graphql.Field {
+	return &graphql.Field{Type: graphql.String, Resolve: mediaResolver(func(m business.Media) any { return get(m) })}
+}
+
+// intField returns a field resolving an int attribute of a media item.
+func intField(get func(m business.Media) int) *graphql.Field {
+	return &graphql.Field{Type: graphql.Int, Resolve: mediaResolver(func(m business.Media) any { return get(m) })}
+}
+
+// stringList is the type of the list attributes of media items.
+var stringList = graphql.NewList(graphql.NewNonNull(graphql.String))
+
+// stringsField returns a field resolving a list attribute of a media item.
+func stringsField(get func(m business.Media) []string) *graphql.Field {
+	return &graphql.Field{
+		Type:    stringList,
+		Resolve: mediaResolver(func(m business.Media) any { return get(m) }),
+	}
+}
+
+// softwareField returns a field of typ resolving an attribute of the App Store details of an app.
+func softwareField(typ graphql.Output, get func(s business.Software) any) *graphql.Field {
+	return &graphql.Field{Type: typ, Resolve: func(p graphql.ResolveParams) (any, error) {
+		s, ok := p.Source.(*business.Software)
+		if !ok || s == nil {
+			return nil, nil
+		}
+		return get(*s), nil
+	}}
+}
+
+// videoField returns a field of typ resolving an attribute of the details of a movie, a tv season or a tv episode.
+func videoField(typ graphql.Output, get func(v business.Video) any) *graphql.Field {
+	return &graphql.Field{Type: typ, Resolve: func(p graphql.ResolveParams) (any, error) {
+		v, ok := p.Source.(*business.Video)
+		if !ok || v == nil {
+			return nil, nil
+		}
+		return get(*v), nil
+	}}
+}
+
+// loadField returns a field resolving the item of typ whose id is read from the media item, the item is loaded
+// along with every other item requested at the same level of the query.
+func loadField(typ *graphql.Object, id func(m business.Media) int) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			m, ok := p.Source.(business.Media)
+			if !ok || id(m) == 0 {
+				return nil, nil
+			}
+			return loaderFromContext(p.Context).load(id(m)), nil
+		},
+	}
+}
+
+// lookupField returns a root field looking an item of typ up by id.
+func lookupField(typ *graphql.Object, description string) *graphql.Field {
+	return &graphql.Field{
+		Type:        typ,
+		Description: description,
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			id, _ := p.Args["id"].(int)
+			if id <= 0 {
+				return nil, fmt.Errorf("id should be a positive number")
+			}
+			return loaderFromContext(p.Context).load(id), nil
+		},
+	}
+}
+
+// mediaResolver returns a resolver reading a value of the media item the field belongs to.
+func mediaResolver(get func(m business.Media) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		m, ok := p.Source.(business.Media)
+		if !ok {
+			return nil, nil
+		}
+		return get(m), nil
+	}
+}
+
+// resultResolver returns a resolver reading a value of the search result the field belongs to.
+func resultResolver(get func(r business.MediaResult) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		r, ok := p.Source.(business.MediaResult)
+		if !ok {
+			return nil, nil
+		}
+		return get(r), nil
+	}
+}
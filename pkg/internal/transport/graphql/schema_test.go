@@ -0,0 +1,144 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/graphql"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/graphql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaExecute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSearch := mock.NewMocksearchHandler(ctrl)
+	mockLookup := mock.NewMocklookupHandler(ctrl)
+	mockHistory := mock.NewMockhistoryHandler(ctrl)
+	schema, err := graphql.NewSchema(mockSearch, mockLookup, mockHistory, graphql.Limits{})
+	require.NoError(t, err)
+
+	fetchedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	searchResult := business.MediaResult{
+		ID:          7,
+		SearchTerm:  "jack johnson",
+		ResultCount: 2,
+		FetchedAt:   fetchedAt,
+		Media: []business.Media{
+			{WrapperType: "track", TrackID: 1, TrackName: "Upside Down", ArtistID: 10, CollectionID: 100},
+			{WrapperType: "track", TrackID: 2, TrackName: "Banana Pancakes", ArtistID: 10, CollectionID: 200},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		request      graphql.Request
+		mockSetup    func()
+		expectedJSON string
+		rejected     bool
+	}{
+		{
+			name:    "search",
+			request: graphql.Request{Query: `{ search(term: "jack johnson", limit: 2) { id searchTerm resultCount fetchedAt media { trackName } } }`},
+			mockSetup: func() {
+				mockSearch.EXPECT().FetchAndInsertMedia(gomock.Any(), "jack johnson", 2).Return(searchResult, nil)
+			},
+			expectedJSON: `{"data":{"search":{"id":"7","searchTerm":"jack johnson","resultCount":2,"fetchedAt":"2025-01-02T03:04:05Z","media":[{"trackName":"Upside Down"},{"trackName":"Banana Pancakes"}]}}}`,
+		},
+		{
+			name:    "artists and collections are looked up in a single batch",
+			request: graphql.Request{Query: `{ search(term: "jack johnson") { media { artist { name } collection { name artist { id } } } } }`},
+			mockSetup: func() {
+				mockSearch.EXPECT().FetchAndInsertMedia(gomock.Any(), "jack johnson", 20).Return(searchResult, nil)
+				mockLookup.EXPECT().LookupMediaBatch(gomock.Any(), []int{10, 100, 200}).Return([]business.Media{
+					{WrapperType: "artist", ArtistID: 10, ArtistName: "Jack Johnson"},
+					{WrapperType: "collection", CollectionID: 100, CollectionName: "In Between Dreams", ArtistID: 10},
+				}, nil)
+			},
+			expectedJSON: `{"data":{"search":{"media":[` +
+				`{"artist":{"name":"Jack Johnson"},"collection":{"name":"In Between Dreams","artist":{"id":10}}},` +
+				`{"artist":{"name":"Jack Johnson"},"collection":null}]}}}`,
+		},
+		{
+			name:    "lookup by id",
+			request: graphql.Request{Query: `query($id: Int!) { media(id: $id) { trackName } other: artist(id: 10) { name } }`, Variables: map[string]any{"id": 1}},
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaBatch(gomock.Any(), []int{1, 10}).Return([]business.Media{
+					{WrapperType: "track", TrackID: 1, TrackName: "Upside Down"},
+					{WrapperType: "artist", ArtistID: 10, ArtistName: "Jack Johnson"},
+				}, nil)
+			},
+			expectedJSON: `{"data":{"media":{"trackName":"Upside Down"},"other":{"name":"Jack Johnson"}}}`,
+		},
+		{
+			name: "software, video and source details",
+			request: graphql.Request{Query: `{ app: media(id: 1) { source isrc software { bundleId fileSizeBytes } video { seasonNumber } } ` +
+				`episode: media(id: 2) { video { trackHdPrice episodeNumber } software { bundleId } } }`},
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaBatch(gomock.Any(), []int{1, 2}).Return([]business.Media{
+					{WrapperType: "software", TrackID: 1, Source: "itunes", Software: &business.Software{BundleID: "com.example.app", FileSizeBytes: 3221225472}},
+					{WrapperType: "track", TrackID: 2, Video: &business.Video{TrackHDPrice: 2.99, EpisodeNumber: 3}},
+				}, nil)
+			},
+			expectedJSON: `{"data":{"app":{"source":"itunes","isrc":"","software":{"bundleId":"com.example.app","fileSizeBytes":3221225472},"video":null},` +
+				`"episode":{"video":{"trackHdPrice":2.99,"episodeNumber":3},"software":null}}}`,
+		},
+		{
+			name:    "lookup error",
+			request: graphql.Request{Query: `{ media(id: 1) { trackName } }`},
+			mockSetup: func() {
+				mockLookup.EXPECT().LookupMediaBatch(gomock.Any(), []int{1}).Return(nil, errors.New("upstream down"))
+			},
+			expectedJSON: `{"data":{"media":null},"errors":[{"message":"failed to lookup media: upstream down","locations":[{"line":1,"column":3}],"path":["media"]}]}`,
+		},
+		{
+			name: "history",
+			request: graphql.Request{
+				Query: `{ history(term: "jack", since: "2025-01-01T00:00:00Z", limit: 5, offset: 10) { id searchTerm } }`,
+			},
+			mockSetup: func() {
+				mockHistory.EXPECT().ListSearchHistory(gomock.Any(), business.HistoryFilter{
+					Term:   "jack",
+					Since:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Limit:  5,
+					Offset: 10,
+				}).Return([]business.MediaResult{{ID: 3, SearchTerm: "jack"}}, nil)
+			},
+			expectedJSON: `{"data":{"history":[{"id":"3","searchTerm":"jack"}]}}`,
+		},
+		{
+			name:         "syntax error",
+			request:      graphql.Request{Query: `{ search(`},
+			mockSetup:    func() {},
+			expectedJSON: `{"data":null,"errors":[{"message":"Syntax Error GraphQL request (1:10) Expected Name, found EOF\n\n1: { search(\n            ^\n","locations":[{"line":1,"column":10}]}]}`,
+			rejected:     true,
+		},
+		{
+			name:         "unknown field",
+			request:      graphql.Request{Query: `{ unknown }`},
+			mockSetup:    func() {},
+			expectedJSON: `{"data":null,"errors":[{"message":"Cannot query field \"unknown\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}`,
+			rejected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			res := schema.Execute(context.Background(), tt.request)
+
+			assert.Equal(t, tt.rejected, res.Rejected)
+			body, err := json.Marshal(res.Result)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedJSON, string(body))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// maxRequestSize is the largest request body accepted.
+const maxRequestSize = 1 << 20
+
+type (
+	// Request represents a graphql request.
+	Request struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+	}
+
+	// Response represents the result of a graphql request.
+	Response struct {
+		Result *graphql.Result
+		// Rejected is set when the request was not executed because it is malformed, invalid or exceeds the limits.
+		Rejected bool
+	}
+)
+
+// Execute parses, validates and checks the limits of the request before executing it.
+func (s Schema) Execute(ctx context.Context, req Request) Response {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return rejected(gqlerrors.FormatErrors(err))
+	}
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return rejected(validation.Errors)
+	}
+	if err := checkLimits(&s.schema, doc, req.OperationName, req.Variables, s.limits); err != nil {
+		return rejected(gqlerrors.FormatErrors(err))
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       contextWithLoader(ctx, newMediaLoader(ctx, s.lookup)),
+	})
+	return Response{Result: result}
+}
+
+func rejected(errs []gqlerrors.FormattedError) Response {
+	return Response{Result: &graphql.Result{Errors: errs}, Rejected: true}
+}
+
+// MakeEndpoint function to make graphql endpoint call.
+func MakeEndpoint(schema Schema) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req, ok := request.(Request)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse graphql request")
+		}
+		return schema.Execute(ctx, req), nil
+	}
+}
+
+// DecodeRequest function decodes graphql request, read from the json body of POST requests
+// and from the query, operationName and variables parameters of GET requests.
+func DecodeRequest(_ context.Context, r *http.Request) (any, error) {
+	var req Request
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return nil, fmt.Errorf("%w: variables should be a json object", transport.ErrInvalidRequest)
+			}
+		}
+	} else {
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestSize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return nil, fmt.Errorf("%w: body exceeds %d bytes", transport.ErrInvalidRequest, maxRequestSize)
+			}
+			return nil, fmt.Errorf("%w: failed to read body", transport.ErrInvalidRequest)
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, fmt.Errorf("%w: body should be a json object", transport.ErrInvalidRequest)
+		}
+	}
+	if req.Query == "" {
+		return nil, fmt.Errorf("%w: query shouldn't be empty", transport.ErrInvalidRequest)
+	}
+	return req, nil
+}
+
+// EncodeResponse function to encode graphql response back, rejected requests are answered with 400.
+func EncodeResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res, ok := response.(Response)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse graphql response, got %v", response).Error(),
+		})
+	}
+	if res.Rejected {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	return json.NewEncoder(w).Encode(res.Result)
+}
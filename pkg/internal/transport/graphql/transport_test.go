@@ -0,0 +1,115 @@
+package graphql_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/graphql"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *http.Request
+		expectedRequest graphql.Request
+		expectedError   string
+	}{
+		{
+			name: "post body",
+			request: httptest.NewRequest(http.MethodPost, "/graphql",
+				strings.NewReader(`{"query":"query Q($n: Int) { history(limit: $n) { id } }","operationName":"Q","variables":{"n":5}}`)),
+			expectedRequest: graphql.Request{
+				Query:         "query Q($n: Int) { history(limit: $n) { id } }",
+				OperationName: "Q",
+				Variables:     map[string]any{"n": float64(5)},
+			},
+		},
+		{
+			name:            "get parameters",
+			request:         httptest.NewRequest(http.MethodGet, `/graphql?query=%7B+media(id:1)+%7B+kind+%7D+%7D&variables=%7B%22a%22:1%7D`, nil),
+			expectedRequest: graphql.Request{Query: "{ media(id:1) { kind } }", Variables: map[string]any{"a": float64(1)}},
+		},
+		{
+			name:          "malformed body",
+			request:       httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":`)),
+			expectedError: "invalid request: body should be a json object",
+		},
+		{
+			name:          "malformed variables",
+			request:       httptest.NewRequest(http.MethodGet, "/graphql?query=%7B+history+%7B+id+%7D+%7D&variables=1", nil),
+			expectedError: "invalid request: variables should be a json object",
+		},
+		{
+			name:          "body too large",
+			request:       httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(strings.Repeat(" ", 1<<20+1))),
+			expectedError: "invalid request: body exceeds 1048576 bytes",
+		},
+		{
+			name:          "missing query",
+			request:       httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{}`)),
+			expectedError: "invalid request: query shouldn't be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := graphql.DecodeRequest(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.ErrorIs(t, err, transport.ErrInvalidRequest)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, req)
+			}
+		})
+	}
+}
+
+func TestEncodeResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		response       any
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "executed",
+			response:       graphql.Response{Result: &gql.Result{Data: map[string]any{"media": nil}}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"media":null}}`,
+		},
+		{
+			name:           "rejected",
+			response:       graphql.Response{Result: &gql.Result{Errors: []gqlerrors.FormattedError{{Message: "query depth 5 exceeds the limit of 4"}}}, Rejected: true},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":null,"errors":[{"message":"query depth 5 exceeds the limit of 4","locations":null}]}`,
+		},
+		{
+			name:           "unexpected response",
+			response:       "unexpected",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":"failed to parse graphql response, got unexpected"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			err := graphql.EncodeResponse(context.Background(), w, tt.response)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
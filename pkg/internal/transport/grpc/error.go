@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// EncodeError function maps endpoint errors to a grpc status with a matching code. The quota of the api key is
+// sent in the header metadata and, for rate limited calls, the seconds to wait in the retry-after trailer.
+func EncodeError(ctx context.Context, err error) error {
+	if md := quotaMetadata(ctx); md.Len() > 0 {
+		// SetHeader only fails outside of a grpc call, e.g. in tests, the status is returned nonetheless.
+		_ = grpc.SetHeader(ctx, md)
+	}
+	code := codes.Internal
+	switch {
+	case errors.Is(err, transport.ErrInvalidRequest), errors.Is(err, business.ErrUnknownProvider), errors.Is(err, business.ErrUnsupportedFilter):
+		code = codes.InvalidArgument
+	case errors.Is(err, business.ErrUnauthorized):
+		code = codes.Unauthenticated
+	case errors.Is(err, business.ErrForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, business.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, business.ErrConflict):
+		code = codes.FailedPrecondition
+	case errors.Is(err, ratelimit.ErrLimited):
+		code = codes.ResourceExhausted
+		var limitErr *ratelimit.LimitError
+		if errors.As(err, &limitErr) {
+			setRetryAfter(ctx, max(int(math.Ceil(limitErr.RetryAfter.Seconds())), 1))
+		}
+	case errors.Is(err, business.ErrQuotaExceeded):
+		code = codes.ResourceExhausted
+		if info, ok := transport.AuthInfoFromContext(ctx); ok && !info.Quota.Reset.IsZero() {
+			setRetryAfter(ctx, max(int(time.Until(info.Quota.Reset).Seconds())+1, 1))
+		}
+	}
+	return status.Error(code, err.Error())
+}
+
+func setRetryAfter(ctx context.Context, seconds int) {
+	_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+}
+
+// PopulateAuthInfo function returns a request func putting the credentials of the call in the context,
+// the api key is read from the given metadata key and the bearer token from the authorization metadata.
+func PopulateAuthInfo(apiKeyHeader string) func(ctx context.Context, md metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		return transport.ContextWithAuthInfo(ctx, &transport.AuthInfo{
+			APIKey:      first(md, apiKeyHeader),
+			BearerToken: bearerToken(first(md, "authorization")),
+		})
+	}
+}
+
+// PopulateClientIP function puts the ip of the peer of the call in the context.
+func PopulateClientIP(ctx context.Context, _ metadata.MD) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ctx
+	}
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return transport.ContextWithClientIP(ctx, ip)
+}
+
+// EncodeQuotaMetadata function writes the x-ratelimit-* header metadata of the authenticated api key, if any.
+func EncodeQuotaMetadata(ctx context.Context, header *metadata.MD, _ *metadata.MD) context.Context {
+	md := quotaMetadata(ctx)
+	if md.Len() == 0 {
+		return ctx
+	}
+	*header = metadata.Join(*header, md)
+	return ctx
+}
+
+func quotaMetadata(ctx context.Context) metadata.MD {
+	info, ok := transport.AuthInfoFromContext(ctx)
+	if !ok || info.Quota.Limit == 0 {
+		return nil
+	}
+	return metadata.Pairs(
+		"x-ratelimit-limit", strconv.Itoa(info.Quota.Limit),
+		"x-ratelimit-remaining", strconv.Itoa(info.Quota.Remaining),
+		"x-ratelimit-reset", strconv.FormatInt(info.Quota.Reset.Unix(), 10),
+	)
+}
+
+// first returns the first value of key in md.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// bearerToken returns the token of a bearer authorization value, the scheme is case-insensitive.
+func bearerToken(value string) string {
+	scheme, token, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
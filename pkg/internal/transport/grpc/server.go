@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/NawafSwe/media-scout-service/pkg/pb/mediascout/v1"
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+)
+
+// Endpoints holds the endpoints served by the media scout grpc service.
+type Endpoints struct {
+	SearchMedia       endpoint.Endpoint
+	LookupMedia       endpoint.Endpoint
+	ListSearchHistory endpoint.Endpoint
+}
+
+// Server serves the media scout grpc service through go-kit handlers.
+type Server struct {
+	pb.UnimplementedMediaScoutServiceServer
+	searchMedia       kitgrpc.Handler
+	lookupMedia       kitgrpc.Handler
+	listSearchHistory kitgrpc.Handler
+}
+
+// NewServer creates the media scout grpc service serving endpoints with the given server options.
+func NewServer(endpoints Endpoints, opts ...kitgrpc.ServerOption) *Server {
+	return &Server{
+		searchMedia:       kitgrpc.NewServer(endpoints.SearchMedia, DecodeSearchMediaRequest, EncodeSearchMediaResponse, opts...),
+		lookupMedia:       kitgrpc.NewServer(endpoints.LookupMedia, DecodeLookupMediaRequest, EncodeLookupMediaResponse, opts...),
+		listSearchHistory: kitgrpc.NewServer(endpoints.ListSearchHistory, DecodeListSearchHistoryRequest, EncodeListSearchHistoryResponse, opts...),
+	}
+}
+
+// SearchMedia searches media by term and stores the result.
+func (s *Server) SearchMedia(ctx context.Context, req *pb.SearchMediaRequest) (*pb.SearchMediaResponse, error) {
+	ctx, res, err := s.searchMedia.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, EncodeError(ctx, err)
+	}
+	return res.(*pb.SearchMediaResponse), nil
+}
+
+// LookupMedia looks media up by its iTunes id.
+func (s *Server) LookupMedia(ctx context.Context, req *pb.LookupMediaRequest) (*pb.LookupMediaResponse, error) {
+	ctx, res, err := s.lookupMedia.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, EncodeError(ctx, err)
+	}
+	return res.(*pb.LookupMediaResponse), nil
+}
+
+// ListSearchHistory lists the stored searches, most recent first.
+func (s *Server) ListSearchHistory(ctx context.Context, req *pb.ListSearchHistoryRequest) (*pb.ListSearchHistoryResponse, error) {
+	ctx, res, err := s.listSearchHistory.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, EncodeError(ctx, err)
+	}
+	return res.(*pb.ListSearchHistoryResponse), nil
+}
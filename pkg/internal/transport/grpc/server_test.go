@@ -0,0 +1,194 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	grpctransport "github.com/NawafSwe/media-scout-service/pkg/internal/transport/grpc"
+	pb "github.com/NawafSwe/media-scout-service/pkg/pb/mediascout/v1"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newClient serves endpoints on an in-memory listener and returns a client connected to it.
+func newClient(t *testing.T, endpoints grpctransport.Endpoints) pb.MediaScoutServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterMediaScoutServiceServer(srv, grpctransport.NewServer(endpoints,
+		kitgrpc.ServerBefore(grpctransport.PopulateAuthInfo("X-API-Key"), grpctransport.PopulateClientIP),
+		kitgrpc.ServerAfter(grpctransport.EncodeQuotaMetadata),
+	))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return pb.NewMediaScoutServiceClient(conn)
+}
+
+func TestServer_SearchMedia(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	var (
+		received any
+		info     transport.AuthInfo
+	)
+	client := newClient(t, grpctransport.Endpoints{
+		SearchMedia: func(ctx context.Context, request any) (any, error) {
+			received = request
+			authInfo, _ := transport.AuthInfoFromContext(ctx)
+			info = *authInfo
+			authInfo.Quota = business.Quota{Limit: 60, Remaining: 59, Reset: reset}
+			return transport.SearchMediaResponse{
+				ID:          1,
+				SearchTerm:  "jack johnson",
+				ResultCount: 1,
+				Media:       []transport.Media{{WrapperType: "track", Kind: "song", TrackID: 456, Genres: []string{"Rock"}}},
+			}, nil
+		},
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "msk_key", "authorization", "Bearer token")
+	var header metadata.MD
+	res, err := client.SearchMedia(ctx, &pb.SearchMediaRequest{Term: "jack johnson"}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.SearchMediaResponse{
+		Id:          1,
+		SearchTerm:  "jack johnson",
+		ResultCount: 1,
+		Media:       []*pb.Media{{WrapperType: "track", Kind: "song", TrackId: 456, Genres: []string{"Rock"}}},
+	}, res))
+	assert.Equal(t, transport.SearchMediaRequest{Term: "jack johnson", Limit: 20}, received)
+	assert.Equal(t, "msk_key", info.APIKey)
+	assert.Equal(t, "token", info.BearerToken)
+	assert.Equal(t, []string{"60"}, header.Get("x-ratelimit-limit"))
+	assert.Equal(t, []string{"59"}, header.Get("x-ratelimit-remaining"))
+}
+
+func TestServer_Errors(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		request            *pb.SearchMediaRequest
+		expectedCode       codes.Code
+		expectedRetryAfter []string
+	}{
+		{
+			name:         "invalid request",
+			request:      &pb.SearchMediaRequest{},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "unauthorized",
+			err:          business.ErrUnauthorized,
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "forbidden",
+			err:          business.ErrForbidden,
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "not found",
+			err:          business.ErrNotFound,
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:               "rate limited",
+			err:                &ratelimit.LimitError{RetryAfter: 1500 * time.Millisecond},
+			expectedCode:       codes.ResourceExhausted,
+			expectedRetryAfter: []string{"2"},
+		},
+		{
+			name:         "internal",
+			err:          errors.New("itunes is down"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, grpctransport.Endpoints{
+				SearchMedia: func(context.Context, any) (any, error) { return nil, tt.err },
+			})
+			request := tt.request
+			if request == nil {
+				request = &pb.SearchMediaRequest{Term: "jack"}
+			}
+
+			var trailer metadata.MD
+			_, err := client.SearchMedia(context.Background(), request, grpc.Trailer(&trailer))
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedRetryAfter, trailer.Get("retry-after"))
+		})
+	}
+}
+
+func TestServer_LookupMedia(t *testing.T) {
+	client := newClient(t, grpctransport.Endpoints{
+		LookupMedia: func(_ context.Context, request any) (any, error) {
+			assert.Equal(t, transport.LookupMediaRequest{ID: 909253}, request)
+			return transport.LookupMediaResponse{ResultCount: 3, Media: []transport.Media{
+				{WrapperType: "artist", ArtistID: 909253, Source: "itunes"},
+				{WrapperType: "software", TrackID: 1, Software: &transport.Software{BundleID: "com.example.app", UserRatingCount: 10, FileSizeBytes: 1024}},
+				{WrapperType: "track", Kind: "tv-episode", ISRC: "USUM71703861", Video: &transport.Video{TrackHDPrice: 2.99, SeasonNumber: 2, EpisodeNumber: 3}},
+			}}, nil
+		},
+	})
+
+	res, err := client.LookupMedia(context.Background(), &pb.LookupMediaRequest{Id: 909253})
+
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.LookupMediaResponse{ResultCount: 3, Media: []*pb.Media{
+		{WrapperType: "artist", ArtistId: 909253, Source: "itunes"},
+		{WrapperType: "software", TrackId: 1, Software: &pb.Software{BundleId: "com.example.app", UserRatingCount: 10, FileSizeBytes: 1024}},
+		{WrapperType: "track", Kind: "tv-episode", Isrc: "USUM71703861", Video: &pb.Video{TrackHdPrice: 2.99, SeasonNumber: 2, EpisodeNumber: 3}},
+	}}, res))
+
+	_, err = client.LookupMedia(context.Background(), &pb.LookupMediaRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ListSearchHistory(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	client := newClient(t, grpctransport.Endpoints{
+		ListSearchHistory: func(_ context.Context, request any) (any, error) {
+			assert.Equal(t, transport.ListSearchHistoryRequest{Term: "jack", Since: since, Limit: 10}, request)
+			return transport.ListSearchHistoryResponse{Searches: []transport.HistoryEntry{
+				{ID: 1, SearchTerm: "jack", CreatedAt: createdAt},
+			}}, nil
+		},
+	})
+
+	res, err := client.ListSearchHistory(context.Background(), &pb.ListSearchHistoryRequest{Term: "jack", Since: timestamppb.New(since), Limit: 10})
+
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.ListSearchHistoryResponse{Searches: []*pb.HistoryEntry{
+		{Id: 1, SearchTerm: "jack", CreatedAt: timestamppb.New(createdAt)},
+	}}, res))
+
+	_, err = client.ListSearchHistory(context.Background(), &pb.ListSearchHistoryRequest{Offset: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	pb "github.com/NawafSwe/media-scout-service/pkg/pb/mediascout/v1"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultSearchLimit = 20
+
+// DecodeSearchMediaRequest function decodes search media request.
+func DecodeSearchMediaRequest(_ context.Context, request any) (any, error) {
+	req, ok := request.(*pb.SearchMediaRequest)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected request %T", transport.ErrInvalidRequest, request)
+	}
+	if req.GetTerm() == "" {
+		return nil, fmt.Errorf("%w: term shouldn't be empty", transport.ErrInvalidRequest)
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	return transport.SearchMediaRequest{Term: req.GetTerm(), Limit: limit}, nil
+}
+
+// EncodeSearchMediaResponse function to encode media search response back.
+func EncodeSearchMediaResponse(_ context.Context, response any) (any, error) {
+	res, ok := response.(transport.SearchMediaResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse search media response, got %v", response)
+	}
+	return &pb.SearchMediaResponse{
+		Id:          res.ID,
+		SearchTerm:  res.SearchTerm,
+		ResultCount: int32(res.ResultCount),
+		Media:       lo.Map(res.Media, mapMedia),
+	}, nil
+}
+
+// DecodeLookupMediaRequest function decodes lookup media request.
+func DecodeLookupMediaRequest(_ context.Context, request any) (any, error) {
+	req, ok := request.(*pb.LookupMediaRequest)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected request %T", transport.ErrInvalidRequest, request)
+	}
+	if req.GetId() <= 0 {
+		return nil, fmt.Errorf("%w: id should be a positive number", transport.ErrInvalidRequest)
+	}
+	return transport.LookupMediaRequest{ID: int(req.GetId())}, nil
+}
+
+// EncodeLookupMediaResponse function to encode lookup media response back.
+func EncodeLookupMediaResponse(_ context.Context, response any) (any, error) {
+	res, ok := response.(transport.LookupMediaResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse lookup media response, got %v", response)
+	}
+	return &pb.LookupMediaResponse{
+		ResultCount: int32(res.ResultCount),
+		Media:       lo.Map(res.Media, mapMedia),
+	}, nil
+}
+
+// DecodeListSearchHistoryRequest function decodes list search history request.
+func DecodeListSearchHistoryRequest(_ context.Context, request any) (any, error) {
+	req, ok := request.(*pb.ListSearchHistoryRequest)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected request %T", transport.ErrInvalidRequest, request)
+	}
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return nil, fmt.Errorf("%w: limit and offset should be non-negative numbers", transport.ErrInvalidRequest)
+	}
+	return transport.ListSearchHistoryRequest{
+		Term:   req.GetTerm(),
+		Since:  timeOf(req.GetSince()),
+		Until:  timeOf(req.GetUntil()),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	}, nil
+}
+
+// EncodeListSearchHistoryResponse function to encode list search history response back.
+func EncodeListSearchHistoryResponse(_ context.Context, response any) (any, error) {
+	res, ok := response.(transport.ListSearchHistoryResponse)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse list search history response, got %v", response)
+	}
+	return &pb.ListSearchHistoryResponse{Searches: lo.Map(res.Searches, func(e transport.HistoryEntry, _ int) *pb.HistoryEntry {
+		return &pb.HistoryEntry{
+			Id:          e.ID,
+			SearchTerm:  e.SearchTerm,
+			ResultCount: int32(e.ResultCount),
+			Media:       lo.Map(e.Media, mapMedia),
+			CreatedAt:   timestamppb.New(e.CreatedAt),
+		}
+	})}, nil
+}
+
+// timeOf returns the time of an optional timestamp, the zero time when it is unset.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// mapMedia maps a transport.Media to its protobuf representation.
+func mapMedia(m transport.Media, _ int) *pb.Media {
+	return &pb.Media{
+		WrapperType:            m.WrapperType,
+		Kind:                   m.Kind,
+		ArtistId:               int64(m.ArtistID),
+		CollectionId:           int64(m.CollectionID),
+		TrackId:                int64(m.TrackID),
+		ArtistName:             m.ArtistName,
+		CollectionName:         m.CollectionName,
+		TrackName:              m.TrackName,
+		ArtistViewUrl:          m.ArtistViewURL,
+		CollectionViewUrl:      m.CollectionViewURL,
+		FeedUrl:                m.FeedURL,
+		TrackViewUrl:           m.TrackViewURL,
+		ArtworkUrl_30:          m.ArtworkURL30,
+		ArtworkUrl_60:          m.ArtworkURL60,
+		ArtworkUrl_100:         m.ArtworkURL100,
+		ReleaseDate:            m.ReleaseDate,
+		CollectionExplicitness: m.CollectionExplicitness,
+		TrackExplicitness:      m.TrackExplicitness,
+		TrackCount:             int32(m.TrackCount),
+		TrackTimeMillis:        int64(m.TrackTimeMillis),
+		Country:                m.Country,
+		Currency:               m.Currency,
+		PrimaryGenreName:       m.PrimaryGenreName,
+		ContentAdvisoryRating:  m.ContentAdvisoryRating,
+		ArtworkUrl_600:         m.ArtworkURL600,
+		GenreIds:               m.GenreIDs,
+		Genres:                 m.Genres,
+		Source:                 m.Source,
+		SourceId:               m.SourceID,
+		Isrc:                   m.ISRC,
+		Upc:                    m.UPC,
+		Software:               mapSoftware(m.Software),
+		Video:                  mapVideo(m.Video),
+	}
+}
+
+// mapSoftware maps the App Store details of an app, s is nil for the other media.
+func mapSoftware(s *transport.Software) *pb.Software {
+	if s == nil {
+		return nil
+	}
+	return &pb.Software{
+		BundleId:          s.BundleID,
+		AverageUserRating: s.AverageUserRating,
+		UserRatingCount:   int64(s.UserRatingCount),
+		ScreenshotUrls:    s.ScreenshotURLs,
+		SupportedDevices:  s.SupportedDevices,
+		FileSizeBytes:     s.FileSizeBytes,
+		MinimumOsVersion:  s.MinimumOSVersion,
+		SellerName:        s.SellerName,
+		Price:             s.Price,
+		FormattedPrice:    s.FormattedPrice,
+	}
+}
+
+// mapVideo maps the details of a movie, a tv season or a tv episode, v is nil for the other media.
+func mapVideo(v *transport.Video) *pb.Video {
+	if v == nil {
+		return nil
+	}
+	return &pb.Video{
+		LongDescription:    v.LongDescription,
+		ShortDescription:   v.ShortDescription,
+		TrackPrice:         v.TrackPrice,
+		TrackRentalPrice:   v.TrackRentalPrice,
+		TrackHdPrice:       v.TrackHDPrice,
+		TrackHdRentalPrice: v.TrackHDRentalPrice,
+		CollectionPrice:    v.CollectionPrice,
+		CollectionHdPrice:  v.CollectionHDPrice,
+		SeasonNumber:       int32(v.SeasonNumber),
+		EpisodeNumber:      int32(v.EpisodeNumber),
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/gorilla/mux"
+)
+
+// DecodeCreateAPIKeyRequest function decodes create api key request.
+func DecodeCreateAPIKeyRequest(_ context.Context, r *http.Request) (any, error) {
+	var req transport.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode body: %v", transport.ErrInvalidRequest, err)
+	}
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name shouldn't be empty", transport.ErrInvalidRequest)
+	}
+	if req.MinuteQuota < 0 || req.DailyQuota < 0 {
+		return nil, fmt.Errorf("%w: quotas shouldn't be negative", transport.ErrInvalidRequest)
+	}
+	return req, nil
+}
+
+// DecodeListAPIKeysRequest function decodes list api keys request.
+func DecodeListAPIKeysRequest(_ context.Context, _ *http.Request) (any, error) {
+	return nil, nil
+}
+
+// DecodeAPIKeyRequest function decodes a request targeting the api key in the {id} path variable.
+func DecodeAPIKeyRequest(_ context.Context, r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: id should be a number", transport.ErrInvalidRequest)
+	}
+	return transport.APIKeyRequest{ID: id}, nil
+}
+
+// EncodeAPIKeyResponse function encodes the api key endpoints responses.
+func EncodeAPIKeyResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	switch response.(type) {
+	case transport.RevokeAPIKeyResponse:
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	case transport.APIKeySecretResponse, transport.ListAPIKeysResponse:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		return json.NewEncoder(w).Encode(response)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse api key response, got %v", response).Error(),
+		})
+	}
+}
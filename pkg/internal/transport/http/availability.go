@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+)
+
+// DecodeCheckAvailabilityRequest function returns a decoder of requests checking the availability of the media in the
+// {id} path variable in at most maxCountries storefronts. Countries are ISO 3166-1 alpha-2 codes, lowered and
+// de-duplicated.
+func DecodeCheckAvailabilityRequest(maxCountries int) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (any, error) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%w: id should be a positive number", transport.ErrInvalidRequest)
+		}
+		countries := lo.Uniq(lo.Compact(lo.Map(strings.Split(r.URL.Query().Get("countries"), ","), func(c string, _ int) string {
+			return strings.ToLower(strings.TrimSpace(c))
+		})))
+		if len(countries) == 0 {
+			return nil, fmt.Errorf("%w: countries shouldn't be empty", transport.ErrInvalidRequest)
+		}
+		if len(countries) > maxCountries {
+			return nil, fmt.Errorf("%w: at most %d countries can be checked at once", transport.ErrInvalidRequest, maxCountries)
+		}
+		for _, country := range countries {
+			if !isCountryCode(country) {
+				return nil, fmt.Errorf("%w: country %q should be a two letter code", transport.ErrInvalidRequest, country)
+			}
+		}
+		return transport.CheckAvailabilityRequest{ID: id, Countries: countries}, nil
+	}
+}
+
+// isCountryCode reports whether country is made of two lowercase ascii letters.
+func isCountryCode(country string) bool {
+	return len(country) == 2 && country[0] >= 'a' && country[0] <= 'z' && country[1] >= 'a' && country[1] <= 'z'
+}
+
+// EncodeCheckAvailabilityResponse function encodes the availability of a media across storefronts.
+func EncodeCheckAvailabilityResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res, ok := response.(transport.CheckAvailabilityResponse)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse check availability response, got %v", response).Error(),
+		})
+	}
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(res)
+}
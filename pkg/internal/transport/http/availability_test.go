@@ -0,0 +1,69 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCheckAvailabilityRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		vars            map[string]string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid request",
+			target:          "/?countries=us,GB,%20sa,us",
+			vars:            map[string]string{"id": "909253"},
+			expectedRequest: transport.CheckAvailabilityRequest{ID: 909253, Countries: []string{"us", "gb", "sa"}},
+		},
+		{
+			name:          "zero id",
+			target:        "/?countries=us",
+			vars:          map[string]string{"id": "0"},
+			expectedError: "invalid request: id should be a positive number",
+		},
+		{
+			name:          "missing countries",
+			target:        "/",
+			vars:          map[string]string{"id": "909253"},
+			expectedError: "invalid request: countries shouldn't be empty",
+		},
+		{
+			name:          "too many countries",
+			target:        "/?countries=us,gb,sa,fr",
+			vars:          map[string]string{"id": "909253"},
+			expectedError: "invalid request: at most 3 countries can be checked at once",
+		},
+		{
+			name:          "invalid country",
+			target:        "/?countries=us,usa",
+			vars:          map[string]string{"id": "909253"},
+			expectedError: `invalid request: country "usa" should be a two letter code`,
+		},
+	}
+
+	decode := kithttp.DecodeCheckAvailabilityRequest(3)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, tt.target, nil), tt.vars)
+			result, err := decode(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/go-kit/kit/endpoint"
+)
+
+// CachePolicy describes how long responses may be cached by browsers and shared caches.
+type CachePolicy struct {
+	// MaxAge is how long a response stays fresh after its result was fetched, responses must be revalidated when zero.
+	MaxAge time.Duration
+	// StaleWhileRevalidate is how long a stale response may still be served while it is revalidated in the background.
+	StaleWhileRevalidate time.Duration
+}
+
+// cacheRequest holds the cache related parts of a request.
+type cacheRequest struct {
+	policy      CachePolicy
+	ifNoneMatch string
+	private     bool
+}
+
+type cacheRequestKey struct{}
+
+// PopulateCacheRequest function returns a request func putting the cache policy and the conditional headers
+// of the request in the context. It must run after PopulateAuthInfo, responses to requests carrying credentials
+// are only cacheable by the client.
+func PopulateCacheRequest(policy CachePolicy) func(ctx context.Context, r *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		private := false
+		if info, ok := transport.AuthInfoFromContext(ctx); ok {
+			private = info.APIKey != "" || info.BearerToken != ""
+		}
+		return context.WithValue(ctx, cacheRequestKey{}, cacheRequest{
+			policy:      policy,
+			ifNoneMatch: r.Header.Get("If-None-Match"),
+			private:     private,
+		})
+	}
+}
+
+// etag returns a strong entity tag of the json encoding of v.
+func etag(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode etag payload: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// searchMediaETag returns the entity tag of a search media response. The id of the stored search differs on every
+// request, only the result itself identifies the representation.
+func searchMediaETag(res transport.SearchMediaResponse) (string, error) {
+	return etag(struct {
+		SearchTerm  string            `json:"search_term"`
+		ResultCount int               `json:"result_count"`
+		Media       []transport.Media `json:"media"`
+	}{res.SearchTerm, res.ResultCount, res.Media})
+}
+
+// searchFinder finds the latest stored result of a search.
+type searchFinder interface {
+	LatestMedia(ctx context.Context, searchKey string, since time.Time) (business.MediaResult, error)
+}
+
+// MakeRevalidateSearchMiddleware returns a middleware answering conditional searches from their latest result stored
+// within the max age of the cache policy, upstream is only searched when the If-None-Match of the request doesn't match
+// it. It must wrap the search media endpoint directly so auth and rate limits still apply, and requires the request
+// func of PopulateCacheRequest.
+func MakeRevalidateSearchMiddleware(finder searchFinder) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			req, _ := ctx.Value(cacheRequestKey{}).(cacheRequest)
+			body, ok := request.(transport.SearchMediaRequest)
+			if !ok || req.ifNoneMatch == "" || req.policy.MaxAge <= 0 {
+				return next(ctx, request)
+			}
+			// a failed lookup only costs the upstream search, it doesn't fail the request.
+			res, err := finder.LatestMedia(ctx, body.SearchKey(), time.Now().Add(-req.policy.MaxAge))
+			if err != nil {
+				return next(ctx, request)
+			}
+			response := transport.NewSearchMediaResponse(res)
+			tag, err := searchMediaETag(response)
+			if err != nil || !matchesETag(req.ifNoneMatch, tag) {
+				return next(ctx, request)
+			}
+			// the encoder answers 304 along with the age of the stored result.
+			return response, nil
+		}
+	}
+}
+
+// writeCacheHeaders writes the validator and freshness headers of a response whose result was fetched at fetchedAt,
+// and reports whether the client already holds the current representation.
+func writeCacheHeaders(ctx context.Context, w http.ResponseWriter, tag string, fetchedAt time.Time) bool {
+	req, _ := ctx.Value(cacheRequestKey{}).(cacheRequest)
+	age := time.Duration(0)
+	if !fetchedAt.IsZero() {
+		age = max(time.Since(fetchedAt), 0)
+	}
+
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Age", strconv.Itoa(int(age.Seconds())))
+	w.Header().Set("Cache-Control", cacheControl(req, age))
+	return matchesETag(req.ifNoneMatch, tag)
+}
+
+// cacheControl returns the Cache-Control directives of a response of the given age.
+func cacheControl(req cacheRequest, age time.Duration) string {
+	visibility := "public"
+	if req.private {
+		visibility = "private"
+	}
+	if req.policy.MaxAge <= 0 {
+		return visibility + ", no-cache"
+	}
+	// the age is reported separately, max-age is the freshness lifetime of the response.
+	directives := []string{visibility, "max-age=" + strconv.Itoa(int(req.policy.MaxAge.Seconds()))}
+	if req.policy.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+strconv.Itoa(int(req.policy.StaleWhileRevalidate.Seconds())))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// matchesETag reports whether an If-None-Match header matches tag, using the weak comparison required for it.
+func matchesETag(ifNoneMatch, tag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
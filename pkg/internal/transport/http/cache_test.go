@@ -0,0 +1,230 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeSearchMediaResponse_Caching(t *testing.T) {
+	response := transport.SearchMediaResponse{
+		ID:          1,
+		SearchTerm:  "test",
+		ResultCount: 1,
+		Media:       []transport.Media{{Kind: "song", TrackName: "Test Track"}},
+		FetchedAt:   time.Now().Add(-90 * time.Second),
+	}
+	policy := kithttp.CachePolicy{MaxAge: 5 * time.Minute, StaleWhileRevalidate: time.Minute}
+
+	// encode runs the encoder for a request with the given headers.
+	encode := func(t *testing.T, policy kithttp.CachePolicy, header http.Header, response transport.SearchMediaResponse) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/media/search?term=test", nil)
+		req.Header = header
+		ctx := kithttp.PopulateAuthInfo("X-API-Key")(context.Background(), req)
+		ctx = kithttp.PopulateCacheRequest(policy)(ctx, req)
+		rec := httptest.NewRecorder()
+		require.NoError(t, kithttp.EncodeSearchMediaResponse(ctx, rec, response))
+		return rec
+	}
+
+	first := encode(t, policy, http.Header{}, response)
+	tag := first.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, tag)
+	assert.Equal(t, "public, max-age=300, stale-while-revalidate=60", first.Header().Get("Cache-Control"))
+	assert.Equal(t, "90", first.Header().Get("Age"))
+
+	t.Run("same result has the same etag", func(t *testing.T) {
+		other := response
+		other.ID = 2
+		rec := encode(t, policy, http.Header{}, other)
+		assert.Equal(t, tag, rec.Header().Get("ETag"))
+	})
+
+	t.Run("different result has another etag", func(t *testing.T) {
+		other := response
+		other.Media = []transport.Media{{Kind: "song", TrackName: "Other Track"}}
+		rec := encode(t, policy, http.Header{}, other)
+		assert.NotEqual(t, tag, rec.Header().Get("ETag"))
+	})
+
+	tests := []struct {
+		name                 string
+		policy               kithttp.CachePolicy
+		header               http.Header
+		expectedStatus       int
+		expectedCacheControl string
+	}{
+		{
+			name:                 "matching if-none-match",
+			policy:               policy,
+			header:               http.Header{"If-None-Match": {`"other", ` + tag}},
+			expectedStatus:       http.StatusNotModified,
+			expectedCacheControl: "public, max-age=300, stale-while-revalidate=60",
+		},
+		{
+			name:                 "weak matching if-none-match",
+			policy:               policy,
+			header:               http.Header{"If-None-Match": {"W/" + tag}},
+			expectedStatus:       http.StatusNotModified,
+			expectedCacheControl: "public, max-age=300, stale-while-revalidate=60",
+		},
+		{
+			name:                 "wildcard if-none-match",
+			policy:               policy,
+			header:               http.Header{"If-None-Match": {"*"}},
+			expectedStatus:       http.StatusNotModified,
+			expectedCacheControl: "public, max-age=300, stale-while-revalidate=60",
+		},
+		{
+			name:                 "stale if-none-match",
+			policy:               policy,
+			header:               http.Header{"If-None-Match": {`"other"`}},
+			expectedStatus:       http.StatusOK,
+			expectedCacheControl: "public, max-age=300, stale-while-revalidate=60",
+		},
+		{
+			name:                 "authenticated requests are private",
+			policy:               policy,
+			header:               http.Header{"Authorization": {"Bearer token"}},
+			expectedStatus:       http.StatusOK,
+			expectedCacheControl: "private, max-age=300, stale-while-revalidate=60",
+		},
+		{
+			name:                 "no max age requires revalidation",
+			header:               http.Header{},
+			expectedStatus:       http.StatusOK,
+			expectedCacheControl: "public, no-cache",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := encode(t, tt.policy, tt.header, response)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tag, rec.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedCacheControl, rec.Header().Get("Cache-Control"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+// searchFinderFunc finds the latest stored result of a search with a func.
+type searchFinderFunc func(ctx context.Context, searchKey string, since time.Time) (business.MediaResult, error)
+
+func (f searchFinderFunc) LatestMedia(ctx context.Context, searchKey string, since time.Time) (business.MediaResult, error) {
+	return f(ctx, searchKey, since)
+}
+
+func TestMakeRevalidateSearchMiddleware(t *testing.T) {
+	stored := business.MediaResult{
+		ID:          1,
+		SearchTerm:  "test",
+		ResultCount: 1,
+		Media:       []business.Media{{Kind: "song", TrackName: "Test Track"}},
+		FetchedAt:   time.Now().Add(-2 * time.Minute),
+	}
+	policy := kithttp.CachePolicy{MaxAge: 5 * time.Minute}
+	request := transport.SearchMediaRequest{Term: "test", Limit: 20}
+
+	// tag is the etag of the stored result, as the encoder sent it along the previous response.
+	rec := httptest.NewRecorder()
+	require.NoError(t, kithttp.EncodeSearchMediaResponse(context.Background(), rec, transport.NewSearchMediaResponse(stored)))
+	tag := rec.Header().Get("ETag")
+
+	tests := []struct {
+		name           string
+		header         http.Header
+		finderErr      error
+		expectedLookup bool
+		expectedSearch bool
+		expectedStatus int
+		expectedAge    string
+	}{
+		{
+			name:           "matching stored result",
+			header:         http.Header{"If-None-Match": {tag}},
+			expectedLookup: true,
+			expectedStatus: http.StatusNotModified,
+			expectedAge:    "120",
+		},
+		{
+			name:           "stale stored result",
+			header:         http.Header{"If-None-Match": {`"other"`}},
+			expectedLookup: true,
+			expectedSearch: true,
+			expectedStatus: http.StatusOK,
+			expectedAge:    "0",
+		},
+		{
+			name:           "no stored result",
+			header:         http.Header{"If-None-Match": {tag}},
+			finderErr:      business.ErrNotFound,
+			expectedLookup: true,
+			expectedSearch: true,
+			expectedStatus: http.StatusOK,
+			expectedAge:    "0",
+		},
+		{
+			name:           "lookup error",
+			header:         http.Header{"If-None-Match": {tag}},
+			finderErr:      errors.New("select error"),
+			expectedLookup: true,
+			expectedSearch: true,
+			expectedStatus: http.StatusOK,
+			expectedAge:    "0",
+		},
+		{
+			name:           "unconditional request",
+			header:         http.Header{},
+			expectedSearch: true,
+			expectedStatus: http.StatusOK,
+			expectedAge:    "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			looked, searched := false, false
+			finder := searchFinderFunc(func(_ context.Context, searchKey string, since time.Time) (business.MediaResult, error) {
+				looked = true
+				assert.Equal(t, request.SearchKey(), searchKey)
+				assert.WithinDuration(t, time.Now().Add(-policy.MaxAge), since, time.Second)
+				return stored, tt.finderErr
+			})
+			next := func(context.Context, any) (any, error) {
+				searched = true
+				fresh := stored
+				fresh.Media = []business.Media{{Kind: "song", TrackName: "New Track"}}
+				fresh.FetchedAt = time.Now()
+				return transport.NewSearchMediaResponse(fresh), nil
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/media/search?term=test", nil)
+			req.Header = tt.header
+			ctx := kithttp.PopulateCacheRequest(policy)(context.Background(), req)
+			response, err := kithttp.MakeRevalidateSearchMiddleware(finder)(next)(ctx, request)
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+			require.NoError(t, kithttp.EncodeSearchMediaResponse(ctx, rec, response))
+
+			assert.Equal(t, tt.expectedLookup, looked)
+			assert.Equal(t, tt.expectedSearch, searched)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedAge, rec.Header().Get("Age"))
+		})
+	}
+}
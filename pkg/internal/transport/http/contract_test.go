@@ -0,0 +1,790 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/NawafSwe/media-scout-service/pkg/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	gokithttp "github.com/go-kit/kit/transport/http"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// event streams and exports are documented as plain strings, there is no decoder for them otherwise.
+	for _, contentType := range []string{"text/event-stream", "text/csv", "application/x-ndjson", "application/vnd.apache.parquet"} {
+		openapi3filter.RegisterBodyDecoder(contentType, func(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (any, error) {
+			data, err := io.ReadAll(body)
+			return string(data), err
+		})
+	}
+}
+
+// loadSpecRouter loads the bundled OpenAPI document and returns a router finding its operations.
+func loadSpecRouter(t *testing.T) routers.Router {
+	t.Helper()
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(openapi.Spec())
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(loader.Context))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+	return router
+}
+
+// validateContract checks that the response the handlers gave to a request matches the OpenAPI document,
+// along with the request itself unless it is invalid on purpose.
+func validateContract(t *testing.T, router routers.Router, req *http.Request, body []byte, validRequest bool, rec *httptest.ResponseRecorder) {
+	t.Helper()
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err, "route is missing from the spec")
+
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	reqInput := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if validRequest {
+		assert.NoError(t, openapi3filter.ValidateRequest(context.Background(), reqInput), "request doesn't match the spec")
+	}
+
+	resInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: reqInput,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	}
+	resInput.SetBodyBytes(rec.Body.Bytes())
+	assert.NoError(t, openapi3filter.ValidateResponse(context.Background(), resInput), "response doesn't match the spec")
+}
+
+func TestContract(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	specRouter := loadSpecRouter(t)
+	searchHandler := mock.NewMockhandler(ctrl)
+	lookupHandler := mock.NewMocklookupHandler(ctrl)
+	historyHandler := mock.NewMockhistoryHandler(ctrl)
+	batchHandler := mock.NewMockbatchHandler(ctrl)
+	apiKeyHandler := mock.NewMockapiKeyHandler(ctrl)
+	jobHandler := mock.NewMocksearchJobHandler(ctrl)
+	exportHandler := mock.NewMockexportHandler(ctrl)
+	watchlistHandler := mock.NewMockwatchlistHandler(ctrl)
+	webhookHandler := mock.NewMockwebhookHandler(ctrl)
+	podcastHandler := mock.NewMockpodcastHandler(ctrl)
+	availabilityHandler := mock.NewMockavailabilityHandler(ctrl)
+
+	opts := []gokithttp.ServerOption{
+		gokithttp.ServerBefore(kithttp.PopulateAuthInfo("X-API-Key"), kithttp.PopulateCacheRequest(kithttp.CachePolicy{MaxAge: time.Minute})),
+		gokithttp.ServerAfter(kithttp.EncodeQuotaHeaders),
+		gokithttp.ServerErrorEncoder(kithttp.EncodeError),
+	}
+	router := mux.NewRouter()
+	router.Handle("/api/v1/media/search", gokithttp.NewServer(transport.MakeSearchMediaEndpoint(searchHandler), kithttp.DecodeSearchMediaRequest, kithttp.EncodeSearchMediaResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/media/lookup", gokithttp.NewServer(transport.MakeLookupMediaEndpoint(lookupHandler), kithttp.DecodeLookupMediaRequest, kithttp.EncodeLookupMediaResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/media/seasons/{collectionId:[0-9]+}/episodes", gokithttp.NewServer(transport.MakeListSeasonEpisodesEndpoint(lookupHandler), kithttp.DecodeListSeasonEpisodesRequest, kithttp.EncodeListSeasonEpisodesResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/media/{id:[0-9]+}/availability", gokithttp.NewServer(transport.MakeCheckAvailabilityEndpoint(availabilityHandler), kithttp.DecodeCheckAvailabilityRequest(20), kithttp.EncodeCheckAvailabilityResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/media/search:batch", gokithttp.NewServer(transport.MakeSearchBatchEndpoint(batchHandler), kithttp.DecodeSearchBatchRequest(100), kithttp.EncodeSearchBatchResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/api/v1/media/history", gokithttp.NewServer(transport.MakeListSearchHistoryEndpoint(historyHandler), kithttp.DecodeListSearchHistoryRequest, kithttp.EncodeListSearchHistoryResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/searches/export", gokithttp.NewServer(transport.MakeExportSearchesEndpoint(exportHandler), kithttp.DecodeExportSearchesRequest, kithttp.EncodeExportSearchesResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/jobs", gokithttp.NewServer(transport.MakeCreateSearchJobEndpoint(jobHandler), kithttp.DecodeCreateSearchJobRequest(100), kithttp.EncodeSearchJobResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/api/v1/jobs/{id:[0-9]+}", gokithttp.NewServer(transport.MakeGetSearchJobEndpoint(jobHandler), kithttp.DecodeSearchJobRequest, kithttp.EncodeSearchJobResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/jobs/{id:[0-9]+}", gokithttp.NewServer(transport.MakeCancelSearchJobEndpoint(jobHandler), kithttp.DecodeSearchJobRequest, kithttp.EncodeSearchJobResponse, opts...)).Methods(http.MethodDelete)
+	router.Handle("/api/v1/jobs/{id:[0-9]+}/events", gokithttp.NewServer(transport.MakeSearchJobEventsEndpoint(jobHandler), kithttp.DecodeSearchJobEventsRequest, kithttp.EncodeSearchJobEvents(kithttp.SearchJobEventsOptions{PollInterval: time.Second, Heartbeat: time.Second}), opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/watchlists", gokithttp.NewServer(transport.MakeCreateWatchlistEndpoint(watchlistHandler), kithttp.DecodeCreateWatchlistRequest, kithttp.EncodeWatchlistResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/api/v1/watchlists/{id:[0-9]+}", gokithttp.NewServer(transport.MakeGetWatchlistEndpoint(watchlistHandler), kithttp.DecodeWatchlistRequest, kithttp.EncodeWatchlistResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/watchlists/{id:[0-9]+}/artists/{artistId:[0-9]+}", gokithttp.NewServer(transport.MakeSubscribeArtistEndpoint(watchlistHandler), kithttp.DecodeWatchlistArtistRequest, kithttp.EncodeWatchlistResponse, opts...)).Methods(http.MethodPut)
+	router.Handle("/api/v1/watchlists/{id:[0-9]+}/artists/{artistId:[0-9]+}", gokithttp.NewServer(transport.MakeUnsubscribeArtistEndpoint(watchlistHandler), kithttp.DecodeWatchlistArtistRequest, kithttp.EncodeWatchlistResponse, opts...)).Methods(http.MethodDelete)
+	router.Handle("/api/v1/watchlists/{id:[0-9]+}/releases", gokithttp.NewServer(transport.MakeListWatchlistReleasesEndpoint(watchlistHandler), kithttp.DecodeListWatchlistReleasesRequest, kithttp.EncodeWatchlistResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/podcasts/{collectionId:[0-9]+}/episodes", gokithttp.NewServer(transport.MakeListPodcastEpisodesEndpoint(podcastHandler), kithttp.DecodeListPodcastEpisodesRequest, kithttp.EncodeListPodcastEpisodesResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/webhooks", gokithttp.NewServer(transport.MakeCreateWebhookEndpoint(webhookHandler), kithttp.DecodeCreateWebhookRequest, kithttp.EncodeWebhookResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/api/v1/webhooks/{id:[0-9]+}", gokithttp.NewServer(transport.MakeGetWebhookEndpoint(webhookHandler), kithttp.DecodeWebhookRequest, kithttp.EncodeWebhookResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/webhooks/{id:[0-9]+}", gokithttp.NewServer(transport.MakeDeleteWebhookEndpoint(webhookHandler), kithttp.DecodeWebhookRequest, kithttp.EncodeWebhookResponse, opts...)).Methods(http.MethodDelete)
+	router.Handle("/api/v1/webhooks/{id:[0-9]+}/deliveries", gokithttp.NewServer(transport.MakeListWebhookDeliveriesEndpoint(webhookHandler), kithttp.DecodeListWebhookDeliveriesRequest, kithttp.EncodeWebhookResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/api/v1/webhooks/{id:[0-9]+}/deliveries/{deliveryId:[0-9]+}/redeliver", gokithttp.NewServer(transport.MakeRedeliverWebhookEndpoint(webhookHandler), kithttp.DecodeRedeliverWebhookRequest, kithttp.EncodeWebhookResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/admin/api-keys", gokithttp.NewServer(transport.MakeCreateAPIKeyEndpoint(apiKeyHandler), kithttp.DecodeCreateAPIKeyRequest, kithttp.EncodeAPIKeyResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/admin/api-keys", gokithttp.NewServer(transport.MakeListAPIKeysEndpoint(apiKeyHandler), kithttp.DecodeListAPIKeysRequest, kithttp.EncodeAPIKeyResponse, opts...)).Methods(http.MethodGet)
+	router.Handle("/admin/api-keys/{id:[0-9]+}/rotate", gokithttp.NewServer(transport.MakeRotateAPIKeyEndpoint(apiKeyHandler), kithttp.DecodeAPIKeyRequest, kithttp.EncodeAPIKeyResponse, opts...)).Methods(http.MethodPost)
+	router.Handle("/admin/api-keys/{id:[0-9]+}", gokithttp.NewServer(transport.MakeRevokeAPIKeyEndpoint(apiKeyHandler), kithttp.DecodeAPIKeyRequest, kithttp.EncodeAPIKeyResponse, opts...)).Methods(http.MethodDelete)
+
+	result := business.MediaResult{
+		ID:          1,
+		SearchTerm:  "jack johnson",
+		ResultCount: 1,
+		Media:       []business.Media{{WrapperType: "track", Kind: "song", ArtistID: 909253, TrackName: "Upside Down", Genres: []string{"Rock"}}},
+		FetchedAt:   time.Now(),
+	}
+	now := time.Now().UTC()
+	key := business.APIKey{ID: 1, Name: "ingestion", Prefix: "msk_0a1b2c3d", MinuteQuota: 60, DailyQuota: 1000, CreatedAt: now}
+	revoked := key
+	revoked.RevokedAt = &now
+	job := business.SearchJob{
+		ID:          1,
+		Status:      business.SearchJobRunning,
+		Searches:    []business.SearchQuery{{Term: "jack johnson", Limit: 1}, {Term: "broken", Limit: 20}, {Term: "beatles", Limit: 20}},
+		Results:     []business.SearchJobResult{{MediaResultID: 1, Result: &result}, {Error: "failed to fetch and insert media: upstream down"}, {}},
+		Attempts:    1,
+		MaxAttempts: 3,
+		RunAt:       now,
+		StartedAt:   &now,
+		CreatedAt:   now,
+	}
+
+	watchlist := business.Watchlist{
+		ID:   1,
+		Name: "favorites",
+		Artists: []business.WatchedArtist{
+			{ArtistID: 909253, ArtistName: "Jack Johnson", LastReleaseDate: &now, CheckedAt: &now, SubscribedAt: now},
+			{ArtistID: 1171421960, SubscribedAt: now},
+		},
+		CreatedAt: now,
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		header         http.Header
+		invalid        bool
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:   "search media",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack+johnson&limit=1",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "jack johnson", 1).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "search media without results",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=nothing",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "nothing", 20).Return(business.MediaResult{SearchTerm: "nothing"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "search media not modified",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack+johnson",
+			header: http.Header{"If-None-Match": {"*"}},
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "jack johnson", 20).Return(result, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:   "search media from provider",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack+johnson&provider=musicbrainz",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "musicbrainz", "jack johnson", 20).Return(business.MediaResult{
+					SearchTerm:  "jack johnson",
+					ResultCount: 1,
+					Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackName: "Better Together", Source: "musicbrainz", SourceID: "rec1"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "search media from unknown provider",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack&provider=spotify",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "spotify", "jack", 20).Return(business.MediaResult{}, fmt.Errorf("%w: %q", business.ErrUnknownProvider, "spotify"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "search software with filters",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=notes&min_rating=4&device=iPad&price=free",
+			mockSetup: func() {
+				filter := business.SearchFilter{Media: "software", MinRating: 4, Device: "iPad", Price: business.PriceFree}
+				searchHandler.EXPECT().FetchAndInsertFilteredMedia(gomock.Any(), "", "notes", 20, filter).Return(business.MediaResult{
+					SearchTerm:  "notes",
+					ResultCount: 1,
+					Media: []business.Media{{WrapperType: "software", Kind: "software", TrackName: "Notes", Software: &business.Software{
+						BundleID:          "com.example.notes",
+						AverageUserRating: 4.5,
+						UserRatingCount:   1200,
+						ScreenshotURLs:    []string{"https://is1-ssl.mzstatic.com/image/1.png"},
+						SupportedDevices:  []string{"iPadAir2-iPadAir2"},
+						FileSizeBytes:     52428800,
+						MinimumOSVersion:  "15.0",
+						SellerName:        "Example Inc.",
+						FormattedPrice:    "Free",
+					}}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "search media by type from provider without media types",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack&provider=musicbrainz&media=music",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertFilteredMedia(gomock.Any(), "musicbrainz", "jack", 20, business.SearchFilter{Media: "music"}).
+					Return(business.MediaResult{}, fmt.Errorf("%w: musicbrainz doesn't search by media type", business.ErrUnsupportedFilter))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "search media with an invalid price",
+			method:         http.MethodGet,
+			target:         "/api/v1/media/search?term=notes&price=cheap",
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "search media federated",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack&providers=itunes,musicbrainz",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertFederatedMedia(gomock.Any(), []string{"itunes", "musicbrainz"}, "jack", 20).Return(business.FederatedMediaResult{
+					MediaResult: business.MediaResult{
+						SearchTerm:  "jack",
+						ResultCount: 1,
+						Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackName: "Better Together", ISRC: "USUM70400001", Source: "itunes"}},
+					},
+					Providers: []string{"itunes", "musicbrainz"},
+					Failures:  []business.ProviderFailure{{Provider: "musicbrainz", Err: errors.New("context deadline exceeded")}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "search media from provider and providers",
+			method:         http.MethodGet,
+			target:         "/api/v1/media/search?term=jack&provider=itunes&providers=itunes,musicbrainz",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "search media without term",
+			method:         http.MethodGet,
+			target:         "/api/v1/media/search?term=",
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "search media unauthorized",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "jack", 20).Return(business.MediaResult{}, business.ErrUnauthorized)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "search media quota exceeded",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "jack", 20).Return(business.MediaResult{}, business.ErrQuotaExceeded)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:   "search media failure",
+			method: http.MethodGet,
+			target: "/api/v1/media/search?term=jack",
+			mockSetup: func() {
+				searchHandler.EXPECT().FetchAndInsertMediaFrom(gomock.Any(), "", "jack", 20).Return(business.MediaResult{}, errors.New("itunes is down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "lookup media",
+			method: http.MethodGet,
+			target: "/api/v1/media/lookup?id=909253",
+			mockSetup: func() {
+				lookupHandler.EXPECT().LookupMedia(gomock.Any(), 909253).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "lookup unknown media",
+			method: http.MethodGet,
+			target: "/api/v1/media/lookup?id=1",
+			mockSetup: func() {
+				lookupHandler.EXPECT().LookupMedia(gomock.Any(), 1).Return(business.MediaResult{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "lookup media without id",
+			method:         http.MethodGet,
+			target:         "/api/v1/media/lookup",
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "list season episodes",
+			method: http.MethodGet,
+			target: "/api/v1/media/seasons/20/episodes",
+			mockSetup: func() {
+				lookupHandler.EXPECT().ListSeasonEpisodes(gomock.Any(), 20).Return(business.TVSeason{
+					Season: business.Media{WrapperType: "collection", CollectionID: 20, CollectionName: "Test Show, Season 2", ContentAdvisoryRating: "TV-14", Video: &business.Video{
+						LongDescription: "The second season.", CollectionPrice: 19.99, CollectionHDPrice: 24.99, SeasonNumber: 2,
+					}},
+					Episodes: []business.Media{{WrapperType: "track", Kind: "tv-episode", CollectionID: 20, TrackID: 30, TrackName: "Pilot", Video: &business.Video{
+						TrackPrice: 2.99, TrackHDPrice: 3.99, SeasonNumber: 2, EpisodeNumber: 1,
+					}}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list episodes of an unknown season",
+			method: http.MethodGet,
+			target: "/api/v1/media/seasons/1/episodes",
+			mockSetup: func() {
+				lookupHandler.EXPECT().ListSeasonEpisodes(gomock.Any(), 1).Return(business.TVSeason{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "check availability",
+			method: http.MethodGet,
+			target: "/api/v1/media/909253/availability?countries=us,gb,sa",
+			mockSetup: func() {
+				normalized := 1.26
+				availabilityHandler.EXPECT().CheckAvailability(gomock.Any(), 909253, []string{"us", "gb", "sa"}).Return(business.Availability{
+					ID:           909253,
+					Media:        &business.Media{WrapperType: "track", Kind: "song", TrackID: 909253, TrackName: "Better Together"},
+					BaseCurrency: "USD",
+					Storefronts: []business.StorefrontAvailability{
+						{Country: "us", Available: true, Price: 1.29, Currency: "USD", NormalizedPrice: &normalized},
+						{Country: "gb", Err: errors.New("context deadline exceeded")},
+						{Country: "sa"},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "check availability without countries",
+			method:         http.MethodGet,
+			target:         "/api/v1/media/909253/availability",
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "search media batch",
+			method: http.MethodPost,
+			target: "/api/v1/media/search:batch",
+			body:   `{"searches":[{"term":"jack johnson","limit":1},{"term":"broken"}]}`,
+			header: http.Header{"Content-Type": {"application/json"}},
+			mockSetup: func() {
+				batchHandler.EXPECT().SearchBatch(gomock.Any(), gomock.Any()).Return([]business.SearchOutcome{
+					{Result: result},
+					{Err: errors.New("upstream down")},
+				})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "search media batch without searches",
+			method:         http.MethodPost,
+			target:         "/api/v1/media/search:batch",
+			body:           `{"searches":[]}`,
+			header:         http.Header{"Content-Type": {"application/json"}},
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "list search history",
+			method: http.MethodGet,
+			target: "/api/v1/media/history?term=jack+johnson&since=2026-10-01T00:00:00Z&limit=10",
+			mockSetup: func() {
+				historyHandler.EXPECT().ListSearchHistory(gomock.Any(), gomock.Any()).Return([]business.MediaResult{result}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "list search history with invalid since",
+			method:         http.MethodGet,
+			target:         "/api/v1/media/history?since=yesterday",
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "export searches as csv",
+			method: http.MethodGet,
+			target: "/api/v1/searches/export?term=jack+johnson&since=2026-10-01T00:00:00Z",
+			mockSetup: func() {
+				exportHandler.EXPECT().ExportSearches(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ business.HistoryFilter, fn func(business.ExportRow) error) error {
+					return fn(business.ExportRow{ResultID: result.ID, SearchTerm: result.SearchTerm, FetchedAt: result.FetchedAt, Media: result.Media[0]})
+				})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "export searches as parquet",
+			method: http.MethodGet,
+			target: "/api/v1/searches/export?format=parquet",
+			mockSetup: func() {
+				exportHandler.EXPECT().ExportSearches(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "export searches with unsupported format",
+			method:         http.MethodGet,
+			target:         "/api/v1/searches/export?format=xlsx",
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "export searches failure",
+			method: http.MethodGet,
+			target: "/api/v1/searches/export?format=ndjson",
+			mockSetup: func() {
+				exportHandler.EXPECT().ExportSearches(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "create watchlist",
+			method: http.MethodPost,
+			target: "/api/v1/watchlists",
+			body:   `{"name":"favorites","artist_ids":[909253,1171421960]}`,
+			header: http.Header{"Content-Type": {"application/json"}},
+			mockSetup: func() {
+				watchlistHandler.EXPECT().CreateWatchlist(gomock.Any(), "", "favorites", []int{909253, 1171421960}).Return(watchlist, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create watchlist without name",
+			method:         http.MethodPost,
+			target:         "/api/v1/watchlists",
+			body:           `{"name":"","artist_ids":[909253]}`,
+			header:         http.Header{"Content-Type": {"application/json"}},
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "get unknown watchlist",
+			method: http.MethodGet,
+			target: "/api/v1/watchlists/42",
+			mockSetup: func() {
+				watchlistHandler.EXPECT().GetWatchlist(gomock.Any(), "", int64(42)).Return(business.Watchlist{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "subscribe watchlist artist",
+			method: http.MethodPut,
+			target: "/api/v1/watchlists/1/artists/909253",
+			mockSetup: func() {
+				watchlistHandler.EXPECT().SubscribeArtist(gomock.Any(), "", int64(1), 909253).Return(watchlist, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "unsubscribe watchlist artist not subscribed",
+			method: http.MethodDelete,
+			target: "/api/v1/watchlists/1/artists/42",
+			mockSetup: func() {
+				watchlistHandler.EXPECT().UnsubscribeArtist(gomock.Any(), "", int64(1), 42).Return(business.Watchlist{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "list watchlist releases",
+			method: http.MethodGet,
+			target: "/api/v1/watchlists/1/releases?limit=10",
+			mockSetup: func() {
+				watchlistHandler.EXPECT().ListWatchlistReleases(gomock.Any(), "", int64(1), 10, 0).Return([]business.Release{
+					{ID: 1, ArtistID: 909253, ArtistName: "Jack Johnson", CollectionID: 1, CollectionName: "In Between Dreams", ReleaseDate: now, DetectedAt: now},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list podcast episodes",
+			method: http.MethodGet,
+			target: "/api/v1/podcasts/1200361736/episodes?limit=10&offset=10",
+			mockSetup: func() {
+				podcastHandler.EXPECT().ListPodcastEpisodes(gomock.Any(), 1200361736, 10, 10).Return(business.PodcastEpisodes{
+					Podcast: business.Podcast{CollectionID: 1200361736, Title: "The Daily", FeedURL: "https://feeds.example.com/the-daily", RefreshedAt: &now},
+					Episodes: []business.Episode{
+						{ID: 1, CollectionID: 1200361736, GUID: "ep-1", Title: "Episode 1", AudioURL: "https://cdn.example.com/1.mp3", AudioType: "audio/mpeg", PublishedAt: &now, DurationSeconds: 1800},
+						{ID: 2, CollectionID: 1200361736, GUID: "ep-2"},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list episodes of unknown podcast",
+			method: http.MethodGet,
+			target: "/api/v1/podcasts/42/episodes",
+			mockSetup: func() {
+				podcastHandler.EXPECT().ListPodcastEpisodes(gomock.Any(), 42, 0, 0).Return(business.PodcastEpisodes{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "create webhook",
+			method: http.MethodPost,
+			target: "/api/v1/webhooks",
+			body:   `{"url":"https://example.com/hooks","events":["search.stored","job.finished"]}`,
+			header: http.Header{"Content-Type": {"application/json"}},
+			mockSetup: func() {
+				webhookHandler.EXPECT().CreateWebhook(gomock.Any(), "", "https://example.com/hooks", []business.EventType{business.EventSearchStored, business.EventJobFinished}).
+					Return(business.Webhook{ID: 1, URL: "https://example.com/hooks", Secret: "whsec_test", Events: []business.EventType{business.EventSearchStored, business.EventJobFinished}, CreatedAt: now}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create webhook with unknown event",
+			method:         http.MethodPost,
+			target:         "/api/v1/webhooks",
+			body:           `{"url":"https://example.com/hooks","events":["search.deleted"]}`,
+			header:         http.Header{"Content-Type": {"application/json"}},
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "get webhook",
+			method: http.MethodGet,
+			target: "/api/v1/webhooks/1",
+			mockSetup: func() {
+				webhookHandler.EXPECT().GetWebhook(gomock.Any(), "", int64(1)).
+					Return(business.Webhook{ID: 1, URL: "https://example.com/hooks", Events: []business.EventType{business.EventReleaseDetected}, CreatedAt: now}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "delete webhook",
+			method: http.MethodDelete,
+			target: "/api/v1/webhooks/1",
+			mockSetup: func() {
+				webhookHandler.EXPECT().DeleteWebhook(gomock.Any(), "", int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "list dead webhook deliveries",
+			method: http.MethodGet,
+			target: "/api/v1/webhooks/1/deliveries?status=dead",
+			mockSetup: func() {
+				webhookHandler.EXPECT().ListWebhookDeliveries(gomock.Any(), "", int64(1), business.WebhookDeliveryDead, 0, 0).Return([]business.WebhookDelivery{
+					{
+						ID: 2, WebhookID: 1, Event: business.EventSearchStored, Payload: []byte(`{"type":"search.stored"}`), Status: business.WebhookDeliveryDead,
+						Attempts: 8, LastStatusCode: 500, LastError: "received non-2xx response code: 500", CreatedAt: now,
+						Log: []business.WebhookAttempt{{DeliveryID: 2, StatusCode: 500, Error: "received non-2xx response code: 500", Duration: time.Second, CreatedAt: now}},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "redeliver webhook delivery",
+			method: http.MethodPost,
+			target: "/api/v1/webhooks/1/deliveries/2/redeliver",
+			mockSetup: func() {
+				webhookHandler.EXPECT().RedeliverWebhook(gomock.Any(), "", int64(1), int64(2)).Return(business.WebhookDelivery{
+					ID: 2, WebhookID: 1, Event: business.EventSearchStored, Payload: []byte(`{"type":"search.stored"}`), Status: business.WebhookDeliveryPending,
+					Attempts: 0, NextAttemptAt: now, LastStatusCode: 500, LastError: "received non-2xx response code: 500", CreatedAt: now,
+				}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:   "redeliver webhook delivery being delivered",
+			method: http.MethodPost,
+			target: "/api/v1/webhooks/1/deliveries/3/redeliver",
+			mockSetup: func() {
+				webhookHandler.EXPECT().RedeliverWebhook(gomock.Any(), "", int64(1), int64(3)).Return(business.WebhookDelivery{}, fmt.Errorf("%w: webhook delivery is being delivered", business.ErrConflict))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "create search job",
+			method: http.MethodPost,
+			target: "/api/v1/jobs",
+			body:   `{"searches":[{"term":"jack johnson","limit":1},{"term":"broken"}]}`,
+			header: http.Header{"Content-Type": {"application/json"}},
+			mockSetup: func() {
+				jobHandler.EXPECT().EnqueueSearchJob(gomock.Any(), "", gomock.Any()).Return(business.SearchJob{
+					ID:          2,
+					Status:      business.SearchJobQueued,
+					Searches:    []business.SearchQuery{{Term: "jack johnson", Limit: 1}, {Term: "broken", Limit: 20}},
+					MaxAttempts: 3,
+					RunAt:       now,
+					CreatedAt:   now,
+				}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "create search job without searches",
+			method:         http.MethodPost,
+			target:         "/api/v1/jobs",
+			body:           `{"searches":[]}`,
+			header:         http.Header{"Content-Type": {"application/json"}},
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "get search job",
+			method: http.MethodGet,
+			target: "/api/v1/jobs/1",
+			mockSetup: func() {
+				jobHandler.EXPECT().GetSearchJob(gomock.Any(), "", int64(1)).Return(job, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "get unknown search job",
+			method: http.MethodGet,
+			target: "/api/v1/jobs/42",
+			mockSetup: func() {
+				jobHandler.EXPECT().GetSearchJob(gomock.Any(), "", int64(42)).Return(business.SearchJob{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "cancel finished search job",
+			method: http.MethodDelete,
+			target: "/api/v1/jobs/1",
+			mockSetup: func() {
+				jobHandler.EXPECT().CancelSearchJob(gomock.Any(), "", int64(1)).Return(business.SearchJob{}, fmt.Errorf("%w: search job is already succeeded", business.ErrConflict))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "stream finished search job events",
+			method: http.MethodGet,
+			target: "/api/v1/jobs/1/events",
+			header: http.Header{"Last-Event-Id": {"3"}},
+			mockSetup: func() {
+				finished := job
+				finished.Status = business.SearchJobFailed
+				jobHandler.EXPECT().PollSearchJobEvents(gomock.Any(), "", int64(1), int64(3)).Return(business.SearchJobProgress{
+					Job:    finished,
+					Events: []business.SearchJobEvent{{ID: 4, JobID: 1, Kind: business.SearchJobEventResult, Position: 1, Term: "broken", Limit: 20, Error: "upstream down"}},
+					Done:   true,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "stream unknown search job events",
+			method: http.MethodGet,
+			target: "/api/v1/jobs/42/events",
+			mockSetup: func() {
+				jobHandler.EXPECT().PollSearchJobEvents(gomock.Any(), "", int64(42), int64(0)).Return(business.SearchJobProgress{}, business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "create api key",
+			method: http.MethodPost,
+			target: "/admin/api-keys",
+			body:   `{"name":"ingestion","minute_quota":60,"daily_quota":1000}`,
+			header: http.Header{"Content-Type": {"application/json"}},
+			mockSetup: func() {
+				apiKeyHandler.EXPECT().CreateAPIKey(gomock.Any(), "ingestion", 60, 1000).Return(key, "msk_0a1b2c3d_secret", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "create api key with negative quota",
+			method:         http.MethodPost,
+			target:         "/admin/api-keys",
+			body:           `{"name":"ingestion","minute_quota":-1}`,
+			header:         http.Header{"Content-Type": {"application/json"}},
+			invalid:        true,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "list api keys",
+			method: http.MethodGet,
+			target: "/admin/api-keys",
+			mockSetup: func() {
+				apiKeyHandler.EXPECT().ListAPIKeys(gomock.Any()).Return([]business.APIKey{key, revoked}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "rotate unknown api key",
+			method: http.MethodPost,
+			target: "/admin/api-keys/42/rotate",
+			mockSetup: func() {
+				apiKeyHandler.EXPECT().RotateAPIKey(gomock.Any(), int64(42)).Return(business.APIKey{}, "", business.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "revoke api key",
+			method: http.MethodDelete,
+			target: "/admin/api-keys/1",
+			mockSetup: func() {
+				apiKeyHandler.EXPECT().RevokeAPIKey(gomock.Any(), int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			newRequest := func() *http.Request {
+				req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+				for k, v := range tt.header {
+					req.Header[k] = v
+				}
+				return req
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, newRequest())
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			validateContract(t, specRouter, newRequest(), []byte(tt.body), !tt.invalid, rec)
+		})
+	}
+}
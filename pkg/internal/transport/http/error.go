@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+)
+
+// EncodeError function encodes endpoint errors into the json error envelope with a matching status code.
+func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	EncodeQuotaHeaders(ctx, w)
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, transport.ErrInvalidRequest), errors.Is(err, business.ErrUnknownProvider), errors.Is(err, business.ErrUnsupportedFilter):
+		status = http.StatusBadRequest
+	case errors.Is(err, business.ErrUnauthorized):
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	case errors.Is(err, business.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, business.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, business.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, ratelimit.ErrLimited):
+		status = http.StatusTooManyRequests
+		var limitErr *ratelimit.LimitError
+		if errors.As(err, &limitErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(limitErr.RetryAfter.Seconds())), 1)))
+		}
+	case errors.Is(err, business.ErrQuotaExceeded):
+		status = http.StatusTooManyRequests
+		if info, ok := transport.AuthInfoFromContext(ctx); ok && !info.Quota.Reset.IsZero() {
+			retryAfter := int(time.Until(info.Quota.Reset).Seconds()) + 1
+			w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"errors": err.Error(),
+	})
+}
+
+// PopulateAuthInfo function returns a request func putting the credentials of the request in the context,
+// the api key is read from the given header and the bearer token from the Authorization header.
+func PopulateAuthInfo(apiKeyHeader string) func(ctx context.Context, r *http.Request) context.Context {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return transport.ContextWithAuthInfo(ctx, &transport.AuthInfo{
+			APIKey:      r.Header.Get(apiKeyHeader),
+			BearerToken: bearerToken(r.Header.Get("Authorization")),
+		})
+	}
+}
+
+// bearerToken returns the token of a bearer Authorization header, the scheme is case-insensitive.
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// EncodeQuotaHeaders function writes the X-RateLimit-* headers of the authenticated api key, if any.
+func EncodeQuotaHeaders(ctx context.Context, w http.ResponseWriter) context.Context {
+	info, ok := transport.AuthInfoFromContext(ctx)
+	if !ok || info.Quota.Limit == 0 {
+		return ctx
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(info.Quota.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(info.Quota.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(info.Quota.Reset.Unix(), 10))
+	return ctx
+}
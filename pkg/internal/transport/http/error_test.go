@@ -0,0 +1,102 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeError(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second)
+	tests := []struct {
+		name            string
+		err             error
+		info            *transport.AuthInfo
+		expectedStatus  int
+		expectedBody    string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "invalid request",
+			err:            fmt.Errorf("%w: term shouldn't be empty", transport.ErrInvalidRequest),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":"invalid request: term shouldn't be empty"}`,
+		},
+		{
+			name:           "unauthorized",
+			err:            fmt.Errorf("failed to authenticate api key: %w", business.ErrUnauthorized),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"errors":"failed to authenticate api key: unauthorized"}`,
+			expectedHeaders: map[string]string{
+				"WWW-Authenticate": "Bearer",
+			},
+		},
+		{
+			name:           "forbidden",
+			err:            fmt.Errorf("%w: token lacks required scopes", business.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"errors":"forbidden: token lacks required scopes"}`,
+		},
+		{
+			name:           "conflict",
+			err:            fmt.Errorf("failed to cancel search job: %w: search job is already succeeded", business.ErrConflict),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"errors":"failed to cancel search job: conflict: search job is already succeeded"}`,
+		},
+		{
+			name:           "quota exceeded",
+			err:            fmt.Errorf("failed to authenticate api key: %w", business.ErrQuotaExceeded),
+			info:           &transport.AuthInfo{Quota: business.Quota{Limit: 10, Remaining: 0, Reset: reset}},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   `{"errors":"failed to authenticate api key: quota exceeded"}`,
+			expectedHeaders: map[string]string{
+				"X-RateLimit-Limit":     "10",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     fmt.Sprint(reset.Unix()),
+				"Retry-After":           "30",
+			},
+		},
+		{
+			name:           "rate limited",
+			err:            fmt.Errorf("failed to allow request: %w", &ratelimit.LimitError{RetryAfter: 1200 * time.Millisecond}),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   `{"errors":"failed to allow request: rate limited"}`,
+			expectedHeaders: map[string]string{
+				"Retry-After": "2",
+			},
+		},
+		{
+			name:           "unknown error",
+			err:            errors.New("boom"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"errors":"boom"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.info != nil {
+				ctx = transport.ContextWithAuthInfo(ctx, tt.info)
+			}
+			rec := httptest.NewRecorder()
+			kithttp.EncodeError(ctx, tt.err, rec)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			for k, v := range tt.expectedHeaders {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+		})
+	}
+}
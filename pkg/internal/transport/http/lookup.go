@@ -0,0 +1,140 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/gorilla/mux"
+)
+
+// DecodeLookupMediaRequest function decodes lookup media request.
+func DecodeLookupMediaRequest(_ context.Context, r *http.Request) (any, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("%w: id should be a positive number", transport.ErrInvalidRequest)
+	}
+	return transport.LookupMediaRequest{ID: id}, nil
+}
+
+// EncodeLookupMediaResponse function to encode lookup media response back, it is cached like search responses.
+func EncodeLookupMediaResponse(ctx context.Context, w http.ResponseWriter, response any) error {
+	res, ok := response.(transport.LookupMediaResponse)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse lookup media response, got %v", response).Error(),
+		})
+	}
+	tag, err := etag(res)
+	if err != nil {
+		return err
+	}
+	if writeCacheHeaders(ctx, w, tag, res.FetchedAt) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// DecodeListSeasonEpisodesRequest function decodes a request listing the episodes of the tv season in the
+// {collectionId} path variable.
+func DecodeListSeasonEpisodesRequest(_ context.Context, r *http.Request) (any, error) {
+	collectionID, err := strconv.Atoi(mux.Vars(r)["collectionId"])
+	if err != nil || collectionID <= 0 {
+		return nil, fmt.Errorf("%w: collection id should be a positive number", transport.ErrInvalidRequest)
+	}
+	return transport.ListSeasonEpisodesRequest{CollectionID: collectionID}, nil
+}
+
+// EncodeListSeasonEpisodesResponse function to encode the episodes of a tv season back, it is cached like lookup
+// responses.
+func EncodeListSeasonEpisodesResponse(ctx context.Context, w http.ResponseWriter, response any) error {
+	res, ok := response.(transport.ListSeasonEpisodesResponse)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse season episodes response, got %v", response).Error(),
+		})
+	}
+	tag, err := etag(res)
+	if err != nil {
+		return err
+	}
+	if writeCacheHeaders(ctx, w, tag, res.FetchedAt) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// DecodeListSearchHistoryRequest function decodes list search history request,
+// since and until are RFC 3339 timestamps.
+func DecodeListSearchHistoryRequest(_ context.Context, r *http.Request) (any, error) {
+	q := r.URL.Query()
+	req := transport.ListSearchHistoryRequest{Term: q.Get("term")}
+	var err error
+	if req.Since, err = parseTime(q, "since"); err != nil {
+		return nil, err
+	}
+	if req.Until, err = parseTime(q, "until"); err != nil {
+		return nil, err
+	}
+	if req.Limit, err = parseInt(q, "limit"); err != nil {
+		return nil, err
+	}
+	if req.Offset, err = parseInt(q, "offset"); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// EncodeListSearchHistoryResponse function to encode list search history response back.
+func EncodeListSearchHistoryResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, ok := response.(transport.ListSearchHistoryResponse); !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse list search history response, got %v", response).Error(),
+		})
+	}
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
+
+// parseTime parses the optional RFC 3339 query parameter key.
+func parseTime(q url.Values, key string) (time.Time, error) {
+	v := q.Get(key)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s should be an RFC 3339 timestamp", transport.ErrInvalidRequest, key)
+	}
+	return t, nil
+}
+
+// parseInt parses the optional non-negative integer query parameter key.
+func parseInt(q url.Values, key string) (int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s should be a non-negative number", transport.ErrInvalidRequest, key)
+	}
+	return n, nil
+}
@@ -0,0 +1,172 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeLookupMediaRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid request",
+			queryParams:     "id=909253",
+			expectedRequest: transport.LookupMediaRequest{ID: 909253},
+		},
+		{
+			name:          "missing id",
+			queryParams:   "",
+			expectedError: "invalid request: id should be a positive number",
+		},
+		{
+			name:          "negative id",
+			queryParams:   "id=-1",
+			expectedError: "invalid request: id should be a positive number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.queryParams, nil)
+			result, err := kithttp.DecodeLookupMediaRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestDecodeListSeasonEpisodesRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		collectionID    string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid request",
+			collectionID:    "1440658281",
+			expectedRequest: transport.ListSeasonEpisodesRequest{CollectionID: 1440658281},
+		},
+		{
+			name:          "zero collection id",
+			collectionID:  "0",
+			expectedError: "invalid request: collection id should be a positive number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"collectionId": tt.collectionID})
+			result, err := kithttp.DecodeListSeasonEpisodesRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestDecodeListSearchHistoryRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:        "every filter",
+			queryParams: "term=jack&since=2026-10-01T00:00:00Z&until=2026-10-19T00:00:00Z&limit=10&offset=20",
+			expectedRequest: transport.ListSearchHistoryRequest{
+				Term:   "jack",
+				Since:  time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				Until:  time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				Limit:  10,
+				Offset: 20,
+			},
+		},
+		{
+			name:            "no filter",
+			expectedRequest: transport.ListSearchHistoryRequest{},
+		},
+		{
+			name:          "invalid since",
+			queryParams:   "since=yesterday",
+			expectedError: "invalid request: since should be an RFC 3339 timestamp",
+		},
+		{
+			name:          "negative offset",
+			queryParams:   "offset=-1",
+			expectedError: "invalid request: offset should be a non-negative number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.queryParams, nil)
+			result, err := kithttp.DecodeListSearchHistoryRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestEncodeListSearchHistoryResponse(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		response       any
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "valid response",
+			response: transport.ListSearchHistoryResponse{Searches: []transport.HistoryEntry{
+				{ID: 1, SearchTerm: "jack", Media: []transport.Media{}, CreatedAt: createdAt},
+			}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"searches":[{"id":1,"search_term":"jack","result_count":0,"media":[],"created_at":"2026-10-19T10:00:00Z"}]}`,
+		},
+		{
+			name:           "invalid response type",
+			response:       "invalid response",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"errors":"failed to parse list search history response, got invalid response"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			err := kithttp.EncodeListSearchHistoryResponse(context.Background(), rec, tt.response)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
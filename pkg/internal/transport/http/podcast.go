@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/gorilla/mux"
+)
+
+// DecodeListPodcastEpisodesRequest function decodes a request listing the episodes of the podcast in the
+// {collectionId} path variable.
+func DecodeListPodcastEpisodesRequest(_ context.Context, r *http.Request) (any, error) {
+	collectionID, err := strconv.Atoi(mux.Vars(r)["collectionId"])
+	if err != nil || collectionID <= 0 {
+		return nil, fmt.Errorf("%w: collection id should be a positive number", transport.ErrInvalidRequest)
+	}
+	res := transport.ListPodcastEpisodesRequest{CollectionID: collectionID}
+	q := r.URL.Query()
+	if res.Limit, err = parseInt(q, "limit"); err != nil {
+		return nil, err
+	}
+	if res.Offset, err = parseInt(q, "offset"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// EncodeListPodcastEpisodesResponse function encodes the listed episodes of a podcast.
+func EncodeListPodcastEpisodesResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res, ok := response.(transport.ListPodcastEpisodesResponse)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse podcast episodes response, got %v", response).Error(),
+		})
+	}
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,76 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeListPodcastEpisodesRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		vars            map[string]string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid request",
+			target:          "/?limit=10&offset=20",
+			vars:            map[string]string{"collectionId": "1200361736"},
+			expectedRequest: transport.ListPodcastEpisodesRequest{CollectionID: 1200361736, Limit: 10, Offset: 20},
+		},
+		{
+			name:            "without pagination",
+			target:          "/",
+			vars:            map[string]string{"collectionId": "5"},
+			expectedRequest: transport.ListPodcastEpisodesRequest{CollectionID: 5},
+		},
+		{
+			name:          "invalid collection id",
+			target:        "/",
+			vars:          map[string]string{"collectionId": "0"},
+			expectedError: "invalid request: collection id should be a positive number",
+		},
+		{
+			name:          "invalid limit",
+			target:        "/?limit=abc",
+			vars:          map[string]string{"collectionId": "5"},
+			expectedError: "invalid request: limit should be a non-negative number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, tt.target, nil), tt.vars)
+
+			result, err := kithttp.DecodeListPodcastEpisodesRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRequest, result)
+		})
+	}
+}
+
+func TestEncodeListPodcastEpisodesResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := kithttp.EncodeListPodcastEpisodesResponse(context.Background(), rec, transport.ListPodcastEpisodesResponse{
+		Podcast:  transport.Podcast{CollectionID: 5, FeedURL: "https://example.com/feed"},
+		Episodes: []transport.Episode{},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"podcast":{"collection_id":5,"feed_url":"https://example.com/feed"},"episodes":[]}`, rec.Body.String())
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// maxSearchBatchBodySize is the largest search batch body accepted.
+const maxSearchBatchBodySize = 1 << 20
+
+// searchBatchBody represents the json body of a search batch request.
+type searchBatchBody struct {
+	Searches []struct {
+		Term  string `json:"term"`
+		Limit int    `json:"limit"`
+	} `json:"searches"`
+}
+
+// DecodeSearchBatchRequest function returns a decoder of search batch requests holding at most maxSize searches.
+// Searches without a limit default to the limit of a single search.
+func DecodeSearchBatchRequest(maxSize int) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (any, error) {
+		searches, err := decodeSearches(r, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		return transport.SearchBatchRequest{Searches: searches}, nil
+	}
+}
+
+// decodeSearches decodes and validates the searches of a json body holding at most maxSize of them.
+func decodeSearches(r *http.Request, maxSize int) ([]transport.SearchMediaRequest, error) {
+	var body searchBatchBody
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxSearchBatchBodySize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode body: %v", transport.ErrInvalidRequest, err)
+	}
+	if len(body.Searches) == 0 {
+		return nil, fmt.Errorf("%w: searches shouldn't be empty", transport.ErrInvalidRequest)
+	}
+	if len(body.Searches) > maxSize {
+		return nil, fmt.Errorf("%w: a batch holds at most %d searches", transport.ErrInvalidRequest, maxSize)
+	}
+
+	searches := make([]transport.SearchMediaRequest, len(body.Searches))
+	for i, search := range body.Searches {
+		if search.Term == "" {
+			return nil, fmt.Errorf("%w: term of search %d shouldn't be empty", transport.ErrInvalidRequest, i)
+		}
+		if search.Limit < 0 {
+			return nil, fmt.Errorf("%w: limit of search %d shouldn't be negative", transport.ErrInvalidRequest, i)
+		}
+		if search.Limit == 0 {
+			search.Limit = defaultSearchLimit
+		}
+		searches[i] = transport.SearchMediaRequest{Term: search.Term, Limit: search.Limit}
+	}
+	return searches, nil
+}
+
+// EncodeSearchBatchResponse function to encode search batch response back, it is answered with 200
+// even when some searches failed.
+func EncodeSearchBatchResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, ok := response.(transport.SearchBatchResponse); !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse search batch response, got %v", response).Error(),
+		})
+	}
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,90 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSearchBatchRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name: "valid request",
+			body: `{"searches":[{"term":"jack","limit":5},{"term":"johnson"}]}`,
+			expectedRequest: transport.SearchBatchRequest{Searches: []transport.SearchMediaRequest{
+				{Term: "jack", Limit: 5},
+				{Term: "johnson", Limit: 20},
+			}},
+		},
+		{
+			name:          "malformed body",
+			body:          `{"searches":`,
+			expectedError: "invalid request: failed to decode body: unexpected EOF",
+		},
+		{
+			name:          "no search",
+			body:          `{"searches":[]}`,
+			expectedError: "invalid request: searches shouldn't be empty",
+		},
+		{
+			name:          "too many searches",
+			body:          `{"searches":[{"term":"a"},{"term":"b"},{"term":"c"}]}`,
+			expectedError: "invalid request: a batch holds at most 2 searches",
+		},
+		{
+			name:          "empty term",
+			body:          `{"searches":[{"term":"jack"},{"term":""}]}`,
+			expectedError: "invalid request: term of search 1 shouldn't be empty",
+		},
+		{
+			name:          "negative limit",
+			body:          `{"searches":[{"term":"jack","limit":-1}]}`,
+			expectedError: "invalid request: limit of search 0 shouldn't be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			result, err := kithttp.DecodeSearchBatchRequest(2)(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestEncodeSearchBatchResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := kithttp.EncodeSearchBatchResponse(context.Background(), w, transport.SearchBatchResponse{
+		Results: []transport.SearchBatchItem{
+			{Term: "jack", Limit: 1, Result: &transport.SearchMediaResponse{ID: 1, SearchTerm: "jack", ResultCount: 0, Media: []transport.Media{}}},
+			{Term: "broken", Limit: 2, Error: "failed to fetch and insert media: upstream down"},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"results":[`+
+		`{"term":"jack","limit":1,"result":{"id":1,"search_term":"jack","result_count":0,"media":[]}},`+
+		`{"term":"broken","limit":2,"error":"failed to fetch and insert media: upstream down"}],`+
+		`"succeeded":1,"failed":1}`, w.Body.String())
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/export"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+)
+
+// DecodeExportSearchesRequest function decodes an export of the stored searches, filtered by the same query
+// parameters as the history. The format defaults to csv.
+func DecodeExportSearchesRequest(ctx context.Context, r *http.Request) (any, error) {
+	history, err := DecodeListSearchHistoryRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	format := export.FormatCSV
+	if v := r.URL.Query().Get("format"); v != "" {
+		if format, err = export.ParseFormat(v); err != nil {
+			return nil, fmt.Errorf("%w: %w", transport.ErrInvalidRequest, err)
+		}
+	}
+	return transport.ExportSearchesRequest{Format: format, ListSearchHistoryRequest: history.(transport.ListSearchHistoryRequest)}, nil
+}
+
+// EncodeExportSearchesResponse function streams the exported searches as a file download. Errors raised
+// before anything was written are answered as usual, the connection is aborted on later ones so clients
+// don't mistake a truncated export for a complete one.
+func EncodeExportSearchesResponse(ctx context.Context, w http.ResponseWriter, response any) error {
+	res, ok := response.(transport.ExportSearchesResponse)
+	if !ok {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse export searches response, got %v", response).Error(),
+		})
+	}
+	body := &exportBody{w: w, format: res.Format}
+	writer, err := export.NewWriter(res.Format, body)
+	if err != nil {
+		return err
+	}
+	if err = res.Export(ctx, writer); err == nil {
+		err = writer.Close()
+	}
+	if err != nil && body.started {
+		panic(http.ErrAbortHandler)
+	}
+	return err
+}
+
+// exportBody starts the export response on its first write, so failures before it can still be answered.
+type exportBody struct {
+	w       http.ResponseWriter
+	format  export.Format
+	started bool
+}
+
+func (b *exportBody) Write(p []byte) (int, error) {
+	if !b.started {
+		b.started = true
+		b.w.Header().Set("Content-Type", b.format.ContentType())
+		b.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="searches.%s"`, b.format))
+		b.w.WriteHeader(http.StatusOK)
+	}
+	return b.w.Write(p)
+}
@@ -0,0 +1,132 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/export"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeExportSearchesRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:        "history filters",
+			queryParams: "format=parquet&term=jack&since=2026-10-01T00:00:00Z&limit=10",
+			expectedRequest: transport.ExportSearchesRequest{
+				Format:                   export.FormatParquet,
+				ListSearchHistoryRequest: transport.ListSearchHistoryRequest{Term: "jack", Since: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Limit: 10},
+			},
+		},
+		{
+			name:            "defaults to csv",
+			expectedRequest: transport.ExportSearchesRequest{Format: export.FormatCSV},
+		},
+		{
+			name:          "unsupported format",
+			queryParams:   "format=xml",
+			expectedError: "invalid request: format should be one of csv, ndjson or parquet",
+		},
+		{
+			name:          "invalid filter",
+			queryParams:   "format=csv&until=today",
+			expectedError: "invalid request: until should be an RFC 3339 timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.queryParams, nil)
+
+			result, err := kithttp.DecodeExportSearchesRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestEncodeExportSearchesResponse(t *testing.T) {
+	row := business.ExportRow{ResultID: 7, SearchTerm: "jack", Media: business.Media{TrackID: 456}}
+
+	t.Run("streams the export", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := kithttp.EncodeExportSearchesResponse(context.Background(), w, transport.ExportSearchesResponse{
+			Format: export.FormatNDJSON,
+			Export: func(_ context.Context, ew export.Writer) error {
+				return ew.Write(row)
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="searches.ndjson"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, 1, strings.Count(w.Body.String(), "\n"))
+		assert.Contains(t, w.Body.String(), `"result_id":7,"search_term":"jack"`)
+	})
+
+	t.Run("error before any row is answered", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := kithttp.EncodeExportSearchesResponse(context.Background(), w, transport.ExportSearchesResponse{
+			Format: export.FormatCSV,
+			Export: func(context.Context, export.Writer) error {
+				return errors.New("failed to export searches: db down")
+			},
+		})
+
+		assert.EqualError(t, err, "failed to export searches: db down")
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("error once streaming aborts the response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			_ = kithttp.EncodeExportSearchesResponse(context.Background(), w, transport.ExportSearchesResponse{
+				Format: export.FormatNDJSON,
+				Export: func(_ context.Context, ew export.Writer) error {
+					if err := ew.Write(row); err != nil {
+						return err
+					}
+					// rows are buffered, closing flushes them as a failing export would once they overflow the buffer.
+					if err := ew.Close(); err != nil {
+						return err
+					}
+					return errors.New("failed to export searches: db down")
+				},
+			})
+		})
+		assert.Equal(t, `attachment; filename="searches.ndjson"`, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("unexpected response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := kithttp.EncodeExportSearchesResponse(context.Background(), w, "unexpected")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, `{"errors":"failed to parse export searches response, got unexpected"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+)
+
+// SearchJobEventsOptions configures the streams of search job events.
+type SearchJobEventsOptions struct {
+	// PollInterval is how often new events are read, clients reconnect after it as well.
+	PollInterval time.Duration
+	// Heartbeat is how long a stream may stay silent before a comment is sent, so proxies keep it open.
+	Heartbeat time.Duration
+	// Done ends the streams once closed, a server stops serving them on shutdown.
+	Done <-chan struct{}
+}
+
+// DecodeCreateSearchJobRequest function returns a decoder of create search job requests holding at most maxSize
+// searches, the body is the same as the one of a search batch.
+func DecodeCreateSearchJobRequest(maxSize int) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (any, error) {
+		searches, err := decodeSearches(r, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		return transport.CreateSearchJobRequest{Searches: searches}, nil
+	}
+}
+
+// DecodeSearchJobRequest function decodes a request targeting the search job in the {id} path variable.
+func DecodeSearchJobRequest(_ context.Context, r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: id should be a number", transport.ErrInvalidRequest)
+	}
+	return transport.SearchJobRequest{ID: id}, nil
+}
+
+// DecodeSearchJobEventsRequest function decodes a request streaming the events of the search job in the {id}
+// path variable, reconnecting clients resume after the event in the Last-Event-ID header.
+func DecodeSearchJobEventsRequest(ctx context.Context, r *http.Request) (any, error) {
+	req, err := DecodeSearchJobRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	var lastEventID int64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		if lastEventID, err = strconv.ParseInt(v, 10, 64); err != nil || lastEventID < 0 {
+			return nil, fmt.Errorf("%w: Last-Event-ID should be a positive number", transport.ErrInvalidRequest)
+		}
+	}
+	return transport.SearchJobEventsRequest{ID: req.(transport.SearchJobRequest).ID, LastEventID: lastEventID}, nil
+}
+
+// EncodeSearchJobEvents function returns an encoder streaming the events of a search job as server-sent events,
+// polling new ones until the job finished, the client went away or opts.Done is closed.
+func EncodeSearchJobEvents(opts SearchJobEventsOptions) kithttp.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response any) error {
+		res, ok := response.(transport.SearchJobEventsResponse)
+		if !ok {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusInternalServerError)
+			return json.NewEncoder(w).Encode(map[string]any{
+				"errors": fmt.Errorf("failed to parse search job events response, got %v", response).Error(),
+			})
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		// nginx buffers responses unless told otherwise.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		// the response controller reaches the flusher through the writers wrapping w.
+		rc := http.NewResponseController(w)
+		// once the stream started errors can't be answered anymore, a failed write means the client is gone.
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", opts.PollInterval.Milliseconds()); err != nil {
+			return nil
+		}
+
+		poll := time.NewTicker(opts.PollInterval)
+		defer poll.Stop()
+		heartbeat := time.NewTicker(opts.Heartbeat)
+		defer heartbeat.Stop()
+		for {
+			for _, event := range res.Events {
+				if err := writeEvent(w, event); err != nil {
+					return nil
+				}
+			}
+			if len(res.Events) > 0 {
+				heartbeat.Reset(opts.Heartbeat)
+			}
+			if err := rc.Flush(); err != nil || res.Done {
+				return nil
+			}
+			if !waitForPoll(ctx, w, rc, poll.C, heartbeat.C, opts.Done) {
+				return nil
+			}
+			next, err := res.Poll(ctx, res.LastEventID)
+			if err != nil {
+				if ctx.Err() == nil {
+					_ = writeEvent(w, transport.SearchJobEventResponse{Event: "error", Data: map[string]any{"errors": err.Error()}})
+					_ = rc.Flush()
+				}
+				return nil
+			}
+			res = next
+		}
+	}
+}
+
+// waitForPoll waits for the next poll, sending heartbeats meanwhile. It reports false once the stream should end.
+func waitForPoll(ctx context.Context, w io.Writer, rc *http.ResponseController, poll, heartbeat <-chan time.Time, done <-chan struct{}) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-done:
+			return false
+		case <-poll:
+			return true
+		case <-heartbeat:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return false
+			}
+			if err := rc.Flush(); err != nil {
+				return false
+			}
+		}
+	}
+}
+
+// writeEvent writes a single server-sent event, its data fits a single line since JSON escapes newlines.
+func writeEvent(w io.Writer, event transport.SearchJobEventResponse) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	var id string
+	if event.ID != 0 {
+		id = fmt.Sprintf("id: %d\n", event.ID)
+	}
+	_, err = fmt.Fprintf(w, "%sevent: %s\ndata: %s\n\n", id, event.Event, data)
+	return err
+}
+
+// EncodeSearchJobResponse function encodes the search job endpoints responses, a created job is answered
+// with 202 and the location to poll it at.
+func EncodeSearchJobResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	switch res := response.(type) {
+	case transport.CreateSearchJobResponse:
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/jobs/%d", res.ID))
+		w.WriteHeader(http.StatusAccepted)
+		return json.NewEncoder(w).Encode(res)
+	case transport.SearchJobResponse:
+		w.WriteHeader(http.StatusOK)
+		return json.NewEncoder(w).Encode(res)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse search job response, got %v", response).Error(),
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCreateSearchJobRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"searches":[{"term":"jack","limit":5},{"term":"johnson"}]}`))
+
+	result, err := kithttp.DecodeCreateSearchJobRequest(2)(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, transport.CreateSearchJobRequest{Searches: []transport.SearchMediaRequest{
+		{Term: "jack", Limit: 5},
+		{Term: "johnson", Limit: 20},
+	}}, result)
+}
+
+func TestDecodeSearchJobRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid id",
+			id:              "42",
+			expectedRequest: transport.SearchJobRequest{ID: 42},
+		},
+		{
+			name:          "invalid id",
+			id:            "abc",
+			expectedError: "invalid request: id should be a number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"id": tt.id})
+
+			result, err := kithttp.DecodeSearchJobRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestEncodeSearchJobResponse(t *testing.T) {
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	job := transport.SearchJobResponse{
+		ID:          7,
+		Status:      "queued",
+		MaxAttempts: 3,
+		Results:     []transport.SearchBatchItem{{Term: "jack", Limit: 5}},
+		NextRunAt:   &createdAt,
+		CreatedAt:   createdAt,
+	}
+	tests := []struct {
+		name             string
+		response         any
+		expectedStatus   int
+		expectedLocation string
+		expectedBody     string
+	}{
+		{
+			name:             "created job",
+			response:         transport.CreateSearchJobResponse{SearchJobResponse: job},
+			expectedStatus:   http.StatusAccepted,
+			expectedLocation: "/api/v1/jobs/7",
+			expectedBody: `{"id":7,"status":"queued","attempts":0,"max_attempts":3,"results":[{"term":"jack","limit":5}],` +
+				`"succeeded":0,"failed":0,"next_run_at":"2026-10-19T12:00:00Z","created_at":"2026-10-19T12:00:00Z"}`,
+		},
+		{
+			name:           "polled job",
+			response:       job,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"id":7,"status":"queued","attempts":0,"max_attempts":3,"results":[{"term":"jack","limit":5}],` +
+				`"succeeded":0,"failed":0,"next_run_at":"2026-10-19T12:00:00Z","created_at":"2026-10-19T12:00:00Z"}`,
+		},
+		{
+			name:           "unexpected response",
+			response:       "unexpected",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"errors":"failed to parse search job response, got unexpected"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			err := kithttp.EncodeSearchJobResponse(context.Background(), w, tt.response)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestDecodeSearchJobEventsRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		lastEventID     string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "new stream",
+			expectedRequest: transport.SearchJobEventsRequest{ID: 42},
+		},
+		{
+			name:            "resumed stream",
+			lastEventID:     "7",
+			expectedRequest: transport.SearchJobEventsRequest{ID: 42, LastEventID: 7},
+		},
+		{
+			name:          "invalid last event id",
+			lastEventID:   "-1",
+			expectedError: "invalid request: Last-Event-ID should be a positive number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"id": "42"})
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			result, err := kithttp.DecodeSearchJobEventsRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRequest, result)
+			}
+		})
+	}
+}
+
+func TestEncodeSearchJobEvents(t *testing.T) {
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	result := transport.SearchJobEventResponse{ID: 4, Event: "result", Data: transport.SearchJobResultEvent{Term: "jack", Limit: 5, MediaResultID: 7, ResultCount: 2, At: at}}
+	summary := transport.SearchJobEventResponse{Event: "summary", Data: transport.SearchJobSummaryEvent{Status: "succeeded", Attempts: 1, Succeeded: 1}}
+	const (
+		resultEvent  = "id: 4\nevent: result\ndata: {\"position\":0,\"term\":\"jack\",\"limit\":5,\"media_result_id\":7,\"result_count\":2,\"at\":\"2026-10-19T12:00:00Z\"}\n\n"
+		summaryEvent = "event: summary\ndata: {\"status\":\"succeeded\",\"attempts\":1,\"succeeded\":1,\"failed\":0}\n\n"
+	)
+	closed := make(chan struct{})
+	close(closed)
+	tests := []struct {
+		name             string
+		opts             kithttp.SearchJobEventsOptions
+		response         func(t *testing.T) any
+		expectedStatus   int
+		expectedBody     string
+		expectedContains []string
+	}{
+		{
+			name: "finished job",
+			opts: kithttp.SearchJobEventsOptions{PollInterval: time.Second, Heartbeat: time.Second},
+			response: func(*testing.T) any {
+				return transport.SearchJobEventsResponse{Events: []transport.SearchJobEventResponse{result, summary}, LastEventID: 4, Done: true}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "retry: 1000\n\n" + resultEvent + summaryEvent,
+		},
+		{
+			name: "polls after the last event until the job finished",
+			opts: kithttp.SearchJobEventsOptions{PollInterval: time.Millisecond, Heartbeat: time.Hour},
+			response: func(t *testing.T) any {
+				return transport.SearchJobEventsResponse{
+					LastEventID: 3,
+					Poll: func(_ context.Context, afterEventID int64) (transport.SearchJobEventsResponse, error) {
+						assert.Equal(t, int64(3), afterEventID)
+						return transport.SearchJobEventsResponse{Events: []transport.SearchJobEventResponse{result, summary}, LastEventID: 4, Done: true}, nil
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "retry: 1\n\n" + resultEvent + summaryEvent,
+		},
+		{
+			name: "heartbeats while no event is sent",
+			opts: kithttp.SearchJobEventsOptions{PollInterval: 50 * time.Millisecond, Heartbeat: time.Millisecond},
+			response: func(*testing.T) any {
+				return transport.SearchJobEventsResponse{
+					Poll: func(context.Context, int64) (transport.SearchJobEventsResponse, error) {
+						return transport.SearchJobEventsResponse{Events: []transport.SearchJobEventResponse{summary}, Done: true}, nil
+					},
+				}
+			},
+			expectedStatus:   http.StatusOK,
+			expectedContains: []string{": heartbeat\n\n", summaryEvent},
+		},
+		{
+			name: "poll error ends the stream",
+			opts: kithttp.SearchJobEventsOptions{PollInterval: time.Millisecond, Heartbeat: time.Hour},
+			response: func(*testing.T) any {
+				return transport.SearchJobEventsResponse{
+					Events:      []transport.SearchJobEventResponse{result},
+					LastEventID: 4,
+					Poll: func(context.Context, int64) (transport.SearchJobEventsResponse, error) {
+						return transport.SearchJobEventsResponse{}, errors.New("failed to poll search job events: not found")
+					},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "retry: 1\n\n" + resultEvent + "event: error\ndata: {\"errors\":\"failed to poll search job events: not found\"}\n\n",
+		},
+		{
+			name: "shutdown ends the stream",
+			opts: kithttp.SearchJobEventsOptions{PollInterval: time.Hour, Heartbeat: time.Hour, Done: closed},
+			response: func(*testing.T) any {
+				return transport.SearchJobEventsResponse{Events: []transport.SearchJobEventResponse{result}, LastEventID: 4}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "retry: 3600000\n\n" + resultEvent,
+		},
+		{
+			name:           "unexpected response",
+			opts:           kithttp.SearchJobEventsOptions{PollInterval: time.Second, Heartbeat: time.Second},
+			response:       func(*testing.T) any { return "unexpected" },
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "{\"errors\":\"failed to parse search job events response, got unexpected\"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			err := kithttp.EncodeSearchJobEvents(tt.opts)(context.Background(), w, tt.response(t))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+				assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+				assert.True(t, w.Flushed)
+			}
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+			for _, s := range tt.expectedContains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
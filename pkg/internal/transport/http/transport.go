@@ -3,31 +3,93 @@ package http
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
 )
 
+// defaultSearchLimit is the limit of searches sent without one.
+const defaultSearchLimit = 20
+
+// mediaTypes are the media types a search can be restricted to, as named by iTunes.
+var mediaTypes = []string{"movie", "podcast", "music", "musicVideo", "audiobook", "shortFilm", "tvShow", "software", "ebook", "all"}
+
 // DecodeSearchMediaRequest function decodes search media request.
 func DecodeSearchMediaRequest(_ context.Context, r *http.Request) (any, error) {
 	term := r.URL.Query().Get("term")
-	defaultLimit := 20
 	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
 	if err != nil {
-		limit = defaultLimit
+		limit = defaultSearchLimit
 	}
 	if term == "" {
-		return nil, errors.New("term shouldn't be empty")
+		return nil, fmt.Errorf("%w: term shouldn't be empty", transport.ErrInvalidRequest)
+	}
+
+	provider := r.URL.Query().Get("provider")
+	var providers []string
+	if federated := r.URL.Query().Get("providers"); federated != "" {
+		providers = lo.Compact(lo.Map(strings.Split(federated, ","), func(p string, _ int) string { return strings.TrimSpace(p) }))
+	}
+	if provider != "" && len(providers) > 0 {
+		return nil, fmt.Errorf("%w: provider and providers can't be set together", transport.ErrInvalidRequest)
+	}
+
+	filter, err := decodeSearchFilter(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	if filter != (business.SearchFilter{}) && len(providers) > 0 {
+		return nil, fmt.Errorf("%w: federated searches can't be filtered", transport.ErrInvalidRequest)
 	}
 
-	return transport.SearchMediaRequest{Term: term, Limit: limit}, nil
+	return transport.SearchMediaRequest{Term: term, Limit: limit, Provider: provider, Providers: providers, Filter: filter}, nil
+}
+
+// decodeSearchFilter decodes the media type and software filters of a search, the software filters restrict it to
+// software when no media type is set.
+func decodeSearchFilter(q url.Values) (business.SearchFilter, error) {
+	filter := business.SearchFilter{
+		Media:  q.Get("media"),
+		Device: q.Get("device"),
+		Price:  business.PriceFilter(q.Get("price")),
+	}
+	if filter.Media != "" && !slices.Contains(mediaTypes, filter.Media) {
+		return business.SearchFilter{}, fmt.Errorf("%w: media should be one of %s", transport.ErrInvalidRequest, strings.Join(mediaTypes, ", "))
+	}
+	if v := q.Get("min_rating"); v != "" {
+		rating, err := strconv.ParseFloat(v, 64)
+		if err != nil || rating < 0 || rating > 5 {
+			return business.SearchFilter{}, fmt.Errorf("%w: min_rating should be a number between 0 and 5", transport.ErrInvalidRequest)
+		}
+		filter.MinRating = rating
+	}
+	if filter.Price != "" && filter.Price != business.PriceFree && filter.Price != business.PricePaid {
+		return business.SearchFilter{}, fmt.Errorf("%w: price should be free or paid", transport.ErrInvalidRequest)
+	}
+	if filter.MinRating > 0 || filter.Device != "" || filter.Price != "" {
+		switch filter.Media {
+		case "":
+			filter.Media = "software"
+		case "software":
+		default:
+			return business.SearchFilter{}, fmt.Errorf("%w: min_rating, device and price only filter software", transport.ErrInvalidRequest)
+		}
+	}
+	return filter, nil
 }
 
 // EncodeSearchMediaResponse function to encode media search response back.
-func EncodeSearchMediaResponse(_ context.Context, w http.ResponseWriter, response any) error {
-	_, ok := response.(transport.SearchMediaResponse)
+// The response carries a strong ETag of its result and freshness headers derived from when the result was fetched,
+// 304 is returned instead when the request's If-None-Match matches it.
+func EncodeSearchMediaResponse(ctx context.Context, w http.ResponseWriter, response any) error {
+	res, ok := response.(transport.SearchMediaResponse)
 	if !ok {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusBadRequest)
@@ -35,6 +97,14 @@ func EncodeSearchMediaResponse(_ context.Context, w http.ResponseWriter, respons
 			"errors": fmt.Errorf("failed to parse search media response, got %v", response).Error(),
 		})
 	}
+	tag, err := searchMediaETag(res)
+	if err != nil {
+		return err
+	}
+	if writeCacheHeaders(ctx, w, tag, res.FetchedAt) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(response)
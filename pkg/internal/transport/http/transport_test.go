@@ -2,6 +2,7 @@ package http_test
 
 import (
 	"context"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
 	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
 	"github.com/stretchr/testify/assert"
@@ -12,11 +13,12 @@ import (
 
 func TestDecodeSearchMediaRequest(t *testing.T) {
 	tests := []struct {
-		name          string
-		queryParams   string
-		expectedError string
-		expectedTerm  string
-		expectedLimit int
+		name           string
+		queryParams    string
+		expectedError  string
+		expectedTerm   string
+		expectedLimit  int
+		expectedFilter business.SearchFilter
 	}{
 		{
 			name:          "valid request",
@@ -39,6 +41,45 @@ func TestDecodeSearchMediaRequest(t *testing.T) {
 			expectedTerm:  "test",
 			expectedLimit: 20, // default limit
 		},
+		{
+			name:           "media type",
+			queryParams:    "term=test&media=musicVideo",
+			expectedTerm:   "test",
+			expectedLimit:  20,
+			expectedFilter: business.SearchFilter{Media: "musicVideo"},
+		},
+		{
+			name:           "software filters imply software",
+			queryParams:    "term=test&min_rating=4.5&device=iPad&price=free",
+			expectedTerm:   "test",
+			expectedLimit:  20,
+			expectedFilter: business.SearchFilter{Media: "software", MinRating: 4.5, Device: "iPad", Price: business.PriceFree},
+		},
+		{
+			name:          "unknown media type",
+			queryParams:   "term=test&media=games",
+			expectedError: "invalid request: media should be one of movie, podcast, music",
+		},
+		{
+			name:          "invalid min rating",
+			queryParams:   "term=test&min_rating=6",
+			expectedError: "invalid request: min_rating should be a number between 0 and 5",
+		},
+		{
+			name:          "invalid price",
+			queryParams:   "term=test&price=cheap",
+			expectedError: "invalid request: price should be free or paid",
+		},
+		{
+			name:          "software filters on another media type",
+			queryParams:   "term=test&media=music&price=paid",
+			expectedError: "invalid request: min_rating, device and price only filter software",
+		},
+		{
+			name:          "filtered federated search",
+			queryParams:   "term=test&providers=itunes,musicbrainz&media=music",
+			expectedError: "invalid request: federated searches can't be filtered",
+		},
 	}
 
 	for _, tt := range tests {
@@ -51,7 +92,7 @@ func TestDecodeSearchMediaRequest(t *testing.T) {
 				assert.Contains(t, err.Error(), tt.expectedError)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, transport.SearchMediaRequest{Term: tt.expectedTerm, Limit: tt.expectedLimit}, result)
+				assert.Equal(t, transport.SearchMediaRequest{Term: tt.expectedTerm, Limit: tt.expectedLimit, Filter: tt.expectedFilter}, result)
 			}
 		})
 	}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// maxWatchlistBodySize is the largest create watchlist body accepted.
+	maxWatchlistBodySize = 1 << 16
+	// maxWatchlistArtists is the most artists a watchlist may be created with.
+	maxWatchlistArtists = 100
+)
+
+// createWatchlistBody represents the json body of a create watchlist request.
+type createWatchlistBody struct {
+	Name      string `json:"name"`
+	ArtistIDs []int  `json:"artist_ids"`
+}
+
+// DecodeCreateWatchlistRequest function decodes a request creating a watchlist subscribed to the given artists.
+func DecodeCreateWatchlistRequest(_ context.Context, r *http.Request) (any, error) {
+	var body createWatchlistBody
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxWatchlistBodySize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode body: %v", transport.ErrInvalidRequest, err)
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		return nil, fmt.Errorf("%w: name shouldn't be empty", transport.ErrInvalidRequest)
+	}
+	if len(body.ArtistIDs) > maxWatchlistArtists {
+		return nil, fmt.Errorf("%w: a watchlist is created with at most %d artists", transport.ErrInvalidRequest, maxWatchlistArtists)
+	}
+	for i, id := range body.ArtistIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: artist id %d should be a positive number", transport.ErrInvalidRequest, i)
+		}
+	}
+	return transport.CreateWatchlistRequest{Name: body.Name, ArtistIDs: body.ArtistIDs}, nil
+}
+
+// DecodeWatchlistRequest function decodes a request targeting the watchlist in the {id} path variable.
+func DecodeWatchlistRequest(_ context.Context, r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: id should be a number", transport.ErrInvalidRequest)
+	}
+	return transport.WatchlistRequest{ID: id}, nil
+}
+
+// DecodeWatchlistArtistRequest function decodes a request targeting the artist in the {artistId} path variable
+// of the watchlist in the {id} one.
+func DecodeWatchlistArtistRequest(ctx context.Context, r *http.Request) (any, error) {
+	req, err := DecodeWatchlistRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	artistID, err := strconv.Atoi(mux.Vars(r)["artistId"])
+	if err != nil || artistID <= 0 {
+		return nil, fmt.Errorf("%w: artist id should be a positive number", transport.ErrInvalidRequest)
+	}
+	return transport.WatchlistArtistRequest{ID: req.(transport.WatchlistRequest).ID, ArtistID: artistID}, nil
+}
+
+// DecodeListWatchlistReleasesRequest function decodes a request listing the releases of the watchlist in the
+// {id} path variable.
+func DecodeListWatchlistReleasesRequest(ctx context.Context, r *http.Request) (any, error) {
+	req, err := DecodeWatchlistRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	res := transport.ListWatchlistReleasesRequest{ID: req.(transport.WatchlistRequest).ID}
+	q := r.URL.Query()
+	if res.Limit, err = parseInt(q, "limit"); err != nil {
+		return nil, err
+	}
+	if res.Offset, err = parseInt(q, "offset"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// EncodeWatchlistResponse function encodes the watchlist endpoints responses, a created watchlist is answered
+// with 201 and its location.
+func EncodeWatchlistResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	switch res := response.(type) {
+	case transport.CreateWatchlistResponse:
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/watchlists/%d", res.ID))
+		w.WriteHeader(http.StatusCreated)
+		return json.NewEncoder(w).Encode(res)
+	case transport.WatchlistResponse, transport.ListWatchlistReleasesResponse:
+		w.WriteHeader(http.StatusOK)
+		return json.NewEncoder(w).Encode(res)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse watchlist response, got %v", response).Error(),
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCreateWatchlistRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid body",
+			body:            `{"name":" favorites ","artist_ids":[909253,1171421960]}`,
+			expectedRequest: transport.CreateWatchlistRequest{Name: "favorites", ArtistIDs: []int{909253, 1171421960}},
+		},
+		{
+			name:            "without artists",
+			body:            `{"name":"favorites"}`,
+			expectedRequest: transport.CreateWatchlistRequest{Name: "favorites"},
+		},
+		{
+			name:          "empty name",
+			body:          `{"name":" ","artist_ids":[909253]}`,
+			expectedError: "invalid request: name shouldn't be empty",
+		},
+		{
+			name:          "invalid artist id",
+			body:          `{"name":"favorites","artist_ids":[909253,0]}`,
+			expectedError: "invalid request: artist id 1 should be a positive number",
+		},
+		{
+			name:          "malformed body",
+			body:          `{"name":`,
+			expectedError: "invalid request: failed to decode body: unexpected EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			result, err := kithttp.DecodeCreateWatchlistRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRequest, result)
+		})
+	}
+}
+
+func TestDecodeWatchlistArtistRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		vars            map[string]string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "valid ids",
+			vars:            map[string]string{"id": "1", "artistId": "909253"},
+			expectedRequest: transport.WatchlistArtistRequest{ID: 1, ArtistID: 909253},
+		},
+		{
+			name:          "invalid watchlist id",
+			vars:          map[string]string{"id": "abc", "artistId": "909253"},
+			expectedError: "invalid request: id should be a number",
+		},
+		{
+			name:          "invalid artist id",
+			vars:          map[string]string{"id": "1", "artistId": "0"},
+			expectedError: "invalid request: artist id should be a positive number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodPut, "/", nil), tt.vars)
+
+			result, err := kithttp.DecodeWatchlistArtistRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRequest, result)
+		})
+	}
+}
+
+func TestEncodeWatchlistResponse(t *testing.T) {
+	t.Run("created watchlist", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := kithttp.EncodeWatchlistResponse(context.Background(), rec, transport.CreateWatchlistResponse{WatchlistResponse: transport.WatchlistResponse{ID: 7, Name: "favorites"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/api/v1/watchlists/7", rec.Header().Get("Location"))
+	})
+
+	t.Run("releases", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := kithttp.EncodeWatchlistResponse(context.Background(), rec, transport.ListWatchlistReleasesResponse{Releases: []transport.Release{}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"releases":[]}`, rec.Body.String())
+	})
+}
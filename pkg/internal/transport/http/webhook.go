@@ -0,0 +1,146 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NawafSwe/media-scout-service/pkg/clients/publicnet"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/gorilla/mux"
+)
+
+// maxWebhookBodySize is the largest create webhook body accepted.
+const maxWebhookBodySize = 1 << 14
+
+// webhookDeliveryStatuses are the statuses deliveries may be listed by.
+var webhookDeliveryStatuses = []business.WebhookDeliveryStatus{
+	business.WebhookDeliveryPending,
+	business.WebhookDeliveryDelivering,
+	business.WebhookDeliveryDelivered,
+	business.WebhookDeliveryDead,
+}
+
+// createWebhookBody represents the json body of a create webhook request.
+type createWebhookBody struct {
+	URL    string               `json:"url"`
+	Events []business.EventType `json:"events"`
+}
+
+// DecodeCreateWebhookRequest function decodes a request subscribing an absolute http or https url to events.
+func DecodeCreateWebhookRequest(_ context.Context, r *http.Request) (any, error) {
+	var body createWebhookBody
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxWebhookBodySize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode body: %v", transport.ErrInvalidRequest, err)
+	}
+	body.URL = strings.TrimSpace(body.URL)
+	u, err := url.Parse(body.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: url should be an absolute http or https url", transport.ErrInvalidRequest)
+	}
+	if !isPublicHost(u.Hostname()) {
+		return nil, fmt.Errorf("%w: url should target a public host", transport.ErrInvalidRequest)
+	}
+	if len(body.Events) == 0 {
+		return nil, fmt.Errorf("%w: events shouldn't be empty", transport.ErrInvalidRequest)
+	}
+	for _, event := range body.Events {
+		if !slices.Contains(business.EventTypes, event) {
+			return nil, fmt.Errorf("%w: unknown event %q", transport.ErrInvalidRequest, event)
+		}
+	}
+	return transport.CreateWebhookRequest{URL: body.URL, Events: body.Events}, nil
+}
+
+// isPublicHost reports whether host may be subscribed, addresses are checked again by the webhook client once
+// names are resolved.
+func isPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return publicnet.IsPublicAddress(addr)
+	}
+	return true
+}
+
+// DecodeWebhookRequest function decodes a request targeting the webhook in the {id} path variable.
+func DecodeWebhookRequest(_ context.Context, r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: id should be a number", transport.ErrInvalidRequest)
+	}
+	return transport.WebhookRequest{ID: id}, nil
+}
+
+// DecodeListWebhookDeliveriesRequest function decodes a request listing the deliveries of the webhook in the
+// {id} path variable, optionally filtered by status.
+func DecodeListWebhookDeliveriesRequest(ctx context.Context, r *http.Request) (any, error) {
+	req, err := DecodeWebhookRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+	res := transport.ListWebhookDeliveriesRequest{ID: req.(transport.WebhookRequest).ID, Status: business.WebhookDeliveryStatus(q.Get("status"))}
+	if res.Status != "" && !slices.Contains(webhookDeliveryStatuses, res.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", transport.ErrInvalidRequest, res.Status)
+	}
+	if res.Limit, err = parseInt(q, "limit"); err != nil {
+		return nil, err
+	}
+	if res.Offset, err = parseInt(q, "offset"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DecodeRedeliverWebhookRequest function decodes a request redelivering the delivery in the {deliveryId} path
+// variable of the webhook in the {id} one.
+func DecodeRedeliverWebhookRequest(ctx context.Context, r *http.Request) (any, error) {
+	req, err := DecodeWebhookRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	deliveryID, err := strconv.ParseInt(mux.Vars(r)["deliveryId"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: delivery id should be a number", transport.ErrInvalidRequest)
+	}
+	return transport.RedeliverWebhookRequest{ID: req.(transport.WebhookRequest).ID, DeliveryID: deliveryID}, nil
+}
+
+// EncodeWebhookResponse function encodes the webhook endpoints responses, a created webhook is answered with 201
+// and its location, a redelivery with 202 since it is sent by the delivery workers.
+func EncodeWebhookResponse(_ context.Context, w http.ResponseWriter, response any) error {
+	switch res := response.(type) {
+	case transport.DeleteWebhookResponse:
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	case transport.CreateWebhookResponse:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/webhooks/%d", res.ID))
+		w.WriteHeader(http.StatusCreated)
+		return json.NewEncoder(w).Encode(res)
+	case transport.RedeliverWebhookResponse:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusAccepted)
+		return json.NewEncoder(w).Encode(res)
+	case transport.WebhookResponse, transport.ListWebhookDeliveriesResponse:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		return json.NewEncoder(w).Encode(res)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(w).Encode(map[string]any{
+			"errors": fmt.Errorf("failed to parse webhook response, got %v", response).Error(),
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttp "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCreateWebhookRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name: "valid body",
+			body: `{"url":" https://example.com/hooks ","events":["search.stored","job.finished"]}`,
+			expectedRequest: transport.CreateWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []business.EventType{business.EventSearchStored, business.EventJobFinished},
+			},
+		},
+		{
+			name:          "relative url",
+			body:          `{"url":"/hooks","events":["search.stored"]}`,
+			expectedError: "invalid request: url should be an absolute http or https url",
+		},
+		{
+			name:          "unsupported scheme",
+			body:          `{"url":"ftp://example.com/hooks","events":["search.stored"]}`,
+			expectedError: "invalid request: url should be an absolute http or https url",
+		},
+		{
+			name:          "loopback address",
+			body:          `{"url":"http://127.0.0.1:8080/hooks","events":["search.stored"]}`,
+			expectedError: "invalid request: url should target a public host",
+		},
+		{
+			name:          "link-local address",
+			body:          `{"url":"http://169.254.169.254/latest/meta-data","events":["search.stored"]}`,
+			expectedError: "invalid request: url should target a public host",
+		},
+		{
+			name:          "private address",
+			body:          `{"url":"https://[fd00::1]/hooks","events":["search.stored"]}`,
+			expectedError: "invalid request: url should target a public host",
+		},
+		{
+			name:          "unspecified address",
+			body:          `{"url":"http://0.0.0.0/hooks","events":["search.stored"]}`,
+			expectedError: "invalid request: url should target a public host",
+		},
+		{
+			name:          "localhost",
+			body:          `{"url":"http://LocalHost./hooks","events":["search.stored"]}`,
+			expectedError: "invalid request: url should target a public host",
+		},
+		{
+			name:          "without events",
+			body:          `{"url":"https://example.com/hooks"}`,
+			expectedError: "invalid request: events shouldn't be empty",
+		},
+		{
+			name:          "unknown event",
+			body:          `{"url":"https://example.com/hooks","events":["search.deleted"]}`,
+			expectedError: `invalid request: unknown event "search.deleted"`,
+		},
+		{
+			name:          "malformed body",
+			body:          `{"url":`,
+			expectedError: "invalid request: failed to decode body: unexpected EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			result, err := kithttp.DecodeCreateWebhookRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRequest, result)
+		})
+	}
+}
+
+func TestDecodeListWebhookDeliveriesRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		expectedError   string
+		expectedRequest any
+	}{
+		{
+			name:            "filtered by status",
+			target:          "/?status=dead&limit=10&offset=5",
+			expectedRequest: transport.ListWebhookDeliveriesRequest{ID: 1, Status: business.WebhookDeliveryDead, Limit: 10, Offset: 5},
+		},
+		{
+			name:            "any status",
+			target:          "/",
+			expectedRequest: transport.ListWebhookDeliveriesRequest{ID: 1},
+		},
+		{
+			name:          "unknown status",
+			target:        "/?status=lost",
+			expectedError: `invalid request: unknown status "lost"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, tt.target, nil), map[string]string{"id": "1"})
+
+			result, err := kithttp.DecodeListWebhookDeliveriesRequest(context.Background(), req)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRequest, result)
+		})
+	}
+}
+
+func TestEncodeWebhookResponse(t *testing.T) {
+	t.Run("created webhook", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := kithttp.EncodeWebhookResponse(context.Background(), rec, transport.CreateWebhookResponse{WebhookResponse: transport.WebhookResponse{ID: 7}, Secret: "whsec_test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/api/v1/webhooks/7", rec.Header().Get("Location"))
+	})
+
+	t.Run("deleted webhook", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := kithttp.EncodeWebhookResponse(context.Background(), rec, transport.DeleteWebhookResponse{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("redelivery", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		err := kithttp.EncodeWebhookResponse(context.Background(), rec, transport.RedeliverWebhookResponse{WebhookDelivery: transport.WebhookDelivery{ID: 2}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+}
@@ -0,0 +1,141 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=lookup.go -destination=mock/lookup.go -package=mock
+type (
+	lookupHandler interface {
+		LookupMedia(ctx context.Context, id int) (business.MediaResult, error)
+		ListSeasonEpisodes(ctx context.Context, collectionID int) (business.TVSeason, error)
+	}
+	historyHandler interface {
+		ListSearchHistory(ctx context.Context, filter business.HistoryFilter) ([]business.MediaResult, error)
+	}
+)
+
+type (
+	// LookupMediaRequest represents the received request to look a media up by its iTunes id.
+	LookupMediaRequest struct {
+		ID int
+	}
+
+	// LookupMediaResponse represents the looked up media, collections are returned along with their tracks.
+	LookupMediaResponse struct {
+		ResultCount int     `json:"result_count"`
+		Media       []Media `json:"media"`
+		// FetchedAt is when the result was fetched from upstream.
+		FetchedAt time.Time `json:"-"`
+	}
+
+	// ListSeasonEpisodesRequest represents the received request to list the episodes of a tv season by its iTunes
+	// collection id.
+	ListSeasonEpisodesRequest struct {
+		CollectionID int
+	}
+
+	// ListSeasonEpisodesResponse represents a tv season along with its episodes, ordered by their number.
+	ListSeasonEpisodesResponse struct {
+		Season   Media   `json:"season"`
+		Episodes []Media `json:"episodes"`
+		// FetchedAt is when the season was fetched from upstream.
+		FetchedAt time.Time `json:"-"`
+	}
+
+	// ListSearchHistoryRequest represents the received request to list the stored searches.
+	ListSearchHistoryRequest struct {
+		Term   string
+		Since  time.Time
+		Until  time.Time
+		Limit  int
+		Offset int
+	}
+
+	// HistoryEntry represents a stored search.
+	HistoryEntry struct {
+		ID          int64     `json:"id"`
+		SearchTerm  string    `json:"search_term"`
+		ResultCount int       `json:"result_count"`
+		Media       []Media   `json:"media"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	// ListSearchHistoryResponse represents the listed stored searches.
+	ListSearchHistoryResponse struct {
+		Searches []HistoryEntry `json:"searches"`
+	}
+)
+
+// MakeLookupMediaEndpoint function to make lookup media endpoint call.
+func MakeLookupMediaEndpoint(handler lookupHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(LookupMediaRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse lookup media request")
+		}
+		res, err := handler.LookupMedia(ctx, body.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lookup media: %w", err)
+		}
+		return LookupMediaResponse{
+			ResultCount: res.ResultCount,
+			Media:       lo.Map(res.Media, mapMedia),
+			FetchedAt:   res.FetchedAt,
+		}, nil
+	}
+}
+
+// MakeListSeasonEpisodesEndpoint function to make list season episodes endpoint call.
+func MakeListSeasonEpisodesEndpoint(handler lookupHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(ListSeasonEpisodesRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse list season episodes request")
+		}
+		season, err := handler.ListSeasonEpisodes(ctx, body.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list season episodes: %w", err)
+		}
+		return ListSeasonEpisodesResponse{
+			Season:    mapMedia(season.Season, 0),
+			Episodes:  lo.Map(season.Episodes, mapMedia),
+			FetchedAt: season.FetchedAt,
+		}, nil
+	}
+}
+
+// MakeListSearchHistoryEndpoint function to make list search history endpoint call.
+func MakeListSearchHistoryEndpoint(handler historyHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(ListSearchHistoryRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse list search history request")
+		}
+		results, err := handler.ListSearchHistory(ctx, business.HistoryFilter{
+			Term:   body.Term,
+			Since:  body.Since,
+			Until:  body.Until,
+			Limit:  body.Limit,
+			Offset: body.Offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list search history: %w", err)
+		}
+		return ListSearchHistoryResponse{Searches: lo.Map(results, func(r business.MediaResult, _ int) HistoryEntry {
+			return HistoryEntry{
+				ID:          r.ID,
+				SearchTerm:  r.SearchTerm,
+				ResultCount: r.ResultCount,
+				Media:       lo.Map(r.Media, mapMedia),
+				CreatedAt:   r.FetchedAt,
+			}
+		})}, nil
+	}
+}
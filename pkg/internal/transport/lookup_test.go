@@ -0,0 +1,208 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeLookupMediaEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMocklookupHandler(ctrl)
+	endpoint := transport.MakeLookupMediaEndpoint(mockHandler)
+	fetchedAt := time.Now()
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "successful lookup",
+			request: transport.LookupMediaRequest{ID: 456},
+			mockSetup: func() {
+				mockHandler.EXPECT().LookupMedia(gomock.Any(), 456).Return(business.MediaResult{
+					ResultCount: 1,
+					Media:       []business.Media{{WrapperType: "track", Kind: "song", TrackID: 456}},
+					FetchedAt:   fetchedAt,
+				}, nil)
+			},
+			expectedResponse: transport.LookupMediaResponse{
+				ResultCount: 1,
+				Media:       []transport.Media{{WrapperType: "track", Kind: "song", TrackID: 456}},
+				FetchedAt:   fetchedAt,
+			},
+		},
+		{
+			name:    "not found",
+			request: transport.LookupMediaRequest{ID: 1},
+			mockSetup: func() {
+				mockHandler.EXPECT().LookupMedia(gomock.Any(), 1).Return(business.MediaResult{}, business.ErrNotFound)
+			},
+			expectedError: "failed to lookup media: not found",
+		},
+		{
+			name:          "invalid request",
+			request:       "invalid",
+			mockSetup:     func() {},
+			expectedError: "failed to parse lookup media request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+		})
+	}
+}
+
+func TestMakeListSeasonEpisodesEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMocklookupHandler(ctrl)
+	endpoint := transport.MakeListSeasonEpisodesEndpoint(mockHandler)
+	fetchedAt := time.Now()
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "successful list",
+			request: transport.ListSeasonEpisodesRequest{CollectionID: 20},
+			mockSetup: func() {
+				mockHandler.EXPECT().ListSeasonEpisodes(gomock.Any(), 20).Return(business.TVSeason{
+					Season:    business.Media{WrapperType: "collection", CollectionID: 20, Video: &business.Video{SeasonNumber: 2, CollectionHDPrice: 24.99}},
+					Episodes:  []business.Media{{WrapperType: "track", Kind: "tv-episode", TrackID: 30, Video: &business.Video{SeasonNumber: 2, EpisodeNumber: 1}}},
+					FetchedAt: fetchedAt,
+				}, nil)
+			},
+			expectedResponse: transport.ListSeasonEpisodesResponse{
+				Season:    transport.Media{WrapperType: "collection", CollectionID: 20, Video: &transport.Video{SeasonNumber: 2, CollectionHDPrice: 24.99}},
+				Episodes:  []transport.Media{{WrapperType: "track", Kind: "tv-episode", TrackID: 30, Video: &transport.Video{SeasonNumber: 2, EpisodeNumber: 1}}},
+				FetchedAt: fetchedAt,
+			},
+		},
+		{
+			name:    "not found",
+			request: transport.ListSeasonEpisodesRequest{CollectionID: 1},
+			mockSetup: func() {
+				mockHandler.EXPECT().ListSeasonEpisodes(gomock.Any(), 1).Return(business.TVSeason{}, business.ErrNotFound)
+			},
+			expectedError: "failed to list season episodes: not found",
+		},
+		{
+			name:          "invalid request",
+			request:       "invalid",
+			mockSetup:     func() {},
+			expectedError: "failed to parse list season episodes request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+		})
+	}
+}
+
+func TestMakeListSearchHistoryEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockhistoryHandler(ctrl)
+	endpoint := transport.MakeListSearchHistoryEndpoint(mockHandler)
+	createdAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "successful list",
+			request: transport.ListSearchHistoryRequest{Term: "jack", Since: createdAt, Limit: 10, Offset: 10},
+			mockSetup: func() {
+				mockHandler.EXPECT().ListSearchHistory(gomock.Any(), business.HistoryFilter{Term: "jack", Since: createdAt, Limit: 10, Offset: 10}).
+					Return([]business.MediaResult{{
+						ID:          1,
+						SearchTerm:  "jack",
+						ResultCount: 1,
+						Media:       []business.Media{{Kind: "song", TrackID: 456}},
+						FetchedAt:   createdAt,
+					}}, nil)
+			},
+			expectedResponse: transport.ListSearchHistoryResponse{Searches: []transport.HistoryEntry{{
+				ID:          1,
+				SearchTerm:  "jack",
+				ResultCount: 1,
+				Media:       []transport.Media{{Kind: "song", TrackID: 456}},
+				CreatedAt:   createdAt,
+			}}},
+		},
+		{
+			name:    "handler error",
+			request: transport.ListSearchHistoryRequest{},
+			mockSetup: func() {
+				mockHandler.EXPECT().ListSearchHistory(gomock.Any(), business.HistoryFilter{}).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to list search history: db down",
+		},
+		{
+			name:          "invalid request",
+			request:       "invalid",
+			mockSetup:     func() {},
+			expectedError: "failed to parse list search history request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	jwt "github.com/NawafSwe/media-scout-service/pkg/jwt"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockapiKeyHandler is a mock of apiKeyHandler interface.
+type MockapiKeyHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyHandlerMockRecorder
+}
+
+// MockapiKeyHandlerMockRecorder is the mock recorder for MockapiKeyHandler.
+type MockapiKeyHandlerMockRecorder struct {
+	mock *MockapiKeyHandler
+}
+
+// NewMockapiKeyHandler creates a new mock instance.
+func NewMockapiKeyHandler(ctrl *gomock.Controller) *MockapiKeyHandler {
+	mock := &MockapiKeyHandler{ctrl: ctrl}
+	mock.recorder = &MockapiKeyHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyHandler) EXPECT() *MockapiKeyHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockapiKeyHandler) CreateAPIKey(ctx context.Context, name string, minuteQuota, dailyQuota int) (business.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, name, minuteQuota, dailyQuota)
+	ret0, _ := ret[0].(business.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockapiKeyHandlerMockRecorder) CreateAPIKey(ctx, name, minuteQuota, dailyQuota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockapiKeyHandler)(nil).CreateAPIKey), ctx, name, minuteQuota, dailyQuota)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockapiKeyHandler) ListAPIKeys(ctx context.Context) ([]business.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]business.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockapiKeyHandlerMockRecorder) ListAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockapiKeyHandler)(nil).ListAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockapiKeyHandler) RevokeAPIKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockapiKeyHandlerMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockapiKeyHandler)(nil).RevokeAPIKey), ctx, id)
+}
+
+// RotateAPIKey mocks base method.
+func (m *MockapiKeyHandler) RotateAPIKey(ctx context.Context, id int64) (business.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAPIKey", ctx, id)
+	ret0, _ := ret[0].(business.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RotateAPIKey indicates an expected call of RotateAPIKey.
+func (mr *MockapiKeyHandlerMockRecorder) RotateAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAPIKey", reflect.TypeOf((*MockapiKeyHandler)(nil).RotateAPIKey), ctx, id)
+}
+
+// Mockauthenticator is a mock of authenticator interface.
+type Mockauthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockauthenticatorMockRecorder
+}
+
+// MockauthenticatorMockRecorder is the mock recorder for Mockauthenticator.
+type MockauthenticatorMockRecorder struct {
+	mock *Mockauthenticator
+}
+
+// NewMockauthenticator creates a new mock instance.
+func NewMockauthenticator(ctrl *gomock.Controller) *Mockauthenticator {
+	mock := &Mockauthenticator{ctrl: ctrl}
+	mock.recorder = &MockauthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockauthenticator) EXPECT() *MockauthenticatorMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *Mockauthenticator) Authenticate(ctx context.Context, raw string) (business.APIKey, business.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, raw)
+	ret0, _ := ret[0].(business.APIKey)
+	ret1, _ := ret[1].(business.Quota)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockauthenticatorMockRecorder) Authenticate(ctx, raw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*Mockauthenticator)(nil).Authenticate), ctx, raw)
+}
+
+// MocktokenVerifier is a mock of tokenVerifier interface.
+type MocktokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenVerifierMockRecorder
+}
+
+// MocktokenVerifierMockRecorder is the mock recorder for MocktokenVerifier.
+type MocktokenVerifierMockRecorder struct {
+	mock *MocktokenVerifier
+}
+
+// NewMocktokenVerifier creates a new mock instance.
+func NewMocktokenVerifier(ctrl *gomock.Controller) *MocktokenVerifier {
+	mock := &MocktokenVerifier{ctrl: ctrl}
+	mock.recorder = &MocktokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenVerifier) EXPECT() *MocktokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MocktokenVerifier) Verify(ctx context.Context, raw string) (jwt.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, raw)
+	ret0, _ := ret[0].(jwt.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MocktokenVerifierMockRecorder) Verify(ctx, raw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MocktokenVerifier)(nil).Verify), ctx, raw)
+}
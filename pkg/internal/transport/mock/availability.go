@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: availability.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockavailabilityHandler is a mock of availabilityHandler interface.
+type MockavailabilityHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockavailabilityHandlerMockRecorder
+}
+
+// MockavailabilityHandlerMockRecorder is the mock recorder for MockavailabilityHandler.
+type MockavailabilityHandlerMockRecorder struct {
+	mock *MockavailabilityHandler
+}
+
+// NewMockavailabilityHandler creates a new mock instance.
+func NewMockavailabilityHandler(ctrl *gomock.Controller) *MockavailabilityHandler {
+	mock := &MockavailabilityHandler{ctrl: ctrl}
+	mock.recorder = &MockavailabilityHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockavailabilityHandler) EXPECT() *MockavailabilityHandlerMockRecorder {
+	return m.recorder
+}
+
+// CheckAvailability mocks base method.
+func (m *MockavailabilityHandler) CheckAvailability(ctx context.Context, id int, countries []string) (business.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAvailability", ctx, id, countries)
+	ret0, _ := ret[0].(business.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAvailability indicates an expected call of CheckAvailability.
+func (mr *MockavailabilityHandlerMockRecorder) CheckAvailability(ctx, id, countries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAvailability", reflect.TypeOf((*MockavailabilityHandler)(nil).CheckAvailability), ctx, id, countries)
+}
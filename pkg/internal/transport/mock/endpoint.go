@@ -35,17 +35,47 @@ func (m *Mockhandler) EXPECT() *MockhandlerMockRecorder {
 	return m.recorder
 }
 
-// FetchAndInsertMedia mocks base method.
-func (m *Mockhandler) FetchAndInsertMedia(ctx context.Context, term string, limit int) (business.MediaResult, error) {
+// FetchAndInsertFederatedMedia mocks base method.
+func (m *Mockhandler) FetchAndInsertFederatedMedia(ctx context.Context, providers []string, term string, limit int) (business.FederatedMediaResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchAndInsertMedia", ctx, term, limit)
+	ret := m.ctrl.Call(m, "FetchAndInsertFederatedMedia", ctx, providers, term, limit)
+	ret0, _ := ret[0].(business.FederatedMediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAndInsertFederatedMedia indicates an expected call of FetchAndInsertFederatedMedia.
+func (mr *MockhandlerMockRecorder) FetchAndInsertFederatedMedia(ctx, providers, term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndInsertFederatedMedia", reflect.TypeOf((*Mockhandler)(nil).FetchAndInsertFederatedMedia), ctx, providers, term, limit)
+}
+
+// FetchAndInsertFilteredMedia mocks base method.
+func (m *Mockhandler) FetchAndInsertFilteredMedia(ctx context.Context, provider, term string, limit int, filter business.SearchFilter) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndInsertFilteredMedia", ctx, provider, term, limit, filter)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAndInsertFilteredMedia indicates an expected call of FetchAndInsertFilteredMedia.
+func (mr *MockhandlerMockRecorder) FetchAndInsertFilteredMedia(ctx, provider, term, limit, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndInsertFilteredMedia", reflect.TypeOf((*Mockhandler)(nil).FetchAndInsertFilteredMedia), ctx, provider, term, limit, filter)
+}
+
+// FetchAndInsertMediaFrom mocks base method.
+func (m *Mockhandler) FetchAndInsertMediaFrom(ctx context.Context, provider, term string, limit int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndInsertMediaFrom", ctx, provider, term, limit)
 	ret0, _ := ret[0].(business.MediaResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchAndInsertMedia indicates an expected call of FetchAndInsertMedia.
-func (mr *MockhandlerMockRecorder) FetchAndInsertMedia(ctx, term, limit interface{}) *gomock.Call {
+// FetchAndInsertMediaFrom indicates an expected call of FetchAndInsertMediaFrom.
+func (mr *MockhandlerMockRecorder) FetchAndInsertMediaFrom(ctx, provider, term, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndInsertMedia", reflect.TypeOf((*Mockhandler)(nil).FetchAndInsertMedia), ctx, term, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndInsertMediaFrom", reflect.TypeOf((*Mockhandler)(nil).FetchAndInsertMediaFrom), ctx, provider, term, limit)
 }
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lookup.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocklookupHandler is a mock of lookupHandler interface.
+type MocklookupHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MocklookupHandlerMockRecorder
+}
+
+// MocklookupHandlerMockRecorder is the mock recorder for MocklookupHandler.
+type MocklookupHandlerMockRecorder struct {
+	mock *MocklookupHandler
+}
+
+// NewMocklookupHandler creates a new mock instance.
+func NewMocklookupHandler(ctrl *gomock.Controller) *MocklookupHandler {
+	mock := &MocklookupHandler{ctrl: ctrl}
+	mock.recorder = &MocklookupHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklookupHandler) EXPECT() *MocklookupHandlerMockRecorder {
+	return m.recorder
+}
+
+// ListSeasonEpisodes mocks base method.
+func (m *MocklookupHandler) ListSeasonEpisodes(ctx context.Context, collectionID int) (business.TVSeason, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeasonEpisodes", ctx, collectionID)
+	ret0, _ := ret[0].(business.TVSeason)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeasonEpisodes indicates an expected call of ListSeasonEpisodes.
+func (mr *MocklookupHandlerMockRecorder) ListSeasonEpisodes(ctx, collectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeasonEpisodes", reflect.TypeOf((*MocklookupHandler)(nil).ListSeasonEpisodes), ctx, collectionID)
+}
+
+// LookupMedia mocks base method.
+func (m *MocklookupHandler) LookupMedia(ctx context.Context, id int) (business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupMedia", ctx, id)
+	ret0, _ := ret[0].(business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupMedia indicates an expected call of LookupMedia.
+func (mr *MocklookupHandlerMockRecorder) LookupMedia(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupMedia", reflect.TypeOf((*MocklookupHandler)(nil).LookupMedia), ctx, id)
+}
+
+// MockhistoryHandler is a mock of historyHandler interface.
+type MockhistoryHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockhistoryHandlerMockRecorder
+}
+
+// MockhistoryHandlerMockRecorder is the mock recorder for MockhistoryHandler.
+type MockhistoryHandlerMockRecorder struct {
+	mock *MockhistoryHandler
+}
+
+// NewMockhistoryHandler creates a new mock instance.
+func NewMockhistoryHandler(ctrl *gomock.Controller) *MockhistoryHandler {
+	mock := &MockhistoryHandler{ctrl: ctrl}
+	mock.recorder = &MockhistoryHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhistoryHandler) EXPECT() *MockhistoryHandlerMockRecorder {
+	return m.recorder
+}
+
+// ListSearchHistory mocks base method.
+func (m *MockhistoryHandler) ListSearchHistory(ctx context.Context, filter business.HistoryFilter) ([]business.MediaResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSearchHistory", ctx, filter)
+	ret0, _ := ret[0].([]business.MediaResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSearchHistory indicates an expected call of ListSearchHistory.
+func (mr *MockhistoryHandlerMockRecorder) ListSearchHistory(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearchHistory", reflect.TypeOf((*MockhistoryHandler)(nil).ListSearchHistory), ctx, filter)
+}
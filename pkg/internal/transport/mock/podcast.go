@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: podcast.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpodcastHandler is a mock of podcastHandler interface.
+type MockpodcastHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockpodcastHandlerMockRecorder
+}
+
+// MockpodcastHandlerMockRecorder is the mock recorder for MockpodcastHandler.
+type MockpodcastHandlerMockRecorder struct {
+	mock *MockpodcastHandler
+}
+
+// NewMockpodcastHandler creates a new mock instance.
+func NewMockpodcastHandler(ctrl *gomock.Controller) *MockpodcastHandler {
+	mock := &MockpodcastHandler{ctrl: ctrl}
+	mock.recorder = &MockpodcastHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpodcastHandler) EXPECT() *MockpodcastHandlerMockRecorder {
+	return m.recorder
+}
+
+// ListPodcastEpisodes mocks base method.
+func (m *MockpodcastHandler) ListPodcastEpisodes(ctx context.Context, collectionID, limit, offset int) (business.PodcastEpisodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPodcastEpisodes", ctx, collectionID, limit, offset)
+	ret0, _ := ret[0].(business.PodcastEpisodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPodcastEpisodes indicates an expected call of ListPodcastEpisodes.
+func (mr *MockpodcastHandlerMockRecorder) ListPodcastEpisodes(ctx, collectionID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPodcastEpisodes", reflect.TypeOf((*MockpodcastHandler)(nil).ListPodcastEpisodes), ctx, collectionID, limit, offset)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_batch.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockbatchHandler is a mock of batchHandler interface.
+type MockbatchHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockbatchHandlerMockRecorder
+}
+
+// MockbatchHandlerMockRecorder is the mock recorder for MockbatchHandler.
+type MockbatchHandlerMockRecorder struct {
+	mock *MockbatchHandler
+}
+
+// NewMockbatchHandler creates a new mock instance.
+func NewMockbatchHandler(ctrl *gomock.Controller) *MockbatchHandler {
+	mock := &MockbatchHandler{ctrl: ctrl}
+	mock.recorder = &MockbatchHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbatchHandler) EXPECT() *MockbatchHandlerMockRecorder {
+	return m.recorder
+}
+
+// SearchBatch mocks base method.
+func (m *MockbatchHandler) SearchBatch(ctx context.Context, queries []business.SearchQuery) []business.SearchOutcome {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBatch", ctx, queries)
+	ret0, _ := ret[0].([]business.SearchOutcome)
+	return ret0
+}
+
+// SearchBatch indicates an expected call of SearchBatch.
+func (mr *MockbatchHandlerMockRecorder) SearchBatch(ctx, queries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBatch", reflect.TypeOf((*MockbatchHandler)(nil).SearchBatch), ctx, queries)
+}
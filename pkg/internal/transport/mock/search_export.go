@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_export.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockexportHandler is a mock of exportHandler interface.
+type MockexportHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockexportHandlerMockRecorder
+}
+
+// MockexportHandlerMockRecorder is the mock recorder for MockexportHandler.
+type MockexportHandlerMockRecorder struct {
+	mock *MockexportHandler
+}
+
+// NewMockexportHandler creates a new mock instance.
+func NewMockexportHandler(ctrl *gomock.Controller) *MockexportHandler {
+	mock := &MockexportHandler{ctrl: ctrl}
+	mock.recorder = &MockexportHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexportHandler) EXPECT() *MockexportHandlerMockRecorder {
+	return m.recorder
+}
+
+// ExportSearches mocks base method.
+func (m *MockexportHandler) ExportSearches(ctx context.Context, filter business.HistoryFilter, fn func(business.ExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSearches", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSearches indicates an expected call of ExportSearches.
+func (mr *MockexportHandlerMockRecorder) ExportSearches(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSearches", reflect.TypeOf((*MockexportHandler)(nil).ExportSearches), ctx, filter, fn)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_job.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocksearchJobHandler is a mock of searchJobHandler interface.
+type MocksearchJobHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MocksearchJobHandlerMockRecorder
+}
+
+// MocksearchJobHandlerMockRecorder is the mock recorder for MocksearchJobHandler.
+type MocksearchJobHandlerMockRecorder struct {
+	mock *MocksearchJobHandler
+}
+
+// NewMocksearchJobHandler creates a new mock instance.
+func NewMocksearchJobHandler(ctrl *gomock.Controller) *MocksearchJobHandler {
+	mock := &MocksearchJobHandler{ctrl: ctrl}
+	mock.recorder = &MocksearchJobHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksearchJobHandler) EXPECT() *MocksearchJobHandlerMockRecorder {
+	return m.recorder
+}
+
+// CancelSearchJob mocks base method.
+func (m *MocksearchJobHandler) CancelSearchJob(ctx context.Context, owner string, id int64) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSearchJob", ctx, owner, id)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSearchJob indicates an expected call of CancelSearchJob.
+func (mr *MocksearchJobHandlerMockRecorder) CancelSearchJob(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSearchJob", reflect.TypeOf((*MocksearchJobHandler)(nil).CancelSearchJob), ctx, owner, id)
+}
+
+// EnqueueSearchJob mocks base method.
+func (m *MocksearchJobHandler) EnqueueSearchJob(ctx context.Context, owner string, queries []business.SearchQuery) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueSearchJob", ctx, owner, queries)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueSearchJob indicates an expected call of EnqueueSearchJob.
+func (mr *MocksearchJobHandlerMockRecorder) EnqueueSearchJob(ctx, owner, queries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueSearchJob", reflect.TypeOf((*MocksearchJobHandler)(nil).EnqueueSearchJob), ctx, owner, queries)
+}
+
+// GetSearchJob mocks base method.
+func (m *MocksearchJobHandler) GetSearchJob(ctx context.Context, owner string, id int64) (business.SearchJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchJob", ctx, owner, id)
+	ret0, _ := ret[0].(business.SearchJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchJob indicates an expected call of GetSearchJob.
+func (mr *MocksearchJobHandlerMockRecorder) GetSearchJob(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchJob", reflect.TypeOf((*MocksearchJobHandler)(nil).GetSearchJob), ctx, owner, id)
+}
+
+// PollSearchJobEvents mocks base method.
+func (m *MocksearchJobHandler) PollSearchJobEvents(ctx context.Context, owner string, id, afterEventID int64) (business.SearchJobProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PollSearchJobEvents", ctx, owner, id, afterEventID)
+	ret0, _ := ret[0].(business.SearchJobProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PollSearchJobEvents indicates an expected call of PollSearchJobEvents.
+func (mr *MocksearchJobHandlerMockRecorder) PollSearchJobEvents(ctx, owner, id, afterEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollSearchJobEvents", reflect.TypeOf((*MocksearchJobHandler)(nil).PollSearchJobEvents), ctx, owner, id, afterEventID)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watchlist.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwatchlistHandler is a mock of watchlistHandler interface.
+type MockwatchlistHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockwatchlistHandlerMockRecorder
+}
+
+// MockwatchlistHandlerMockRecorder is the mock recorder for MockwatchlistHandler.
+type MockwatchlistHandlerMockRecorder struct {
+	mock *MockwatchlistHandler
+}
+
+// NewMockwatchlistHandler creates a new mock instance.
+func NewMockwatchlistHandler(ctrl *gomock.Controller) *MockwatchlistHandler {
+	mock := &MockwatchlistHandler{ctrl: ctrl}
+	mock.recorder = &MockwatchlistHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwatchlistHandler) EXPECT() *MockwatchlistHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateWatchlist mocks base method.
+func (m *MockwatchlistHandler) CreateWatchlist(ctx context.Context, owner, name string, artistIDs []int) (business.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWatchlist", ctx, owner, name, artistIDs)
+	ret0, _ := ret[0].(business.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWatchlist indicates an expected call of CreateWatchlist.
+func (mr *MockwatchlistHandlerMockRecorder) CreateWatchlist(ctx, owner, name, artistIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWatchlist", reflect.TypeOf((*MockwatchlistHandler)(nil).CreateWatchlist), ctx, owner, name, artistIDs)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockwatchlistHandler) GetWatchlist(ctx context.Context, owner string, id int64) (business.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, owner, id)
+	ret0, _ := ret[0].(business.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockwatchlistHandlerMockRecorder) GetWatchlist(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockwatchlistHandler)(nil).GetWatchlist), ctx, owner, id)
+}
+
+// ListWatchlistReleases mocks base method.
+func (m *MockwatchlistHandler) ListWatchlistReleases(ctx context.Context, owner string, id int64, limit, offset int) ([]business.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWatchlistReleases", ctx, owner, id, limit, offset)
+	ret0, _ := ret[0].([]business.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWatchlistReleases indicates an expected call of ListWatchlistReleases.
+func (mr *MockwatchlistHandlerMockRecorder) ListWatchlistReleases(ctx, owner, id, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWatchlistReleases", reflect.TypeOf((*MockwatchlistHandler)(nil).ListWatchlistReleases), ctx, owner, id, limit, offset)
+}
+
+// SubscribeArtist mocks base method.
+func (m *MockwatchlistHandler) SubscribeArtist(ctx context.Context, owner string, id int64, artistID int) (business.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeArtist", ctx, owner, id, artistID)
+	ret0, _ := ret[0].(business.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeArtist indicates an expected call of SubscribeArtist.
+func (mr *MockwatchlistHandlerMockRecorder) SubscribeArtist(ctx, owner, id, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeArtist", reflect.TypeOf((*MockwatchlistHandler)(nil).SubscribeArtist), ctx, owner, id, artistID)
+}
+
+// UnsubscribeArtist mocks base method.
+func (m *MockwatchlistHandler) UnsubscribeArtist(ctx context.Context, owner string, id int64, artistID int) (business.Watchlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeArtist", ctx, owner, id, artistID)
+	ret0, _ := ret[0].(business.Watchlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsubscribeArtist indicates an expected call of UnsubscribeArtist.
+func (mr *MockwatchlistHandlerMockRecorder) UnsubscribeArtist(ctx, owner, id, artistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeArtist", reflect.TypeOf((*MockwatchlistHandler)(nil).UnsubscribeArtist), ctx, owner, id, artistID)
+}
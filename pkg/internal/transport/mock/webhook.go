@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	business "github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwebhookHandler is a mock of webhookHandler interface.
+type MockwebhookHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookHandlerMockRecorder
+}
+
+// MockwebhookHandlerMockRecorder is the mock recorder for MockwebhookHandler.
+type MockwebhookHandlerMockRecorder struct {
+	mock *MockwebhookHandler
+}
+
+// NewMockwebhookHandler creates a new mock instance.
+func NewMockwebhookHandler(ctrl *gomock.Controller) *MockwebhookHandler {
+	mock := &MockwebhookHandler{ctrl: ctrl}
+	mock.recorder = &MockwebhookHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookHandler) EXPECT() *MockwebhookHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockwebhookHandler) CreateWebhook(ctx context.Context, owner, url string, events []business.EventType) (business.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, owner, url, events)
+	ret0, _ := ret[0].(business.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockwebhookHandlerMockRecorder) CreateWebhook(ctx, owner, url, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockwebhookHandler)(nil).CreateWebhook), ctx, owner, url, events)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockwebhookHandler) DeleteWebhook(ctx context.Context, owner string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, owner, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockwebhookHandlerMockRecorder) DeleteWebhook(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockwebhookHandler)(nil).DeleteWebhook), ctx, owner, id)
+}
+
+// GetWebhook mocks base method.
+func (m *MockwebhookHandler) GetWebhook(ctx context.Context, owner string, id int64) (business.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, owner, id)
+	ret0, _ := ret[0].(business.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockwebhookHandlerMockRecorder) GetWebhook(ctx, owner, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockwebhookHandler)(nil).GetWebhook), ctx, owner, id)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockwebhookHandler) ListWebhookDeliveries(ctx context.Context, owner string, id int64, status business.WebhookDeliveryStatus, limit, offset int) ([]business.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, owner, id, status, limit, offset)
+	ret0, _ := ret[0].([]business.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockwebhookHandlerMockRecorder) ListWebhookDeliveries(ctx, owner, id, status, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockwebhookHandler)(nil).ListWebhookDeliveries), ctx, owner, id, status, limit, offset)
+}
+
+// RedeliverWebhook mocks base method.
+func (m *MockwebhookHandler) RedeliverWebhook(ctx context.Context, owner string, id, deliveryID int64) (business.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhook", ctx, owner, id, deliveryID)
+	ret0, _ := ret[0].(business.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhook indicates an expected call of RedeliverWebhook.
+func (mr *MockwebhookHandlerMockRecorder) RedeliverWebhook(ctx, owner, id, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhook", reflect.TypeOf((*MockwebhookHandler)(nil).RedeliverWebhook), ctx, owner, id, deliveryID)
+}
@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=podcast.go -destination=mock/podcast.go -package=mock
+type podcastHandler interface {
+	ListPodcastEpisodes(ctx context.Context, collectionID int, limit, offset int) (business.PodcastEpisodes, error)
+}
+
+type (
+	// ListPodcastEpisodesRequest represents the received request to list the episodes of a podcast.
+	ListPodcastEpisodesRequest struct {
+		CollectionID int
+		Limit        int
+		Offset       int
+	}
+
+	// Podcast represents a podcast whose feed is refreshed by the service.
+	Podcast struct {
+		CollectionID int        `json:"collection_id"`
+		Title        string     `json:"title,omitempty"`
+		FeedURL      string     `json:"feed_url"`
+		RefreshedAt  *time.Time `json:"refreshed_at,omitempty"`
+	}
+
+	// Episode represents an episode of a podcast.
+	Episode struct {
+		ID              int64      `json:"id"`
+		GUID            string     `json:"guid"`
+		Title           string     `json:"title,omitempty"`
+		Description     string     `json:"description,omitempty"`
+		Link            string     `json:"link,omitempty"`
+		AudioURL        string     `json:"audio_url,omitempty"`
+		AudioType       string     `json:"audio_type,omitempty"`
+		PublishedAt     *time.Time `json:"published_at,omitempty"`
+		DurationSeconds int        `json:"duration_seconds,omitempty"`
+	}
+
+	// ListPodcastEpisodesResponse represents the listed episodes of a podcast, most recent first.
+	ListPodcastEpisodesResponse struct {
+		Podcast  Podcast   `json:"podcast"`
+		Episodes []Episode `json:"episodes"`
+	}
+)
+
+// MakeListPodcastEpisodesEndpoint function to make list podcast episodes endpoint call.
+func MakeListPodcastEpisodesEndpoint(handler podcastHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(ListPodcastEpisodesRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse list podcast episodes request")
+		}
+		res, err := handler.ListPodcastEpisodes(ctx, body.CollectionID, body.Limit, body.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list podcast episodes: %w", err)
+		}
+		return ListPodcastEpisodesResponse{
+			Podcast: Podcast{
+				CollectionID: res.Podcast.CollectionID,
+				Title:        res.Podcast.Title,
+				FeedURL:      res.Podcast.FeedURL,
+				RefreshedAt:  res.Podcast.RefreshedAt,
+			},
+			Episodes: lo.Map(res.Episodes, func(e business.Episode, _ int) Episode {
+				return Episode{
+					ID:              e.ID,
+					GUID:            e.GUID,
+					Title:           e.Title,
+					Description:     e.Description,
+					Link:            e.Link,
+					AudioURL:        e.AudioURL,
+					AudioType:       e.AudioType,
+					PublishedAt:     e.PublishedAt,
+					DurationSeconds: e.DurationSeconds,
+				}
+			}),
+		}, nil
+	}
+}
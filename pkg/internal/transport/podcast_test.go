@@ -0,0 +1,45 @@
+package transport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeListPodcastEpisodesEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockpodcastHandler(ctrl)
+	endpoint := transport.MakeListPodcastEpisodesEndpoint(mockHandler)
+	now := time.Now()
+
+	t.Run("episodes are listed", func(t *testing.T) {
+		mockHandler.EXPECT().ListPodcastEpisodes(gomock.Any(), 5, 10, 20).Return(business.PodcastEpisodes{
+			Podcast:  business.Podcast{CollectionID: 5, Title: "The Daily", FeedURL: "https://example.com/feed", ETag: `"v1"`, RefreshedAt: &now},
+			Episodes: []business.Episode{{ID: 3, CollectionID: 5, GUID: "ep-3", Title: "Episode 3", PublishedAt: &now, DurationSeconds: 60}},
+		}, nil)
+
+		response, err := endpoint(context.Background(), transport.ListPodcastEpisodesRequest{CollectionID: 5, Limit: 10, Offset: 20})
+
+		assert.NoError(t, err)
+		assert.Equal(t, transport.ListPodcastEpisodesResponse{
+			Podcast:  transport.Podcast{CollectionID: 5, Title: "The Daily", FeedURL: "https://example.com/feed", RefreshedAt: &now},
+			Episodes: []transport.Episode{{ID: 3, GUID: "ep-3", Title: "Episode 3", PublishedAt: &now, DurationSeconds: 60}},
+		}, response)
+	})
+
+	t.Run("unknown podcast", func(t *testing.T) {
+		mockHandler.EXPECT().ListPodcastEpisodes(gomock.Any(), 6, 0, 0).Return(business.PodcastEpisodes{}, business.ErrNotFound)
+
+		_, err := endpoint(context.Background(), transport.ListPodcastEpisodesRequest{CollectionID: 6})
+
+		assert.ErrorIs(t, err, business.ErrNotFound)
+	})
+}
@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/go-kit/kit/endpoint"
+)
+
+//go:generate mockgen -source=rate_limit.go -destination=mock/rate_limit.go -package=mock
+type rateLimiter interface {
+	Allow(ctx context.Context, key string, rule ratelimit.Rule) (ratelimit.Result, error)
+}
+
+type clientIPKey struct{}
+
+// ContextWithClientIP returns a copy of ctx carrying the ip of the client.
+func ContextWithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the client ip put in ctx by the transport.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// MakeRateLimitMiddleware function to make a middleware rate limiting clients with the given rule under scope.
+// It must run after authentication: clients are keyed by their api key, then by their token subject and
+// only then by their ip, so clients sharing an ip are limited independently once authenticated.
+func MakeRateLimitMiddleware(limiter rateLimiter, scope string, rule ratelimit.Rule) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			if _, err := limiter.Allow(ctx, scope+":"+rateLimitKey(ctx), rule); err != nil {
+				return nil, fmt.Errorf("failed to allow request: %w", err)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// MakeIPRateLimitMiddleware function to make a middleware rate limiting clients by ip with the given rule under scope.
+// It runs ahead of authentication, so clients sending invalid credentials are limited too.
+func MakeIPRateLimitMiddleware(limiter rateLimiter, scope string, rule ratelimit.Rule) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			if _, err := limiter.Allow(ctx, scope+":ip:"+ClientIPFromContext(ctx), rule); err != nil {
+				return nil, fmt.Errorf("failed to allow request: %w", err)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// rateLimitKey returns the identity a request is rate limited by.
+func rateLimitKey(ctx context.Context) string {
+	if identity := authIdentity(ctx); identity != "" {
+		return identity
+	}
+	return "ip:" + ClientIPFromContext(ctx)
+}
@@ -0,0 +1,123 @@
+package transport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/NawafSwe/media-scout-service/pkg/jwt"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeRateLimitMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimiter := mock.NewMockrateLimiter(ctrl)
+	rule := ratelimit.Rule{Rate: 5, Burst: 10}
+	next := func(context.Context, any) (any, error) { return "ok", nil }
+	ep := transport.MakeRateLimitMiddleware(mockLimiter, "search.media", rule)(next)
+
+	tests := []struct {
+		name          string
+		info          *transport.AuthInfo
+		expectedKey   string
+		limitErr      error
+		expectedError error
+	}{
+		{
+			name:        "anonymous clients are keyed by ip",
+			expectedKey: "search.media:ip:10.0.0.1",
+		},
+		{
+			name:        "api key clients are keyed by key",
+			info:        &transport.AuthInfo{Key: business.APIKey{ID: 7}},
+			expectedKey: "search.media:key:7",
+		},
+		{
+			name:        "token clients are keyed by subject",
+			info:        &transport.AuthInfo{Identity: jwt.Identity{Subject: "user-1"}},
+			expectedKey: "search.media:sub:user-1",
+		},
+		{
+			name:          "limited client",
+			expectedKey:   "search.media:ip:10.0.0.1",
+			limitErr:      &ratelimit.LimitError{RetryAfter: time.Second},
+			expectedError: ratelimit.ErrLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLimiter.EXPECT().Allow(gomock.Any(), tt.expectedKey, rule).Return(ratelimit.Result{}, tt.limitErr)
+			ctx := transport.ContextWithClientIP(context.Background(), "10.0.0.1")
+			if tt.info != nil {
+				ctx = transport.ContextWithAuthInfo(ctx, tt.info)
+			}
+
+			response, err := ep(ctx, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ok", response)
+			}
+		})
+	}
+}
+
+func TestMakeIPRateLimitMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimiter := mock.NewMockrateLimiter(ctrl)
+	rule := ratelimit.Rule{Rate: 20, Burst: 100}
+	next := func(context.Context, any) (any, error) { return "ok", nil }
+	ep := transport.MakeIPRateLimitMiddleware(mockLimiter, "auth", rule)(next)
+
+	tests := []struct {
+		name          string
+		info          *transport.AuthInfo
+		limitErr      error
+		expectedError error
+	}{
+		{
+			name: "anonymous clients are keyed by ip",
+		},
+		{
+			name: "clients carrying credentials are keyed by ip",
+			info: &transport.AuthInfo{APIKey: "msk_guess"},
+		},
+		{
+			name:          "limited ip",
+			info:          &transport.AuthInfo{APIKey: "msk_guess"},
+			limitErr:      &ratelimit.LimitError{RetryAfter: time.Second},
+			expectedError: ratelimit.ErrLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLimiter.EXPECT().Allow(gomock.Any(), "auth:ip:10.0.0.1", rule).Return(ratelimit.Result{}, tt.limitErr)
+			ctx := transport.ContextWithClientIP(context.Background(), "10.0.0.1")
+			if tt.info != nil {
+				ctx = transport.ContextWithAuthInfo(ctx, tt.info)
+			}
+
+			response, err := ep(ctx, nil)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "ok", response)
+			}
+		})
+	}
+}
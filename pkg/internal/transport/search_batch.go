@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=search_batch.go -destination=mock/search_batch.go -package=mock
+type batchHandler interface {
+	SearchBatch(ctx context.Context, queries []business.SearchQuery) []business.SearchOutcome
+}
+
+type (
+	// SearchBatchRequest represents the received request to run many searches at once.
+	SearchBatchRequest struct {
+		Searches []SearchMediaRequest
+	}
+
+	// SearchBatchItem represents the outcome of a single search of a batch, either its result or the error it failed with.
+	SearchBatchItem struct {
+		Term   string               `json:"term"`
+		Limit  int                  `json:"limit"`
+		Result *SearchMediaResponse `json:"result,omitempty"`
+		Error  string               `json:"error,omitempty"`
+	}
+
+	// SearchBatchResponse represents the outcomes of the searches of a batch, in the order they were requested.
+	SearchBatchResponse struct {
+		Results   []SearchBatchItem `json:"results"`
+		Succeeded int               `json:"succeeded"`
+		Failed    int               `json:"failed"`
+	}
+)
+
+// MakeSearchBatchEndpoint function to make search batch endpoint call, failed searches are reported
+// along with the successful ones instead of failing the whole batch.
+func MakeSearchBatchEndpoint(handler batchHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(SearchBatchRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse search batch request")
+		}
+
+		queries := lo.Map(body.Searches, func(s SearchMediaRequest, _ int) business.SearchQuery {
+			return business.SearchQuery{Term: s.Term, Limit: s.Limit}
+		})
+		outcomes := handler.SearchBatch(ctx, queries)
+
+		res := SearchBatchResponse{Results: make([]SearchBatchItem, len(outcomes))}
+		for i, outcome := range outcomes {
+			item := SearchBatchItem{Term: queries[i].Term, Limit: queries[i].Limit}
+			if outcome.Err != nil {
+				item.Error = fmt.Errorf("failed to fetch and insert media: %w", outcome.Err).Error()
+				res.Failed++
+			} else {
+				item.Result = &SearchMediaResponse{
+					ID:          outcome.Result.ID,
+					SearchTerm:  outcome.Result.SearchTerm,
+					ResultCount: outcome.Result.ResultCount,
+					Media:       lo.Map(outcome.Result.Media, mapMedia),
+					FetchedAt:   outcome.Result.FetchedAt,
+				}
+				res.Succeeded++
+			}
+			res.Results[i] = item
+		}
+		return res, nil
+	}
+}
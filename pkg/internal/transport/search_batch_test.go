@@ -0,0 +1,89 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeSearchBatchEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockbatchHandler(ctrl)
+	endpoint := transport.MakeSearchBatchEndpoint(mockHandler)
+	fetchedAt := time.Now()
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name: "partial success",
+			request: transport.SearchBatchRequest{Searches: []transport.SearchMediaRequest{
+				{Term: "jack", Limit: 1},
+				{Term: "broken", Limit: 2},
+			}},
+			mockSetup: func() {
+				mockHandler.EXPECT().SearchBatch(gomock.Any(), []business.SearchQuery{
+					{Term: "jack", Limit: 1},
+					{Term: "broken", Limit: 2},
+				}).Return([]business.SearchOutcome{
+					{Result: business.MediaResult{
+						ID:          1,
+						SearchTerm:  "jack",
+						ResultCount: 1,
+						Media:       []business.Media{{WrapperType: "track", TrackID: 456}},
+						FetchedAt:   fetchedAt,
+					}},
+					{Err: errors.New("upstream down")},
+				})
+			},
+			expectedResponse: transport.SearchBatchResponse{
+				Results: []transport.SearchBatchItem{
+					{Term: "jack", Limit: 1, Result: &transport.SearchMediaResponse{
+						ID:          1,
+						SearchTerm:  "jack",
+						ResultCount: 1,
+						Media:       []transport.Media{{WrapperType: "track", TrackID: 456}},
+						FetchedAt:   fetchedAt,
+					}},
+					{Term: "broken", Limit: 2, Error: "failed to fetch and insert media: upstream down"},
+				},
+				Succeeded: 1,
+				Failed:    1,
+			},
+		},
+		{
+			name:          "invalid request",
+			request:       "invalid",
+			mockSetup:     func() {},
+			expectedError: "failed to parse search batch request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(context.Background(), tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/export"
+	"github.com/go-kit/kit/endpoint"
+)
+
+//go:generate mockgen -source=search_export.go -destination=mock/search_export.go -package=mock
+type exportHandler interface {
+	ExportSearches(ctx context.Context, filter business.HistoryFilter, fn func(business.ExportRow) error) error
+}
+
+type (
+	// ExportSearchesRequest represents the received request to export the stored searches, filtered like the history.
+	ExportSearchesRequest struct {
+		Format export.Format
+		ListSearchHistoryRequest
+	}
+
+	// ExportSearchesResponse represents an export to stream, Export writes its rows to w once the response started.
+	ExportSearchesResponse struct {
+		Format export.Format
+		Export func(ctx context.Context, w export.Writer) error
+	}
+)
+
+// MakeExportSearchesEndpoint function to make export searches endpoint call, the rows are only read
+// once the response is encoded so they can be streamed.
+func MakeExportSearchesEndpoint(handler exportHandler) endpoint.Endpoint {
+	return func(_ context.Context, request any) (any, error) {
+		body, ok := request.(ExportSearchesRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse export searches request")
+		}
+		filter := business.HistoryFilter{
+			Term:   body.Term,
+			Since:  body.Since,
+			Until:  body.Until,
+			Limit:  body.Limit,
+			Offset: body.Offset,
+		}
+		return ExportSearchesResponse{
+			Format: body.Format,
+			Export: func(ctx context.Context, w export.Writer) error {
+				if err := handler.ExportSearches(ctx, filter, w.Write); err != nil {
+					return fmt.Errorf("failed to export searches: %w", err)
+				}
+				return nil
+			},
+		}, nil
+	}
+}
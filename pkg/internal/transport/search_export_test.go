@@ -0,0 +1,54 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/export"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeExportSearchesEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockexportHandler(ctrl)
+	endpoint := transport.MakeExportSearchesEndpoint(mockHandler)
+
+	response, err := endpoint(context.Background(), transport.ExportSearchesRequest{
+		Format:                   export.FormatNDJSON,
+		ListSearchHistoryRequest: transport.ListSearchHistoryRequest{Term: "jack", Limit: 10},
+	})
+	require.NoError(t, err)
+	res, ok := response.(transport.ExportSearchesResponse)
+	require.True(t, ok)
+	assert.Equal(t, export.FormatNDJSON, res.Format)
+
+	// rows are only read once the export is written.
+	mockHandler.EXPECT().ExportSearches(gomock.Any(), business.HistoryFilter{Term: "jack", Limit: 10}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ business.HistoryFilter, fn func(business.ExportRow) error) error {
+			return fn(business.ExportRow{ResultID: 7})
+		})
+	var buf bytes.Buffer
+	w, err := export.NewWriter(res.Format, &buf)
+	require.NoError(t, err)
+
+	assert.NoError(t, res.Export(context.Background(), w))
+	assert.NoError(t, w.Close())
+	assert.Contains(t, buf.String(), `"result_id":7`)
+
+	mockHandler.EXPECT().ExportSearches(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+
+	assert.EqualError(t, res.Export(context.Background(), w), "failed to export searches: db down")
+
+	_, err = endpoint(context.Background(), "invalid")
+
+	assert.EqualError(t, err, "failed to parse export searches request")
+}
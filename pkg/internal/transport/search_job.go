@@ -0,0 +1,271 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=search_job.go -destination=mock/search_job.go -package=mock
+type searchJobHandler interface {
+	EnqueueSearchJob(ctx context.Context, owner string, queries []business.SearchQuery) (business.SearchJob, error)
+	GetSearchJob(ctx context.Context, owner string, id int64) (business.SearchJob, error)
+	CancelSearchJob(ctx context.Context, owner string, id int64) (business.SearchJob, error)
+	PollSearchJobEvents(ctx context.Context, owner string, id, afterEventID int64) (business.SearchJobProgress, error)
+}
+
+type (
+	// CreateSearchJobRequest represents the received request to run searches in the background.
+	CreateSearchJobRequest struct {
+		Searches []SearchMediaRequest
+	}
+
+	// SearchJobRequest represents a received request targeting a single search job.
+	SearchJobRequest struct {
+		ID int64
+	}
+
+	// SearchJobResponse represents a search job along with the outcome of its searches so far.
+	SearchJobResponse struct {
+		ID          int64             `json:"id"`
+		Status      string            `json:"status"`
+		Attempts    int               `json:"attempts"`
+		MaxAttempts int               `json:"max_attempts"`
+		LastError   string            `json:"last_error,omitempty"`
+		Results     []SearchBatchItem `json:"results"`
+		Succeeded   int               `json:"succeeded"`
+		Failed      int               `json:"failed"`
+		// NextRunAt is when a queued job is due.
+		NextRunAt  *time.Time `json:"next_run_at,omitempty"`
+		StartedAt  *time.Time `json:"started_at,omitempty"`
+		FinishedAt *time.Time `json:"finished_at,omitempty"`
+		CreatedAt  time.Time  `json:"created_at"`
+	}
+
+	// CreateSearchJobResponse represents a newly queued search job.
+	CreateSearchJobResponse struct {
+		SearchJobResponse
+	}
+
+	// SearchJobEventsRequest represents a received request to stream the events of a search job following
+	// the event with id LastEventID.
+	SearchJobEventsRequest struct {
+		ID          int64
+		LastEventID int64
+	}
+
+	// SearchJobEventResponse represents a single event of a search job stream, ID is zero for the summary
+	// closing the stream since it isn't stored.
+	SearchJobEventResponse struct {
+		ID    int64
+		Event string
+		Data  any
+	}
+
+	// SearchJobResultEvent represents the outcome of a single search of a job.
+	SearchJobResultEvent struct {
+		Position      int       `json:"position"`
+		Term          string    `json:"term"`
+		Limit         int       `json:"limit"`
+		MediaResultID int64     `json:"media_result_id,omitempty"`
+		ResultCount   int       `json:"result_count"`
+		Error         string    `json:"error,omitempty"`
+		At            time.Time `json:"at"`
+	}
+
+	// SearchJobStatusEvent represents a job that started running or got queued for a retry.
+	SearchJobStatusEvent struct {
+		Status    string     `json:"status"`
+		Attempts  int        `json:"attempts"`
+		NextRunAt *time.Time `json:"next_run_at,omitempty"`
+		Error     string     `json:"error,omitempty"`
+		At        time.Time  `json:"at"`
+	}
+
+	// SearchJobSummaryEvent represents the final state of a job.
+	SearchJobSummaryEvent struct {
+		Status     string     `json:"status"`
+		Attempts   int        `json:"attempts"`
+		Succeeded  int        `json:"succeeded"`
+		Failed     int        `json:"failed"`
+		LastError  string     `json:"last_error,omitempty"`
+		FinishedAt *time.Time `json:"finished_at,omitempty"`
+	}
+
+	// SearchJobEventsResponse represents the events of a job following a given event, Poll returns the ones
+	// following the event with the given id.
+	SearchJobEventsResponse struct {
+		Events []SearchJobEventResponse
+		// LastEventID is the id of the last stored event streamed once Events are.
+		LastEventID int64
+		// Done reports whether the job finished, Events then ends with its summary.
+		Done bool
+		Poll func(ctx context.Context, afterEventID int64) (SearchJobEventsResponse, error)
+	}
+)
+
+// mapSearchJobProgress maps the progress of a job following the event with id afterEventID to the events
+// to stream, a finished job ends with its summary.
+func mapSearchJobProgress(progress business.SearchJobProgress, afterEventID int64) SearchJobEventsResponse {
+	res := SearchJobEventsResponse{
+		LastEventID: afterEventID,
+		Events: lo.Map(progress.Events, func(e business.SearchJobEvent, _ int) SearchJobEventResponse {
+			if e.Kind == business.SearchJobEventResult {
+				return SearchJobEventResponse{ID: e.ID, Event: string(e.Kind), Data: SearchJobResultEvent{
+					Position:      e.Position,
+					Term:          e.Term,
+					Limit:         e.Limit,
+					MediaResultID: e.MediaResultID,
+					ResultCount:   e.ResultCount,
+					Error:         e.Error,
+					At:            e.CreatedAt,
+				}}
+			}
+			return SearchJobEventResponse{ID: e.ID, Event: string(e.Kind), Data: SearchJobStatusEvent{
+				Status:    string(e.Status),
+				Attempts:  e.Attempts,
+				NextRunAt: e.NextRunAt,
+				Error:     e.Error,
+				At:        e.CreatedAt,
+			}}
+		}),
+		Done: progress.Done,
+	}
+	if len(progress.Events) > 0 {
+		res.LastEventID = progress.Events[len(progress.Events)-1].ID
+	}
+	if !progress.Done {
+		return res
+	}
+	job := progress.Job
+	summary := SearchJobSummaryEvent{
+		Status:     string(job.Status),
+		Attempts:   job.Attempts,
+		LastError:  job.LastError,
+		FinishedAt: job.FinishedAt,
+	}
+	for _, result := range job.Results {
+		switch {
+		case result.MediaResultID != 0:
+			summary.Succeeded++
+		case result.Error != "":
+			summary.Failed++
+		}
+	}
+	res.Events = append(res.Events, SearchJobEventResponse{Event: "summary", Data: summary})
+	return res
+}
+
+// mapSearchJob maps a job to its response, searches without a stored result or error yet are pending.
+func mapSearchJob(job business.SearchJob) SearchJobResponse {
+	res := SearchJobResponse{
+		ID:          job.ID,
+		Status:      string(job.Status),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		Results:     make([]SearchBatchItem, len(job.Searches)),
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+		CreatedAt:   job.CreatedAt,
+	}
+	if job.Status == business.SearchJobQueued {
+		res.NextRunAt = lo.ToPtr(job.RunAt)
+	}
+	for i, search := range job.Searches {
+		item := SearchBatchItem{Term: search.Term, Limit: search.Limit}
+		if i < len(job.Results) {
+			result := job.Results[i]
+			switch {
+			case result.Result != nil:
+				item.Result = &SearchMediaResponse{
+					ID:          result.Result.ID,
+					SearchTerm:  result.Result.SearchTerm,
+					ResultCount: result.Result.ResultCount,
+					Media:       lo.Map(result.Result.Media, mapMedia),
+					FetchedAt:   result.Result.FetchedAt,
+				}
+				res.Succeeded++
+			case result.Error != "":
+				item.Error = result.Error
+				res.Failed++
+			}
+		}
+		res.Results[i] = item
+	}
+	return res
+}
+
+// MakeCreateSearchJobEndpoint function to make create search job endpoint call.
+func MakeCreateSearchJobEndpoint(handler searchJobHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(CreateSearchJobRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse create search job request")
+		}
+		job, err := handler.EnqueueSearchJob(ctx, authIdentity(ctx), lo.Map(body.Searches, func(s SearchMediaRequest, _ int) business.SearchQuery {
+			return business.SearchQuery{Term: s.Term, Limit: s.Limit}
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to enqueue search job: %w", err)
+		}
+		return CreateSearchJobResponse{SearchJobResponse: mapSearchJob(job)}, nil
+	}
+}
+
+// MakeGetSearchJobEndpoint function to make get search job endpoint call.
+func MakeGetSearchJobEndpoint(handler searchJobHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(SearchJobRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse get search job request")
+		}
+		job, err := handler.GetSearchJob(ctx, authIdentity(ctx), body.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get search job: %w", err)
+		}
+		return mapSearchJob(job), nil
+	}
+}
+
+// MakeCancelSearchJobEndpoint function to make cancel search job endpoint call.
+func MakeCancelSearchJobEndpoint(handler searchJobHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(SearchJobRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse cancel search job request")
+		}
+		job, err := handler.CancelSearchJob(ctx, authIdentity(ctx), body.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cancel search job: %w", err)
+		}
+		return mapSearchJob(job), nil
+	}
+}
+
+// MakeSearchJobEventsEndpoint function to make search job events endpoint call, the response polls the events
+// following the ones it holds so they can be streamed. Every poll is made for the client which opened the stream.
+func MakeSearchJobEventsEndpoint(handler searchJobHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(SearchJobEventsRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse search job events request")
+		}
+		owner := authIdentity(ctx)
+		var poll func(ctx context.Context, afterEventID int64) (SearchJobEventsResponse, error)
+		poll = func(ctx context.Context, afterEventID int64) (SearchJobEventsResponse, error) {
+			progress, err := handler.PollSearchJobEvents(ctx, owner, body.ID, afterEventID)
+			if err != nil {
+				return SearchJobEventsResponse{}, fmt.Errorf("failed to poll search job events: %w", err)
+			}
+			res := mapSearchJobProgress(progress, afterEventID)
+			res.Poll = poll
+			return res, nil
+		}
+		return poll(ctx, body.LastEventID)
+	}
+}
@@ -0,0 +1,191 @@
+package transport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeGetSearchJobEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMocksearchJobHandler(ctrl)
+	endpoint := transport.MakeGetSearchJobEndpoint(mockHandler)
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "queued job with partial results",
+			request: transport.SearchJobRequest{ID: 1},
+			mockSetup: func() {
+				mockHandler.EXPECT().GetSearchJob(gomock.Any(), "key:7", int64(1)).Return(business.SearchJob{
+					ID:       1,
+					Status:   business.SearchJobQueued,
+					Searches: []business.SearchQuery{{Term: "jack", Limit: 1}, {Term: "broken", Limit: 2}, {Term: "johnson", Limit: 3}},
+					Results: []business.SearchJobResult{
+						{MediaResultID: 7, Result: &business.MediaResult{ID: 7, SearchTerm: "jack", ResultCount: 1, Media: []business.Media{{TrackID: 456}}, FetchedAt: now}},
+						{Error: "failed to fetch and insert media: upstream down"},
+						{},
+					},
+					Attempts:    1,
+					MaxAttempts: 3,
+					LastError:   "failed to fetch and insert media: upstream down",
+					RunAt:       now,
+					StartedAt:   &now,
+					CreatedAt:   now,
+				}, nil)
+			},
+			expectedResponse: transport.SearchJobResponse{
+				ID:          1,
+				Status:      "queued",
+				Attempts:    1,
+				MaxAttempts: 3,
+				LastError:   "failed to fetch and insert media: upstream down",
+				Results: []transport.SearchBatchItem{
+					{Term: "jack", Limit: 1, Result: &transport.SearchMediaResponse{ID: 7, SearchTerm: "jack", ResultCount: 1, Media: []transport.Media{{TrackID: 456}}, FetchedAt: now}},
+					{Term: "broken", Limit: 2, Error: "failed to fetch and insert media: upstream down"},
+					{Term: "johnson", Limit: 3},
+				},
+				Succeeded: 1,
+				Failed:    1,
+				NextRunAt: &now,
+				StartedAt: &now,
+				CreatedAt: now,
+			},
+		},
+		{
+			name:    "unknown job",
+			request: transport.SearchJobRequest{ID: 2},
+			mockSetup: func() {
+				mockHandler.EXPECT().GetSearchJob(gomock.Any(), "key:7", int64(2)).Return(business.SearchJob{}, business.ErrNotFound)
+			},
+			expectedError: "failed to get search job: not found",
+		},
+		{
+			name:          "invalid request",
+			request:       "invalid",
+			mockSetup:     func() {},
+			expectedError: "failed to parse get search job request",
+		},
+	}
+
+	ctx := transport.ContextWithAuthInfo(context.Background(), &transport.AuthInfo{Key: business.APIKey{ID: 7}})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(ctx, tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResponse, response)
+			}
+		})
+	}
+}
+
+func TestMakeCreateSearchJobEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMocksearchJobHandler(ctrl)
+	endpoint := transport.MakeCreateSearchJobEndpoint(mockHandler)
+	now := time.Now()
+
+	mockHandler.EXPECT().EnqueueSearchJob(gomock.Any(), "key:7", []business.SearchQuery{{Term: "jack", Limit: 1}}).Return(business.SearchJob{
+		ID:          1,
+		Status:      business.SearchJobQueued,
+		Searches:    []business.SearchQuery{{Term: "jack", Limit: 1}},
+		Results:     []business.SearchJobResult{{}},
+		MaxAttempts: 3,
+		RunAt:       now,
+		CreatedAt:   now,
+	}, nil)
+
+	ctx := transport.ContextWithAuthInfo(context.Background(), &transport.AuthInfo{Key: business.APIKey{ID: 7}})
+	response, err := endpoint(ctx, transport.CreateSearchJobRequest{Searches: []transport.SearchMediaRequest{{Term: "jack", Limit: 1}}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transport.CreateSearchJobResponse{SearchJobResponse: transport.SearchJobResponse{
+		ID:          1,
+		Status:      "queued",
+		MaxAttempts: 3,
+		Results:     []transport.SearchBatchItem{{Term: "jack", Limit: 1}},
+		NextRunAt:   &now,
+		CreatedAt:   now,
+	}}, response)
+}
+
+func TestMakeSearchJobEventsEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMocksearchJobHandler(ctrl)
+	endpoint := transport.MakeSearchJobEventsEndpoint(mockHandler)
+	now := time.Now()
+	nextRunAt := now.Add(time.Minute)
+
+	mockHandler.EXPECT().PollSearchJobEvents(gomock.Any(), "key:7", int64(1), int64(3)).Return(business.SearchJobProgress{
+		Job: business.SearchJob{ID: 1, Status: business.SearchJobQueued},
+		Events: []business.SearchJobEvent{
+			{ID: 4, JobID: 1, Kind: business.SearchJobEventResult, Position: 1, Term: "broken", Limit: 2, Error: "upstream down", CreatedAt: now},
+			{ID: 5, JobID: 1, Kind: business.SearchJobEventStatus, Status: business.SearchJobQueued, Attempts: 1, NextRunAt: &nextRunAt, Error: "upstream down", CreatedAt: now},
+		},
+	}, nil)
+
+	ctx := transport.ContextWithAuthInfo(context.Background(), &transport.AuthInfo{Key: business.APIKey{ID: 7}})
+	response, err := endpoint(ctx, transport.SearchJobEventsRequest{ID: 1, LastEventID: 3})
+
+	assert.NoError(t, err)
+	res, ok := response.(transport.SearchJobEventsResponse)
+	assert.True(t, ok)
+	assert.Equal(t, []transport.SearchJobEventResponse{
+		{ID: 4, Event: "result", Data: transport.SearchJobResultEvent{Position: 1, Term: "broken", Limit: 2, Error: "upstream down", At: now}},
+		{ID: 5, Event: "status", Data: transport.SearchJobStatusEvent{Status: "queued", Attempts: 1, NextRunAt: &nextRunAt, Error: "upstream down", At: now}},
+	}, res.Events)
+	assert.Equal(t, int64(5), res.LastEventID)
+	assert.False(t, res.Done)
+
+	// polling again reads the events of the same job for the same client and a finished job ends with its summary.
+	mockHandler.EXPECT().PollSearchJobEvents(gomock.Any(), "key:7", int64(1), int64(5)).Return(business.SearchJobProgress{
+		Job: business.SearchJob{
+			ID:         1,
+			Status:     business.SearchJobFailed,
+			Results:    []business.SearchJobResult{{MediaResultID: 7}, {Error: "upstream down"}, {}},
+			Attempts:   3,
+			LastError:  "upstream down",
+			FinishedAt: &now,
+		},
+		Done: true,
+	}, nil)
+
+	res, err = res.Poll(context.Background(), res.LastEventID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []transport.SearchJobEventResponse{
+		{Event: "summary", Data: transport.SearchJobSummaryEvent{Status: "failed", Attempts: 3, Succeeded: 1, Failed: 1, LastError: "upstream down", FinishedAt: &now}},
+	}, res.Events)
+	assert.Equal(t, int64(5), res.LastEventID)
+	assert.True(t, res.Done)
+
+	mockHandler.EXPECT().PollSearchJobEvents(gomock.Any(), "key:7", int64(2), int64(0)).Return(business.SearchJobProgress{}, business.ErrNotFound)
+
+	_, err = endpoint(ctx, transport.SearchJobEventsRequest{ID: 2})
+
+	assert.EqualError(t, err, "failed to poll search job events: not found")
+}
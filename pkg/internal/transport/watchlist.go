@@ -0,0 +1,192 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+//go:generate mockgen -source=watchlist.go -destination=mock/watchlist.go -package=mock
+type watchlistHandler interface {
+	CreateWatchlist(ctx context.Context, owner, name string, artistIDs []int) (business.Watchlist, error)
+	GetWatchlist(ctx context.Context, owner string, id int64) (business.Watchlist, error)
+	SubscribeArtist(ctx context.Context, owner string, id int64, artistID int) (business.Watchlist, error)
+	UnsubscribeArtist(ctx context.Context, owner string, id int64, artistID int) (business.Watchlist, error)
+	ListWatchlistReleases(ctx context.Context, owner string, id int64, limit, offset int) ([]business.Release, error)
+}
+
+type (
+	// CreateWatchlistRequest represents the received request to create a watchlist of artists.
+	CreateWatchlistRequest struct {
+		Name      string
+		ArtistIDs []int
+	}
+
+	// WatchlistRequest represents a received request targeting a single watchlist.
+	WatchlistRequest struct {
+		ID int64
+	}
+
+	// WatchlistArtistRequest represents a received request to subscribe a watchlist to an artist or unsubscribe it.
+	WatchlistArtistRequest struct {
+		ID       int64
+		ArtistID int
+	}
+
+	// ListWatchlistReleasesRequest represents the received request to list the releases of a watchlist.
+	ListWatchlistReleasesRequest struct {
+		ID     int64
+		Limit  int
+		Offset int
+	}
+
+	// WatchedArtist represents an artist of a watchlist.
+	WatchedArtist struct {
+		ArtistID   int    `json:"artist_id"`
+		ArtistName string `json:"artist_name,omitempty"`
+		// LastReleaseDate and CheckedAt are unset until the artist was checked for new releases.
+		LastReleaseDate *time.Time `json:"last_release_date,omitempty"`
+		CheckedAt       *time.Time `json:"checked_at,omitempty"`
+		SubscribedAt    time.Time  `json:"subscribed_at"`
+	}
+
+	// WatchlistResponse represents a watchlist along with its artists.
+	WatchlistResponse struct {
+		ID        int64           `json:"id"`
+		Name      string          `json:"name"`
+		Artists   []WatchedArtist `json:"artists"`
+		CreatedAt time.Time       `json:"created_at"`
+	}
+
+	// CreateWatchlistResponse represents a newly created watchlist.
+	CreateWatchlistResponse struct {
+		WatchlistResponse
+	}
+
+	// Release represents a new release of an artist of a watchlist.
+	Release struct {
+		ID                int64     `json:"id"`
+		ArtistID          int       `json:"artist_id"`
+		ArtistName        string    `json:"artist_name,omitempty"`
+		CollectionID      int       `json:"collection_id"`
+		CollectionName    string    `json:"collection_name,omitempty"`
+		ReleaseDate       time.Time `json:"release_date"`
+		CollectionViewURL string    `json:"collection_view_url,omitempty"`
+		ArtworkURL100     string    `json:"artwork_url_100,omitempty"`
+		DetectedAt        time.Time `json:"detected_at"`
+	}
+
+	// ListWatchlistReleasesResponse represents the listed releases of a watchlist.
+	ListWatchlistReleasesResponse struct {
+		Releases []Release `json:"releases"`
+	}
+)
+
+// mapWatchlist maps a watchlist to its response.
+func mapWatchlist(watchlist business.Watchlist) WatchlistResponse {
+	return WatchlistResponse{
+		ID:   watchlist.ID,
+		Name: watchlist.Name,
+		Artists: lo.Map(watchlist.Artists, func(a business.WatchedArtist, _ int) WatchedArtist {
+			return WatchedArtist{
+				ArtistID:        a.ArtistID,
+				ArtistName:      a.ArtistName,
+				LastReleaseDate: a.LastReleaseDate,
+				CheckedAt:       a.CheckedAt,
+				SubscribedAt:    a.SubscribedAt,
+			}
+		}),
+		CreatedAt: watchlist.CreatedAt,
+	}
+}
+
+// MakeCreateWatchlistEndpoint function to make create watchlist endpoint call.
+func MakeCreateWatchlistEndpoint(handler watchlistHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(CreateWatchlistRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse create watchlist request")
+		}
+		watchlist, err := handler.CreateWatchlist(ctx, authIdentity(ctx), body.Name, body.ArtistIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create watchlist: %w", err)
+		}
+		return CreateWatchlistResponse{WatchlistResponse: mapWatchlist(watchlist)}, nil
+	}
+}
+
+// MakeGetWatchlistEndpoint function to make get watchlist endpoint call.
+func MakeGetWatchlistEndpoint(handler watchlistHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(WatchlistRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse get watchlist request")
+		}
+		watchlist, err := handler.GetWatchlist(ctx, authIdentity(ctx), body.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get watchlist: %w", err)
+		}
+		return mapWatchlist(watchlist), nil
+	}
+}
+
+// MakeSubscribeArtistEndpoint function to make subscribe artist endpoint call.
+func MakeSubscribeArtistEndpoint(handler watchlistHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(WatchlistArtistRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse subscribe artist request")
+		}
+		watchlist, err := handler.SubscribeArtist(ctx, authIdentity(ctx), body.ID, body.ArtistID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe artist: %w", err)
+		}
+		return mapWatchlist(watchlist), nil
+	}
+}
+
+// MakeUnsubscribeArtistEndpoint function to make unsubscribe artist endpoint call.
+func MakeUnsubscribeArtistEndpoint(handler watchlistHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(WatchlistArtistRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse unsubscribe artist request")
+		}
+		watchlist, err := handler.UnsubscribeArtist(ctx, authIdentity(ctx), body.ID, body.ArtistID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unsubscribe artist: %w", err)
+		}
+		return mapWatchlist(watchlist), nil
+	}
+}
+
+// MakeListWatchlistReleasesEndpoint function to make list watchlist releases endpoint call.
+func MakeListWatchlistReleasesEndpoint(handler watchlistHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(ListWatchlistReleasesRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse list watchlist releases request")
+		}
+		releases, err := handler.ListWatchlistReleases(ctx, authIdentity(ctx), body.ID, body.Limit, body.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list watchlist releases: %w", err)
+		}
+		return ListWatchlistReleasesResponse{Releases: lo.Map(releases, func(r business.Release, _ int) Release {
+			return Release{
+				ID:                r.ID,
+				ArtistID:          r.ArtistID,
+				ArtistName:        r.ArtistName,
+				CollectionID:      r.CollectionID,
+				CollectionName:    r.CollectionName,
+				ReleaseDate:       r.ReleaseDate,
+				CollectionViewURL: r.CollectionViewURL,
+				ArtworkURL100:     r.ArtworkURL100,
+				DetectedAt:        r.DetectedAt,
+			}
+		})}, nil
+	}
+}
@@ -0,0 +1,130 @@
+package transport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeCreateWatchlistEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockwatchlistHandler(ctrl)
+	endpoint := transport.MakeCreateWatchlistEndpoint(mockHandler)
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "created watchlist",
+			request: transport.CreateWatchlistRequest{Name: "favorites", ArtistIDs: []int{10, 20}},
+			mockSetup: func() {
+				mockHandler.EXPECT().CreateWatchlist(gomock.Any(), "key:7", "favorites", []int{10, 20}).Return(business.Watchlist{
+					ID:   1,
+					Name: "favorites",
+					Artists: []business.WatchedArtist{
+						{ArtistID: 10, ArtistName: "Jack Johnson", LastReleaseDate: &now, CheckedAt: &now, SubscribedAt: now},
+						{ArtistID: 20, SubscribedAt: now},
+					},
+					CreatedAt: now,
+				}, nil)
+			},
+			expectedResponse: transport.CreateWatchlistResponse{WatchlistResponse: transport.WatchlistResponse{
+				ID:   1,
+				Name: "favorites",
+				Artists: []transport.WatchedArtist{
+					{ArtistID: 10, ArtistName: "Jack Johnson", LastReleaseDate: &now, CheckedAt: &now, SubscribedAt: now},
+					{ArtistID: 20, SubscribedAt: now},
+				},
+				CreatedAt: now,
+			}},
+		},
+		{
+			name:    "handler error",
+			request: transport.CreateWatchlistRequest{Name: "favorites", ArtistIDs: []int{10}},
+			mockSetup: func() {
+				mockHandler.EXPECT().CreateWatchlist(gomock.Any(), "key:7", "favorites", []int{10}).Return(business.Watchlist{}, assert.AnError)
+			},
+			expectedError: "failed to create watchlist: " + assert.AnError.Error(),
+		},
+		{
+			name:          "invalid request",
+			request:       transport.WatchlistRequest{ID: 1},
+			mockSetup:     func() {},
+			expectedError: "failed to parse create watchlist request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			ctx := transport.ContextWithAuthInfo(context.Background(), &transport.AuthInfo{Key: business.APIKey{ID: 7}})
+			response, err := endpoint(ctx, tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
+
+func TestMakeUnsubscribeArtistEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockwatchlistHandler(ctrl)
+	endpoint := transport.MakeUnsubscribeArtistEndpoint(mockHandler)
+
+	t.Run("unsubscribed", func(t *testing.T) {
+		mockHandler.EXPECT().UnsubscribeArtist(gomock.Any(), "", int64(1), 10).Return(business.Watchlist{ID: 1, Name: "favorites"}, nil)
+
+		response, err := endpoint(context.Background(), transport.WatchlistArtistRequest{ID: 1, ArtistID: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, transport.WatchlistResponse{ID: 1, Name: "favorites", Artists: []transport.WatchedArtist{}}, response)
+	})
+
+	t.Run("not subscribed", func(t *testing.T) {
+		mockHandler.EXPECT().UnsubscribeArtist(gomock.Any(), "", int64(1), 20).Return(business.Watchlist{}, business.ErrNotFound)
+
+		_, err := endpoint(context.Background(), transport.WatchlistArtistRequest{ID: 1, ArtistID: 20})
+
+		assert.ErrorIs(t, err, business.ErrNotFound)
+	})
+}
+
+func TestMakeListWatchlistReleasesEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockwatchlistHandler(ctrl)
+	endpoint := transport.MakeListWatchlistReleasesEndpoint(mockHandler)
+	now := time.Now()
+
+	mockHandler.EXPECT().ListWatchlistReleases(gomock.Any(), "", int64(1), 10, 5).Return([]business.Release{
+		{ID: 3, ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 2, CollectionName: "New", ReleaseDate: now, DetectedAt: now},
+	}, nil)
+
+	response, err := endpoint(context.Background(), transport.ListWatchlistReleasesRequest{ID: 1, Limit: 10, Offset: 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transport.ListWatchlistReleasesResponse{Releases: []transport.Release{
+		{ID: 3, ArtistID: 10, ArtistName: "Jack Johnson", CollectionID: 2, CollectionName: "New", ReleaseDate: now, DetectedAt: now},
+	}}, response)
+}
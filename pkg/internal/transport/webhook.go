@@ -0,0 +1,204 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/samber/lo"
+)
+
+// Webhooks are owned by the authenticated client which created them, see authIdentity, every client of a deployment
+// with auth disabled shares the same empty owner.
+//
+//go:generate mockgen -source=webhook.go -destination=mock/webhook.go -package=mock
+type webhookHandler interface {
+	CreateWebhook(ctx context.Context, owner, url string, events []business.EventType) (business.Webhook, error)
+	GetWebhook(ctx context.Context, owner string, id int64) (business.Webhook, error)
+	DeleteWebhook(ctx context.Context, owner string, id int64) error
+	ListWebhookDeliveries(ctx context.Context, owner string, id int64, status business.WebhookDeliveryStatus, limit, offset int) ([]business.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, owner string, id, deliveryID int64) (business.WebhookDelivery, error)
+}
+
+type (
+	// CreateWebhookRequest represents the received request to subscribe an endpoint to events.
+	CreateWebhookRequest struct {
+		URL    string
+		Events []business.EventType
+	}
+
+	// WebhookRequest represents a received request targeting a single webhook.
+	WebhookRequest struct {
+		ID int64
+	}
+
+	// ListWebhookDeliveriesRequest represents the received request to list the deliveries of a webhook,
+	// an empty status lists deliveries of any status.
+	ListWebhookDeliveriesRequest struct {
+		ID     int64
+		Status business.WebhookDeliveryStatus
+		Limit  int
+		Offset int
+	}
+
+	// RedeliverWebhookRequest represents the received request to send a delivery of a webhook again.
+	RedeliverWebhookRequest struct {
+		ID         int64
+		DeliveryID int64
+	}
+
+	// WebhookResponse represents a webhook, its secret is only returned on creation.
+	WebhookResponse struct {
+		ID        int64                `json:"id"`
+		URL       string               `json:"url"`
+		Events    []business.EventType `json:"events"`
+		CreatedAt time.Time            `json:"created_at"`
+	}
+
+	// CreateWebhookResponse represents a newly created webhook along with the secret its payloads are signed with.
+	CreateWebhookResponse struct {
+		WebhookResponse
+		Secret string `json:"secret"`
+	}
+
+	// DeleteWebhookResponse represents the response of a deleted webhook.
+	DeleteWebhookResponse struct{}
+
+	// WebhookAttempt represents a single attempt of sending a delivery.
+	WebhookAttempt struct {
+		StatusCode int       `json:"status_code,omitempty"`
+		Error      string    `json:"error,omitempty"`
+		DurationMS int64     `json:"duration_ms"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	// WebhookDelivery represents an event sent to a webhook.
+	WebhookDelivery struct {
+		ID             int64                          `json:"id"`
+		Event          business.EventType             `json:"event"`
+		Payload        json.RawMessage                `json:"payload"`
+		Status         business.WebhookDeliveryStatus `json:"status"`
+		Attempts       int                            `json:"attempts"`
+		NextAttemptAt  *time.Time                     `json:"next_attempt_at,omitempty"`
+		LastStatusCode int                            `json:"last_status_code,omitempty"`
+		LastError      string                         `json:"last_error,omitempty"`
+		DeliveredAt    *time.Time                     `json:"delivered_at,omitempty"`
+		CreatedAt      time.Time                      `json:"created_at"`
+		// Log is only listed along with deliveries.
+		Log []WebhookAttempt `json:"log,omitempty"`
+	}
+
+	// RedeliverWebhookResponse represents a delivery queued again.
+	RedeliverWebhookResponse struct {
+		WebhookDelivery
+	}
+
+	// ListWebhookDeliveriesResponse represents the listed deliveries of a webhook.
+	ListWebhookDeliveriesResponse struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+)
+
+// mapWebhook maps a webhook to its response.
+func mapWebhook(webhook business.Webhook) WebhookResponse {
+	return WebhookResponse{ID: webhook.ID, URL: webhook.URL, Events: webhook.Events, CreatedAt: webhook.CreatedAt}
+}
+
+// mapWebhookDelivery maps a delivery to its response, the next attempt is only reported while it is pending.
+func mapWebhookDelivery(d business.WebhookDelivery, _ int) WebhookDelivery {
+	delivery := WebhookDelivery{
+		ID:             d.ID,
+		Event:          d.Event,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		Log: lo.Map(d.Log, func(a business.WebhookAttempt, _ int) WebhookAttempt {
+			return WebhookAttempt{StatusCode: a.StatusCode, Error: a.Error, DurationMS: a.Duration.Milliseconds(), CreatedAt: a.CreatedAt}
+		}),
+	}
+	if d.Status == business.WebhookDeliveryPending {
+		delivery.NextAttemptAt = lo.ToPtr(d.NextAttemptAt)
+	}
+	return delivery
+}
+
+// MakeCreateWebhookEndpoint function to make create webhook endpoint call.
+func MakeCreateWebhookEndpoint(handler webhookHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(CreateWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse create webhook request")
+		}
+		webhook, err := handler.CreateWebhook(ctx, authIdentity(ctx), body.URL, body.Events)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook: %w", err)
+		}
+		return CreateWebhookResponse{WebhookResponse: mapWebhook(webhook), Secret: webhook.Secret}, nil
+	}
+}
+
+// MakeGetWebhookEndpoint function to make get webhook endpoint call.
+func MakeGetWebhookEndpoint(handler webhookHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(WebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse get webhook request")
+		}
+		webhook, err := handler.GetWebhook(ctx, authIdentity(ctx), body.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webhook: %w", err)
+		}
+		return mapWebhook(webhook), nil
+	}
+}
+
+// MakeDeleteWebhookEndpoint function to make delete webhook endpoint call.
+func MakeDeleteWebhookEndpoint(handler webhookHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(WebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse delete webhook request")
+		}
+		if err := handler.DeleteWebhook(ctx, authIdentity(ctx), body.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete webhook: %w", err)
+		}
+		return DeleteWebhookResponse{}, nil
+	}
+}
+
+// MakeListWebhookDeliveriesEndpoint function to make list webhook deliveries endpoint call.
+func MakeListWebhookDeliveriesEndpoint(handler webhookHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(ListWebhookDeliveriesRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse list webhook deliveries request")
+		}
+		deliveries, err := handler.ListWebhookDeliveries(ctx, authIdentity(ctx), body.ID, body.Status, body.Limit, body.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+		}
+		return ListWebhookDeliveriesResponse{Deliveries: lo.Map(deliveries, mapWebhookDelivery)}, nil
+	}
+}
+
+// MakeRedeliverWebhookEndpoint function to make redeliver webhook endpoint call.
+func MakeRedeliverWebhookEndpoint(handler webhookHandler) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		body, ok := request.(RedeliverWebhookRequest)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse redeliver webhook request")
+		}
+		delivery, err := handler.RedeliverWebhook(ctx, authIdentity(ctx), body.ID, body.DeliveryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+		}
+		return RedeliverWebhookResponse{WebhookDelivery: mapWebhookDelivery(delivery, 0)}, nil
+	}
+}
@@ -0,0 +1,135 @@
+package transport_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport/mock"
+	"github.com/NawafSwe/media-scout-service/pkg/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeCreateWebhookEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockwebhookHandler(ctrl)
+	endpoint := transport.MakeCreateWebhookEndpoint(mockHandler)
+	now := time.Now()
+	events := []business.EventType{business.EventSearchStored}
+	ctx := transport.ContextWithAuthInfo(context.Background(), &transport.AuthInfo{Key: business.APIKey{ID: 7}})
+
+	tests := []struct {
+		name             string
+		request          any
+		mockSetup        func()
+		expectedError    string
+		expectedResponse any
+	}{
+		{
+			name:    "created webhook",
+			request: transport.CreateWebhookRequest{URL: "https://example.com/hooks", Events: events},
+			mockSetup: func() {
+				mockHandler.EXPECT().CreateWebhook(gomock.Any(), "key:7", "https://example.com/hooks", events).
+					Return(business.Webhook{ID: 1, URL: "https://example.com/hooks", Secret: "whsec_test", Events: events, CreatedAt: now}, nil)
+			},
+			expectedResponse: transport.CreateWebhookResponse{
+				WebhookResponse: transport.WebhookResponse{ID: 1, URL: "https://example.com/hooks", Events: events, CreatedAt: now},
+				Secret:          "whsec_test",
+			},
+		},
+		{
+			name:    "handler error",
+			request: transport.CreateWebhookRequest{URL: "https://example.com/hooks", Events: events},
+			mockSetup: func() {
+				mockHandler.EXPECT().CreateWebhook(gomock.Any(), "key:7", "https://example.com/hooks", events).Return(business.Webhook{}, assert.AnError)
+			},
+			expectedError: "failed to create webhook: " + assert.AnError.Error(),
+		},
+		{
+			name:          "invalid request",
+			request:       transport.WebhookRequest{ID: 1},
+			mockSetup:     func() {},
+			expectedError: "failed to parse create webhook request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			response, err := endpoint(ctx, tt.request)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
+
+func TestMakeListWebhookDeliveriesEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockwebhookHandler(ctrl)
+	endpoint := transport.MakeListWebhookDeliveriesEndpoint(mockHandler)
+	now := time.Now()
+	payload := json.RawMessage(`{"type":"search.stored"}`)
+
+	mockHandler.EXPECT().ListWebhookDeliveries(gomock.Any(), "sub:alice", int64(1), business.WebhookDeliveryPending, 10, 5).Return([]business.WebhookDelivery{
+		{
+			ID: 2, WebhookID: 1, Event: business.EventSearchStored, Payload: payload, Status: business.WebhookDeliveryPending,
+			Attempts: 1, NextAttemptAt: now, LastStatusCode: 500, LastError: "received non-2xx response code: 500", CreatedAt: now,
+			Log: []business.WebhookAttempt{{DeliveryID: 2, StatusCode: 500, Error: "received non-2xx response code: 500", Duration: 80 * time.Millisecond, CreatedAt: now}},
+		},
+	}, nil)
+
+	ctx := transport.ContextWithAuthInfo(context.Background(), &transport.AuthInfo{Identity: jwt.Identity{Subject: "alice"}})
+	response, err := endpoint(ctx, transport.ListWebhookDeliveriesRequest{ID: 1, Status: business.WebhookDeliveryPending, Limit: 10, Offset: 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, transport.ListWebhookDeliveriesResponse{Deliveries: []transport.WebhookDelivery{
+		{
+			ID: 2, Event: business.EventSearchStored, Payload: payload, Status: business.WebhookDeliveryPending,
+			Attempts: 1, NextAttemptAt: &now, LastStatusCode: 500, LastError: "received non-2xx response code: 500", CreatedAt: now,
+			Log: []transport.WebhookAttempt{{StatusCode: 500, Error: "received non-2xx response code: 500", DurationMS: 80, CreatedAt: now}},
+		},
+	}}, response)
+}
+
+func TestMakeRedeliverWebhookEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock.NewMockwebhookHandler(ctrl)
+	endpoint := transport.MakeRedeliverWebhookEndpoint(mockHandler)
+
+	t.Run("redelivered", func(t *testing.T) {
+		now := time.Now()
+		mockHandler.EXPECT().RedeliverWebhook(gomock.Any(), "", int64(1), int64(2)).
+			Return(business.WebhookDelivery{ID: 2, Event: business.EventJobFinished, Status: business.WebhookDeliveryPending, NextAttemptAt: now, CreatedAt: now}, nil)
+
+		response, err := endpoint(context.Background(), transport.RedeliverWebhookRequest{ID: 1, DeliveryID: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, transport.RedeliverWebhookResponse{WebhookDelivery: transport.WebhookDelivery{
+			ID: 2, Event: business.EventJobFinished, Status: business.WebhookDeliveryPending, NextAttemptAt: &now, CreatedAt: now, Log: []transport.WebhookAttempt{},
+		}}, response)
+	})
+
+	t.Run("being delivered", func(t *testing.T) {
+		mockHandler.EXPECT().RedeliverWebhook(gomock.Any(), "", int64(1), int64(3)).Return(business.WebhookDelivery{}, business.ErrConflict)
+
+		_, err := endpoint(context.Background(), transport.RedeliverWebhookRequest{ID: 1, DeliveryID: 3})
+
+		assert.ErrorIs(t, err, business.ErrConflict)
+	})
+}
@@ -0,0 +1,239 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultRefreshInterval = 15 * time.Minute
+	// minRefreshInterval bounds how often an unknown kid can trigger a refresh, so forged kids can't hammer the source.
+	minRefreshInterval = 30 * time.Second
+)
+
+// ErrUnknownKey is returned when the key set has no key with the requested id.
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// jsonWebKey is a single key of a JWKS document, only the RSA and EC public parameters are read.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jsonWebKeySet is a JWKS document.
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// KeySet is a cached JWKS loaded from a file or a URL, it is refreshed periodically and whenever
+// a token is signed by a key it doesn't know yet, so signing key rotations are picked up.
+// A single refresh runs at a time and outside of the lock, requests arriving meanwhile wait for it.
+type KeySet struct {
+	load            func(ctx context.Context) ([]byte, error)
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	loadedAt    time.Time
+	lastAttempt time.Time
+	// lastErr is the error of the last refresh, nil when it succeeded.
+	lastErr error
+	// refreshing is closed once the refresh in flight is done, it is nil when none is.
+	refreshing chan struct{}
+}
+
+// NewFileKeySet creates a key set read from the JWKS file at path.
+func NewFileKeySet(path string, refreshInterval time.Duration) *KeySet {
+	return newKeySet(func(context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, refreshInterval)
+}
+
+// NewURLKeySet creates a key set fetched from the JWKS document served at url.
+func NewURLKeySet(url string, refreshInterval time.Duration, tracer *trace.TracerProvider) *KeySet {
+	client := &http.Client{
+		Transport: otelhttp.NewTransport(nil, otelhttp.WithTracerProvider(tracer)),
+		Timeout:   10 * time.Second,
+	}
+	return newKeySet(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("received non-200 response code: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	}, refreshInterval)
+}
+
+func newKeySet(load func(ctx context.Context) ([]byte, error), refreshInterval time.Duration) *KeySet {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+	return &KeySet{load: load, refreshInterval: refreshInterval, now: time.Now}
+}
+
+// Key returns the public key with the given id.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	now := s.now()
+	stale := s.keys == nil || now.Sub(s.loadedAt) >= s.refreshInterval
+	_, known := s.keys[kid]
+	done := s.refreshing
+	// an unknown key waits for the refresh in flight, it may bring that key.
+	needsRefresh := stale || !known && (done != nil || now.Sub(s.lastAttempt) >= minRefreshInterval)
+	if needsRefresh && done == nil {
+		done = make(chan struct{})
+		s.refreshing = done
+		s.lastAttempt = now
+		s.mu.Unlock()
+		s.refresh(ctx, now, done)
+	} else {
+		s.mu.Unlock()
+	}
+	if needsRefresh {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			// a waiting request gives up on the refresh, the cached keys are served if any.
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		if s.lastErr != nil {
+			return nil, s.lastErr
+		}
+		return nil, ctx.Err()
+	}
+	// keep serving the cached keys when the source is temporarily unavailable.
+	key, known := s.keys[kid]
+	if !known {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+// refresh loads the key set without holding the lock and swaps the keys in, done is closed once it is over.
+// The load isn't canceled along with ctx since other requests may be waiting for it.
+func (s *KeySet) refresh(ctx context.Context, now time.Time, done chan struct{}) {
+	keys, err := s.fetch(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastErr = err
+	if err == nil {
+		s.keys = keys
+		s.loadedAt = now
+	}
+	s.refreshing = nil
+	close(done)
+}
+
+// fetch loads and parses the key set.
+func (s *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	raw, err := s.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jwks: %w", err)
+	}
+	return parseKeySet(raw)
+}
+
+// parseKeySet parses the signing keys of a JWKS document, keys of unsupported types are skipped.
+func parseKeySet(raw []byte) (map[string]crypto.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = parseRSAKey(k)
+		case "EC":
+			key, err = parseECKey(k)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jwk %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func parseRSAKey(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+func parseECKey(k jsonWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+	key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("point is not on curve")
+	}
+	return key, nil
+}
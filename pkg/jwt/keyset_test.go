@@ -0,0 +1,105 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	oldJWKS = `{"keys":[{"kty":"EC","kid":"old","crv":"P-256","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4","y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM"}]}`
+	newJWKS = `{"keys":[{"kty":"EC","kid":"old","crv":"P-256","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4","y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM"},` +
+		`{"kty":"RSA","kid":"new","use":"sig","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw","e":"AQAB"},` +
+		`{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"}]}`
+)
+
+func TestKeySet_Key(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	var (
+		fetches int
+		current = oldJWKS
+		loadErr error
+	)
+	keys := newKeySet(func(context.Context) ([]byte, error) {
+		fetches++
+		return []byte(current), loadErr
+	}, time.Hour)
+	keys.now = func() time.Time { return now }
+
+	_, err := keys.Key(context.Background(), "old")
+	require.NoError(t, err)
+	_, err = keys.Key(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetches, "known keys are served from the cache")
+
+	// the signing key is rotated.
+	current = newJWKS
+	_, err = keys.Key(context.Background(), "new")
+	assert.ErrorIs(t, err, ErrUnknownKey, "unknown kids don't refresh more often than the minimum interval")
+	assert.Equal(t, 1, fetches)
+
+	now = now.Add(minRefreshInterval)
+	_, err = keys.Key(context.Background(), "new")
+	assert.NoError(t, err, "an unknown kid refreshes the key set")
+	assert.Equal(t, 2, fetches)
+
+	_, err = keys.Key(context.Background(), "enc")
+	assert.ErrorIs(t, err, ErrUnknownKey, "encryption keys are skipped")
+
+	// the source becomes unavailable once the cache expired.
+	now = now.Add(time.Hour)
+	loadErr = errors.New("connection refused")
+	_, err = keys.Key(context.Background(), "old")
+	assert.NoError(t, err, "cached keys are kept when a refresh fails")
+	assert.Equal(t, 3, fetches)
+}
+
+func TestKeySet_Key_RefreshOutsideLock(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	var (
+		fetches atomic.Int32
+		release = make(chan struct{})
+	)
+	keys := newKeySet(func(context.Context) ([]byte, error) {
+		if fetches.Add(1) > 1 {
+			<-release
+			return []byte(newJWKS), nil
+		}
+		return []byte(oldJWKS), nil
+	}, time.Hour)
+	keys.now = func() time.Time { return now }
+
+	_, err := keys.Key(context.Background(), "old")
+	require.NoError(t, err)
+
+	// a token signed by an unknown key triggers a slow refresh.
+	now = now.Add(minRefreshInterval)
+	refreshed := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := keys.Key(context.Background(), "new")
+			refreshed <- err
+		}()
+	}
+	require.Eventually(t, func() bool { return fetches.Load() == 2 }, time.Second, time.Millisecond)
+
+	// known keys are still served while the refresh is in flight.
+	_, err = keys.Key(context.Background(), "old")
+	require.NoError(t, err)
+
+	// a request giving up doesn't wait for the refresh.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = keys.Key(ctx, "new")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	close(release)
+	assert.NoError(t, <-refreshed)
+	assert.NoError(t, <-refreshed)
+	assert.Equal(t, int32(2), fetches.Load(), "concurrent requests share the refresh")
+}
@@ -0,0 +1,89 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity is the identity a verified token was issued for.
+type Identity struct {
+	Subject string
+	Scopes  []string
+}
+
+// HasScopes reports whether the identity was granted every given scope.
+func (i Identity) HasScopes(scopes ...string) bool {
+	for _, s := range scopes {
+		if !slices.Contains(i.Scopes, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// Options configures the checks of a Verifier.
+type Options struct {
+	// Issuer is the expected iss claim, it isn't checked when empty.
+	Issuer string
+	// Audience is the expected aud claim, it isn't checked when empty.
+	Audience string
+	// Leeway is the clock skew tolerated when checking exp, nbf and iat.
+	Leeway time.Duration
+}
+
+// Verifier verifies RS256 and ES256 signed tokens against a key set.
+type Verifier struct {
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+// claims are the registered claims along with the scopes, which are either a space separated scope claim
+// or an scp array depending on the issuer.
+type claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope,omitempty"`
+	Scp   []string `json:"scp,omitempty"`
+}
+
+// NewVerifier creates a verifier checking tokens against keys.
+func NewVerifier(keys *KeySet, opts Options) *Verifier {
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	return &Verifier{keys: keys, parser: jwt.NewParser(parserOpts...)}
+}
+
+// Verify checks the signature and claims of the raw token and returns the identity it was issued for.
+func (v *Verifier) Verify(ctx context.Context, raw string) (Identity, error) {
+	var c claims
+	_, err := v.parser.ParseWithClaims(raw, &c, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("token has no kid header")
+		}
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return Identity{}, fmt.Errorf("invalid token: %w", err)
+	}
+	scopes := c.Scp
+	if c.Scope != "" {
+		scopes = strings.Fields(c.Scope)
+	}
+	return Identity{Subject: c.Subject, Scopes: scopes}, nil
+}
@@ -0,0 +1,149 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/jwt"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwk encodes the public part of key as a JWKS entry.
+func jwk(t *testing.T, kid string, key crypto.Signer) map[string]string {
+	t.Helper()
+	enc := base64.RawURLEncoding.EncodeToString
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": enc(pub.N.Bytes()), "e": enc(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": kid, "crv": "P-256", "x": enc(pub.X.FillBytes(make([]byte, 32))), "y": enc(pub.Y.FillBytes(make([]byte, 32)))}
+	}
+	t.Fatalf("unsupported key %T", key)
+	return nil
+}
+
+func jwks(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+	raw, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return raw
+}
+
+func sign(t *testing.T, method gojwt.SigningMethod, kid string, key crypto.Signer, claims gojwt.MapClaims) string {
+	t.Helper()
+	token := gojwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwks(t, jwk(t, "rsa", rsaKey), jwk(t, "ec", ecKey)), 0o600))
+	verifier := jwt.NewVerifier(jwt.NewFileKeySet(path, time.Hour), jwt.Options{
+		Issuer:   "https://issuer.example.com",
+		Audience: "media-scout",
+	})
+
+	now := time.Now()
+	claims := func(overrides gojwt.MapClaims) gojwt.MapClaims {
+		c := gojwt.MapClaims{
+			"iss": "https://issuer.example.com",
+			"aud": "media-scout",
+			"sub": "user-1",
+			"exp": now.Add(time.Hour).Unix(),
+			"iat": now.Unix(),
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name             string
+		token            string
+		expectedError    string
+		expectedIdentity jwt.Identity
+	}{
+		{
+			name:             "rs256 token with scope claim",
+			token:            sign(t, gojwt.SigningMethodRS256, "rsa", rsaKey, claims(gojwt.MapClaims{"scope": "media:read profile"})),
+			expectedIdentity: jwt.Identity{Subject: "user-1", Scopes: []string{"media:read", "profile"}},
+		},
+		{
+			name:             "es256 token with scp claim",
+			token:            sign(t, gojwt.SigningMethodES256, "ec", ecKey, claims(gojwt.MapClaims{"scp": []string{"media:read"}})),
+			expectedIdentity: jwt.Identity{Subject: "user-1", Scopes: []string{"media:read"}},
+		},
+		{
+			name:          "expired token",
+			token:         sign(t, gojwt.SigningMethodRS256, "rsa", rsaKey, claims(gojwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})),
+			expectedError: "token is expired",
+		},
+		{
+			name:          "token without expiry",
+			token:         sign(t, gojwt.SigningMethodRS256, "rsa", rsaKey, claims(gojwt.MapClaims{"exp": nil})),
+			expectedError: "token is missing required claim",
+		},
+		{
+			name:          "wrong issuer",
+			token:         sign(t, gojwt.SigningMethodRS256, "rsa", rsaKey, claims(gojwt.MapClaims{"iss": "https://evil.example.com"})),
+			expectedError: "token has invalid issuer",
+		},
+		{
+			name:          "wrong audience",
+			token:         sign(t, gojwt.SigningMethodRS256, "rsa", rsaKey, claims(gojwt.MapClaims{"aud": "other"})),
+			expectedError: "token has invalid audience",
+		},
+		{
+			name:          "unknown kid",
+			token:         sign(t, gojwt.SigningMethodRS256, "unknown", otherKey, claims(nil)),
+			expectedError: "unknown signing key",
+		},
+		{
+			name:          "signed by another key",
+			token:         sign(t, gojwt.SigningMethodRS256, "rsa", otherKey, claims(nil)),
+			expectedError: "signature is invalid",
+		},
+		{
+			name:          "disallowed algorithm",
+			token:         sign(t, gojwt.SigningMethodRS512, "rsa", rsaKey, claims(nil)),
+			expectedError: "signing method RS512 is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(context.Background(), tt.token)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedIdentity, identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// FormatJSON writes stdout records as JSON objects.
+	FormatJSON = "json"
+	// FormatText writes stdout records as key=value pairs.
+	FormatText = "text"
+)
+
+// Options configures the logger returned by NewLogger.
+type Options struct {
+	// Export bridges records to Provider, they are only written to stdout otherwise.
+	Export bool
+	// Level is the minimum level to log, one of debug, info, warn or error. Defaults to info.
+	Level string
+	// Format is the stdout format, one of FormatJSON or FormatText. Defaults to FormatJSON.
+	Format string
+	// Provider is the OTel log provider records are bridged to when exported.
+	Provider log.LoggerProvider
+}
+
+// NewLogger creates a slog.Logger writing to w and, when exported, to the OTel log provider of the given options,
+// stdout records carry the trace and span ids of the span in the logged context.
+func NewLogger(name string, w io.Writer, opts Options) (*slog.Logger, error) {
+	level, err := parseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	var stdout slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", FormatJSON:
+		stdout = slog.NewJSONHandler(w, handlerOpts)
+	case FormatText:
+		stdout = slog.NewTextHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unsupported logging format %q", opts.Format)
+	}
+
+	handlers := []slog.Handler{traceHandler{Handler: stdout}}
+	if opts.Export && opts.Provider != nil {
+		// the bridge correlates records with the span in the context on its own.
+		handlers = append(handlers, levelHandler{
+			Handler: otelslog.NewHandler(name, otelslog.WithLoggerProvider(opts.Provider)),
+			level:   level,
+		})
+	}
+	return slog.New(contextHandler{Handler: fanoutHandler(handlers)}), nil
+}
+
+type ctxAttrsKey struct{}
+
+// ContextWithAttrs returns a copy of ctx carrying attrs, they are added to every record logged with the returned context.
+func ContextWithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(ctxAttrsKey{}).([]slog.Attr)
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, ctxAttrsKey{}, merged)
+}
+
+func parseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return l, fmt.Errorf("invalid logging level %q: %w", level, err)
+	}
+	return l, nil
+}
+
+// fanoutHandler dispatches every record to all of its handlers.
+type fanoutHandler []slog.Handler
+
+func (f fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range f {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, len(f))
+	for i, h := range f {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (f fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, len(f))
+	for i, h := range f {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
+
+// contextHandler adds the attributes stored in the context by ContextWithAttrs to every record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (c contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(ctxAttrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+	return c.Handler.Handle(ctx, r)
+}
+
+func (c contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: c.Handler.WithAttrs(attrs)}
+}
+
+func (c contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: c.Handler.WithGroup(name)}
+}
+
+// traceHandler adds the trace and span ids of the span in the context to every record.
+type traceHandler struct {
+	slog.Handler
+}
+
+func (t traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return t.Handler.Handle(ctx, r)
+}
+
+func (t traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{Handler: t.Handler.WithAttrs(attrs)}
+}
+
+func (t traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{Handler: t.Handler.WithGroup(name)}
+}
+
+// levelHandler drops records below level, the OTel bridge has no level of its own.
+type levelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (l levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= l.level && l.Handler.Enabled(ctx, level)
+}
+
+func (l levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return levelHandler{Handler: l.Handler.WithAttrs(attrs), level: l.level}
+}
+
+func (l levelHandler) WithGroup(name string) slog.Handler {
+	return levelHandler{Handler: l.Handler.WithGroup(name), level: l.level}
+}
@@ -0,0 +1,151 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewLogger(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	tests := []struct {
+		name          string
+		opts          logging.Options
+		ctx           context.Context
+		log           func(lgr logging.Logger, ctx context.Context)
+		expectedError string
+		expectedAttrs map[string]any
+		expectEmpty   bool
+	}{
+		{
+			name: "json record carries trace and span ids",
+			opts: logging.Options{},
+			ctx:  spanCtx,
+			log: func(lgr logging.Logger, ctx context.Context) {
+				lgr.InfoContext(ctx, "hello", "term", "jack")
+			},
+			expectedAttrs: map[string]any{
+				"msg":      "hello",
+				"term":     "jack",
+				"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"span_id":  "00f067aa0ba902b7",
+			},
+		},
+		{
+			name: "record carries context attributes",
+			opts: logging.Options{Format: logging.FormatJSON},
+			ctx:  logging.ContextWithAttrs(context.Background(), slog.String("request_id", "abc")),
+			log: func(lgr logging.Logger, ctx context.Context) {
+				lgr.InfoContext(ctx, "hello")
+			},
+			expectedAttrs: map[string]any{"msg": "hello", "request_id": "abc"},
+		},
+		{
+			name: "record without span has no trace ids",
+			opts: logging.Options{Format: logging.FormatJSON},
+			ctx:  context.Background(),
+			log: func(lgr logging.Logger, ctx context.Context) {
+				lgr.WarnContext(ctx, "hello")
+			},
+			expectedAttrs: map[string]any{"msg": "hello", "level": "WARN"},
+		},
+		{
+			name: "records below level are dropped",
+			opts: logging.Options{Level: "warn"},
+			ctx:  context.Background(),
+			log: func(lgr logging.Logger, ctx context.Context) {
+				lgr.InfoContext(ctx, "hello")
+			},
+			expectEmpty: true,
+		},
+		{
+			name: "records are written to stdout without being exported",
+			opts: logging.Options{Provider: logtest.NewRecorder()},
+			ctx:  context.Background(),
+			log: func(lgr logging.Logger, ctx context.Context) {
+				lgr.ErrorContext(ctx, "hello")
+			},
+			expectedAttrs: map[string]any{"msg": "hello", "level": "ERROR"},
+		},
+		{
+			name:          "invalid level",
+			opts:          logging.Options{Level: "loud"},
+			expectedError: `invalid logging level "loud"`,
+		},
+		{
+			name:          "invalid format",
+			opts:          logging.Options{Format: "xml"},
+			expectedError: `unsupported logging format "xml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			lgr, err := logging.NewLogger("test", &buf, tt.opts)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			tt.log(lgr, tt.ctx)
+			if tt.expectEmpty {
+				assert.Empty(t, buf.String())
+				return
+			}
+			var record map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			for k, v := range tt.expectedAttrs {
+				assert.Equal(t, v, record[k], k)
+			}
+			if _, ok := tt.expectedAttrs["trace_id"]; !ok {
+				assert.NotContains(t, record, "trace_id")
+			}
+		})
+	}
+}
+
+func TestNewLogger_Export(t *testing.T) {
+	tests := []struct {
+		name            string
+		export          bool
+		expectedRecords int
+	}{
+		{name: "exported records are bridged", export: true, expectedRecords: 1},
+		{name: "records are not bridged unless exported", export: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			recorder := logtest.NewRecorder()
+			lgr, err := logging.NewLogger("test", &buf, logging.Options{Export: tt.export, Provider: recorder})
+			assert.NoError(t, err)
+
+			lgr.InfoContext(context.Background(), "hello")
+
+			assert.Contains(t, buf.String(), `"msg":"hello"`)
+			var records int
+			for _, scope := range recorder.Result() {
+				records += len(scope.Records)
+			}
+			assert.Equal(t, tt.expectedRecords, records)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package openapi bundles the OpenAPI document of the service along with a page rendering it.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+var (
+	//go:embed openapi.json
+	spec []byte
+	//go:embed docs.html
+	docs []byte
+)
+
+// Spec returns the OpenAPI document of the service.
+func Spec() []byte {
+	return spec
+}
+
+// SpecHandler serves the OpenAPI document.
+func SpecHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(spec)
+	})
+}
+
+// DocsHandler serves a page rendering the OpenAPI document, it needs no external assets.
+func DocsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(docs)
+	})
+}
@@ -0,0 +1,1270 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: mediascout/v1/media_scout.proto
+
+package mediascoutv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Media is a single media item as returned by the iTunes API.
+type Media struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	WrapperType            string                 `protobuf:"bytes,1,opt,name=wrapper_type,json=wrapperType,proto3" json:"wrapper_type,omitempty"`
+	Kind                   string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ArtistId               int64                  `protobuf:"varint,3,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	CollectionId           int64                  `protobuf:"varint,4,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	TrackId                int64                  `protobuf:"varint,5,opt,name=track_id,json=trackId,proto3" json:"track_id,omitempty"`
+	ArtistName             string                 `protobuf:"bytes,6,opt,name=artist_name,json=artistName,proto3" json:"artist_name,omitempty"`
+	CollectionName         string                 `protobuf:"bytes,7,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	TrackName              string                 `protobuf:"bytes,8,opt,name=track_name,json=trackName,proto3" json:"track_name,omitempty"`
+	ArtistViewUrl          string                 `protobuf:"bytes,9,opt,name=artist_view_url,json=artistViewUrl,proto3" json:"artist_view_url,omitempty"`
+	CollectionViewUrl      string                 `protobuf:"bytes,10,opt,name=collection_view_url,json=collectionViewUrl,proto3" json:"collection_view_url,omitempty"`
+	FeedUrl                string                 `protobuf:"bytes,11,opt,name=feed_url,json=feedUrl,proto3" json:"feed_url,omitempty"`
+	TrackViewUrl           string                 `protobuf:"bytes,12,opt,name=track_view_url,json=trackViewUrl,proto3" json:"track_view_url,omitempty"`
+	ArtworkUrl_30          string                 `protobuf:"bytes,13,opt,name=artwork_url_30,json=artworkUrl30,proto3" json:"artwork_url_30,omitempty"`
+	ArtworkUrl_60          string                 `protobuf:"bytes,14,opt,name=artwork_url_60,json=artworkUrl60,proto3" json:"artwork_url_60,omitempty"`
+	ArtworkUrl_100         string                 `protobuf:"bytes,15,opt,name=artwork_url_100,json=artworkUrl100,proto3" json:"artwork_url_100,omitempty"`
+	ReleaseDate            string                 `protobuf:"bytes,16,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	CollectionExplicitness string                 `protobuf:"bytes,17,opt,name=collection_explicitness,json=collectionExplicitness,proto3" json:"collection_explicitness,omitempty"`
+	TrackExplicitness      string                 `protobuf:"bytes,18,opt,name=track_explicitness,json=trackExplicitness,proto3" json:"track_explicitness,omitempty"`
+	TrackCount             int32                  `protobuf:"varint,19,opt,name=track_count,json=trackCount,proto3" json:"track_count,omitempty"`
+	TrackTimeMillis        int64                  `protobuf:"varint,20,opt,name=track_time_millis,json=trackTimeMillis,proto3" json:"track_time_millis,omitempty"`
+	Country                string                 `protobuf:"bytes,21,opt,name=country,proto3" json:"country,omitempty"`
+	Currency               string                 `protobuf:"bytes,22,opt,name=currency,proto3" json:"currency,omitempty"`
+	PrimaryGenreName       string                 `protobuf:"bytes,23,opt,name=primary_genre_name,json=primaryGenreName,proto3" json:"primary_genre_name,omitempty"`
+	ContentAdvisoryRating  string                 `protobuf:"bytes,24,opt,name=content_advisory_rating,json=contentAdvisoryRating,proto3" json:"content_advisory_rating,omitempty"`
+	ArtworkUrl_600         string                 `protobuf:"bytes,25,opt,name=artwork_url_600,json=artworkUrl600,proto3" json:"artwork_url_600,omitempty"`
+	GenreIds               []string               `protobuf:"bytes,26,rep,name=genre_ids,json=genreIds,proto3" json:"genre_ids,omitempty"`
+	Genres                 []string               `protobuf:"bytes,27,rep,name=genres,proto3" json:"genres,omitempty"`
+	// source is the name of the provider the media was found by, e.g. itunes.
+	Source string `protobuf:"bytes,28,opt,name=source,proto3" json:"source,omitempty"`
+	// source_id is the id of the media at its source when it isn't numeric, e.g. a MusicBrainz id.
+	SourceId string `protobuf:"bytes,29,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Isrc     string `protobuf:"bytes,30,opt,name=isrc,proto3" json:"isrc,omitempty"`
+	Upc      string `protobuf:"bytes,31,opt,name=upc,proto3" json:"upc,omitempty"`
+	// software is only set on apps.
+	Software *Software `protobuf:"bytes,32,opt,name=software,proto3" json:"software,omitempty"`
+	// video is only set on movies, tv seasons and tv episodes.
+	Video         *Video `protobuf:"bytes,33,opt,name=video,proto3" json:"video,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Media) Reset() {
+	*x = Media{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Media) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Media) ProtoMessage() {}
+
+func (x *Media) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Media.ProtoReflect.Descriptor instead.
+func (*Media) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Media) GetWrapperType() string {
+	if x != nil {
+		return x.WrapperType
+	}
+	return ""
+}
+
+func (x *Media) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Media) GetArtistId() int64 {
+	if x != nil {
+		return x.ArtistId
+	}
+	return 0
+}
+
+func (x *Media) GetCollectionId() int64 {
+	if x != nil {
+		return x.CollectionId
+	}
+	return 0
+}
+
+func (x *Media) GetTrackId() int64 {
+	if x != nil {
+		return x.TrackId
+	}
+	return 0
+}
+
+func (x *Media) GetArtistName() string {
+	if x != nil {
+		return x.ArtistName
+	}
+	return ""
+}
+
+func (x *Media) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *Media) GetTrackName() string {
+	if x != nil {
+		return x.TrackName
+	}
+	return ""
+}
+
+func (x *Media) GetArtistViewUrl() string {
+	if x != nil {
+		return x.ArtistViewUrl
+	}
+	return ""
+}
+
+func (x *Media) GetCollectionViewUrl() string {
+	if x != nil {
+		return x.CollectionViewUrl
+	}
+	return ""
+}
+
+func (x *Media) GetFeedUrl() string {
+	if x != nil {
+		return x.FeedUrl
+	}
+	return ""
+}
+
+func (x *Media) GetTrackViewUrl() string {
+	if x != nil {
+		return x.TrackViewUrl
+	}
+	return ""
+}
+
+func (x *Media) GetArtworkUrl_30() string {
+	if x != nil {
+		return x.ArtworkUrl_30
+	}
+	return ""
+}
+
+func (x *Media) GetArtworkUrl_60() string {
+	if x != nil {
+		return x.ArtworkUrl_60
+	}
+	return ""
+}
+
+func (x *Media) GetArtworkUrl_100() string {
+	if x != nil {
+		return x.ArtworkUrl_100
+	}
+	return ""
+}
+
+func (x *Media) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Media) GetCollectionExplicitness() string {
+	if x != nil {
+		return x.CollectionExplicitness
+	}
+	return ""
+}
+
+func (x *Media) GetTrackExplicitness() string {
+	if x != nil {
+		return x.TrackExplicitness
+	}
+	return ""
+}
+
+func (x *Media) GetTrackCount() int32 {
+	if x != nil {
+		return x.TrackCount
+	}
+	return 0
+}
+
+func (x *Media) GetTrackTimeMillis() int64 {
+	if x != nil {
+		return x.TrackTimeMillis
+	}
+	return 0
+}
+
+func (x *Media) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Media) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Media) GetPrimaryGenreName() string {
+	if x != nil {
+		return x.PrimaryGenreName
+	}
+	return ""
+}
+
+func (x *Media) GetContentAdvisoryRating() string {
+	if x != nil {
+		return x.ContentAdvisoryRating
+	}
+	return ""
+}
+
+func (x *Media) GetArtworkUrl_600() string {
+	if x != nil {
+		return x.ArtworkUrl_600
+	}
+	return ""
+}
+
+func (x *Media) GetGenreIds() []string {
+	if x != nil {
+		return x.GenreIds
+	}
+	return nil
+}
+
+func (x *Media) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *Media) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Media) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Media) GetIsrc() string {
+	if x != nil {
+		return x.Isrc
+	}
+	return ""
+}
+
+func (x *Media) GetUpc() string {
+	if x != nil {
+		return x.Upc
+	}
+	return ""
+}
+
+func (x *Media) GetSoftware() *Software {
+	if x != nil {
+		return x.Software
+	}
+	return nil
+}
+
+func (x *Media) GetVideo() *Video {
+	if x != nil {
+		return x.Video
+	}
+	return nil
+}
+
+// Software represents the App Store details of an app.
+type Software struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BundleId          string                 `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	AverageUserRating float64                `protobuf:"fixed64,2,opt,name=average_user_rating,json=averageUserRating,proto3" json:"average_user_rating,omitempty"`
+	UserRatingCount   int64                  `protobuf:"varint,3,opt,name=user_rating_count,json=userRatingCount,proto3" json:"user_rating_count,omitempty"`
+	ScreenshotUrls    []string               `protobuf:"bytes,4,rep,name=screenshot_urls,json=screenshotUrls,proto3" json:"screenshot_urls,omitempty"`
+	SupportedDevices  []string               `protobuf:"bytes,5,rep,name=supported_devices,json=supportedDevices,proto3" json:"supported_devices,omitempty"`
+	FileSizeBytes     int64                  `protobuf:"varint,6,opt,name=file_size_bytes,json=fileSizeBytes,proto3" json:"file_size_bytes,omitempty"`
+	MinimumOsVersion  string                 `protobuf:"bytes,7,opt,name=minimum_os_version,json=minimumOsVersion,proto3" json:"minimum_os_version,omitempty"`
+	SellerName        string                 `protobuf:"bytes,8,opt,name=seller_name,json=sellerName,proto3" json:"seller_name,omitempty"`
+	Price             float64                `protobuf:"fixed64,9,opt,name=price,proto3" json:"price,omitempty"`
+	FormattedPrice    string                 `protobuf:"bytes,10,opt,name=formatted_price,json=formattedPrice,proto3" json:"formatted_price,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Software) Reset() {
+	*x = Software{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Software) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Software) ProtoMessage() {}
+
+func (x *Software) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Software.ProtoReflect.Descriptor instead.
+func (*Software) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Software) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+func (x *Software) GetAverageUserRating() float64 {
+	if x != nil {
+		return x.AverageUserRating
+	}
+	return 0
+}
+
+func (x *Software) GetUserRatingCount() int64 {
+	if x != nil {
+		return x.UserRatingCount
+	}
+	return 0
+}
+
+func (x *Software) GetScreenshotUrls() []string {
+	if x != nil {
+		return x.ScreenshotUrls
+	}
+	return nil
+}
+
+func (x *Software) GetSupportedDevices() []string {
+	if x != nil {
+		return x.SupportedDevices
+	}
+	return nil
+}
+
+func (x *Software) GetFileSizeBytes() int64 {
+	if x != nil {
+		return x.FileSizeBytes
+	}
+	return 0
+}
+
+func (x *Software) GetMinimumOsVersion() string {
+	if x != nil {
+		return x.MinimumOsVersion
+	}
+	return ""
+}
+
+func (x *Software) GetSellerName() string {
+	if x != nil {
+		return x.SellerName
+	}
+	return ""
+}
+
+func (x *Software) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Software) GetFormattedPrice() string {
+	if x != nil {
+		return x.FormattedPrice
+	}
+	return ""
+}
+
+// Video represents the details of a movie, a tv season or a tv episode.
+type Video struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	LongDescription    string                 `protobuf:"bytes,1,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`
+	ShortDescription   string                 `protobuf:"bytes,2,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	TrackPrice         float64                `protobuf:"fixed64,3,opt,name=track_price,json=trackPrice,proto3" json:"track_price,omitempty"`
+	TrackRentalPrice   float64                `protobuf:"fixed64,4,opt,name=track_rental_price,json=trackRentalPrice,proto3" json:"track_rental_price,omitempty"`
+	TrackHdPrice       float64                `protobuf:"fixed64,5,opt,name=track_hd_price,json=trackHdPrice,proto3" json:"track_hd_price,omitempty"`
+	TrackHdRentalPrice float64                `protobuf:"fixed64,6,opt,name=track_hd_rental_price,json=trackHdRentalPrice,proto3" json:"track_hd_rental_price,omitempty"`
+	CollectionPrice    float64                `protobuf:"fixed64,7,opt,name=collection_price,json=collectionPrice,proto3" json:"collection_price,omitempty"`
+	CollectionHdPrice  float64                `protobuf:"fixed64,8,opt,name=collection_hd_price,json=collectionHdPrice,proto3" json:"collection_hd_price,omitempty"`
+	SeasonNumber       int32                  `protobuf:"varint,9,opt,name=season_number,json=seasonNumber,proto3" json:"season_number,omitempty"`
+	EpisodeNumber      int32                  `protobuf:"varint,10,opt,name=episode_number,json=episodeNumber,proto3" json:"episode_number,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Video) Reset() {
+	*x = Video{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Video) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Video) ProtoMessage() {}
+
+func (x *Video) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Video.ProtoReflect.Descriptor instead.
+func (*Video) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Video) GetLongDescription() string {
+	if x != nil {
+		return x.LongDescription
+	}
+	return ""
+}
+
+func (x *Video) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *Video) GetTrackPrice() float64 {
+	if x != nil {
+		return x.TrackPrice
+	}
+	return 0
+}
+
+func (x *Video) GetTrackRentalPrice() float64 {
+	if x != nil {
+		return x.TrackRentalPrice
+	}
+	return 0
+}
+
+func (x *Video) GetTrackHdPrice() float64 {
+	if x != nil {
+		return x.TrackHdPrice
+	}
+	return 0
+}
+
+func (x *Video) GetTrackHdRentalPrice() float64 {
+	if x != nil {
+		return x.TrackHdRentalPrice
+	}
+	return 0
+}
+
+func (x *Video) GetCollectionPrice() float64 {
+	if x != nil {
+		return x.CollectionPrice
+	}
+	return 0
+}
+
+func (x *Video) GetCollectionHdPrice() float64 {
+	if x != nil {
+		return x.CollectionHdPrice
+	}
+	return 0
+}
+
+func (x *Video) GetSeasonNumber() int32 {
+	if x != nil {
+		return x.SeasonNumber
+	}
+	return 0
+}
+
+func (x *Video) GetEpisodeNumber() int32 {
+	if x != nil {
+		return x.EpisodeNumber
+	}
+	return 0
+}
+
+type SearchMediaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// term is the search term, it is required.
+	Term string `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	// limit is the number of results to return, it defaults to 20.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMediaRequest) Reset() {
+	*x = SearchMediaRequest{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMediaRequest) ProtoMessage() {}
+
+func (x *SearchMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMediaRequest.ProtoReflect.Descriptor instead.
+func (*SearchMediaRequest) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchMediaRequest) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *SearchMediaRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchMediaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the id of the stored search, 0 when it couldn't be stored.
+	Id            int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SearchTerm    string   `protobuf:"bytes,2,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	ResultCount   int32    `protobuf:"varint,3,opt,name=result_count,json=resultCount,proto3" json:"result_count,omitempty"`
+	Media         []*Media `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMediaResponse) Reset() {
+	*x = SearchMediaResponse{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMediaResponse) ProtoMessage() {}
+
+func (x *SearchMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMediaResponse.ProtoReflect.Descriptor instead.
+func (*SearchMediaResponse) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchMediaResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SearchMediaResponse) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+func (x *SearchMediaResponse) GetResultCount() int32 {
+	if x != nil {
+		return x.ResultCount
+	}
+	return 0
+}
+
+func (x *SearchMediaResponse) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type LookupMediaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the iTunes id of a track, collection or artist.
+	Id            int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupMediaRequest) Reset() {
+	*x = LookupMediaRequest{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupMediaRequest) ProtoMessage() {}
+
+func (x *LookupMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupMediaRequest.ProtoReflect.Descriptor instead.
+func (*LookupMediaRequest) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LookupMediaRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type LookupMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResultCount   int32                  `protobuf:"varint,1,opt,name=result_count,json=resultCount,proto3" json:"result_count,omitempty"`
+	Media         []*Media               `protobuf:"bytes,2,rep,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupMediaResponse) Reset() {
+	*x = LookupMediaResponse{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupMediaResponse) ProtoMessage() {}
+
+func (x *LookupMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupMediaResponse.ProtoReflect.Descriptor instead.
+func (*LookupMediaResponse) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LookupMediaResponse) GetResultCount() int32 {
+	if x != nil {
+		return x.ResultCount
+	}
+	return 0
+}
+
+func (x *LookupMediaResponse) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type ListSearchHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// term only lists searches of this term, matched case-insensitively.
+	Term string `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	// since only lists searches stored at or after this time.
+	Since *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// until only lists searches stored before this time.
+	Until *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	// limit is the number of searches to return, it defaults to 20 and is capped at 100.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// offset is the number of searches to skip.
+	Offset        int32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSearchHistoryRequest) Reset() {
+	*x = ListSearchHistoryRequest{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSearchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSearchHistoryRequest) ProtoMessage() {}
+
+func (x *ListSearchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSearchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListSearchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSearchHistoryRequest) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *ListSearchHistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListSearchHistoryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListSearchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSearchHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// HistoryEntry is a stored search.
+type HistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SearchTerm    string                 `protobuf:"bytes,2,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	ResultCount   int32                  `protobuf:"varint,3,opt,name=result_count,json=resultCount,proto3" json:"result_count,omitempty"`
+	Media         []*Media               `protobuf:"bytes,4,rep,name=media,proto3" json:"media,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HistoryEntry) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetResultCount() int32 {
+	if x != nil {
+		return x.ResultCount
+	}
+	return 0
+}
+
+func (x *HistoryEntry) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListSearchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Searches      []*HistoryEntry        `protobuf:"bytes,1,rep,name=searches,proto3" json:"searches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSearchHistoryResponse) Reset() {
+	*x = ListSearchHistoryResponse{}
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSearchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSearchHistoryResponse) ProtoMessage() {}
+
+func (x *ListSearchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mediascout_v1_media_scout_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSearchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListSearchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_mediascout_v1_media_scout_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSearchHistoryResponse) GetSearches() []*HistoryEntry {
+	if x != nil {
+		return x.Searches
+	}
+	return nil
+}
+
+var File_mediascout_v1_media_scout_proto protoreflect.FileDescriptor
+
+var file_mediascout_v1_media_scout_proto_rawDesc = string([]byte{
+	0x0a, 0x1f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2f, 0x76, 0x31, 0x2f,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x9e, 0x09, 0x0a, 0x05, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x77,
+	0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x72, 0x74, 0x69,
+	0x73, 0x74, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x55, 0x72, 0x6c,
+	0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76,
+	0x69, 0x65, 0x77, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x69, 0x65, 0x77, 0x55, 0x72, 0x6c,
+	0x12, 0x19, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x66, 0x65, 0x65, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x56, 0x69, 0x65, 0x77, 0x55, 0x72,
+	0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x72, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x75, 0x72, 0x6c,
+	0x5f, 0x33, 0x30, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x55, 0x72, 0x6c, 0x33, 0x30, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x72, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x36, 0x30, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x61, 0x72, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55, 0x72, 0x6c, 0x36, 0x30, 0x12, 0x26, 0x0a,
+	0x0f, 0x61, 0x72, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x31, 0x30, 0x30,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x72, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55,
+	0x72, 0x6c, 0x31, 0x30, 0x30, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x37, 0x0a, 0x17, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x6e,
+	0x65, 0x73, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x6e, 0x65, 0x73,
+	0x73, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x65, 0x78, 0x70, 0x6c, 0x69,
+	0x63, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x45, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x72,
+	0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x67,
+	0x65, 0x6e, 0x72, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x47, 0x65, 0x6e, 0x72, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x36, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x64, 0x76,
+	0x69, 0x73, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x41, 0x64, 0x76, 0x69, 0x73,
+	0x6f, 0x72, 0x79, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x72, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x36, 0x30, 0x30, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x61, 0x72, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x55, 0x72, 0x6c, 0x36, 0x30,
+	0x30, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x1a,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x49, 0x64, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x67, 0x65, 0x6e, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x1d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69,
+	0x73, 0x72, 0x63, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x73, 0x72, 0x63, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x70, 0x63, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x70,
+	0x63, 0x12, 0x33, 0x0a, 0x08, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x52, 0x08, 0x73, 0x6f,
+	0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x18,
+	0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f,
+	0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x52, 0x05, 0x76, 0x69, 0x64,
+	0x65, 0x6f, 0x22, 0x8f, 0x03, 0x0a, 0x08, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13,
+	0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x61, 0x76, 0x65, 0x72, 0x61,
+	0x67, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x11,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x52, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x63, 0x72, 0x65,
+	0x65, 0x6e, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x73, 0x68, 0x6f, 0x74, 0x55, 0x72, 0x6c,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75,
+	0x6d, 0x5f, 0x6f, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x4f, 0x73, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x6c, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x22, 0xae, 0x03, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x29,
+	0x0a, 0x10, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x6e, 0x67, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x5f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x10, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x68,
+	0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x48, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x5f, 0x68, 0x64, 0x5f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x74, 0x72, 0x61, 0x63,
+	0x6b, 0x48, 0x64, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25,
+	0x0a, 0x0e, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x65, 0x70, 0x69, 0x73, 0x6f, 0x64, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3e, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d,
+	0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x22, 0x24, 0x0a,
+	0x12, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x13, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a,
+	0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x22, 0xc0, 0x01, 0x0a, 0x18, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x05,
+	0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0xc9, 0x01, 0x0a,
+	0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x54, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73,
+	0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x65, 0x73, 0x32, 0xa7,
+	0x02, 0x0a, 0x11, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x63, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65,
+	0x64, 0x69, 0x61, 0x12, 0x21, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63,
+	0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0b, 0x4c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x21, 0x2e, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x6f,
+	0x6b, 0x75, 0x70, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x66, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x27, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f,
+	0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x4b, 0x5a, 0x49, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e, 0x61, 0x77, 0x61, 0x66, 0x53, 0x77, 0x65, 0x2f,
+	0x6d, 0x65, 0x64, 0x69, 0x61, 0x2d, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x2d, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x73, 0x63, 0x6f, 0x75, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x73, 0x63,
+	0x6f, 0x75, 0x74, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_mediascout_v1_media_scout_proto_rawDescOnce sync.Once
+	file_mediascout_v1_media_scout_proto_rawDescData []byte
+)
+
+func file_mediascout_v1_media_scout_proto_rawDescGZIP() []byte {
+	file_mediascout_v1_media_scout_proto_rawDescOnce.Do(func() {
+		file_mediascout_v1_media_scout_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mediascout_v1_media_scout_proto_rawDesc), len(file_mediascout_v1_media_scout_proto_rawDesc)))
+	})
+	return file_mediascout_v1_media_scout_proto_rawDescData
+}
+
+var file_mediascout_v1_media_scout_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_mediascout_v1_media_scout_proto_goTypes = []any{
+	(*Media)(nil),                     // 0: mediascout.v1.Media
+	(*Software)(nil),                  // 1: mediascout.v1.Software
+	(*Video)(nil),                     // 2: mediascout.v1.Video
+	(*SearchMediaRequest)(nil),        // 3: mediascout.v1.SearchMediaRequest
+	(*SearchMediaResponse)(nil),       // 4: mediascout.v1.SearchMediaResponse
+	(*LookupMediaRequest)(nil),        // 5: mediascout.v1.LookupMediaRequest
+	(*LookupMediaResponse)(nil),       // 6: mediascout.v1.LookupMediaResponse
+	(*ListSearchHistoryRequest)(nil),  // 7: mediascout.v1.ListSearchHistoryRequest
+	(*HistoryEntry)(nil),              // 8: mediascout.v1.HistoryEntry
+	(*ListSearchHistoryResponse)(nil), // 9: mediascout.v1.ListSearchHistoryResponse
+	(*timestamppb.Timestamp)(nil),     // 10: google.protobuf.Timestamp
+}
+var file_mediascout_v1_media_scout_proto_depIdxs = []int32{
+	1,  // 0: mediascout.v1.Media.software:type_name -> mediascout.v1.Software
+	2,  // 1: mediascout.v1.Media.video:type_name -> mediascout.v1.Video
+	0,  // 2: mediascout.v1.SearchMediaResponse.media:type_name -> mediascout.v1.Media
+	0,  // 3: mediascout.v1.LookupMediaResponse.media:type_name -> mediascout.v1.Media
+	10, // 4: mediascout.v1.ListSearchHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	10, // 5: mediascout.v1.ListSearchHistoryRequest.until:type_name -> google.protobuf.Timestamp
+	0,  // 6: mediascout.v1.HistoryEntry.media:type_name -> mediascout.v1.Media
+	10, // 7: mediascout.v1.HistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: mediascout.v1.ListSearchHistoryResponse.searches:type_name -> mediascout.v1.HistoryEntry
+	3,  // 9: mediascout.v1.MediaScoutService.SearchMedia:input_type -> mediascout.v1.SearchMediaRequest
+	5,  // 10: mediascout.v1.MediaScoutService.LookupMedia:input_type -> mediascout.v1.LookupMediaRequest
+	7,  // 11: mediascout.v1.MediaScoutService.ListSearchHistory:input_type -> mediascout.v1.ListSearchHistoryRequest
+	4,  // 12: mediascout.v1.MediaScoutService.SearchMedia:output_type -> mediascout.v1.SearchMediaResponse
+	6,  // 13: mediascout.v1.MediaScoutService.LookupMedia:output_type -> mediascout.v1.LookupMediaResponse
+	9,  // 14: mediascout.v1.MediaScoutService.ListSearchHistory:output_type -> mediascout.v1.ListSearchHistoryResponse
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_mediascout_v1_media_scout_proto_init() }
+func file_mediascout_v1_media_scout_proto_init() {
+	if File_mediascout_v1_media_scout_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mediascout_v1_media_scout_proto_rawDesc), len(file_mediascout_v1_media_scout_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mediascout_v1_media_scout_proto_goTypes,
+		DependencyIndexes: file_mediascout_v1_media_scout_proto_depIdxs,
+		MessageInfos:      file_mediascout_v1_media_scout_proto_msgTypes,
+	}.Build()
+	File_mediascout_v1_media_scout_proto = out.File
+	file_mediascout_v1_media_scout_proto_goTypes = nil
+	file_mediascout_v1_media_scout_proto_depIdxs = nil
+}
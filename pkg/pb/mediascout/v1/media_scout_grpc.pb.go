@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: mediascout/v1/media_scout.proto
+
+package mediascoutv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MediaScoutService_SearchMedia_FullMethodName       = "/mediascout.v1.MediaScoutService/SearchMedia"
+	MediaScoutService_LookupMedia_FullMethodName       = "/mediascout.v1.MediaScoutService/LookupMedia"
+	MediaScoutService_ListSearchHistory_FullMethodName = "/mediascout.v1.MediaScoutService/ListSearchHistory"
+)
+
+// MediaScoutServiceClient is the client API for MediaScoutService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MediaScoutService searches media through the iTunes API and keeps a history of every search.
+type MediaScoutServiceClient interface {
+	// SearchMedia searches media by term and stores the result.
+	SearchMedia(ctx context.Context, in *SearchMediaRequest, opts ...grpc.CallOption) (*SearchMediaResponse, error)
+	// LookupMedia looks media up by its iTunes id, collections and artists are returned along with their tracks.
+	LookupMedia(ctx context.Context, in *LookupMediaRequest, opts ...grpc.CallOption) (*LookupMediaResponse, error)
+	// ListSearchHistory lists the stored searches, most recent first.
+	ListSearchHistory(ctx context.Context, in *ListSearchHistoryRequest, opts ...grpc.CallOption) (*ListSearchHistoryResponse, error)
+}
+
+type mediaScoutServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMediaScoutServiceClient(cc grpc.ClientConnInterface) MediaScoutServiceClient {
+	return &mediaScoutServiceClient{cc}
+}
+
+func (c *mediaScoutServiceClient) SearchMedia(ctx context.Context, in *SearchMediaRequest, opts ...grpc.CallOption) (*SearchMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMediaResponse)
+	err := c.cc.Invoke(ctx, MediaScoutService_SearchMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaScoutServiceClient) LookupMedia(ctx context.Context, in *LookupMediaRequest, opts ...grpc.CallOption) (*LookupMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupMediaResponse)
+	err := c.cc.Invoke(ctx, MediaScoutService_LookupMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaScoutServiceClient) ListSearchHistory(ctx context.Context, in *ListSearchHistoryRequest, opts ...grpc.CallOption) (*ListSearchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSearchHistoryResponse)
+	err := c.cc.Invoke(ctx, MediaScoutService_ListSearchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MediaScoutServiceServer is the server API for MediaScoutService service.
+// All implementations must embed UnimplementedMediaScoutServiceServer
+// for forward compatibility.
+//
+// MediaScoutService searches media through the iTunes API and keeps a history of every search.
+type MediaScoutServiceServer interface {
+	// SearchMedia searches media by term and stores the result.
+	SearchMedia(context.Context, *SearchMediaRequest) (*SearchMediaResponse, error)
+	// LookupMedia looks media up by its iTunes id, collections and artists are returned along with their tracks.
+	LookupMedia(context.Context, *LookupMediaRequest) (*LookupMediaResponse, error)
+	// ListSearchHistory lists the stored searches, most recent first.
+	ListSearchHistory(context.Context, *ListSearchHistoryRequest) (*ListSearchHistoryResponse, error)
+	mustEmbedUnimplementedMediaScoutServiceServer()
+}
+
+// UnimplementedMediaScoutServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMediaScoutServiceServer struct{}
+
+func (UnimplementedMediaScoutServiceServer) SearchMedia(context.Context, *SearchMediaRequest) (*SearchMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMedia not implemented")
+}
+func (UnimplementedMediaScoutServiceServer) LookupMedia(context.Context, *LookupMediaRequest) (*LookupMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupMedia not implemented")
+}
+func (UnimplementedMediaScoutServiceServer) ListSearchHistory(context.Context, *ListSearchHistoryRequest) (*ListSearchHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSearchHistory not implemented")
+}
+func (UnimplementedMediaScoutServiceServer) mustEmbedUnimplementedMediaScoutServiceServer() {}
+func (UnimplementedMediaScoutServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeMediaScoutServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MediaScoutServiceServer will
+// result in compilation errors.
+type UnsafeMediaScoutServiceServer interface {
+	mustEmbedUnimplementedMediaScoutServiceServer()
+}
+
+func RegisterMediaScoutServiceServer(s grpc.ServiceRegistrar, srv MediaScoutServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMediaScoutServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MediaScoutService_ServiceDesc, srv)
+}
+
+func _MediaScoutService_SearchMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaScoutServiceServer).SearchMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaScoutService_SearchMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaScoutServiceServer).SearchMedia(ctx, req.(*SearchMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaScoutService_LookupMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaScoutServiceServer).LookupMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaScoutService_LookupMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaScoutServiceServer).LookupMedia(ctx, req.(*LookupMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaScoutService_ListSearchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSearchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaScoutServiceServer).ListSearchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaScoutService_ListSearchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaScoutServiceServer).ListSearchHistory(ctx, req.(*ListSearchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MediaScoutService_ServiceDesc is the grpc.ServiceDesc for MediaScoutService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MediaScoutService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mediascout.v1.MediaScoutService",
+	HandlerType: (*MediaScoutServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchMedia",
+			Handler:    _MediaScoutService_SearchMedia_Handler,
+		},
+		{
+			MethodName: "LookupMedia",
+			Handler:    _MediaScoutService_LookupMedia_Handler,
+		},
+		{
+			MethodName: "ListSearchHistory",
+			Handler:    _MediaScoutService_ListSearchHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mediascout/v1/media_scout.proto",
+}
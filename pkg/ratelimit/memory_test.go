@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	rule := Rule{Rate: 2, Burst: 3}
+
+	steps := []struct {
+		name     string
+		advance  time.Duration
+		key      string
+		expected Result
+	}{
+		{name: "new bucket starts full", key: "a", expected: Result{Allowed: true, Remaining: 2}},
+		{name: "burst is consumed", key: "a", expected: Result{Allowed: true, Remaining: 1}},
+		{name: "last token of the burst", key: "a", expected: Result{Allowed: true, Remaining: 0}},
+		{name: "empty bucket rejects", key: "a", expected: Result{Allowed: false, Remaining: 0, RetryAfter: 500 * time.Millisecond}},
+		{name: "other keys have their own bucket", key: "b", expected: Result{Allowed: true, Remaining: 2}},
+		{name: "partial refill still rejects", advance: 250 * time.Millisecond, key: "a", expected: Result{Allowed: false, Remaining: 0, RetryAfter: 250 * time.Millisecond}},
+		{name: "refilled token is taken", advance: 250 * time.Millisecond, key: "a", expected: Result{Allowed: true, Remaining: 0}},
+		{name: "refill is capped to the burst", advance: time.Hour, key: "a", expected: Result{Allowed: true, Remaining: 2}},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		res, err := store.Take(context.Background(), step.key, rule)
+		assert.NoError(t, err, step.name)
+		assert.Equal(t, step.expected, res, step.name)
+	}
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	_, _ = store.Take(context.Background(), "idle", Rule{Rate: 1, Burst: 5})
+	_, _ = store.Take(context.Background(), "slow", Rule{Rate: 0.001, Burst: 5})
+	now = now.Add(sweepInterval)
+	_, _ = store.Take(context.Background(), "new", Rule{Rate: 1, Burst: 5})
+
+	assert.NotContains(t, store.buckets, "idle", "refilled buckets are dropped")
+	assert.Contains(t, store.buckets, "slow")
+	assert.Contains(t, store.buckets, "new")
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresStore keeps buckets in postgres so every replica shares them.
+// Buckets are refilled with the database clock, replica clock skew doesn't matter.
+type PostgresStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresStore creates a store keeping buckets in the rate_limit_bucket table.
+func NewPostgresStore(db *sqlx.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take atomically refills the bucket of key and takes a token from it.
+func (s *PostgresStore) Take(ctx context.Context, key string, rule Rule) (Result, error) {
+	// every SET expression reads the bucket as it was before the update.
+	query := `
+		INSERT INTO rate_limit_bucket AS b (bucket_key, tokens, allowed, updated_at)
+		VALUES ($1, $2::DOUBLE PRECISION - 1, TRUE, now())
+		ON CONFLICT (bucket_key) DO UPDATE SET
+			tokens = LEAST($2::DOUBLE PRECISION, b.tokens + GREATEST(EXTRACT(EPOCH FROM now() - b.updated_at)::DOUBLE PRECISION, 0) * $3::DOUBLE PRECISION)
+				- CASE WHEN LEAST($2::DOUBLE PRECISION, b.tokens + GREATEST(EXTRACT(EPOCH FROM now() - b.updated_at)::DOUBLE PRECISION, 0) * $3::DOUBLE PRECISION) >= 1 THEN 1 ELSE 0 END,
+			allowed = LEAST($2::DOUBLE PRECISION, b.tokens + GREATEST(EXTRACT(EPOCH FROM now() - b.updated_at)::DOUBLE PRECISION, 0) * $3::DOUBLE PRECISION) >= 1,
+			updated_at = now()
+		RETURNING tokens, allowed
+	`
+	var (
+		tokens  float64
+		allowed bool
+	)
+	if err := s.db.QueryRowContext(ctx, query, key, rule.Burst, rule.Rate).Scan(&tokens, &allowed); err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token in db: %w", err)
+	}
+	return result(allowed, tokens, rule), nil
+}
+
+// Prune deletes the buckets untouched for longer than idle, idle must exceed the time a bucket takes to refill
+// so only buckets indistinguishable from new ones are deleted.
+func (s *PostgresStore) Prune(ctx context.Context, idle time.Duration) (int64, error) {
+	query := `DELETE FROM rate_limit_bucket WHERE updated_at < now() - make_interval(secs => $1)`
+	res, err := s.db.ExecContext(ctx, query, idle.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune rate limit buckets in db: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune rate limit buckets in db: %w", err)
+	}
+	return deleted, nil
+}
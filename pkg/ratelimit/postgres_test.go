@@ -0,0 +1,87 @@
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresStore_Take(t *testing.T) {
+	rule := ratelimit.Rule{Rate: 4, Burst: 10}
+	tests := []struct {
+		name           string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedError  string
+		expectedResult ratelimit.Result
+	}{
+		{
+			name: "allowed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO rate_limit_bucket").
+					WithArgs("search.media:ip:10.0.0.1", 10, 4.0).
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "allowed"}).AddRow(6.5, true))
+			},
+			expectedResult: ratelimit.Result{Allowed: true, Remaining: 6},
+		},
+		{
+			name: "limited",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO rate_limit_bucket").
+					WithArgs("search.media:ip:10.0.0.1", 10, 4.0).
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "allowed"}).AddRow(0.5, false))
+			},
+			expectedResult: ratelimit.Result{Allowed: false, RetryAfter: 125 * time.Millisecond},
+		},
+		{
+			name: "query error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO rate_limit_bucket").
+					WithArgs("search.media:ip:10.0.0.1", 10, 4.0).
+					WillReturnError(fmt.Errorf("connection refused"))
+			},
+			expectedError: "failed to take rate limit token in db: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockSetup(mock)
+
+			store := ratelimit.NewPostgresStore(sqlx.NewDb(db, "sqlmock"))
+			res, err := store.Take(context.Background(), "search.media:ip:10.0.0.1", rule)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStore_Prune(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM rate_limit_bucket WHERE updated_at").
+		WithArgs(3600.0).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	deleted, err := ratelimit.NewPostgresStore(sqlx.NewDb(db, "sqlmock")).Prune(context.Background(), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,154 @@
+// Package ratelimit implements token bucket rate limiting backed by an in-memory or a shared store.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+const meterName = "github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+
+// ErrLimited is returned when a client used up its bucket.
+var ErrLimited = errors.New("rate limited")
+
+// LimitError is returned when a client used up its bucket, it tells when the next request will be accepted.
+type LimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return ErrLimited.Error()
+}
+
+// Is reports whether target is ErrLimited.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimited
+}
+
+// Rule is a token bucket refilled with Rate tokens per second up to Burst tokens, each request takes a token.
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseRule parses a rule written as rate:burst, e.g. 5:10 allows bursts of 10 requests refilled at 5 per second.
+func ParseRule(s string) (Rule, error) {
+	rawRate, rawBurst, ok := strings.Cut(s, ":")
+	if !ok {
+		return Rule{}, fmt.Errorf("invalid rule %q, expected rate:burst", s)
+	}
+	rate, err := strconv.ParseFloat(rawRate, 64)
+	if err != nil || rate <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate in rule %q", s)
+	}
+	burst, err := strconv.Atoi(rawBurst)
+	if err != nil || burst < 1 {
+		return Rule{}, fmt.Errorf("invalid burst in rule %q", s)
+	}
+	return Rule{Rate: rate, Burst: burst}, nil
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// RetryAfter is how long until the next token is available, it is zero when the request is allowed.
+	RetryAfter time.Duration
+}
+
+// Store holds the buckets of every client.
+type Store interface {
+	// Take takes a token from the bucket of key, creating a full bucket when there is none.
+	Take(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// refill returns the tokens of a bucket holding tokens after elapsed time, capped to the burst of rule.
+func refill(tokens float64, elapsed time.Duration, rule Rule) float64 {
+	return math.Min(float64(rule.Burst), tokens+max(elapsed.Seconds(), 0)*rule.Rate)
+}
+
+// result builds the result of a take given the tokens left in the bucket.
+func result(allowed bool, tokens float64, rule Rule) Result {
+	res := Result{Allowed: allowed, Remaining: int(math.Floor(tokens))}
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) / rule.Rate * float64(time.Second))
+	}
+	return res
+}
+
+// Limiter takes tokens from a store on behalf of clients.
+type Limiter struct {
+	store    Store
+	lgr      logging.Logger
+	failOpen otelmetric.Int64Counter
+}
+
+// Option configures a Limiter.
+type Option func(l *Limiter)
+
+// WithMeter counts the requests allowed because the store failed with the instruments of meter.
+func WithMeter(meter *metric.MeterProvider) Option {
+	return func(l *Limiter) {
+		if meter == nil {
+			return
+		}
+		l.failOpen, _ = meter.Meter(meterName).Int64Counter("ratelimit.fail_open",
+			otelmetric.WithDescription("Number of requests allowed because the rate limit store failed."))
+	}
+}
+
+// NewLimiter creates a limiter taking tokens from store.
+func NewLimiter(store Store, lgr logging.Logger, opts ...Option) *Limiter {
+	l := &Limiter{store: store, lgr: lgr}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.failOpen == nil {
+		l.failOpen, _ = noop.NewMeterProvider().Meter(meterName).Int64Counter("ratelimit.fail_open")
+	}
+	return l
+}
+
+// Allow takes a token from the bucket of key, a *LimitError is returned when the bucket is empty.
+// Requests are allowed when the store fails so an unavailable shared store doesn't take the service down.
+func (l *Limiter) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	res, err := l.store.Take(ctx, key, rule)
+	if err != nil {
+		l.lgr.WarnContext(ctx, "failed to take rate limit token, allowing request", "key", key, "error", err.Error())
+		l.failOpen.Add(ctx, 1)
+		return Result{Allowed: true, Remaining: rule.Burst}, nil
+	}
+	if !res.Allowed {
+		return res, &LimitError{RetryAfter: res.RetryAfter}
+	}
+	return res, nil
+}
+
+// Wait takes a token from the bucket of key, waiting for the next one when the bucket is empty.
+// The error of ctx is returned when it is done first.
+func (l *Limiter) Wait(ctx context.Context, key string, rule Rule) error {
+	for {
+		res, err := l.Allow(ctx, key, rule)
+		if err == nil {
+			return nil
+		}
+		timer := time.NewTimer(res.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	logmock "github.com/NawafSwe/media-scout-service/pkg/logging/mock"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		raw           string
+		expectedRule  ratelimit.Rule
+		expectedError bool
+	}{
+		{raw: "5:10", expectedRule: ratelimit.Rule{Rate: 5, Burst: 10}},
+		{raw: "0.5:1", expectedRule: ratelimit.Rule{Rate: 0.5, Burst: 1}},
+		{raw: "5", expectedError: true},
+		{raw: "0:10", expectedError: true},
+		{raw: "5:0", expectedError: true},
+		{raw: "fast:10", expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			rule, err := ratelimit.ParseRule(tt.raw)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRule, rule)
+			}
+		})
+	}
+}
+
+// storeFunc adapts a function to a ratelimit.Store.
+type storeFunc func(ctx context.Context, key string, rule ratelimit.Rule) (ratelimit.Result, error)
+
+func (f storeFunc) Take(ctx context.Context, key string, rule ratelimit.Rule) (ratelimit.Result, error) {
+	return f(ctx, key, rule)
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rule := ratelimit.Rule{Rate: 1, Burst: 5}
+	tests := []struct {
+		name             string
+		store            storeFunc
+		mockSetup        func(lgr *logmock.MockLogger)
+		expectedResult   ratelimit.Result
+		expectedRetry    time.Duration
+		expectedFailOpen int64
+	}{
+		{
+			name: "allowed",
+			store: func(context.Context, string, ratelimit.Rule) (ratelimit.Result, error) {
+				return ratelimit.Result{Allowed: true, Remaining: 4}, nil
+			},
+			mockSetup:      func(*logmock.MockLogger) {},
+			expectedResult: ratelimit.Result{Allowed: true, Remaining: 4},
+		},
+		{
+			name: "limited",
+			store: func(context.Context, string, ratelimit.Rule) (ratelimit.Result, error) {
+				return ratelimit.Result{RetryAfter: time.Second}, nil
+			},
+			mockSetup:      func(*logmock.MockLogger) {},
+			expectedResult: ratelimit.Result{RetryAfter: time.Second},
+			expectedRetry:  time.Second,
+		},
+		{
+			name: "store failure allows the request",
+			store: func(context.Context, string, ratelimit.Rule) (ratelimit.Result, error) {
+				return ratelimit.Result{}, errors.New("connection refused")
+			},
+			mockSetup: func(lgr *logmock.MockLogger) {
+				lgr.EXPECT().WarnContext(gomock.Any(), "failed to take rate limit token, allowing request", gomock.Any())
+			},
+			expectedResult:   ratelimit.Result{Allowed: true, Remaining: 5},
+			expectedFailOpen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lgr := logmock.NewMockLogger(ctrl)
+			tt.mockSetup(lgr)
+
+			reader := metric.NewManualReader()
+
+			res, err := ratelimit.NewLimiter(tt.store, lgr, ratelimit.WithMeter(metric.NewMeterProvider(metric.WithReader(reader)))).
+				Allow(context.Background(), "key", rule)
+
+			assert.Equal(t, tt.expectedResult, res)
+			var rm metricdata.ResourceMetrics
+			assert.NoError(t, reader.Collect(context.Background(), &rm))
+			var failOpen int64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "ratelimit.fail_open" {
+						for _, dp := range sum.DataPoints {
+							failOpen += dp.Value
+						}
+					}
+				}
+			}
+			assert.Equal(t, tt.expectedFailOpen, failOpen)
+			if tt.expectedRetry == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ratelimit.ErrLimited)
+			var limitErr *ratelimit.LimitError
+			assert.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, tt.expectedRetry, limitErr.RetryAfter)
+		})
+	}
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	rule := ratelimit.Rule{Rate: 1, Burst: 1}
+
+	t.Run("waits for the next token", func(t *testing.T) {
+		takes := 0
+		store := storeFunc(func(context.Context, string, ratelimit.Rule) (ratelimit.Result, error) {
+			takes++
+			if takes < 3 {
+				return ratelimit.Result{RetryAfter: time.Millisecond}, nil
+			}
+			return ratelimit.Result{Allowed: true}, nil
+		})
+
+		err := ratelimit.NewLimiter(store, nil).Wait(context.Background(), "key", rule)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, takes)
+	})
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		store := storeFunc(func(context.Context, string, ratelimit.Rule) (ratelimit.Result, error) {
+			return ratelimit.Result{RetryAfter: time.Hour}, nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := ratelimit.NewLimiter(store, nil).Wait(ctx, "key", rule)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
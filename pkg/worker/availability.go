@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	kithttptransport "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+const (
+	defaultAvailabilityMaxCountries = 20
+	defaultAvailabilityConcurrency  = 4
+)
+
+// withAvailabilityDefaults returns cfg with its unset values defaulted.
+func withAvailabilityDefaults(cfg config.Availability) config.Availability {
+	if cfg.MaxCountries <= 0 {
+		cfg.MaxCountries = defaultAvailabilityMaxCountries
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultAvailabilityConcurrency
+	}
+	return cfg
+}
+
+// newAvailabilityPolicy creates the availability policy of the given config, currencies are upper cased.
+func newAvailabilityPolicy(cfg config.Availability) (business.AvailabilityPolicy, error) {
+	rates := make(map[string]float64, len(cfg.ExchangeRates))
+	for _, rawRate := range cfg.ExchangeRates {
+		currency, value, ok := strings.Cut(rawRate, "=")
+		if !ok {
+			return business.AvailabilityPolicy{}, fmt.Errorf("invalid exchange rate %q, expected currency=rate", rawRate)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return business.AvailabilityPolicy{}, fmt.Errorf("invalid exchange rate of %q, it must be a positive number", currency)
+		}
+		rates[strings.ToUpper(strings.TrimSpace(currency))] = rate
+	}
+	if len(rates) > 0 && cfg.BaseCurrency == "" {
+		return business.AvailabilityPolicy{}, fmt.Errorf("exchange rates are set without a base currency")
+	}
+	return business.AvailabilityPolicy{
+		Concurrency:   cfg.Concurrency,
+		BaseCurrency:  strings.ToUpper(cfg.BaseCurrency),
+		ExchangeRates: rates,
+	}, nil
+}
+
+// makeCheckAvailabilityHandler function to return http handler for checking the availability of a media across
+// storefronts.
+func makeCheckAvailabilityHandler(itunesClient *itunes.Client, maxCountries int, policy business.AvailabilityPolicy, lgr logging.Logger, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	handler := business.NewAvailabilityHandler(mediafetcher.NewMediaFetcher(itunesClient), policy, lgr)
+	ep := applyMiddlewares(transport.MakeCheckAvailabilityEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeCheckAvailabilityRequest(maxCountries), kithttptransport.EncodeCheckAvailabilityResponse, opts...)
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAvailabilityPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            config.Availability
+		expectedError  string
+		expectedPolicy business.AvailabilityPolicy
+	}{
+		{
+			name:           "without normalization",
+			cfg:            config.Availability{Concurrency: 4},
+			expectedPolicy: business.AvailabilityPolicy{Concurrency: 4, ExchangeRates: map[string]float64{}},
+		},
+		{
+			name: "with exchange rates",
+			cfg:  config.Availability{Concurrency: 4, BaseCurrency: "usd", ExchangeRates: []string{"gbp=1.27", "SAR = 0.27"}},
+			expectedPolicy: business.AvailabilityPolicy{
+				Concurrency:   4,
+				BaseCurrency:  "USD",
+				ExchangeRates: map[string]float64{"GBP": 1.27, "SAR": 0.27},
+			},
+		},
+		{
+			name:          "malformed exchange rate",
+			cfg:           config.Availability{BaseCurrency: "USD", ExchangeRates: []string{"GBP"}},
+			expectedError: `invalid exchange rate "GBP", expected currency=rate`,
+		},
+		{
+			name:          "negative exchange rate",
+			cfg:           config.Availability{BaseCurrency: "USD", ExchangeRates: []string{"GBP=-1"}},
+			expectedError: `invalid exchange rate of "GBP", it must be a positive number`,
+		},
+		{
+			name:          "exchange rates without base currency",
+			cfg:           config.Availability{ExchangeRates: []string{"GBP=1.27"}},
+			expectedError: "exchange rates are set without a base currency",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newAvailabilityPolicy(tt.cfg)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPolicy, policy)
+		})
+	}
+}
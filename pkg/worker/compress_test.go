@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: ""},
+		{header: "identity", expected: ""},
+		{header: "gzip", expected: "gzip"},
+		{header: "gzip, deflate, br, zstd", expected: "br"},
+		{header: "gzip;q=1.0, br;q=0.5", expected: "gzip"},
+		{header: "zstd, gzip;q=0.9", expected: "zstd"},
+		{header: "br;q=0, *", expected: "zstd"},
+		{header: "*;q=0", expected: ""},
+		{header: "GZIP", expected: "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateEncoding(tt.header))
+		})
+	}
+}
+
+func decode(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+	var r io.Reader
+	switch encoding {
+	case encodingGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		r = gr
+	case encodingBrotli:
+		r = brotli.NewReader(bytes.NewReader(body))
+	case encodingZstd:
+		zr, err := zstd.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	default:
+		return string(body)
+	}
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(raw)
+}
+
+func TestCompress(t *testing.T) {
+	body := strings.Repeat(`{"kind":"song","trackName":"Test Track"}`, 50)
+	var receivedINM string
+	handler := compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedINM = r.Header.Get("If-None-Match")
+		w.Header().Set("ETag", `"abc"`)
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		switch r.URL.Path {
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+		case "/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+		default:
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	tests := []struct {
+		name             string
+		path             string
+		acceptEncoding   string
+		ifNoneMatch      string
+		expectedStatus   int
+		expectedEncoding string
+		expectedETag     string
+		expectedINM      string
+	}{
+		{name: "identity", path: "/", expectedStatus: http.StatusOK, expectedETag: `"abc"`},
+		{name: "gzip", path: "/", acceptEncoding: "gzip", expectedStatus: http.StatusOK, expectedEncoding: "gzip", expectedETag: `"abc-gzip"`},
+		{name: "brotli", path: "/", acceptEncoding: "gzip, br", expectedStatus: http.StatusOK, expectedEncoding: "br", expectedETag: `"abc-br"`},
+		{name: "zstd", path: "/", acceptEncoding: "zstd", expectedStatus: http.StatusOK, expectedEncoding: "zstd", expectedETag: `"abc-zstd"`},
+		{name: "incompressible type", path: "/image", acceptEncoding: "gzip", expectedStatus: http.StatusOK, expectedETag: `"abc"`},
+		{name: "event stream", path: "/events", acceptEncoding: "gzip", expectedStatus: http.StatusOK, expectedETag: `"abc"`},
+		{
+			name:           "conditional request on compressed tag",
+			path:           "/",
+			acceptEncoding: "br",
+			ifNoneMatch:    `"abc-br"`,
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"abc-br"`,
+			expectedINM:    `"abc"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedEncoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.expectedETag, rec.Header().Get("ETag"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, tt.expectedINM, receivedINM)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, body, decode(t, tt.expectedEncoding, rec.Body.Bytes()))
+			} else {
+				assert.Empty(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+)
+
+var (
+	// defaultCORSHeaders are always allowed on top of the configured ones.
+	defaultCORSHeaders = []string{"Content-Type", headerRequestID}
+	// defaultExposedHeaders are always exposed on top of the configured ones.
+	defaultExposedHeaders = []string{headerRequestID, "Traceparent"}
+	// corsMethods are the methods probed against the router to answer preflight requests.
+	corsMethods = []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}
+)
+
+// corsPolicy is the config-driven cross-origin policy, the allowed methods of a path are the methods
+// its routes are registered with.
+type corsPolicy struct {
+	router           *mux.Router
+	anyOrigin        bool
+	origins          map[string]struct{}
+	patterns         []*regexp.Regexp
+	allowedHeaders   string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+}
+
+func newCORSPolicy(cfg config.CORS, router *mux.Router) (*corsPolicy, error) {
+	p := &corsPolicy{
+		router:           router,
+		origins:          map[string]struct{}{},
+		allowedHeaders:   strings.Join(mergeHeaders(defaultCORSHeaders, cfg.AllowedHeaders), ", "),
+		exposedHeaders:   strings.Join(mergeHeaders(defaultExposedHeaders, cfg.ExposedHeaders), ", "),
+		allowCredentials: cfg.AllowCredentials,
+	}
+	if cfg.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.TrimSpace(origin)
+		switch {
+		case origin == "":
+			continue
+		case origin == "*":
+			p.anyOrigin = true
+		case strings.Contains(origin, "*"):
+			pattern := strings.ReplaceAll(regexp.QuoteMeta(strings.ToLower(origin)), `\*`, `[a-z0-9-]+(\.[a-z0-9-]+)*`)
+			re, err := regexp.Compile("^" + pattern + "$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid cors origin pattern %q: %w", origin, err)
+			}
+			p.patterns = append(p.patterns, re)
+		default:
+			p.origins[strings.ToLower(origin)] = struct{}{}
+		}
+	}
+	if p.anyOrigin && p.allowCredentials {
+		return nil, errors.New("cors wildcard origin can't be combined with credentials")
+	}
+	return p, nil
+}
+
+func mergeHeaders(defaults, configured []string) []string {
+	seen := map[string]struct{}{}
+	merged := make([]string, 0, len(defaults)+len(configured))
+	for _, h := range append(append([]string{}, defaults...), configured...) {
+		h = http.CanonicalHeaderKey(strings.TrimSpace(h))
+		if _, ok := seen[h]; ok || h == "" {
+			continue
+		}
+		seen[h] = struct{}{}
+		merged = append(merged, h)
+	}
+	return merged
+}
+
+func (p *corsPolicy) allowOrigin(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if _, ok := p.origins[origin]; ok {
+		return true
+	}
+	for _, re := range p.patterns {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeMethods returns the methods the router serves the request path with.
+func (p *corsPolicy) routeMethods(r *http.Request) []string {
+	var methods []string
+	for _, m := range corsMethods {
+		probe := r.Clone(r.Context())
+		probe.Method = m
+		var match mux.RouteMatch
+		if p.router.Match(probe, &match) {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// middleware applies the policy, preflight requests are answered here and never reach the router.
+func (p *corsPolicy) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers := w.Header()
+		headers.Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !p.allowOrigin(origin) {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if p.anyOrigin {
+			headers.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			headers.Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.allowCredentials {
+			headers.Set("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			headers.Set("Access-Control-Expose-Headers", p.exposedHeaders)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		headers.Add("Vary", "Access-Control-Request-Method")
+		headers.Add("Vary", "Access-Control-Request-Headers")
+		methods := p.routeMethods(r)
+		if len(methods) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requested := r.Header.Get("Access-Control-Request-Method")
+		if !lo.Contains(methods, requested) {
+			headers.Set("Allow", strings.Join(methods, ", "))
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		headers.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		headers.Set("Access-Control-Allow-Headers", p.allowedHeaders)
+		if p.maxAge != "" {
+			headers.Set("Access-Control-Max-Age", p.maxAge)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
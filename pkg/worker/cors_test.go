@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSPolicy(t *testing.T) {
+	router := mux.NewRouter()
+	router.Handle("/api/v1/media/search", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).Methods(http.MethodGet)
+	router.Handle("/api/v1/jobs", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})).Methods(http.MethodGet, http.MethodPost)
+
+	policy, err := newCORSPolicy(config.CORS{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.preview.example.com"},
+		AllowedHeaders:   []string{"authorization"},
+		ExposedHeaders:   []string{"X-RateLimit-Remaining"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}, router)
+	require.NoError(t, err)
+	h := policy.middleware(router)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		headers         map[string]string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "same-origin request passes through untouched",
+			method:         http.MethodGet,
+			path:           "/api/v1/media/search",
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Vary":                        "Origin",
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			name:           "allowed origin is echoed with credentials",
+			method:         http.MethodGet,
+			path:           "/api/v1/media/search",
+			headers:        map[string]string{"Origin": "https://app.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Request-Id, Traceparent, X-Ratelimit-Remaining",
+			},
+		},
+		{
+			name:           "origin matching a pattern is allowed",
+			method:         http.MethodGet,
+			path:           "/api/v1/media/search",
+			headers:        map[string]string{"Origin": "https://pr-12.preview.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "https://pr-12.preview.example.com",
+			},
+		},
+		{
+			name:           "unknown origin gets no cors headers",
+			method:         http.MethodGet,
+			path:           "/api/v1/media/search",
+			headers:        map[string]string{"Origin": "https://evil.example.org"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			name:   "preflight lists the methods of the route",
+			method: http.MethodOptions,
+			path:   "/api/v1/jobs",
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.example.com",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Content-Type, X-Request-Id, Authorization",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:   "preflight for a method the route doesn't serve",
+			method: http.MethodOptions,
+			path:   "/api/v1/media/search",
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": http.MethodDelete,
+			},
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedHeaders: map[string]string{
+				"Allow":                        "GET",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		{
+			name:   "preflight from unknown origin is forbidden",
+			method: http.MethodOptions,
+			path:   "/api/v1/media/search",
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.org",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "preflight for an unknown path",
+			method: http.MethodOptions,
+			path:   "/nope",
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": http.MethodGet,
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			for k, v := range tt.expectedHeaders {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestNewCORSPolicyRejectsWildcardWithCredentials(t *testing.T) {
+	_, err := newCORSPolicy(config.CORS{AllowedOrigins: []string{"*"}, AllowCredentials: true}, mux.NewRouter())
+	assert.EqualError(t, err, "cors wildcard origin can't be combined with credentials")
+}
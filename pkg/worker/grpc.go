@@ -0,0 +1,235 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	grpctransport "github.com/NawafSwe/media-scout-service/pkg/internal/transport/grpc"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	pb "github.com/NawafSwe/media-scout-service/pkg/pb/mediascout/v1"
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// healthCheckInterval is how often the db is pinged to update the serving status reported by the health service.
+const healthCheckInterval = 10 * time.Second
+
+// GRPCWorker represents grpc worker, it serves the media scout service along with the grpc health and reflection services.
+type GRPCWorker struct {
+	cfg    config.Config
+	Name   string
+	db     *sqlx.DB
+	lgr    logging.Logger
+	port   int
+	tracer *trace.TracerProvider
+	meter  *metric.MeterProvider
+	guard  *APIGuard
+	health *health.Server
+}
+
+// NewGRPCWorker function creates grpc worker.
+func NewGRPCWorker(cfg config.Config, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, guard *APIGuard, name string) (*GRPCWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	lgrWithAttrs := lgr.With("service", name)
+	if cfg.Auth.APIKeyHeader == "" {
+		cfg.Auth.APIKeyHeader = defaultAPIKeyHeader
+	}
+	return &GRPCWorker{
+		cfg:    cfg,
+		Name:   name,
+		db:     db,
+		lgr:    lgrWithAttrs,
+		port:   cfg.GRPC.Port,
+		tracer: tracer,
+		meter:  meter,
+		guard:  guard,
+		health: health.NewServer(),
+	}, nil
+}
+
+// Run serves grpc until ctx is done, then stops gracefully within the configured timeout.
+func (g *GRPCWorker) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", g.port))
+	if err != nil {
+		g.lgr.ErrorContext(ctx, "failed to listen to grpc port", "port", g.port)
+		return fmt.Errorf("failed to listen on grpc port %d", g.port)
+	}
+	opts, err := g.serverOptions(ctx)
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer(opts...)
+	g.register(srv)
+
+	healthCtx, stopHealthChecks := context.WithCancel(ctx)
+	defer stopHealthChecks()
+	go g.checkHealth(healthCtx)
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(lis) }()
+	g.lgr.InfoContext(ctx, "running grpc server", "port", g.port, "tls", g.cfg.General.TlsEnabled)
+
+	select {
+	case err := <-serveErr:
+		g.lgr.ErrorContext(ctx, "failed to serve grpc server", "port", g.port, "error", err.Error())
+		return fmt.Errorf("failed to serve grpc server: %w", err)
+	case <-ctx.Done():
+	}
+	g.lgr.InfoContext(ctx, "grpc graceful shutdown started")
+	g.health.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	timeout := g.cfg.GRPC.GracefulShutdown
+	if timeout <= 0 {
+		timeout = g.cfg.HTTP.GracefulShutdown
+	}
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		g.lgr.ErrorContext(ctx, "failed to stop grpc server in graceful shutdown, forcing it")
+		srv.Stop()
+	}
+	g.lgr.InfoContext(ctx, "stopped grpc server gracefully.")
+	return nil
+}
+
+// serverOptions returns the options of the grpc server: OTel instrumentation, panic recovery, access logs
+// and, when enabled, the same tls config as the http server.
+func (g *GRPCWorker) serverOptions(ctx context.Context) ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(g.tracer), otelgrpc.WithMeterProvider(g.meter))),
+		grpc.ChainUnaryInterceptor(accessLogUnary(g.lgr), recoverUnary(g.lgr)),
+	}
+	if !g.cfg.General.TlsEnabled {
+		return opts, nil
+	}
+	reloader, err := newCertReloader(g.cfg.TLS, g.lgr)
+	if err != nil {
+		g.lgr.ErrorContext(ctx, "failed to load tls files", "error", err.Error())
+		return nil, fmt.Errorf("failed to load tls files: %w", err)
+	}
+	go func() {
+		defer reloader.Close()
+		reloader.watch(ctx)
+	}()
+	tlsConfig, err := newTLSConfig(g.cfg.TLS, reloader)
+	if err != nil {
+		g.lgr.ErrorContext(ctx, "invalid tls config", "error", err.Error())
+		return nil, fmt.Errorf("invalid tls config: %w", err)
+	}
+	return append(opts, grpc.Creds(credentials.NewTLS(tlsConfig))), nil
+}
+
+// register registers the media scout, health and reflection services on srv.
+func (g *GRPCWorker) register(srv *grpc.Server) {
+	pb.RegisterMediaScoutServiceServer(srv, makeMediaScoutServer(g.cfg, g.db, newITunesClient(g.cfg.ITunes, g.tracer, g.meter, g.lgr), g.tracer, g.lgr, g.kitServerOptions(), g.guard.middlewares))
+	healthpb.RegisterHealthServer(srv, g.health)
+	reflection.Register(srv)
+}
+
+// kitServerOptions returns the options shared by every go-kit grpc server.
+func (g *GRPCWorker) kitServerOptions() []kitgrpc.ServerOption {
+	return []kitgrpc.ServerOption{
+		kitgrpc.ServerBefore(grpctransport.PopulateAuthInfo(g.cfg.Auth.APIKeyHeader), grpctransport.PopulateClientIP),
+		kitgrpc.ServerAfter(grpctransport.EncodeQuotaMetadata),
+	}
+}
+
+// checkHealth reports the service as serving while the db is reachable, until ctx is done.
+func (g *GRPCWorker) checkHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if err := g.db.PingContext(ctx); err != nil {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		g.health.SetServingStatus("", servingStatus)
+		g.health.SetServingStatus(pb.MediaScoutService_ServiceDesc.ServiceName, servingStatus)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// makeMediaScoutServer function to return the media scout grpc service, each endpoint is wrapped
+// with the middlewares returned for its operation name.
+func makeMediaScoutServer(cfg config.Config, db *sqlx.DB, itunesClient *itunes.Client, tracer *trace.TracerProvider, lgr logging.Logger, opts []kitgrpc.ServerOption, middlewares func(operation string) []endpoint.Middleware) *grpctransport.Server {
+	searcher := newSearcher(cfg, db, newMediaProviders(cfg.Providers, itunesClient, tracer, lgr), lgr)
+	return grpctransport.NewServer(grpctransport.Endpoints{
+		SearchMedia:       applyMiddlewares(transport.MakeSearchMediaEndpoint(searcher), middlewares("search.media")),
+		LookupMedia:       applyMiddlewares(transport.MakeLookupMediaEndpoint(business.NewLookupMediaHandler(mediafetcher.NewMediaFetcher(itunesClient))), middlewares("lookup.media")),
+		ListSearchHistory: applyMiddlewares(transport.MakeListSearchHistoryEndpoint(business.NewSearchHistoryHandler(newMediaRepository(cfg.Outbox, db))), middlewares("history.media")),
+	}, opts...)
+}
+
+// recoverUnary turns a panicking handler into an internal error instead of a crashed server.
+func recoverUnary(lgr logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				lgr.ErrorContext(ctx, "recovered from panic",
+					"method", info.FullMethod,
+					"panic", fmt.Sprint(p),
+					"stack", string(debug.Stack()),
+				)
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// accessLogUnary writes a structured log line for every call once it is served.
+func accessLogUnary(lgr logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		var clientAddr string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			clientAddr = p.Addr.String()
+		}
+		lgr.InfoContext(ctx, "grpc request",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"peer", clientAddr,
+		)
+		return resp, err
+	}
+}
@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	pb "github.com/NawafSwe/media-scout-service/pkg/pb/mediascout/v1"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCWorker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectQuery("FROM media_result").WillReturnRows(sqlmock.NewRows([]string{"id", "search_term", "returned_result", "created_at", "updated_at"}))
+
+	cfg := config.Config{RateLimit: config.RateLimit{Enabled: true, Rate: 0.001, Burst: 1}}
+	guard, err := NewAPIGuard(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), sqlx.NewDb(db, "sqlmock"), "test")
+	require.NoError(t, err)
+	g, err := NewGRPCWorker(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), sqlx.NewDb(db, "sqlmock"), guard, "test")
+	require.NoError(t, err)
+
+	opts, err := g.serverOptions(context.Background())
+	require.NoError(t, err)
+	srv := grpc.NewServer(opts...)
+	g.register(srv)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.checkHealth(ctx)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("health", func(t *testing.T) {
+		client := healthpb.NewHealthClient(conn)
+		assert.Eventually(t, func() bool {
+			res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pb.MediaScoutService_ServiceDesc.ServiceName})
+			return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_SERVING
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("reflection", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}))
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		var services []string
+		for _, s := range res.GetListServicesResponse().GetService() {
+			services = append(services, s.GetName())
+		}
+		assert.Contains(t, services, pb.MediaScoutService_ServiceDesc.ServiceName)
+		assert.Contains(t, services, "grpc.health.v1.Health")
+	})
+
+	t.Run("rate limited like http", func(t *testing.T) {
+		client := pb.NewMediaScoutServiceClient(conn)
+		_, err := client.ListSearchHistory(context.Background(), &pb.ListSearchHistoryRequest{})
+		require.NoError(t, err)
+
+		_, err = client.ListSearchHistory(context.Background(), &pb.ListSearchHistoryRequest{})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
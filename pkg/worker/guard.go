@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/apikeydb"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/jwt"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// APIGuard authenticates and rate limits the calls to the public api, it is created once and shared by the http
+// and grpc workers so both transports enforce the same policy out of the same rate limit buckets.
+type APIGuard struct {
+	cfg        config.Auth
+	db         *sqlx.DB
+	verifier   *jwt.Verifier
+	rateLimits *rateLimits
+}
+
+// NewAPIGuard function creates the api guard from the auth and rate limit config.
+func NewAPIGuard(cfg config.Config, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, name string) (*APIGuard, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	g := &APIGuard{cfg: cfg.Auth, db: db}
+	if cfg.Auth.JWTEnabled {
+		verifier, err := newVerifier(cfg.Auth, tracer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create jwt verifier: %w", err)
+		}
+		g.verifier = verifier
+	}
+	rateLimits, err := newRateLimits(cfg.RateLimit, db, meter, lgr.With("service", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rate limits: %w", err)
+	}
+	g.rateLimits = rateLimits
+	return g, nil
+}
+
+// middlewares returns the endpoint middlewares applied to the api endpoint with the given operation name,
+// the last one is the outermost. When both are enabled, bearer tokens are verified first and requests without
+// one fall back to their api key. Clients are rate limited by ip ahead of authentication, so invalid credentials
+// can't be tried at will, and by their identity once authenticated.
+func (g *APIGuard) middlewares(operation string) []endpoint.Middleware {
+	var middlewares []endpoint.Middleware
+	if g.rateLimits != nil {
+		middlewares = append(middlewares, g.rateLimits.middleware(operation))
+	}
+	var apiKeyMiddleware endpoint.Middleware
+	if g.cfg.APIKeysEnabled {
+		apiKeys := business.NewAPIKeyHandler(apikeydb.NewAPIKeyRepository(g.db))
+		apiKeyMiddleware = transport.MakeAPIKeyMiddleware(apiKeys)
+	}
+	switch {
+	case g.cfg.JWTEnabled:
+		middlewares = append(middlewares, transport.MakeJWTMiddleware(g.verifier, apiKeyMiddleware, g.cfg.JWTRequiredScopes...))
+	case apiKeyMiddleware != nil:
+		middlewares = append(middlewares, apiKeyMiddleware)
+	}
+	if g.rateLimits != nil && (g.cfg.JWTEnabled || g.cfg.APIKeysEnabled) {
+		// anonymous clients are already limited by ip.
+		middlewares = append(middlewares, g.rateLimits.ipMiddleware())
+	}
+	return middlewares
+}
+
+// pruneRateLimits drops the idle rate limit buckets until ctx is done, it is a no-op when rate limiting is disabled.
+func (g *APIGuard) pruneRateLimits(ctx context.Context) {
+	if g.rateLimits != nil {
+		g.rateLimits.prune(ctx)
+	}
+}
+
+// newVerifier creates the bearer token verifier from the auth config, the jwks file takes precedence over its url.
+func newVerifier(cfg config.Auth, tracer *trace.TracerProvider) (*jwt.Verifier, error) {
+	var keys *jwt.KeySet
+	switch {
+	case cfg.JWKSFile != "":
+		keys = jwt.NewFileKeySet(cfg.JWKSFile, cfg.JWKSRefreshInterval)
+	case cfg.JWKSURL != "":
+		keys = jwt.NewURLKeySet(cfg.JWKSURL, cfg.JWKSRefreshInterval, tracer)
+	default:
+		return nil, errors.New("jwt auth requires a jwks file or url")
+	}
+	return jwt.NewVerifier(keys, jwt.Options{
+		Issuer:   cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		Leeway:   cfg.JWTLeeway,
+	}), nil
+}
@@ -2,57 +2,147 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	graphqltransport "github.com/NawafSwe/media-scout-service/pkg/internal/transport/graphql"
 	kithttptransport "github.com/NawafSwe/media-scout-service/pkg/internal/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
-	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 
 	"github.com/NawafSwe/media-scout-service/cmd/config"
 	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/apikeydb"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediadb"
 	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
 	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/NawafSwe/media-scout-service/pkg/openapi"
 	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 )
 
+const (
+	defaultAPIKeyHeader = "X-API-Key"
+
+	defaultBatchMaxSize     = 100
+	defaultBatchConcurrency = 4
+
+	defaultGraphQLMaxDepth      = 10
+	defaultGraphQLMaxComplexity = 10000
+)
+
+// quotaHeaders are the headers describing the quota of the api key of a request.
+var quotaHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"}
+
 // HTTPWorker represents http worker.
 type HTTPWorker struct {
-	cfg     config.Config
-	Name    string
-	db      *sqlx.DB
-	lgr     logging.Logger
-	port    int
-	tracer  *trace.TracerProvider
-	router  *mux.Router
-	srv     *http.Server
+	cfg          config.Config
+	Name         string
+	db           *sqlx.DB
+	lgr          logging.Logger
+	port         int
+	proxies      []*net.IPNet
+	tracer       *trace.TracerProvider
+	meter        *metric.MeterProvider
+	metrics      routeMetrics
+	router       *mux.Router
+	adminRouter  *mux.Router
+	cors         *corsPolicy
+	guard        *APIGuard
+	itunes       *itunes.Client
+	searcher     business.SearchMediaHandler
+	availability business.AvailabilityPolicy
+	graphql      graphqltransport.Schema
+	srv          *http.Server
+	adminSrv     *http.Server
+	// streams is closed on shutdown to end the event streams, the server would otherwise wait on them.
+	streams chan struct{}
 	signals chan os.Signal
 }
 
 // NewHTTPWorker function creates http worker.
-func NewHTTPWorker(cfg config.Config, tracer *trace.TracerProvider, db *sqlx.DB, name string) (*HTTPWorker, error) {
-	handler := slog.NewJSONHandler(os.Stdout, nil)
-	lgr := slog.New(handler)
+func NewHTTPWorker(cfg config.Config, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, guard *APIGuard, name string) (*HTTPWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
 	lgrWithAttrs := lgr.With("service", name)
+	metrics, err := newRouteMetrics(meter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create route metrics: %w", err)
+	}
+	proxies, err := parseTrustedProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted proxies: %w", err)
+	}
+	if cfg.Auth.APIKeyHeader == "" {
+		cfg.Auth.APIKeyHeader = defaultAPIKeyHeader
+	}
+	if cfg.Batch.MaxSize <= 0 {
+		cfg.Batch.MaxSize = defaultBatchMaxSize
+	}
+	if cfg.Batch.Concurrency <= 0 {
+		cfg.Batch.Concurrency = defaultBatchConcurrency
+	}
+	cfg.Jobs = withJobsDefaults(cfg.Jobs)
+	cfg.Watchlists = withWatchlistsDefaults(cfg.Watchlists)
+	cfg.Podcasts = withPodcastsDefaults(cfg.Podcasts)
+	cfg.Availability = withAvailabilityDefaults(cfg.Availability)
+	availability, err := newAvailabilityPolicy(cfg.Availability)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create availability policy: %w", err)
+	}
+	itunesClient := newITunesClient(cfg.ITunes, tracer, meter, lgrWithAttrs)
+	searcher := newSearcher(cfg, db, newMediaProviders(cfg.Providers, itunesClient, tracer, lgrWithAttrs), lgrWithAttrs)
+	graphqlSchema, err := newGraphQLSchema(cfg.GraphQL, cfg.Outbox, db, itunesClient, searcher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create graphql schema: %w", err)
+	}
+	router := mux.NewRouter()
+	corsCfg := cfg.CORS
+	corsCfg.AllowedHeaders = slices.Concat(corsCfg.AllowedHeaders, []string{cfg.Auth.APIKeyHeader, "Authorization", "If-None-Match", "Content-Type", "Last-Event-ID"})
+	corsCfg.ExposedHeaders = slices.Concat(corsCfg.ExposedHeaders, quotaHeaders, []string{"ETag", "Age", "Location", "Content-Disposition"})
+	cors, err := newCORSPolicy(corsCfg, router)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cors policy: %w", err)
+	}
 	return &HTTPWorker{
-		cfg:     cfg,
-		Name:    name,
-		lgr:     lgrWithAttrs,
-		db:      db,
-		tracer:  tracer,
-		port:    cfg.HTTP.Port,
-		router:  mux.NewRouter(),
-		signals: make(chan os.Signal, 1),
+		cfg:          cfg,
+		Name:         name,
+		lgr:          lgrWithAttrs,
+		db:           db,
+		tracer:       tracer,
+		meter:        meter,
+		metrics:      metrics,
+		port:         cfg.HTTP.Port,
+		proxies:      proxies,
+		router:       router,
+		cors:         cors,
+		guard:        guard,
+		itunes:       itunesClient,
+		searcher:     searcher,
+		availability: availability,
+		graphql:      graphqlSchema,
+		adminRouter:  mux.NewRouter(),
+		streams:      make(chan struct{}),
+		signals:      make(chan os.Signal, 1),
 	}, nil
 }
 
@@ -65,16 +155,46 @@ func (h *HTTPWorker) Run(ctx context.Context) error {
 	h.registerHandlers()
 	srv := http.Server{
 		Addr:    fmt.Sprintf(":%d", h.port),
-		Handler: enableCORS(h.router),
+		Handler: h.handler(),
 	}
 	h.srv = &srv
+	srv.RegisterOnShutdown(func() { close(h.streams) })
+	if h.cfg.General.TlsEnabled {
+		reloader, err := newCertReloader(h.cfg.TLS, h.lgr)
+		if err != nil {
+			h.lgr.ErrorContext(ctx, "failed to load tls files", "error", err.Error())
+			return fmt.Errorf("failed to load tls files: %w", err)
+		}
+		defer reloader.Close()
+		watchCtx, stopWatching := context.WithCancel(ctx)
+		defer stopWatching()
+		go reloader.watch(watchCtx)
+
+		if srv.TLSConfig, err = newTLSConfig(h.cfg.TLS, reloader); err != nil {
+			h.lgr.ErrorContext(ctx, "invalid tls config", "error", err.Error())
+			return fmt.Errorf("invalid tls config: %w", err)
+		}
+	}
 
 	go func() {
-		if err := srv.Serve(lis); err != nil {
+		serve := srv.Serve
+		if srv.TLSConfig != nil {
+			serve = func(lis net.Listener) error { return srv.ServeTLS(lis, "", "") }
+		}
+		if err := serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			h.lgr.ErrorContext(ctx, "failed to serve http server", "port", h.port)
 		}
 	}()
-	h.lgr.InfoContext(ctx, "running server", "port", h.port)
+	h.lgr.InfoContext(ctx, "running server", "port", h.port, "tls", srv.TLSConfig != nil)
+	// the grpc worker sharing the guard leaves pruning its buckets to the http one.
+	pruneCtx, stopPruning := context.WithCancel(ctx)
+	defer stopPruning()
+	go h.guard.pruneRateLimits(pruneCtx)
+	if err := h.runAdmin(ctx); err != nil {
+		// the main server is already serving, it would otherwise keep serving after Run returned.
+		_ = h.srv.Close()
+		return err
+	}
 	signal.Notify(h.signals, syscall.SIGKILL, syscall.SIGTERM, syscall.SIGQUIT)
 	<-h.signals
 	h.lgr.InfoContext(ctx, "graceful shutdown started")
@@ -84,19 +204,147 @@ func (h *HTTPWorker) Run(ctx context.Context) error {
 		h.lgr.ErrorContext(ctx, "failed to stop server in graceful shutdown", "error", err.Error())
 		return fmt.Errorf("failed to stop server in graceful shutdown: %v", err)
 	}
+	if h.adminSrv != nil {
+		if err := h.adminSrv.Shutdown(ctx); err != nil {
+			h.lgr.ErrorContext(ctx, "failed to stop admin server in graceful shutdown", "error", err.Error())
+			return fmt.Errorf("failed to stop admin server in graceful shutdown: %v", err)
+		}
+	}
 	h.lgr.InfoContext(ctx, "stopped server gracefully.")
 	return nil
 }
 
+// runAdmin runs the admin server on its own port, it is a no-op when no admin port is configured.
+func (h *HTTPWorker) runAdmin(ctx context.Context) error {
+	port := h.cfg.Admin.Port
+	if port == 0 {
+		return nil
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		h.lgr.ErrorContext(ctx, "failed to listen to admin port", "port", port)
+		return fmt.Errorf("failed to listen on admin port %d", port)
+	}
+	h.registerAdminHandlers()
+	h.adminSrv = &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: h.adminRouter,
+	}
+	go func() {
+		if err := h.adminSrv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			h.lgr.ErrorContext(ctx, "failed to serve admin server", "port", port)
+		}
+	}()
+	h.lgr.InfoContext(ctx, "running admin server", "port", port)
+	return nil
+}
+
 func (h *HTTPWorker) SIGINT() {
 	h.signals <- syscall.SIGINT
 }
 
+// handler returns the router wrapped with the middlewares every request goes through.
+func (h *HTTPWorker) handler() http.Handler {
+	middlewares := []middleware{
+		requestID(h.proxies),
+		accessLog(h.lgr),
+		recoverPanic(h.lgr),
+		h.cors.middleware,
+	}
+	if h.cfg.HTTP.CompressionEnabled {
+		middlewares = append(middlewares, compress)
+	}
+	return chain(h.router, middlewares...)
+}
+
 func (h *HTTPWorker) registerHandlers() {
+	h.router.Use(captureRoute)
 	r := h.router.PathPrefix("").Subrouter()
-	r.Handle("/health", otelhttp.NewHandler(http.HandlerFunc(h.healthHandler), "health")).Methods(http.MethodGet)
+	r.Handle("/health", h.instrument("health", http.HandlerFunc(h.healthHandler))).Methods(http.MethodGet)
+	r.Handle("/openapi.json", h.instrument("openapi", openapi.SpecHandler())).Methods(http.MethodGet)
+	r.Handle("/docs", h.instrument("docs", openapi.DocsHandler())).Methods(http.MethodGet)
+	r.Handle("/graphql", h.instrument("graphql", makeGraphQLHandler(h.graphql, h.serverOptions(), h.apiMiddlewares("graphql")...))).Methods(http.MethodGet, http.MethodPost)
 	v1APIs := r.PathPrefix("/api/v1").Subrouter()
-	v1APIs.Handle("/media/search", otelhttp.NewHandler(makeSearchMediaHandler(h.db, h.tracer, h.lgr), "search.media")).Methods(http.MethodGet)
+	v1APIs.Handle("/media/search", h.instrument("search.media", makeSearchMediaHandler(h.searcher, h.cacheServerOptions(), h.apiMiddlewares("search.media")...))).Methods(http.MethodGet)
+	v1APIs.Handle("/media/lookup", h.instrument("lookup.media", makeLookupMediaHandler(h.itunes, h.cacheServerOptions(), h.apiMiddlewares("lookup.media")...))).Methods(http.MethodGet)
+	v1APIs.Handle("/media/seasons/{collectionId:[0-9]+}/episodes", h.instrument("episodes.season", makeListSeasonEpisodesHandler(h.itunes, h.cacheServerOptions(), h.apiMiddlewares("episodes.season")...))).Methods(http.MethodGet)
+	v1APIs.Handle("/media/{id:[0-9]+}/availability", h.instrument("availability.media", makeCheckAvailabilityHandler(h.itunes, h.cfg.Availability.MaxCountries, h.availability, h.lgr, h.serverOptions(), h.apiMiddlewares("availability.media")...))).Methods(http.MethodGet)
+	v1APIs.Handle("/media/search:batch", h.instrument("search.batch.media", makeSearchBatchHandler(h.searcher, h.cfg.Batch, h.serverOptions(), h.apiMiddlewares("search.batch.media")...))).Methods(http.MethodPost)
+	v1APIs.Handle("/media/history", h.instrument("history.media", makeSearchHistoryHandler(h.db, h.serverOptions(), h.apiMiddlewares("history.media")...))).Methods(http.MethodGet)
+	v1APIs.Handle("/searches/export", h.instrument("export.searches", makeExportSearchesHandler(h.db, h.serverOptions(), h.apiMiddlewares("export.searches")...))).Methods(http.MethodGet)
+	jobs := makeSearchJobHandlers(newSearchJobHandler(h.cfg, h.db, h.searcher, h.lgr), h.cfg.Jobs.MaxSize, kithttptransport.SearchJobEventsOptions{
+		PollInterval: h.cfg.Jobs.EventsPollInterval,
+		Heartbeat:    h.cfg.Jobs.EventsHeartbeat,
+		Done:         h.streams,
+	}, h.serverOptions(), h.apiMiddlewares)
+	v1APIs.Handle("/jobs", h.instrument("create.job", jobs.create)).Methods(http.MethodPost)
+	v1APIs.Handle("/jobs/{id:[0-9]+}", h.instrument("get.job", jobs.get)).Methods(http.MethodGet)
+	v1APIs.Handle("/jobs/{id:[0-9]+}", h.instrument("cancel.job", jobs.cancel)).Methods(http.MethodDelete)
+	v1APIs.Handle("/jobs/{id:[0-9]+}/events", h.instrument("events.job", jobs.events)).Methods(http.MethodGet)
+	watchlists := makeWatchlistHandlers(newWatchlistHandler(h.cfg.Watchlists, h.db, h.itunes, h.lgr), h.serverOptions(), h.apiMiddlewares)
+	v1APIs.Handle("/watchlists", h.instrument("create.watchlist", watchlists.create)).Methods(http.MethodPost)
+	v1APIs.Handle("/watchlists/{id:[0-9]+}", h.instrument("get.watchlist", watchlists.get)).Methods(http.MethodGet)
+	v1APIs.Handle("/watchlists/{id:[0-9]+}/artists/{artistId:[0-9]+}", h.instrument("subscribe.watchlist", watchlists.subscribe)).Methods(http.MethodPut)
+	v1APIs.Handle("/watchlists/{id:[0-9]+}/artists/{artistId:[0-9]+}", h.instrument("unsubscribe.watchlist", watchlists.unsubscribe)).Methods(http.MethodDelete)
+	v1APIs.Handle("/watchlists/{id:[0-9]+}/releases", h.instrument("releases.watchlist", watchlists.releases)).Methods(http.MethodGet)
+	v1APIs.Handle("/podcasts/{collectionId:[0-9]+}/episodes", h.instrument("episodes.podcast", makeListPodcastEpisodesHandler(newPodcastHandler(h.cfg.Podcasts, h.db, h.itunes, h.tracer, h.lgr), h.serverOptions(), h.apiMiddlewares("episodes.podcast")...))).Methods(http.MethodGet)
+	webhooks := makeWebhookHandlers(newEventPublisher(h.db), h.serverOptions(), h.apiMiddlewares)
+	v1APIs.Handle("/webhooks", h.instrument("create.webhook", webhooks.create)).Methods(http.MethodPost)
+	v1APIs.Handle("/webhooks/{id:[0-9]+}", h.instrument("get.webhook", webhooks.get)).Methods(http.MethodGet)
+	v1APIs.Handle("/webhooks/{id:[0-9]+}", h.instrument("delete.webhook", webhooks.delete)).Methods(http.MethodDelete)
+	v1APIs.Handle("/webhooks/{id:[0-9]+}/deliveries", h.instrument("deliveries.webhook", webhooks.deliveries)).Methods(http.MethodGet)
+	v1APIs.Handle("/webhooks/{id:[0-9]+}/deliveries/{deliveryId:[0-9]+}/redeliver", h.instrument("redeliver.webhook", webhooks.redeliver)).Methods(http.MethodPost)
+}
+
+// serverOptions returns the options shared by every go-kit http server.
+func (h *HTTPWorker) serverOptions() []kithttp.ServerOption {
+	return []kithttp.ServerOption{
+		kithttp.ServerBefore(kithttptransport.PopulateAuthInfo(h.cfg.Auth.APIKeyHeader), populateClientIP),
+		kithttp.ServerAfter(kithttptransport.EncodeQuotaHeaders),
+		kithttp.ServerErrorEncoder(kithttptransport.EncodeError),
+	}
+}
+
+// cacheServerOptions returns the server options of cacheable endpoints.
+func (h *HTTPWorker) cacheServerOptions() []kithttp.ServerOption {
+	return append(h.serverOptions(), kithttp.ServerBefore(kithttptransport.PopulateCacheRequest(kithttptransport.CachePolicy{
+		MaxAge:               h.cfg.HTTP.CacheMaxAge,
+		StaleWhileRevalidate: h.cfg.HTTP.CacheStaleWhileRevalidate,
+	})))
+}
+
+// populateClientIP puts the client ip resolved by the requestID middleware in the transport context.
+func populateClientIP(ctx context.Context, _ *http.Request) context.Context {
+	return transport.ContextWithClientIP(ctx, clientIPFromContext(ctx))
+}
+
+// apiMiddlewares returns the endpoint middlewares applied to the /api/v1 endpoint with the given operation name.
+func (h *HTTPWorker) apiMiddlewares(operation string) []endpoint.Middleware {
+	return h.guard.middlewares(operation)
+}
+
+func (h *HTTPWorker) registerAdminHandlers() {
+	if h.cfg.Metrics.Enabled {
+		path := h.cfg.Metrics.Path
+		if path == "" {
+			path = "/metrics"
+		}
+		h.adminRouter.Handle(path, promhttp.Handler()).Methods(http.MethodGet)
+	}
+	if h.cfg.Admin.Token == "" {
+		// api keys grant access to the public api, they are never managed without an admin credential.
+		return
+	}
+	apiKeys := makeAPIKeyHandlers(h.db, h.serverOptions(), transport.MakeAdminTokenMiddleware(h.cfg.Admin.Token))
+	h.adminRouter.Handle("/admin/api-keys", apiKeys.create).Methods(http.MethodPost)
+	h.adminRouter.Handle("/admin/api-keys", apiKeys.list).Methods(http.MethodGet)
+	h.adminRouter.Handle("/admin/api-keys/{id:[0-9]+}/rotate", apiKeys.rotate).Methods(http.MethodPost)
+	h.adminRouter.Handle("/admin/api-keys/{id:[0-9]+}", apiKeys.revoke).Methods(http.MethodDelete)
+}
+
+// instrument wraps a route handler with tracing and RED metrics under the given operation name.
+func (h *HTTPWorker) instrument(operation string, handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(traceResponse(h.metrics.instrument(operation, handler)), operation)
 }
 
 func (h *HTTPWorker) healthHandler(r http.ResponseWriter, _ *http.Request) {
@@ -110,33 +358,147 @@ func (h *HTTPWorker) healthHandler(r http.ResponseWriter, _ *http.Request) {
 }
 
 // makeSearchMediaHandler function to return http handler for search media.
-func makeSearchMediaHandler(db *sqlx.DB, tracer *trace.TracerProvider, lgr logging.Logger, middlewares ...endpoint.Middleware) http.Handler {
-	itunesClient := itunes.NewClient(tracer)
-	mediaFetcher := mediafetcher.NewMediaFetcher(itunesClient)
-	mediaDBRepo := mediadb.NewMediaRepository(db)
-	handler := business.NewSearchMediaHandler(mediaDBRepo, mediaFetcher, lgr)
-	ep := transport.MakeSearchMediaEndpoint(handler)
-	// applying middlewares, if any.
-	if middlewares != nil {
-		for _, m := range middlewares {
-			ep = m(ep)
-		}
+func makeSearchMediaHandler(searcher business.SearchMediaHandler, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	// conditional searches are revalidated inside the middlewares, they still count against auth and rate limits.
+	ep := kithttptransport.MakeRevalidateSearchMiddleware(searcher)(transport.MakeSearchMediaEndpoint(searcher))
+	ep = applyMiddlewares(ep, middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeSearchMediaRequest, kithttptransport.EncodeSearchMediaResponse, opts...)
+}
+
+// makeLookupMediaHandler function to return http handler for lookup media.
+func makeLookupMediaHandler(itunesClient *itunes.Client, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	handler := business.NewLookupMediaHandler(mediafetcher.NewMediaFetcher(itunesClient))
+	ep := applyMiddlewares(transport.MakeLookupMediaEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeLookupMediaRequest, kithttptransport.EncodeLookupMediaResponse, opts...)
+}
+
+// makeSearchBatchHandler function to return http handler for batch searches.
+func makeSearchBatchHandler(searcher business.SearchMediaHandler, cfg config.Batch, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	handler := business.NewSearchBatchHandler(searcher, cfg.Concurrency)
+	ep := applyMiddlewares(transport.MakeSearchBatchEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeSearchBatchRequest(cfg.MaxSize), kithttptransport.EncodeSearchBatchResponse, opts...)
+}
+
+// makeSearchHistoryHandler function to return http handler for the search history.
+func makeSearchHistoryHandler(db *sqlx.DB, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	handler := business.NewSearchHistoryHandler(mediadb.NewMediaRepository(db))
+	ep := applyMiddlewares(transport.MakeListSearchHistoryEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeListSearchHistoryRequest, kithttptransport.EncodeListSearchHistoryResponse, opts...)
+}
+
+// makeExportSearchesHandler function to return http handler for exporting the stored searches.
+func makeExportSearchesHandler(db *sqlx.DB, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	handler := business.NewSearchExportHandler(mediadb.NewMediaRepository(db))
+	ep := applyMiddlewares(transport.MakeExportSearchesEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeExportSearchesRequest, kithttptransport.EncodeExportSearchesResponse, opts...)
+}
+
+// searchJobHandlers holds the http handlers of the search job endpoints.
+type searchJobHandlers struct {
+	create, get, cancel, events http.Handler
+}
+
+// makeSearchJobHandlers function to return http handlers for the search job endpoints, each endpoint is wrapped
+// with the middlewares returned for its operation name.
+func makeSearchJobHandlers(handler business.SearchJobHandler, maxSize int, eventsOpts kithttptransport.SearchJobEventsOptions, opts []kithttp.ServerOption, middlewares func(operation string) []endpoint.Middleware) searchJobHandlers {
+	return searchJobHandlers{
+		create: kithttp.NewServer(applyMiddlewares(transport.MakeCreateSearchJobEndpoint(handler), middlewares("create.job")), kithttptransport.DecodeCreateSearchJobRequest(maxSize), kithttptransport.EncodeSearchJobResponse, opts...),
+		get:    kithttp.NewServer(applyMiddlewares(transport.MakeGetSearchJobEndpoint(handler), middlewares("get.job")), kithttptransport.DecodeSearchJobRequest, kithttptransport.EncodeSearchJobResponse, opts...),
+		cancel: kithttp.NewServer(applyMiddlewares(transport.MakeCancelSearchJobEndpoint(handler), middlewares("cancel.job")), kithttptransport.DecodeSearchJobRequest, kithttptransport.EncodeSearchJobResponse, opts...),
+		events: kithttp.NewServer(applyMiddlewares(transport.MakeSearchJobEventsEndpoint(handler), middlewares("events.job")), kithttptransport.DecodeSearchJobEventsRequest, kithttptransport.EncodeSearchJobEvents(eventsOpts), opts...),
 	}
-	return kithttp.NewServer(ep, kithttptransport.DecodeSearchMediaRequest, kithttptransport.EncodeSearchMediaResponse)
 }
 
-func enableCORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*") // Allow all origins
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+// makeListSeasonEpisodesHandler function to return http handler for listing the episodes of a tv season.
+func makeListSeasonEpisodesHandler(itunesClient *itunes.Client, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	handler := business.NewLookupMediaHandler(mediafetcher.NewMediaFetcher(itunesClient))
+	ep := applyMiddlewares(transport.MakeListSeasonEpisodesEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeListSeasonEpisodesRequest, kithttptransport.EncodeListSeasonEpisodesResponse, opts...)
+}
 
-		// Handle preflight requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+// makeListPodcastEpisodesHandler function to return http handler for listing the episodes of a podcast.
+func makeListPodcastEpisodesHandler(handler business.PodcastHandler, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	ep := applyMiddlewares(transport.MakeListPodcastEpisodesEndpoint(handler), middlewares)
+	return kithttp.NewServer(ep, kithttptransport.DecodeListPodcastEpisodesRequest, kithttptransport.EncodeListPodcastEpisodesResponse, opts...)
+}
 
-		next.ServeHTTP(w, r)
-	})
+// watchlistHandlers holds the http handlers of the watchlist endpoints.
+type watchlistHandlers struct {
+	create, get, subscribe, unsubscribe, releases http.Handler
+}
+
+// makeWatchlistHandlers function to return http handlers for the watchlist endpoints, each endpoint is wrapped
+// with the middlewares returned for its operation name.
+func makeWatchlistHandlers(handler business.WatchlistHandler, opts []kithttp.ServerOption, middlewares func(operation string) []endpoint.Middleware) watchlistHandlers {
+	return watchlistHandlers{
+		create:      kithttp.NewServer(applyMiddlewares(transport.MakeCreateWatchlistEndpoint(handler), middlewares("create.watchlist")), kithttptransport.DecodeCreateWatchlistRequest, kithttptransport.EncodeWatchlistResponse, opts...),
+		get:         kithttp.NewServer(applyMiddlewares(transport.MakeGetWatchlistEndpoint(handler), middlewares("get.watchlist")), kithttptransport.DecodeWatchlistRequest, kithttptransport.EncodeWatchlistResponse, opts...),
+		subscribe:   kithttp.NewServer(applyMiddlewares(transport.MakeSubscribeArtistEndpoint(handler), middlewares("subscribe.watchlist")), kithttptransport.DecodeWatchlistArtistRequest, kithttptransport.EncodeWatchlistResponse, opts...),
+		unsubscribe: kithttp.NewServer(applyMiddlewares(transport.MakeUnsubscribeArtistEndpoint(handler), middlewares("unsubscribe.watchlist")), kithttptransport.DecodeWatchlistArtistRequest, kithttptransport.EncodeWatchlistResponse, opts...),
+		releases:    kithttp.NewServer(applyMiddlewares(transport.MakeListWatchlistReleasesEndpoint(handler), middlewares("releases.watchlist")), kithttptransport.DecodeListWatchlistReleasesRequest, kithttptransport.EncodeWatchlistResponse, opts...),
+	}
+}
+
+// webhookHandlers holds the http handlers of the webhook endpoints.
+type webhookHandlers struct {
+	create, get, delete, deliveries, redeliver http.Handler
+}
+
+// makeWebhookHandlers function to return http handlers for the webhook endpoints, each endpoint is wrapped
+// with the middlewares returned for its operation name.
+func makeWebhookHandlers(handler business.WebhookHandler, opts []kithttp.ServerOption, middlewares func(operation string) []endpoint.Middleware) webhookHandlers {
+	return webhookHandlers{
+		create:     kithttp.NewServer(applyMiddlewares(transport.MakeCreateWebhookEndpoint(handler), middlewares("create.webhook")), kithttptransport.DecodeCreateWebhookRequest, kithttptransport.EncodeWebhookResponse, opts...),
+		get:        kithttp.NewServer(applyMiddlewares(transport.MakeGetWebhookEndpoint(handler), middlewares("get.webhook")), kithttptransport.DecodeWebhookRequest, kithttptransport.EncodeWebhookResponse, opts...),
+		delete:     kithttp.NewServer(applyMiddlewares(transport.MakeDeleteWebhookEndpoint(handler), middlewares("delete.webhook")), kithttptransport.DecodeWebhookRequest, kithttptransport.EncodeWebhookResponse, opts...),
+		deliveries: kithttp.NewServer(applyMiddlewares(transport.MakeListWebhookDeliveriesEndpoint(handler), middlewares("deliveries.webhook")), kithttptransport.DecodeListWebhookDeliveriesRequest, kithttptransport.EncodeWebhookResponse, opts...),
+		redeliver:  kithttp.NewServer(applyMiddlewares(transport.MakeRedeliverWebhookEndpoint(handler), middlewares("redeliver.webhook")), kithttptransport.DecodeRedeliverWebhookRequest, kithttptransport.EncodeWebhookResponse, opts...),
+	}
+}
+
+// newGraphQLSchema creates the graphql schema, resolving media with the same handlers as the /api/v1 endpoints.
+func newGraphQLSchema(cfg config.GraphQL, outbox config.Outbox, db *sqlx.DB, itunesClient *itunes.Client, searcher business.SearchMediaHandler) (graphqltransport.Schema, error) {
+	limits := graphqltransport.Limits{MaxDepth: cfg.MaxDepth, MaxComplexity: cfg.MaxComplexity}
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = defaultGraphQLMaxDepth
+	}
+	if limits.MaxComplexity <= 0 {
+		limits.MaxComplexity = defaultGraphQLMaxComplexity
+	}
+	return graphqltransport.NewSchema(
+		searcher,
+		business.NewLookupMediaHandler(mediafetcher.NewMediaFetcher(itunesClient)),
+		business.NewSearchHistoryHandler(newMediaRepository(outbox, db)),
+		limits,
+	)
+}
+
+// makeGraphQLHandler function to return http handler for graphql queries.
+func makeGraphQLHandler(schema graphqltransport.Schema, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) http.Handler {
+	ep := applyMiddlewares(graphqltransport.MakeEndpoint(schema), middlewares)
+	return kithttp.NewServer(ep, graphqltransport.DecodeRequest, graphqltransport.EncodeResponse, opts...)
+}
+
+// applyMiddlewares wraps ep with middlewares, the last one is the outermost.
+func applyMiddlewares(ep endpoint.Endpoint, middlewares []endpoint.Middleware) endpoint.Endpoint {
+	for _, m := range middlewares {
+		ep = m(ep)
+	}
+	return ep
+}
+
+// apiKeyHandlers holds the http handlers of the api key admin endpoints.
+type apiKeyHandlers struct {
+	create, list, rotate, revoke http.Handler
+}
+
+// makeAPIKeyHandlers function to return http handlers for the api key admin endpoints.
+func makeAPIKeyHandlers(db *sqlx.DB, opts []kithttp.ServerOption, middlewares ...endpoint.Middleware) apiKeyHandlers {
+	handler := business.NewAPIKeyHandler(apikeydb.NewAPIKeyRepository(db))
+	return apiKeyHandlers{
+		create: kithttp.NewServer(applyMiddlewares(transport.MakeCreateAPIKeyEndpoint(handler), middlewares), kithttptransport.DecodeCreateAPIKeyRequest, kithttptransport.EncodeAPIKeyResponse, opts...),
+		list:   kithttp.NewServer(applyMiddlewares(transport.MakeListAPIKeysEndpoint(handler), middlewares), kithttptransport.DecodeListAPIKeysRequest, kithttptransport.EncodeAPIKeyResponse, opts...),
+		rotate: kithttp.NewServer(applyMiddlewares(transport.MakeRotateAPIKeyEndpoint(handler), middlewares), kithttptransport.DecodeAPIKeyRequest, kithttptransport.EncodeAPIKeyResponse, opts...),
+		revoke: kithttp.NewServer(applyMiddlewares(transport.MakeRevokeAPIKeyEndpoint(handler), middlewares), kithttptransport.DecodeAPIKeyRequest, kithttptransport.EncodeAPIKeyResponse, opts...),
+	}
 }
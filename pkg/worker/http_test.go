@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestAdminAPIKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		mockSetup      func(sqlmock.Sqlmock)
+		expectedStatus int
+	}{
+		{
+			name:           "not served without an admin token",
+			authorization:  "Bearer s3cret",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing token",
+			token:          "s3cret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong token",
+			token:          "s3cret",
+			authorization:  "Bearer guess",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "admin token",
+			token:         "s3cret",
+			authorization: "Bearer s3cret",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM api_key ORDER BY id").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			if tt.mockSetup != nil {
+				tt.mockSetup(mock)
+			}
+
+			cfg := config.Config{Admin: config.Admin{Token: tt.token}}
+			guard, err := NewAPIGuard(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, "test")
+			require.NoError(t, err)
+			h, err := NewHTTPWorker(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), sqlx.NewDb(db, "sqlmock"), guard, "test")
+			require.NoError(t, err)
+			h.registerAdminHandlers()
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			h.adminRouter.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAdminMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		metrics        config.Metrics
+		target         string
+		expectedStatus int
+	}{
+		{
+			name:           "default path",
+			metrics:        config.Metrics{Enabled: true},
+			target:         "/metrics",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "configured path",
+			metrics:        config.Metrics{Enabled: true, Path: "/internal/metrics"},
+			target:         "/internal/metrics",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "disabled",
+			target:         "/metrics",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{Metrics: tt.metrics}
+			guard, err := NewAPIGuard(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, "test")
+			require.NoError(t, err)
+			h, err := NewHTTPWorker(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, guard, "test")
+			require.NoError(t, err)
+			h.registerAdminHandlers()
+
+			rec := httptest.NewRecorder()
+			h.adminRouter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+				assert.Contains(t, rec.Body.String(), "go_goroutines")
+			}
+		})
+	}
+}
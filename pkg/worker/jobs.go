@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/jobdb"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultJobsMaxSize      = 1000
+	defaultJobsPollInterval = time.Second
+	defaultJobsMaxAttempts  = 3
+	defaultJobsBackoff      = 30 * time.Second
+	defaultJobsMaxBackoff   = 10 * time.Minute
+	defaultJobsLease        = time.Minute
+
+	defaultJobsEventsPollInterval = time.Second
+	defaultJobsEventsHeartbeat    = 15 * time.Second
+)
+
+// JobWorker represents the worker running the queued search jobs.
+type JobWorker struct {
+	cfg    config.Jobs
+	Name   string
+	lgr    logging.Logger
+	poller poller
+}
+
+// NewJobWorker function creates job worker.
+func NewJobWorker(cfg config.Config, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, name string) (*JobWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	lgrWithAttrs := lgr.With("service", name)
+	cfg.Jobs = withJobsDefaults(cfg.Jobs)
+	if cfg.Batch.Concurrency <= 0 {
+		cfg.Batch.Concurrency = defaultBatchConcurrency
+	}
+	itunesClient := newITunesClient(cfg.ITunes, tracer, meter, lgrWithAttrs)
+	handler := newSearchJobHandler(cfg, db, newSearcher(cfg, db, newMediaProviders(cfg.Providers, itunesClient, tracer, lgrWithAttrs), lgrWithAttrs), lgrWithAttrs)
+	return &JobWorker{
+		cfg:  cfg.Jobs,
+		Name: name,
+		lgr:  lgrWithAttrs,
+		poller: poller{
+			work:     handler.ProcessNextSearchJob,
+			workers:  cfg.Jobs.Workers,
+			interval: cfg.Jobs.PollInterval,
+			failure:  "failed to process search job",
+			lgr:      lgrWithAttrs,
+		},
+	}, nil
+}
+
+// Run runs the configured number of jobs at once until ctx is done, running jobs are queued again on shutdown.
+func (j *JobWorker) Run(ctx context.Context) error {
+	j.lgr.InfoContext(ctx, "running job workers", "workers", j.cfg.Workers)
+	j.poller.run(ctx)
+	j.lgr.InfoContext(ctx, "stopped job workers gracefully.")
+	return nil
+}
+
+// withJobsDefaults returns cfg with its unset fields defaulted.
+func withJobsDefaults(cfg config.Jobs) config.Jobs {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultJobsMaxSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultJobsPollInterval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultJobsMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultJobsBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultJobsMaxBackoff
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultJobsLease
+	}
+	if cfg.EventsPollInterval <= 0 {
+		cfg.EventsPollInterval = defaultJobsEventsPollInterval
+	}
+	if cfg.EventsHeartbeat <= 0 {
+		cfg.EventsHeartbeat = defaultJobsEventsHeartbeat
+	}
+	return cfg
+}
+
+// newSearchJobHandler creates the search job handler, job searches run like the ones of a batch.
+func newSearchJobHandler(cfg config.Config, db *sqlx.DB, searcher business.SearchMediaHandler, lgr logging.Logger) business.SearchJobHandler {
+	return business.NewSearchJobHandler(
+		jobdb.NewSearchJobRepository(db),
+		newMediaRepository(cfg.Outbox, db),
+		business.NewSearchBatchHandler(searcher, cfg.Batch.Concurrency),
+		newEventPublisher(db),
+		business.SearchJobPolicy{
+			MaxAttempts: cfg.Jobs.MaxAttempts,
+			Backoff:     cfg.Jobs.Backoff,
+			MaxBackoff:  cfg.Jobs.MaxBackoff,
+			Lease:       cfg.Jobs.Lease,
+		},
+		lgr,
+	)
+}
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+const meterName = "github.com/NawafSwe/media-scout-service/pkg/worker"
+
+// routeMetrics holds the RED (rate, errors, duration) instruments recorded per route.
+type routeMetrics struct {
+	requests otelmetric.Int64Counter
+	errors   otelmetric.Int64Counter
+	duration otelmetric.Float64Histogram
+}
+
+func newRouteMetrics(meter *metric.MeterProvider) (routeMetrics, error) {
+	var m otelmetric.Meter = noop.NewMeterProvider().Meter(meterName)
+	if meter != nil {
+		m = meter.Meter(meterName)
+	}
+	requests, err := m.Int64Counter("http.server.requests",
+		otelmetric.WithDescription("Number of http requests served per route."))
+	if err != nil {
+		return routeMetrics{}, err
+	}
+	errors, err := m.Int64Counter("http.server.errors",
+		otelmetric.WithDescription("Number of http requests per route answered with a 5xx status."))
+	if err != nil {
+		return routeMetrics{}, err
+	}
+	duration, err := m.Float64Histogram("http.server.duration",
+		otelmetric.WithDescription("Duration of http requests served per route."),
+		otelmetric.WithUnit("s"))
+	if err != nil {
+		return routeMetrics{}, err
+	}
+	return routeMetrics{requests: requests, errors: errors, duration: duration}, nil
+}
+
+// instrument wraps the handler of the given route with the RED instruments.
+func (m routeMetrics) instrument(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := newResponseRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		attrs := otelmetric.WithAttributes(
+			attribute.String("http.route", route),
+			attribute.String("http.request.method", r.Method),
+			attribute.Int("http.response.status_code", rec.status),
+		)
+		m.requests.Add(r.Context(), 1, attrs)
+		m.duration.Record(r.Context(), time.Since(start).Seconds(), attrs)
+		if rec.status >= http.StatusInternalServerError {
+			m.errors.Add(r.Context(), 1, attrs)
+		}
+	})
+}
+
+// responseRecorder captures the status code and the number of bytes written by a handler.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
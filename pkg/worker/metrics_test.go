@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRouteMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	metrics, err := newRouteMetrics(metric.NewMeterProvider(metric.WithReader(reader)))
+	require.NoError(t, err)
+
+	h := metrics.instrument("search.media", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("status") {
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			// handlers writing the body right away answer 200.
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	for _, target := range []string{"/?status=ok", "/?status=ok", "/?status=missing", "/?status=broken"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	// counts maps every instrument to its count per status code.
+	counts := map[string]map[int64]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			counts[m.Name] = map[int64]int64{}
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					counts[m.Name][statusCode(t, dp.Attributes)] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					counts[m.Name][statusCode(t, dp.Attributes)] += int64(dp.Count)
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[int64]int64{200: 2, 404: 1, 500: 1}, counts["http.server.requests"])
+	assert.Equal(t, map[int64]int64{200: 2, 404: 1, 500: 1}, counts["http.server.duration"])
+	assert.Equal(t, map[int64]int64{500: 1}, counts["http.server.errors"])
+}
+
+// statusCode returns the status code attribute of a data point along with checking its route attributes.
+func statusCode(t *testing.T, attrs attribute.Set) int64 {
+	t.Helper()
+	route, _ := attrs.Value("http.route")
+	assert.Equal(t, "search.media", route.AsString())
+	method, _ := attrs.Value("http.request.method")
+	assert.Equal(t, http.MethodGet, method.AsString())
+	status, ok := attrs.Value("http.response.status_code")
+	require.True(t, ok)
+	require.Equal(t, attribute.INT64, status.Type())
+	return status.AsInt64()
+}
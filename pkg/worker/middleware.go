@@ -0,0 +1,247 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	headerRequestID     = "X-Request-ID"
+	headerForwardedFor  = "X-Forwarded-For"
+	maxRequestIDLength  = 128
+	requestIDByteLength = 16
+)
+
+// middleware wraps a http.Handler with extra behavior.
+type middleware func(http.Handler) http.Handler
+
+// chain wraps h with the given middlewares, the first middleware is the outermost one.
+func chain(h http.Handler, middlewares ...middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+type (
+	requestIDKey struct{}
+	clientIPKey  struct{}
+	routeKey     struct{}
+)
+
+// requestIDFromContext returns the request id assigned by the requestID middleware.
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// clientIPFromContext returns the client ip resolved by the requestID middleware.
+func clientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// requestID assigns a request id to every request, reusing a well-formed X-Request-ID sent by the caller,
+// and resolves the client ip. Both are added to the context and to every record logged with it.
+func requestID(trustedProxies []*net.IPNet) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(headerRequestID)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			ip := clientIP(r, trustedProxies)
+			w.Header().Set(headerRequestID, id)
+
+			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			ctx = context.WithValue(ctx, clientIPKey{}, ip)
+			ctx = logging.ContextWithAttrs(ctx, slog.String("request_id", id))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_.:", c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, requestIDByteLength)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// clientIP returns the ip of the client, X-Forwarded-For is only honored when the peer is a trusted proxy,
+// in which case the right-most address that is not a trusted proxy is the client.
+func clientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrusted(remote, trustedProxies) {
+		return remote
+	}
+	hops := strings.Split(strings.Join(r.Header.Values(headerForwardedFor), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrusted(hop, trustedProxies) {
+			return hop
+		}
+		remote = hop
+	}
+	return remote
+}
+
+func isTrusted(ip string, trustedProxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range trustedProxies {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses the configured CIDRs, a bare ip is treated as a single host network.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// routeHolder is filled by captureRoute once the router matched the request and by traceResponse once the
+// server span started, so middlewares running in front of the router can read the route template and the span.
+type routeHolder struct {
+	template string
+	span     trace.SpanContext
+}
+
+// captureRoute is a router middleware recording the path template of the matched route.
+func captureRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if holder, ok := r.Context().Value(routeKey{}).(*routeHolder); ok {
+			if route := mux.CurrentRoute(r); route != nil {
+				holder.template, _ = route.GetPathTemplate()
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// accessLog writes a structured log line for every request once it is served.
+func accessLog(lgr logging.Logger) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			holder := &routeHolder{}
+			rec := newResponseRecorder(w)
+			r = r.WithContext(context.WithValue(r.Context(), routeKey{}, holder))
+			next.ServeHTTP(rec, r)
+
+			ctx := r.Context()
+			if holder.span.IsValid() {
+				// the server span is started by the route, the line is logged under it to carry its ids.
+				ctx = trace.ContextWithSpanContext(ctx, holder.span)
+			}
+			lgr.InfoContext(ctx, "http request",
+				"method", r.Method,
+				"route", holder.template,
+				"path", r.URL.Path,
+				"status", rec.status,
+				"bytes", rec.bytes,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"client_ip", clientIPFromContext(ctx),
+			)
+		})
+	}
+}
+
+// recoverPanic turns a panicking handler into a 500 JSON error instead of a dropped connection.
+func recoverPanic(lgr logging.Logger) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := newResponseRecorder(w)
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				id := requestIDFromContext(r.Context())
+				lgr.ErrorContext(r.Context(), "recovered from panic",
+					"panic", fmt.Sprint(p),
+					"stack", string(debug.Stack()),
+				)
+				if rec.wroteHeader {
+					return
+				}
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"errors":     http.StatusText(http.StatusInternalServerError),
+					"request_id": id,
+				})
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// traceResponse returns the traceparent of the server span to the caller and hands the span to the access log,
+// it must run inside otelhttp.
+func traceResponse(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if holder, ok := r.Context().Value(routeKey{}).(*routeHolder); ok {
+			holder.span = trace.SpanContextFromContext(r.Context())
+		}
+		propagation.TraceContext{}.Inject(r.Context(), propagation.HeaderCarrier(w.Header()))
+		next.ServeHTTP(w, r)
+	})
+}
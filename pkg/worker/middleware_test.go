@@ -0,0 +1,191 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/logging/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		expectSame bool
+	}{
+		{name: "well-formed id is reused", header: "abc-123", expectSame: true},
+		{name: "missing id is generated", header: ""},
+		{name: "malformed id is replaced", header: "bad id\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			h := requestID(nil)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				seen = requestIDFromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(headerRequestID, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, seen, rec.Header().Get(headerRequestID))
+			if tt.expectSame {
+				assert.Equal(t, tt.header, seen)
+			} else {
+				assert.Len(t, seen, requestIDByteLength*2)
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expectedIP string
+	}{
+		{
+			name:       "untrusted peer ignores forwarded header",
+			remoteAddr: "203.0.113.7:5555",
+			forwarded:  []string{"198.51.100.1"},
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "trusted peer uses right-most untrusted hop",
+			remoteAddr: "10.1.2.3:5555",
+			forwarded:  []string{"198.51.100.1, 203.0.113.9", "192.168.1.1"},
+			expectedIP: "203.0.113.9",
+		},
+		{
+			name:       "trusted peer without forwarded header",
+			remoteAddr: "10.1.2.3:5555",
+			expectedIP: "10.1.2.3",
+		},
+		{
+			name:       "only trusted hops returns the left-most one",
+			remoteAddr: "10.1.2.3:5555",
+			forwarded:  []string{"10.9.9.9"},
+			expectedIP: "10.9.9.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, f := range tt.forwarded {
+				req.Header.Add(headerForwardedFor, f)
+			}
+			assert.Equal(t, tt.expectedIP, clientIP(req, proxies))
+		})
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lgr := mock.NewMockLogger(ctrl)
+	lgr.EXPECT().ErrorContext(gomock.Any(), "recovered from panic", gomock.Any()).Times(1)
+
+	h := chain(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}), requestID(nil), recoverPanic(lgr))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(headerRequestID, "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "req-1", body["request_id"])
+}
+
+func TestAccessLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var served oteltrace.SpanContext
+	lgr := mock.NewMockLogger(ctrl)
+	lgr.EXPECT().InfoContext(gomock.Any(), "http request", gomock.Any()).
+		Do(func(ctx context.Context, _ string, args ...any) {
+			assert.True(t, served.IsValid())
+			assert.Equal(t, served, oteltrace.SpanContextFromContext(ctx))
+			assert.Subset(t, args, []any{"route", "/items/{id}", "status", http.StatusNoContent})
+		})
+
+	router := mux.NewRouter()
+	router.Use(captureRoute)
+	router.Handle("/items/{id}", otelhttp.NewHandler(traceResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = oteltrace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})), "get.item", otelhttp.WithTracerProvider(trace.NewTracerProvider())))
+	h := chain(router, requestID(nil), accessLog(lgr))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+// TestEventStreamThroughMiddlewares checks that events flushed by a route reach the client right away through
+// the middlewares wrapping every route, and that the stream is neither compressed nor missing its cors headers.
+func TestEventStreamThroughMiddlewares(t *testing.T) {
+	cfg := config.Config{
+		HTTP: config.HTTP{CompressionEnabled: true},
+		CORS: config.CORS{AllowedOrigins: []string{"https://app.example.com"}},
+	}
+	guard, err := NewAPIGuard(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, "test")
+	require.NoError(t, err)
+	h, err := NewHTTPWorker(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, guard, "test")
+	require.NoError(t, err)
+	release := make(chan struct{})
+	h.router.Handle("/events", h.instrument("events", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("event: result\ndata: {}\n\n"))
+		assert.NoError(t, http.NewResponseController(w).Flush())
+		// the response only ends once the client read the event.
+		<-release
+	})))
+	srv := httptest.NewServer(h.handler())
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Accept-Encoding", "gzip")
+	res, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	defer close(release)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "https://app.example.com", res.Header.Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, res.Header.Get("Content-Encoding"))
+	line, err := bufio.NewReader(res.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: result\n", line)
+}
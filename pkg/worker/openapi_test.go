@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/openapi"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// muxVarPattern matches the pattern of a mux path variable, e.g. the :[0-9]+ of {id:[0-9]+}.
+var muxVarPattern = regexp.MustCompile(`\{(\w+):[^}]+\}`)
+
+// TestOpenAPICoversRoutes fails when a route is registered without being described in the OpenAPI document.
+func TestOpenAPICoversRoutes(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Spec())
+	require.NoError(t, err)
+
+	cfg := config.Config{Metrics: config.Metrics{Enabled: true}, Admin: config.Admin{Token: "s3cret"}}
+	guard, err := NewAPIGuard(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, "test")
+	require.NoError(t, err)
+	h, err := NewHTTPWorker(cfg, trace.NewTracerProvider(), metric.NewMeterProvider(), nil, guard, "test")
+	require.NoError(t, err)
+	h.registerHandlers()
+	h.registerAdminHandlers()
+
+	for _, router := range []*mux.Router{h.router, h.adminRouter} {
+		err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+			tpl, err := route.GetPathTemplate()
+			if err != nil {
+				return nil
+			}
+			methods, err := route.GetMethods()
+			if err != nil {
+				// subrouters have no methods of their own.
+				return nil
+			}
+			path := muxVarPattern.ReplaceAllString(tpl, "{$1}")
+			item := doc.Paths.Find(path)
+			if !assert.NotNil(t, item, "path %s is missing from the spec", path) {
+				return nil
+			}
+			for _, method := range methods {
+				assert.NotNil(t, item.GetOperation(method), "operation %s %s is missing from the spec", method, path)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,153 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/broker"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediadb"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/outboxdb"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+const (
+	outboxBrokerMemory = "memory"
+	outboxBrokerNATS   = "nats"
+
+	defaultOutboxNATSURL       = "nats://127.0.0.1:4222"
+	defaultOutboxSubjectPrefix = "mediascout"
+	defaultOutboxTimeout       = 5 * time.Second
+	defaultOutboxPollInterval  = time.Second
+	defaultOutboxBatchSize     = 100
+	defaultOutboxBackoff       = time.Second
+	defaultOutboxMaxBackoff    = time.Minute
+	defaultOutboxLease         = time.Minute
+)
+
+// outboxBroker is the broker the relay publishes to, it is closed once the relay stopped.
+type outboxBroker interface {
+	Publish(ctx context.Context, subject, id string, data []byte) error
+	Close() error
+}
+
+// OutboxWorker represents the relay publishing the events of the outbox.
+type OutboxWorker struct {
+	cfg    config.Outbox
+	Name   string
+	lgr    logging.Logger
+	broker outboxBroker
+	poller poller
+}
+
+// NewOutboxWorker function creates outbox worker, it connects to the configured broker.
+func NewOutboxWorker(cfg config.Config, db *sqlx.DB, name string) (*OutboxWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	lgrWithAttrs := lgr.With("service", name)
+	cfg.Outbox = withOutboxDefaults(cfg.Outbox)
+	b, err := newOutboxBroker(cfg.Outbox, name)
+	if err != nil {
+		return nil, err
+	}
+	handler := business.NewOutboxRelayHandler(outboxdb.NewOutboxRepository(db), b, business.OutboxPolicy{
+		SubjectPrefix: cfg.Outbox.SubjectPrefix,
+		BatchSize:     cfg.Outbox.BatchSize,
+		Backoff:       cfg.Outbox.Backoff,
+		MaxBackoff:    cfg.Outbox.MaxBackoff,
+		Lease:         cfg.Outbox.Lease,
+	})
+	return &OutboxWorker{
+		cfg:    cfg.Outbox,
+		Name:   name,
+		lgr:    lgrWithAttrs,
+		broker: b,
+		poller: poller{
+			work:     batchWork(handler.RelayOutbox, cfg.Outbox.BatchSize),
+			interval: cfg.Outbox.PollInterval,
+			failure:  "failed to relay outbox",
+			lgr:      lgrWithAttrs,
+		},
+	}, nil
+}
+
+// Run relays the outbox until ctx is done, it waits for the poll interval once fewer events than a batch were due.
+// The broker is closed once it stopped.
+func (o *OutboxWorker) Run(ctx context.Context) error {
+	o.lgr.InfoContext(ctx, "running outbox relay", "broker", o.cfg.Broker)
+	o.poller.run(ctx)
+	if err := o.broker.Close(); err != nil {
+		o.lgr.ErrorContext(ctx, "failed to close outbox broker", "error", err.Error())
+	}
+	o.lgr.InfoContext(ctx, "stopped outbox relay gracefully.")
+	return nil
+}
+
+// newOutboxBroker creates the broker configured by cfg.
+func newOutboxBroker(cfg config.Outbox, name string) (outboxBroker, error) {
+	switch cfg.Broker {
+	case "":
+		return nil, errors.New("outbox relay requires a broker")
+	case outboxBrokerMemory:
+		return broker.NewMemoryBroker(), nil
+	case outboxBrokerNATS:
+		b, err := broker.NewNATSBroker(cfg.NATSURL, name, cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create nats broker: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown outbox broker %q", cfg.Broker)
+	}
+}
+
+// newMediaRepository creates the media repository, the domain events of searches are only written to the outbox
+// once a broker is configured since nothing else would relay and delete them.
+func newMediaRepository(cfg config.Outbox, db *sqlx.DB) *mediadb.MediaRepositoryImpl {
+	if cfg.Broker == "" {
+		return mediadb.NewMediaRepository(db)
+	}
+	return mediadb.NewMediaRepository(db, mediadb.WithOutboxEvents())
+}
+
+// withOutboxDefaults returns cfg with its unset fields defaulted.
+func withOutboxDefaults(cfg config.Outbox) config.Outbox {
+	if cfg.NATSURL == "" {
+		cfg.NATSURL = defaultOutboxNATSURL
+	}
+	if cfg.SubjectPrefix == "" {
+		cfg.SubjectPrefix = defaultOutboxSubjectPrefix
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultOutboxTimeout
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultOutboxPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultOutboxBatchSize
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultOutboxBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultOutboxMaxBackoff
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultOutboxLease
+	}
+	return cfg
+}
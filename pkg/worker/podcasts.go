@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/feed"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/feedfetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/podcastdb"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultPodcastsRefreshInterval = time.Hour
+	defaultPodcastsPollInterval    = time.Minute
+	defaultPodcastsBatchSize       = 20
+	defaultPodcastsMaxEpisodes     = 500
+	defaultPodcastsFetchTimeout    = 30 * time.Second
+)
+
+// PodcastWorker represents the refresher fetching the feeds of podcasts.
+type PodcastWorker struct {
+	cfg    config.Podcasts
+	Name   string
+	lgr    logging.Logger
+	poller poller
+}
+
+// NewPodcastWorker function creates podcast worker.
+func NewPodcastWorker(cfg config.Config, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, name string) (*PodcastWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	lgrWithAttrs := lgr.With("service", name)
+	cfg.Podcasts = withPodcastsDefaults(cfg.Podcasts)
+	itunesClient := newITunesClient(cfg.ITunes, tracer, meter, lgrWithAttrs)
+	handler := newPodcastHandler(cfg.Podcasts, db, itunesClient, tracer, lgrWithAttrs)
+	return &PodcastWorker{
+		cfg:  cfg.Podcasts,
+		Name: name,
+		lgr:  lgrWithAttrs,
+		poller: poller{
+			work:     batchWork(handler.RefreshDuePodcasts, cfg.Podcasts.BatchSize),
+			interval: cfg.Podcasts.PollInterval,
+			failure:  "failed to refresh podcasts",
+			lgr:      lgrWithAttrs,
+		},
+	}, nil
+}
+
+// Run refreshes due podcasts until ctx is done, it waits for the poll interval once fewer podcasts than a batch were due.
+func (w *PodcastWorker) Run(ctx context.Context) error {
+	w.lgr.InfoContext(ctx, "running podcast refresher", "refresh_interval", w.cfg.RefreshInterval.String())
+	w.poller.run(ctx)
+	w.lgr.InfoContext(ctx, "stopped podcast refresher gracefully.")
+	return nil
+}
+
+// withPodcastsDefaults returns cfg with its unset fields defaulted.
+func withPodcastsDefaults(cfg config.Podcasts) config.Podcasts {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultPodcastsRefreshInterval
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPodcastsPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultPodcastsBatchSize
+	}
+	if cfg.MaxEpisodes <= 0 {
+		cfg.MaxEpisodes = defaultPodcastsMaxEpisodes
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = defaultMusicBrainzUserAgent
+	}
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = defaultPodcastsFetchTimeout
+	}
+	return cfg
+}
+
+// newPodcastHandler creates the podcast handler, podcasts are looked up through the iTunes lookup.
+func newPodcastHandler(cfg config.Podcasts, db *sqlx.DB, itunesClient *itunes.Client, tracer *trace.TracerProvider, lgr logging.Logger) business.PodcastHandler {
+	return business.NewPodcastHandler(
+		podcastdb.NewPodcastRepository(db),
+		mediafetcher.NewMediaFetcher(itunesClient),
+		feedfetcher.NewFeedFetcher(feed.NewClient(tracer, cfg.UserAgent, cfg.FetchTimeout)),
+		business.PodcastPolicy{
+			RefreshInterval: cfg.RefreshInterval,
+			BatchSize:       cfg.BatchSize,
+			MaxEpisodes:     cfg.MaxEpisodes,
+		},
+		lgr,
+	)
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+)
+
+// poller runs the background work of a subsystem: due work is claimed from the db with FOR UPDATE SKIP LOCKED, so
+// every replica may run pollers of the same subsystem without two of them claiming the same work.
+type poller struct {
+	// work claims and runs the next due work, it reports whether more work may be due right away.
+	work func(ctx context.Context) (more bool, err error)
+	// workers is the number of pollers run at once, interval how long they wait once no more work is due.
+	workers  int
+	interval time.Duration
+	// failure is the message work failing is logged with.
+	failure string
+	lgr     logging.Logger
+}
+
+// batchWork adapts work claiming up to size items at once, more may be due once a full batch was claimed.
+func batchWork(work func(ctx context.Context) (int, error), size int) func(ctx context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		claimed, err := work(ctx)
+		return claimed == size, err
+	}
+}
+
+// run runs the workers of p until ctx is done.
+func (p poller) run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range max(p.workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.poll(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// poll runs work until ctx is done, right away while more is due and once the interval elapsed otherwise.
+func (p poller) poll(ctx context.Context) {
+	for ctx.Err() == nil {
+		more, err := p.work(ctx)
+		if err != nil {
+			p.lgr.ErrorContext(ctx, p.failure, "error", err.Error())
+		}
+		if more && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.interval):
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/pkg/logging/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoller(t *testing.T) {
+	t.Run("more work is run right away", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls := 0
+		p := poller{
+			work: func(context.Context) (bool, error) {
+				calls++
+				if calls == 3 {
+					cancel()
+				}
+				return true, nil
+			},
+			// the test would time out if the poller waited.
+			interval: time.Hour,
+		}
+
+		p.poll(ctx)
+
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("failed work is logged and waits for the interval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		lgr := mock.NewMockLogger(ctrl)
+		lgr.EXPECT().ErrorContext(gomock.Any(), "failed to do work", "error", "boom").Times(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var last time.Time
+		p := poller{
+			work: func(context.Context) (bool, error) {
+				if !last.IsZero() {
+					assert.GreaterOrEqual(t, time.Since(last), 10*time.Millisecond)
+					cancel()
+					return false, nil
+				}
+				last = time.Now()
+				return true, errors.New("boom")
+			},
+			interval: 10 * time.Millisecond,
+			failure:  "failed to do work",
+			lgr:      lgr,
+		}
+
+		p.poll(ctx)
+	})
+
+	t.Run("workers poll at once", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var running atomic.Int32
+		p := poller{
+			work: func(ctx context.Context) (bool, error) {
+				running.Add(1)
+				<-ctx.Done()
+				return false, nil
+			},
+			workers:  3,
+			interval: time.Hour,
+		}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			p.run(ctx)
+		}()
+
+		assert.Eventually(t, func() bool { return running.Load() == 3 }, time.Second, time.Millisecond)
+		cancel()
+		<-done
+	})
+}
+
+func TestBatchWork(t *testing.T) {
+	claimed := 0
+	work := batchWork(func(context.Context) (int, error) { return claimed, nil }, 20)
+
+	claimed = 20
+	more, err := work(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, more)
+
+	claimed = 7
+	more, err = work(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, more)
+}
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/musicbrainz"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/tmdb"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultMusicBrainzBaseURL   = "https://musicbrainz.org/ws/2"
+	defaultMusicBrainzUserAgent = config.ServiceName + " (https://github.com/NawafSwe/media-scout-service)"
+	// defaultMusicBrainzRate is the most calls per second MusicBrainz accepts from a client.
+	defaultMusicBrainzRate = 1
+	defaultTMDBBaseURL     = "https://api.themoviedb.org/3"
+	defaultProviderTimeout = 5 * time.Second
+)
+
+// newMediaProviders creates the registry of the providers media can be searched in, iTunes is the default one
+// and MusicBrainz and TMDB are registered when enabled by cfg.
+func newMediaProviders(cfg config.Providers, itunesClient *itunes.Client, tracer *trace.TracerProvider, lgr logging.Logger) *mediafetcher.Registry {
+	providers := []mediafetcher.Provider{mediafetcher.NewMediaFetcher(itunesClient)}
+	if cfg.MusicBrainz.Enabled {
+		mb := withMusicBrainzDefaults(cfg.MusicBrainz)
+		rule := ratelimit.Rule{Rate: mb.Rate, Burst: 1}
+		client := musicbrainz.NewClient(tracer, mb.BaseURL, mb.UserAgent,
+			musicbrainz.WithRateLimit(ratelimit.NewLimiter(upstreamRateLimits, lgr), rule))
+		providers = append(providers, mediafetcher.NewMusicBrainzFetcher(client))
+	}
+	if cfg.TMDB.Token != "" {
+		baseURL := cfg.TMDB.BaseURL
+		if baseURL == "" {
+			baseURL = defaultTMDBBaseURL
+		}
+		providers = append(providers, mediafetcher.NewTMDBFetcher(tmdb.NewClient(tracer, baseURL, cfg.TMDB.Token)))
+	}
+	return mediafetcher.NewRegistry(providers...)
+}
+
+// newSearchPolicy returns the policy of the searches federated across the providers of cfg.
+func newSearchPolicy(cfg config.Providers) business.SearchPolicy {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultProviderTimeout
+	}
+	return business.SearchPolicy{ProviderTimeout: cfg.Timeout}
+}
+
+// newSearcher creates the handler media are searched with, every api searching media shares it so they all
+// follow the policy of cfg.Providers.
+func newSearcher(cfg config.Config, db *sqlx.DB, providers *mediafetcher.Registry, lgr logging.Logger) business.SearchMediaHandler {
+	return business.NewSearchMediaHandler(newMediaRepository(cfg.Outbox, db), providers, newEventPublisher(db), newSearchPolicy(cfg.Providers), lgr)
+}
+
+// withMusicBrainzDefaults returns cfg with its unset fields defaulted.
+func withMusicBrainzDefaults(cfg config.MusicBrainz) config.MusicBrainz {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultMusicBrainzBaseURL
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = defaultMusicBrainzUserAgent
+	}
+	if cfg.Rate <= 0 {
+		cfg.Rate = defaultMusicBrainzRate
+	}
+	return cfg
+}
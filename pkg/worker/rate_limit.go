@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/transport"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	rateLimitStoreMemory   = "memory"
+	rateLimitStorePostgres = "postgres"
+	// rateLimitPruneInterval is how often idle buckets are deleted from the shared store.
+	rateLimitPruneInterval = 10 * time.Minute
+	// defaultIPRateLimit leaves room for many clients behind a shared ip while throttling credential guessing.
+	defaultIPRateLimit = "20:100"
+	// ipRateLimitScope is the scope of the buckets every ip is limited by ahead of authentication.
+	ipRateLimitScope = "auth"
+)
+
+// upstreamRateLimits holds the bucket of the calls made to the iTunes API, it is shared by every worker of the process.
+var upstreamRateLimits = ratelimit.NewMemoryStore()
+
+// newITunesClient creates the iTunes client, its calls wait for the upstream rate limit when cfg sets a rate.
+func newITunesClient(cfg config.ITunes, tracer *trace.TracerProvider, meter *metric.MeterProvider, lgr logging.Logger) *itunes.Client {
+	if cfg.Rate <= 0 {
+		return itunes.NewClient(tracer, meter)
+	}
+	rule := ratelimit.Rule{Rate: cfg.Rate, Burst: max(cfg.Burst, 1)}
+	return itunes.NewClient(tracer, meter, itunes.WithRateLimit(ratelimit.NewLimiter(upstreamRateLimits, lgr), rule))
+}
+
+// rateLimits holds the rate limiting rule of every route and the one of every ip.
+type rateLimits struct {
+	limiter *ratelimit.Limiter
+	rule    ratelimit.Rule
+	routes  map[string]ratelimit.Rule
+	ip      ratelimit.Rule
+	shared  *ratelimit.PostgresStore
+	lgr     logging.Logger
+}
+
+// newRateLimits creates the rate limits of the given config, it returns nil when rate limiting is disabled.
+func newRateLimits(cfg config.RateLimit, db *sqlx.DB, meter *metric.MeterProvider, lgr logging.Logger) (*rateLimits, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	rule := ratelimit.Rule{Rate: cfg.Rate, Burst: cfg.Burst}
+	if rule.Rate <= 0 || rule.Burst < 1 {
+		return nil, fmt.Errorf("invalid default rate limit, rate and burst must be positive")
+	}
+	routes := make(map[string]ratelimit.Rule, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		operation, rawRule, ok := strings.Cut(route, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route rate limit %q, expected operation=rate:burst", route)
+		}
+		r, err := ratelimit.ParseRule(rawRule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit of route %q: %w", operation, err)
+		}
+		routes[operation] = r
+	}
+
+	if cfg.IPRule == "" {
+		cfg.IPRule = defaultIPRateLimit
+	}
+	ip, err := ratelimit.ParseRule(cfg.IPRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip rate limit: %w", err)
+	}
+
+	limits := &rateLimits{rule: rule, routes: routes, ip: ip, lgr: lgr}
+	var store ratelimit.Store
+	switch cfg.Store {
+	case "", rateLimitStoreMemory:
+		store = ratelimit.NewMemoryStore()
+	case rateLimitStorePostgres:
+		limits.shared = ratelimit.NewPostgresStore(db)
+		store = limits.shared
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+	limits.limiter = ratelimit.NewLimiter(store, lgr, ratelimit.WithMeter(meter))
+	return limits, nil
+}
+
+// middleware returns the endpoint middleware enforcing the rule of the route with the given operation name.
+func (l *rateLimits) middleware(operation string) endpoint.Middleware {
+	rule, ok := l.routes[operation]
+	if !ok {
+		rule = l.rule
+	}
+	return transport.MakeRateLimitMiddleware(l.limiter, operation, rule)
+}
+
+// ipMiddleware returns the endpoint middleware enforcing the rule of every ip, shared by every route.
+func (l *rateLimits) ipMiddleware() endpoint.Middleware {
+	return transport.MakeIPRateLimitMiddleware(l.limiter, ipRateLimitScope, l.ip)
+}
+
+// prune periodically deletes the idle buckets of the shared store until ctx is done.
+func (l *rateLimits) prune(ctx context.Context) {
+	if l.shared == nil {
+		return
+	}
+	ticker := time.NewTicker(rateLimitPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// an hour covers the refill time of any sensible rule.
+			if _, err := l.shared.Prune(ctx, time.Hour); err != nil {
+				l.lgr.WarnContext(ctx, "failed to prune rate limit buckets", "error", err.Error())
+			}
+		}
+	}
+}
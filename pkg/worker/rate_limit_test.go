@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/logging/mock"
+	"github.com/NawafSwe/media-scout-service/pkg/ratelimit"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRateLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	lgr := mock.NewMockLogger(ctrl)
+
+	tests := []struct {
+		name           string
+		cfg            config.RateLimit
+		expectedError  string
+		expectedRules  map[string]ratelimit.Rule
+		expectedIPRule ratelimit.Rule
+		expectedShared bool
+	}{
+		{
+			name: "disabled",
+			cfg:  config.RateLimit{Rate: 1, Burst: 1},
+		},
+		{
+			name: "memory store with route overrides",
+			cfg:  config.RateLimit{Enabled: true, Rate: 10, Burst: 20, Routes: []string{"search.media=1:5"}},
+			expectedRules: map[string]ratelimit.Rule{
+				"search.media": {Rate: 1, Burst: 5},
+				"lookup.media": {Rate: 10, Burst: 20},
+			},
+			expectedIPRule: ratelimit.Rule{Rate: 20, Burst: 100},
+		},
+		{
+			name:           "ip rule",
+			cfg:            config.RateLimit{Enabled: true, Rate: 10, Burst: 20, IPRule: "5:50"},
+			expectedRules:  map[string]ratelimit.Rule{"search.media": {Rate: 10, Burst: 20}},
+			expectedIPRule: ratelimit.Rule{Rate: 5, Burst: 50},
+		},
+		{
+			name:           "postgres store",
+			cfg:            config.RateLimit{Enabled: true, Store: "postgres", Rate: 10, Burst: 20},
+			expectedRules:  map[string]ratelimit.Rule{"search.media": {Rate: 10, Burst: 20}},
+			expectedIPRule: ratelimit.Rule{Rate: 20, Burst: 100},
+			expectedShared: true,
+		},
+		{
+			name:          "invalid default rule",
+			cfg:           config.RateLimit{Enabled: true},
+			expectedError: "invalid default rate limit",
+		},
+		{
+			name:          "invalid route rule",
+			cfg:           config.RateLimit{Enabled: true, Rate: 10, Burst: 20, Routes: []string{"search.media"}},
+			expectedError: "invalid route rate limit",
+		},
+		{
+			name:          "invalid ip rule",
+			cfg:           config.RateLimit{Enabled: true, Rate: 10, Burst: 20, IPRule: "fast"},
+			expectedError: "invalid ip rate limit",
+		},
+		{
+			name:          "unknown store",
+			cfg:           config.RateLimit{Enabled: true, Store: "redis", Rate: 10, Burst: 20},
+			expectedError: `unknown rate limit store "redis"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := newRateLimits(tt.cfg, nil, nil, lgr)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.expectedRules == nil {
+				assert.Nil(t, limits)
+				return
+			}
+			for operation, rule := range tt.expectedRules {
+				r, ok := limits.routes[operation]
+				if !ok {
+					r = limits.rule
+				}
+				assert.Equal(t, rule, r, operation)
+			}
+			assert.Equal(t, tt.expectedIPRule, limits.ip)
+			assert.Equal(t, tt.expectedShared, limits.shared != nil)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/fsnotify/fsnotify"
+)
+
+// certReloader keeps the serving certificate and the client CA pool in sync with the files on disk,
+// so rotated certificates are picked up without restarting the server.
+type certReloader struct {
+	cfg       config.TLS
+	lgr       logging.Logger
+	watcher   *fsnotify.Watcher
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// newCertReloader loads the configured files and starts watching their directories.
+func newCertReloader(cfg config.TLS, lgr logging.Logger) (*certReloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("tls cert and key files are required when tls is enabled")
+	}
+	c := &certReloader{cfg: cfg, lgr: lgr}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tls files watcher: %w", err)
+	}
+	// directories are watched instead of files since rotations usually swap the files (or a symlink to them).
+	dirs := map[string]struct{}{filepath.Dir(cfg.CertFile): {}, filepath.Dir(cfg.KeyFile): {}}
+	if cfg.ClientCAFile != "" {
+		dirs[filepath.Dir(cfg.ClientCAFile)] = struct{}{}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("failed to watch tls directory %s: %w", dir, err)
+		}
+	}
+	c.watcher = watcher
+	return c, nil
+}
+
+// load reads the certificate, key and CA bundle, the previous ones are kept when reading fails.
+func (c *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(c.cfg.CertFile, c.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load tls key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if c.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client ca file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client ca file %s", c.cfg.ClientCAFile)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = &cert
+	c.clientCAs = pool
+	return nil
+}
+
+// watch reloads the files whenever their directories change until ctx is done.
+func (c *certReloader) watch(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-c.watcher.Events:
+			if !ok {
+				return
+			}
+			if err := c.load(); err != nil {
+				c.lgr.WarnContext(ctx, "failed to reload tls files, keeping the previous ones", "error", err.Error())
+				continue
+			}
+			c.lgr.InfoContext(ctx, "reloaded tls files")
+		case err, ok := <-c.watcher.Errors:
+			if !ok {
+				return
+			}
+			c.lgr.ErrorContext(ctx, "tls files watcher failed", "error", err.Error())
+		}
+	}
+}
+
+// Close stops watching the files.
+func (c *certReloader) Close() error {
+	return c.watcher.Close()
+}
+
+func (c *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+func (c *certReloader) getClientCAs() *x509.CertPool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.clientCAs
+}
+
+// newTLSConfig builds the server tls config, certificates and client CAs are resolved per handshake from the reloader.
+func newTLSConfig(cfg config.TLS, reloader *certReloader) (*tls.Config, error) {
+	minVersion, err := parseTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	suites, err := parseCipherSuites(cfg.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	base := &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   suites,
+		GetCertificate: reloader.getCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+	if cfg.ClientCAFile != "" {
+		base.ClientAuth = tls.RequireAndVerifyClientCert
+		if cfg.ClientCertOptional {
+			base.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+	return &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: reloader.getCertificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := base.Clone()
+			c.ClientCAs = reloader.getClientCAs()
+			return c, nil
+		},
+	}, nil
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported tls min version %q", version)
+	}
+}
+
+// parseCipherSuites maps IANA cipher suite names to their ids, insecure suites are rejected.
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16, len(tls.CipherSuites()))
+	for _, s := range tls.CipherSuites() {
+		known[s.Name] = s.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported tls cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/logging/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert, isCA bool) testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	lgr := mock.NewMockLogger(ctrl)
+	lgr.EXPECT().InfoContext(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	lgr.EXPECT().WarnContext(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil, true)
+	server := newTestCert(t, "server", &ca, false)
+	client := newTestCert(t, "client", &ca, false)
+	cfg := config.TLS{
+		CertFile:     filepath.Join(dir, "tls.crt"),
+		KeyFile:      filepath.Join(dir, "tls.key"),
+		ClientCAFile: filepath.Join(dir, "ca.crt"),
+		MinVersion:   "1.2",
+		CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+	}
+	writeFile(t, cfg.CertFile, server.certPEM)
+	writeFile(t, cfg.KeyFile, server.keyPEM)
+	writeFile(t, cfg.ClientCAFile, ca.certPEM)
+
+	reloader, err := newCertReloader(cfg, lgr)
+	require.NoError(t, err)
+	defer reloader.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.watch(ctx)
+
+	tlsCfg, err := newTLSConfig(cfg, reloader)
+	require.NoError(t, err)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = tlsCfg
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientKeyPair, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	require.NoError(t, err)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+		}}}
+	}
+
+	t.Run("client without certificate is rejected", func(t *testing.T) {
+		_, err := newClient().Get(srv.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("client with certificate signed by the ca is accepted", func(t *testing.T) {
+		resp, err := newClient(clientKeyPair).Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, server.cert.SerialNumber, resp.TLS.PeerCertificates[0].SerialNumber)
+	})
+
+	t.Run("rotated certificate is served without restart", func(t *testing.T) {
+		rotated := newTestCert(t, "server", &ca, false)
+		writeFile(t, cfg.KeyFile, rotated.keyPEM)
+		writeFile(t, cfg.CertFile, rotated.certPEM)
+
+		assert.Eventually(t, func() bool {
+			cl := newClient(clientKeyPair)
+			cl.Transport.(*http.Transport).DisableKeepAlives = true
+			resp, err := cl.Get(srv.URL)
+			if err != nil {
+				return false
+			}
+			defer resp.Body.Close()
+			return rotated.cert.SerialNumber.Cmp(resp.TLS.PeerCertificates[0].SerialNumber) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+func TestNewTLSConfigValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.TLS
+		expectedError string
+	}{
+		{name: "unsupported min version", cfg: config.TLS{MinVersion: "1.0"}, expectedError: `unsupported tls min version "1.0"`},
+		{name: "unknown cipher suite", cfg: config.TLS{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}, expectedError: `unsupported tls cipher suite "TLS_RSA_WITH_RC4_128_SHA"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTLSConfig(tt.cfg, &certReloader{})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/itunes"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/mediafetcher"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/watchlistdb"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultWatchlistsCheckInterval = 6 * time.Hour
+	defaultWatchlistsPollInterval  = time.Minute
+	defaultWatchlistsBatchSize     = 50
+)
+
+// defaultWatchlistsEntities are the iTunes entities looked up for new releases, singles are albums as well
+// but songs may be released ahead of their album.
+var defaultWatchlistsEntities = []string{"album", "song"}
+
+// WatchlistWorker represents the checker detecting new releases of the artists of watchlists.
+type WatchlistWorker struct {
+	cfg    config.Watchlists
+	Name   string
+	lgr    logging.Logger
+	poller poller
+}
+
+// NewWatchlistWorker function creates watchlist worker.
+func NewWatchlistWorker(cfg config.Config, tracer *trace.TracerProvider, meter *metric.MeterProvider, db *sqlx.DB, name string) (*WatchlistWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	lgrWithAttrs := lgr.With("service", name)
+	cfg.Watchlists = withWatchlistsDefaults(cfg.Watchlists)
+	itunesClient := newITunesClient(cfg.ITunes, tracer, meter, lgrWithAttrs)
+	handler := newWatchlistHandler(cfg.Watchlists, db, itunesClient, lgrWithAttrs)
+	return &WatchlistWorker{
+		cfg:  cfg.Watchlists,
+		Name: name,
+		lgr:  lgrWithAttrs,
+		poller: poller{
+			work:     batchWork(handler.CheckDueArtists, cfg.Watchlists.BatchSize),
+			interval: cfg.Watchlists.PollInterval,
+			failure:  "failed to check watched artists",
+			lgr:      lgrWithAttrs,
+		},
+	}, nil
+}
+
+// Run checks due artists until ctx is done, it waits for the poll interval once fewer artists than a batch were due.
+func (w *WatchlistWorker) Run(ctx context.Context) error {
+	w.lgr.InfoContext(ctx, "running watchlist checker", "check_interval", w.cfg.CheckInterval.String())
+	w.poller.run(ctx)
+	w.lgr.InfoContext(ctx, "stopped watchlist checker gracefully.")
+	return nil
+}
+
+// withWatchlistsDefaults returns cfg with its unset fields defaulted.
+func withWatchlistsDefaults(cfg config.Watchlists) config.Watchlists {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultWatchlistsCheckInterval
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultWatchlistsPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWatchlistsBatchSize
+	}
+	if len(cfg.Entities) == 0 {
+		cfg.Entities = defaultWatchlistsEntities
+	}
+	return cfg
+}
+
+// newWatchlistHandler creates the watchlist handler, releases are looked up through the iTunes lookup.
+func newWatchlistHandler(cfg config.Watchlists, db *sqlx.DB, itunesClient *itunes.Client, lgr logging.Logger) business.WatchlistHandler {
+	return business.NewWatchlistHandler(
+		watchlistdb.NewWatchlistRepository(db),
+		mediafetcher.NewMediaFetcher(itunesClient),
+		newEventPublisher(db),
+		business.WatchlistPolicy{
+			CheckInterval: cfg.CheckInterval,
+			BatchSize:     cfg.BatchSize,
+			Entities:      cfg.Entities,
+		},
+		lgr,
+	)
+}
@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NawafSwe/media-scout-service/cmd/config"
+	"github.com/NawafSwe/media-scout-service/pkg/clients/webhook"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/business"
+	"github.com/NawafSwe/media-scout-service/pkg/internal/repository/webhookdb"
+	"github.com/NawafSwe/media-scout-service/pkg/logging"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultWebhooksPollInterval = time.Second
+	defaultWebhooksTimeout      = 10 * time.Second
+	defaultWebhooksMaxAttempts  = 8
+	defaultWebhooksBackoff      = 30 * time.Second
+	defaultWebhooksMaxBackoff   = time.Hour
+)
+
+// WebhookWorker represents the worker sending the pending webhook deliveries.
+type WebhookWorker struct {
+	cfg    config.Webhooks
+	Name   string
+	lgr    logging.Logger
+	poller poller
+}
+
+// NewWebhookWorker function creates webhook worker.
+func NewWebhookWorker(cfg config.Config, tracer *trace.TracerProvider, db *sqlx.DB, name string) (*WebhookWorker, error) {
+	lgr, err := logging.NewLogger(name, os.Stdout, logging.Options{
+		Export:   cfg.General.LoggingEnabled,
+		Level:    cfg.General.LoggingLevel,
+		Format:   cfg.General.LoggingFormat,
+		Provider: global.GetLoggerProvider(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	lgrWithAttrs := lgr.With("service", name)
+	cfg.Webhooks = withWebhooksDefaults(cfg.Webhooks)
+	handler := business.NewWebhookDeliveryHandler(
+		webhookdb.NewWebhookRepository(db),
+		webhook.NewClient(tracer, cfg.Webhooks.Timeout),
+		business.WebhookPolicy{
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			Backoff:     cfg.Webhooks.Backoff,
+			MaxBackoff:  cfg.Webhooks.MaxBackoff,
+			// a delivery is claimed again only once its worker surely gave up on it.
+			Lease: 2 * cfg.Webhooks.Timeout,
+		},
+		lgrWithAttrs,
+	)
+	return &WebhookWorker{
+		cfg:  cfg.Webhooks,
+		Name: name,
+		lgr:  lgrWithAttrs,
+		poller: poller{
+			work:     handler.DeliverNextWebhook,
+			workers:  cfg.Webhooks.Workers,
+			interval: cfg.Webhooks.PollInterval,
+			failure:  "failed to deliver webhook",
+			lgr:      lgrWithAttrs,
+		},
+	}, nil
+}
+
+// Run sends the configured number of deliveries at once until ctx is done.
+func (d *WebhookWorker) Run(ctx context.Context) error {
+	d.lgr.InfoContext(ctx, "running webhook workers", "workers", d.cfg.Workers)
+	d.poller.run(ctx)
+	d.lgr.InfoContext(ctx, "stopped webhook workers gracefully.")
+	return nil
+}
+
+// withWebhooksDefaults returns cfg with its unset fields defaulted.
+func withWebhooksDefaults(cfg config.Webhooks) config.Webhooks {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultWebhooksPollInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhooksTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhooksMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultWebhooksBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWebhooksMaxBackoff
+	}
+	return cfg
+}
+
+// newEventPublisher creates the publisher of the events pushed to webhooks, events are queued in the db and sent
+// by the webhook workers of any replica.
+func newEventPublisher(db *sqlx.DB) business.WebhookHandler {
+	return business.NewWebhookHandler(webhookdb.NewWebhookRepository(db))
+}